@@ -103,10 +103,13 @@ Examples:
 
 	librarian generate <library>   # regenerate one library
 	librarian generate --all       # regenerate every library
+	librarian generate --all --list-template-overlays
+	                               # list the templates replaced by overlays
 
 Flags:
 
-	--all       generate all libraries
+	--all                     generate all libraries
+	--list-template-overlays  list the templates replaced by template_overlay instead of generating
 
 A typical librarian workflow for regenerating every library against the
 latest API definitions is:
@@ -127,10 +130,83 @@ generators).
 If [language] is omitted, the language is read from librarian.yaml in the
 current directory.
 
+Tools are installed into a tool environment private to the workspace and
+set of tools, under the librarian bin directory ($LIBRARIAN_BIN, or the bin
+subdirectory of $LIBRARIAN_CACHE): go tools into its GOBIN directory, cargo
+tools into its cargo root, pip tools into its virtualenv, pnpm tools into its
+private pnpm home and store, and Maven tools into its lib directory. The
+commands run by librarian generate and librarian bump resolve executables
+from the tool environment first, so workspaces that need different versions
+of a tool can coexist on one machine. Changing the tools in librarian.yaml
+selects a new tool environment, which must be installed again. If
+$LIBRARIAN_TOOLENV is set, it is the tool environment of every workspace and
+set of tools instead, such as in a container image that installs the tools
+once and generates libraries in a workspace mounted later.
+
+install writes librarian-lock.yaml next to librarian.yaml, recording the
+resolved version of each tool and the SHA256 checksum of the package
+downloaded for it: the module zip for go tools, the .crate file for cargo
+tools, the distribution file for pip tools, the artifact for Maven tools and
+the tarball for pnpm tools. Pip tools record the checksum of each
+distribution file by file name, as their wheels are often platform-specific.
+Each package is verified before the tool is installed from it. When a tool
+is reinstalled at the version in the lockfile, or has a checksum in
+librarian.yaml, install fails if the downloaded package does not match the
+checksum.
+
+With --print-path, install installs nothing and prints the bin directories
+of the tool environment, one per line, for use outside of librarian, such as
+appending them to PATH in CI.
+
+With --prune, install removes the tool environments of the workspace that
+were installed for earlier sets of tools, after installing the current one.
+
+With --check, install installs nothing and reports the drift between
+librarian.yaml, librarian-lock.yaml and the installed tools, failing if
+there is any.
+
 Examples:
 
 	librarian install              # use language from librarian.yaml
 	librarian install go           # install Go-specific tools
+	librarian install --check      # report drift from the lockfile
+	librarian install --prune      # remove tool environments of earlier tools
+	librarian install --print-path # print the bin directories of the tools
+
+Flags:
+
+	--check       report drift between librarian.yaml, the lockfile and the installed tools
+	--print-path  print the bin directories of the tool environment
+	--prune       remove the tool environments of the workspace for earlier sets of tools
+
+# Diagnose the environment used to generate libraries
+
+Usage:
+
+	librarian doctor
+
+doctor checks that the environment of the current workspace can generate
+and build its libraries, and prints a fix for each problem it finds.
+
+For the language in librarian.yaml, doctor checks that:
+
+  - every external executable librarian runs, such as git, protoc, the
+    protoc plugins and the formatters, can be found in the tool environment
+    of the workspace or on PATH, and runs;
+  - the tools in librarian.yaml are installed in the tool environment at
+    the versions they are pinned to;
+  - the librarian cache directory ($LIBRARIAN_CACHE) and bin directory
+    ($LIBRARIAN_BIN) are writable;
+  - the sources in librarian.yaml are available, either as a local
+    directory or with a commit to download, and reports whether each one is
+    already cached.
+
+doctor only reads the environment: it does not install tools or download
+sources. It fails if any check finds a problem.
+
+Examples:
+
+	librarian doctor
 
 # Tidy and validate librarian.yaml
 
@@ -178,6 +254,156 @@ latest API definitions is:
 	librarian update sources.googleapis
 	librarian generate --all
 
+# Inspect the parsed API model
+
+Usage:
+
+	librarian model [dump]
+
+# Print the parsed API model as JSON or YAML
+
+Usage:
+
+	librarian model dump [<library> | --specification-source=<path>]
+
+dump runs the sidekick parser and prints the resolved API model.
+
+The model is the result of parsing the API specification and applying
+pagination overrides, cross-referencing, resource identification, and skip
+rules, exactly as the code generators see it.
+
+The library argument selects a library from librarian.yaml, and its
+language-specific configuration is used to build the model. Use --api to
+select one of the library's APIs, the first one is used by default.
+
+Alternatively, --specification-source parses an ad-hoc specification, using
+the sources from librarian.yaml if it is present.
+
+Use --id to print a single service, method, message, field, enum or enum
+value.
+
+Examples:
+
+	librarian model dump google-cloud-secretmanager-v1
+	librarian model dump google-cloud-secretmanager-v1 --format=yaml
+	librarian model dump google-cloud-secretmanager-v1 --id=.google.cloud.secretmanager.v1.Secret
+	librarian model dump --specification-source=openapi.json --specification-format=openapi
+
+Flags:
+
+	--api string                   the API path within the library to dump
+	--specification-source string  the path of a specification to dump instead of a library
+	--specification-format string  the format of --specification-source: protobuf, openapi or discovery (default: "protobuf")
+	--service-config string        the service config for --specification-source
+	--format string                the output format: json or yaml (default: "json")
+	--id string                    only print the element with this ID
+
+# Compare the API surface of a library between two googleapis revisions
+
+Usage:
+
+	librarian diff [<library> | --all] [--from=<ref>] [--to=<ref>]
+
+diff builds the API model of a library at two googleapis revisions and
+prints the services, methods, fields, enums, enum values and resources that
+were added, removed, renamed or changed.
+
+Each change is classified as additive or breaking. Removing an element,
+changing the type or number of a field, reusing a field number, and removing
+a resource pattern are breaking changes.
+
+--from defaults to the googleapis source in librarian.yaml, and --to defaults
+to the latest commit on the googleapis default branch. Both flags accept a
+commit, a branch, or a local directory.
+
+Examples:
+
+	librarian diff google-cloud-secretmanager-v1
+	librarian diff --all --to=<commit>
+	librarian diff google-cloud-secretmanager-v1 --from=<commit> --to=<commit> --format=json
+
+Flags:
+
+	--all            compare all libraries
+	--from string    the googleapis commit, branch or directory for the old API surface
+	--to string      the googleapis commit, branch or directory for the new API surface (default: "master")
+	--format string  the output format: text, json or yaml (default: "text")
+
+# Check the API model of a library for generator-hostile definitions
+
+Usage:
+
+	librarian lint [<library> | --all]
+
+lint builds the API model of a library and checks it for definitions that
+the code generators cannot handle well, such as list methods that do not
+follow AIP-4233, long-running operations without operation info, routing
+annotations that reference missing fields, and references to undefined
+resource types.
+
+Each finding has a severity. The command fails if any finding is an error.
+
+Findings can be suppressed per library in librarian.yaml:
+
+	libraries:
+	  - name: google-cloud-secretmanager-v1
+	    lint:
+	      suppress:
+	        - rule: pagination-aip-4233
+	          id: .google.cloud.secretmanager.v1.SecretManagerService.ListSecrets
+	          reason: the method predates AIP-4233
+
+Rules:
+
+  - pagination-aip-4233 (warning): methods with a page token that are not recognized as paginated per AIP-4233
+
+  - lro-operation-info (error): methods returning a long-running operation without valid operation info
+
+  - routing-field-missing (error): routing annotations that reference fields missing in the request
+
+  - resource-reference-unresolved (warning): resource references to resource types that are not defined
+
+Examples:
+
+	librarian lint google-cloud-secretmanager-v1
+	librarian lint --all
+
+Flags:
+
+	--all       lint all libraries
+
+# Build and package reference documentation
+
+Usage:
+
+	librarian docs [<library> | --all]
+
+docs builds the reference documentation of a library with the language's
+doc tooling and packages it for publication.
+
+For each library, docs writes docs.metadata.json into the built
+documentation, using the .repo-metadata.json file of the library, the
+library version and the current time. It then archives the documentation
+as <language>-<name>-<version>.tar.gz in the output directory.
+
+Use --upload-to to copy the archives into a local directory that stands in
+for the staging bucket, so the staging step can be tested locally.
+Libraries with skip_release set are skipped with --all.
+
+Only Python is supported.
+
+Examples:
+
+	librarian docs google-cloud-secret-manager
+	librarian docs --all --output /tmp/docs
+	librarian docs --all --upload-to /tmp/staging
+
+Flags:
+
+	--all               build the docs of all libraries
+	--output string     directory to write the documentation archives to (default: "docs-output")
+	--upload-to string  directory to copy the documentation archives to, instead of a staging bucket
+
 # Print the binary version
 
 Usage:
@@ -25,31 +25,53 @@ Usage:
 
 Usage:
 
-	librarianops generate [<repo> | -C <dir>]
+	librarianops generate [<repo>... | --all | -C <dir>]
 
 Examples:
 
 	librarianops generate google-cloud-rust
 	librarianops generate -C ~/workspace/google-cloud-rust
+	librarianops generate google-cloud-go google-cloud-python
+	librarianops generate --all --jobs 4 --summary summary.txt
 
 Specify a repository name to clone and process, or use -C to work in a specific
 directory (repo name is inferred from the directory basename).
 
+Specify several repository names, or use --all to process every supported
+repository, to run in batch mode. Each repository is cloned and processed in
+its own temporary directory, with at most --jobs repositories in parallel. A
+failure does not stop the other repositories. When all repositories are
+done, librarianops prints a summary with the branch, pull request, number of
+libraries changed and failed step of each repository, and writes it to the
+--summary file if set.
+
 For each repository, librarianops will:
  1. Clone the repository to a temporary directory (or use existing directory with -C)
- 2. Create a branch: librarianops-generateall-YYYY-MM-DD
+ 2. Create the branch librarianops-generateall, replacing any previous one
  3. Run librarian tidy
- 4. Run librarian update for configured sources (sources.discovery, sources.googleapis)
+ 4. Run librarian update for the repository sources
  5. Run librarian generate --all
- 6. Run cargo update --workspace (google-cloud-rust only)
+ 6. Run the repository post-generate commands
  7. Commit changes
- 8. Create a pull request
+ 8. Force-push the branch and create a pull request with the repository title,
+    body and labels, or update the pull request already open from the branch
+
+The steps specific to each repository are read from a YAML file listing the
+supported repositories, with their sources, post_generate commands and
+pull_request title, body and labels. A default file is built into
+librarianops, use --config to replace it. When sources is empty, the
+googleapis and discovery sources are updated, if librarian.yaml configures
+them.
 
 Flags:
 
-	-C directory  work in directory (repo name inferred from basename)
-	-v            run librarian with verbose output
-	--docker      run librarian in Docker
+	-C directory    work in directory (repo name inferred from basename)
+	-v              run librarian with verbose output
+	--docker        run librarian in Docker
+	--config file   read the supported repositories from file
+	--all           process all supported repositories
+	--jobs int      maximum number of repositories processed in parallel in batch mode (default: 4)
+	--summary file  write the batch mode summary to file
 
 # Upgrade librarian version in librarian.yaml
 
@@ -61,15 +83,30 @@ Examples:
 
 	librarianops upgrade google-cloud-rust
 	librarianops upgrade -C ~/workspace/google-cloud-rust
+	librarianops upgrade -C ~/workspace/google-cloud-rust --pr --report report.md
 
 For each repository, librarianops will:
  1. Get the latest librarian version from @main.
- 2. Update the version field in librarian.yaml.
- 3. Run 'librarian generate --all'.
+ 2. Run 'librarian tidy' and generate each library with the current version.
+ 3. Discard the changes of the current version, with 'git checkout -- .'
+    and 'git clean -fd', and update the version field in librarian.yaml.
+ 4. Run 'librarian tidy' and generate each library with the new version.
+ 5. Compare the files generated and the libraries that failed with each
+    version, and print the comparison report.
+ 6. With --pr, commit the changes to the branch librarianops-upgrade and
+    create a pull request with the report, or update the pull request already
+    open from the branch.
+
+The upgrade is refused if the new version fails to run tidy or to generate a
+library that the current version generates. Use --label-regression to create
+the pull request anyway, with the librarian-regression label.
 
 Flags:
 
-	-C directory  work in directory (repo name inferred from basename)
-	-v            run librarian with verbose output
+	-C directory        work in directory (repo name inferred from basename)
+	-v                  run librarian with verbose output
+	--pr                commit the upgrade and create a pull request with the report
+	--label-regression  create the pull request with a label instead of refusing when the new version regresses
+	--report file       write the comparison report to file
 */
 package main
@@ -0,0 +1,506 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+// ErrNotRecorded indicates that a command run while replaying a cassette does
+// not match any interaction in the cassette.
+var ErrNotRecorded = errors.New("command not recorded in cassette")
+
+// Cassette is a recording of the commands run through this package, which can
+// be replayed so that tests exercise code that runs external tools, such as
+// protoc, Maven, pnpm or cargo, without installing them.
+//
+// Paths in a cassette are relative to its roots, so that a cassette recorded
+// in one temporary directory can be replayed in another. The files that a
+// command creates, changes or deletes in the output roots are recorded with
+// the command and written back when it is replayed. The files in all roots,
+// such as the sources a generator reads, must be unchanged for a command to
+// be replayed.
+//
+// Commands that run concurrently while a cassette is recorded or replayed
+// are serialized, so that the files written by each command are attributed
+// to it. Code that runs commands in goroutines must not depend on the order
+// in which they run.
+type Cassette struct {
+	// Interactions lists the recorded commands, in the order they ran.
+	Interactions []*Interaction `yaml:"interactions,omitempty"`
+
+	// Roots are the directories whose paths are replaced by placeholders in
+	// the cassette. They are not saved with the cassette, and must be set
+	// before the cassette is recorded or replayed.
+	Roots []*Root `yaml:"-"`
+
+	mu     sync.Mutex
+	played []bool
+}
+
+// Root is a directory whose path is written as ${Name} in a cassette.
+type Root struct {
+	// Name is the name of the placeholder of the directory.
+	Name string
+
+	// Dir is the directory.
+	Dir string
+
+	// Output reports whether commands write to the directory. Files that
+	// commands create, change or delete in output roots are recorded. The
+	// inputs hash of each command covers the files of all roots.
+	Output bool
+}
+
+// Interaction is a command recorded in a cassette.
+type Interaction struct {
+	// Args are the command and its arguments. The command is the executable
+	// that ran, after applying the overrides set by WithTools.
+	Args []string `yaml:"args"`
+
+	// Dir is the directory in which the command ran, or empty for the
+	// working directory of the process.
+	Dir string `yaml:"dir,omitempty"`
+
+	// Env are the environment variables passed to the command in addition to
+	// the environment of the process, including those set by WithTools.
+	Env map[string]string `yaml:"env,omitempty"`
+
+	// InputsHash is the SHA256 checksum of the files in the roots before the
+	// command ran. A command is only replayed if the files have the same
+	// content as when it was recorded.
+	InputsHash string `yaml:"inputs_hash"`
+
+	// Files are the files that the command created, changed or deleted in
+	// the output roots.
+	Files []*RecordedFile `yaml:"files,omitempty"`
+
+	// Stdout is the standard output of the command.
+	Stdout string `yaml:"stdout,omitempty"`
+
+	// Stderr is the standard error of the command.
+	Stderr string `yaml:"stderr,omitempty"`
+
+	// ExitCode is the exit code of the command.
+	ExitCode int `yaml:"exit_code,omitempty"`
+}
+
+// RecordedFile is a file created, changed or deleted by a recorded command.
+type RecordedFile struct {
+	// Path is the path of the file.
+	Path string `yaml:"path"`
+
+	// Mode is the permission bits of the file.
+	Mode fs.FileMode `yaml:"mode,omitempty"`
+
+	// Content is the content of the file, encoded in base64 if Base64 is
+	// set.
+	Content string `yaml:"content,omitempty"`
+
+	// Base64 reports whether Content is encoded in base64, which is the
+	// case for files that are not valid UTF-8.
+	Base64 bool `yaml:"base64,omitempty"`
+
+	// Deleted reports whether the command deleted the file.
+	Deleted bool `yaml:"deleted,omitempty"`
+}
+
+// ReadCassette reads the cassette at path.
+func ReadCassette(path string) (*Cassette, error) {
+	c, err := yaml.Read[Cassette](path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// WriteCassette writes c to path.
+func WriteCassette(path string, c *Cassette) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return yaml.Write(path, c)
+}
+
+// cassetteKey is the context key of the cassette set by Cassette.Record and
+// Cassette.Replay.
+type cassetteKey struct{}
+
+// cassetteMode is the cassette set in a context, and whether it is replayed.
+type cassetteMode struct {
+	cassette *Cassette
+	replay   bool
+}
+
+// Record returns a copy of ctx in which the commands run with ctx are run and
+// recorded in c.
+func (c *Cassette) Record(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cassetteKey{}, &cassetteMode{cassette: c})
+}
+
+// Replay returns a copy of ctx in which the commands run with ctx are not run,
+// but replayed from the interactions of c. Each interaction is replayed once.
+// Commands that do not match an unplayed interaction fail with
+// ErrNotRecorded.
+func (c *Cassette) Replay(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cassetteKey{}, &cassetteMode{cassette: c, replay: true})
+}
+
+// Unplayed returns the interactions of c that have not been replayed.
+func (c *Cassette) Unplayed() []*Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var unplayed []*Interaction
+	for i, in := range c.Interactions {
+		if i >= len(c.played) || !c.played[i] {
+			unplayed = append(unplayed, in)
+		}
+	}
+	return unplayed
+}
+
+// cassetteFrom returns the cassette set in ctx, or nil if there is none.
+func cassetteFrom(ctx context.Context) *cassetteMode {
+	m, _ := ctx.Value(cassetteKey{}).(*cassetteMode)
+	return m
+}
+
+// run records or replays a command. When streaming is true, the output of
+// the command is also written to the stdout and stderr writers of the
+// package, as RunStreaming does. It returns the standard output of the
+// command.
+func (m *cassetteMode) run(ctx context.Context, dir string, env map[string]string, streaming bool, command string, arg ...string) (string, error) {
+	// Record the executable and the environment that the command runs
+	// with, so that replaying catches changes to the tools set by WithTools.
+	command = GetExecutablePath(toolsOverrides(ctx), command)
+	env = withToolsEnv(ctx, env)
+	c := m.cassette
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	before, err := c.snapshot(true)
+	if err != nil {
+		return "", err
+	}
+	inputs, err := c.snapshot(false)
+	if err != nil {
+		return "", err
+	}
+	maps.Copy(inputs, before)
+	want := &Interaction{
+		Args:       c.normalizeAll(append([]string{command}, arg...)),
+		Dir:        c.normalize(dir),
+		InputsHash: inputs.hash(),
+	}
+	if len(env) > 0 {
+		want.Env = map[string]string{}
+		for k, v := range env {
+			want.Env[k] = c.normalize(v)
+		}
+	}
+	if m.replay {
+		return c.replay(want, streaming)
+	}
+	return c.record(ctx, want, before, dir, env, streaming, command, arg...)
+}
+
+// record runs a command, and appends it to c as want, with the files it
+// changed since before and its output.
+func (c *Cassette) record(ctx context.Context, want *Interaction, before snapshot, dir string, env map[string]string, streaming bool, command string, arg ...string) (string, error) {
+	cmd := newCmd(ctx, dir, env, command, arg...)
+	var outBuf, errBuf bytes.Buffer
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+	if streaming {
+		cmd.Stdout = io.MultiWriter(&outBuf, stdout)
+		cmd.Stderr = io.MultiWriter(&errBuf, stderr)
+	}
+	runErr := cmd.Run()
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return "", fmt.Errorf("%s: %w", cmd, runErr)
+	}
+	after, err := c.snapshot(true)
+	if err != nil {
+		return "", err
+	}
+	files, err := c.changedFiles(before, after)
+	if err != nil {
+		return "", err
+	}
+	want.Files = files
+	want.Stdout = c.normalize(outBuf.String())
+	want.Stderr = c.normalize(errBuf.String())
+	if exitErr != nil {
+		want.ExitCode = exitErr.ExitCode()
+	}
+	c.Interactions = append(c.Interactions, want)
+	if runErr != nil {
+		return "", commandError(cmd.String(), errBuf.String(), streaming, runErr)
+	}
+	return outBuf.String(), nil
+}
+
+// replay finds the first unplayed interaction of c that matches want, writes
+// its files and returns its output.
+func (c *Cassette) replay(want *Interaction, streaming bool) (string, error) {
+	args := c.denormalizeAll(want.Args)
+	if Verbose {
+		fmt.Fprintf(stdout, "%s\n", strings.Join(args, " "))
+	}
+	in, err := c.find(want)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range in.Files {
+		if err := c.writeFile(f); err != nil {
+			return "", err
+		}
+	}
+	out := c.denormalize(in.Stdout)
+	errOut := c.denormalize(in.Stderr)
+	if streaming {
+		if _, err := io.WriteString(stdout, out); err != nil {
+			return "", err
+		}
+		if _, err := io.WriteString(stderr, errOut); err != nil {
+			return "", err
+		}
+	}
+	if in.ExitCode != 0 {
+		return "", commandError(strings.Join(args, " "), errOut, streaming, &replayedExitError{code: in.ExitCode})
+	}
+	return out, nil
+}
+
+// find returns the first unplayed interaction of c that matches want, and
+// marks it as played.
+func (c *Cassette) find(want *Interaction) (*Interaction, error) {
+	if len(c.played) < len(c.Interactions) {
+		c.played = append(c.played, make([]bool, len(c.Interactions)-len(c.played))...)
+	}
+	sameCommand := false
+	for i, in := range c.Interactions {
+		if c.played[i] || !slices.Equal(in.Args, want.Args) || in.Dir != want.Dir || !maps.Equal(in.Env, want.Env) {
+			continue
+		}
+		if in.InputsHash != want.InputsHash {
+			sameCommand = true
+			continue
+		}
+		c.played[i] = true
+		return in, nil
+	}
+	if sameCommand {
+		return nil, fmt.Errorf("%w: %s: the files in the roots changed since it was recorded", ErrNotRecorded, strings.Join(want.Args, " "))
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotRecorded, strings.Join(want.Args, " "))
+}
+
+// commandError returns the error of a failed command in the format of
+// runCmd, or of RunStreaming if streaming is true.
+func commandError(cmd, stderr string, streaming bool, err error) error {
+	if streaming {
+		return fmt.Errorf("%s: %w", cmd, err)
+	}
+	return fmt.Errorf("%s: %s: %w", cmd, stderr, err)
+}
+
+// replayedExitError is the error of a replayed command that exited with a
+// non-zero exit code.
+type replayedExitError struct {
+	code int
+}
+
+// Error returns the exit status, in the format of [exec.ExitError].
+func (e *replayedExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+// fileState is the state of a file in a snapshot.
+type fileState struct {
+	sum  [sha256.Size]byte
+	mode fs.FileMode
+}
+
+// snapshot maps the normalized path of each regular file in a set of roots to
+// its state.
+type snapshot map[string]fileState
+
+// hash returns the SHA256 checksum of the paths, content and modes of the
+// files in s.
+func (s snapshot) hash() string {
+	h := sha256.New()
+	for _, path := range slices.Sorted(maps.Keys(s)) {
+		fmt.Fprintf(h, "%s\x00%x\x00%o\n", path, s[path].sum, s[path].mode)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// snapshot returns the state of the files in the roots of c whose Output is
+// output.
+func (c *Cassette) snapshot(output bool) (snapshot, error) {
+	s := snapshot{}
+	for _, root := range c.Roots {
+		if root.Output != output {
+			continue
+		}
+		err := filepath.WalkDir(root.Dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			s[c.normalize(path)] = fileState{sum: sha256.Sum256(data), mode: info.Mode().Perm()}
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("snapshotting %s: %w", root.Dir, err)
+		}
+	}
+	return s, nil
+}
+
+// changedFiles returns the files created, changed or deleted between before
+// and after, sorted by path.
+func (c *Cassette) changedFiles(before, after snapshot) ([]*RecordedFile, error) {
+	var files []*RecordedFile
+	for path, state := range after {
+		if old, ok := before[path]; ok && old == state {
+			continue
+		}
+		data, err := os.ReadFile(c.denormalize(path))
+		if err != nil {
+			return nil, err
+		}
+		f := &RecordedFile{Path: path, Mode: state.mode, Content: string(data)}
+		if !utf8.Valid(data) {
+			f.Content = base64.StdEncoding.EncodeToString(data)
+			f.Base64 = true
+		}
+		files = append(files, f)
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			files = append(files, &RecordedFile{Path: path, Deleted: true})
+		}
+	}
+	slices.SortFunc(files, func(a, b *RecordedFile) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+	return files, nil
+}
+
+// writeFile writes, or deletes, a recorded file.
+func (c *Cassette) writeFile(f *RecordedFile) error {
+	path := c.denormalize(f.Path)
+	if f.Deleted {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data := []byte(f.Content)
+	if f.Base64 {
+		var err error
+		if data, err = base64.StdEncoding.DecodeString(f.Content); err != nil {
+			return fmt.Errorf("decoding %s: %w", f.Path, err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	mode := cmp.Or(f.Mode, 0644)
+	if err := os.WriteFile(path, data, mode); err != nil {
+		return err
+	}
+	// WriteFile does not change the mode of existing files.
+	return os.Chmod(path, mode)
+}
+
+// sortedRoots returns the roots of c, longest directory first, so that
+// nested roots are replaced before the roots that contain them.
+func (c *Cassette) sortedRoots() []*Root {
+	roots := slices.Clone(c.Roots)
+	slices.SortStableFunc(roots, func(a, b *Root) int {
+		return cmp.Compare(len(b.Dir), len(a.Dir))
+	})
+	return roots
+}
+
+// normalize replaces the directories of the roots of c in s with their
+// placeholders.
+func (c *Cassette) normalize(s string) string {
+	for _, root := range c.sortedRoots() {
+		if root.Dir != "" {
+			s = strings.ReplaceAll(s, root.Dir, placeholder(root))
+		}
+	}
+	return s
+}
+
+// denormalize replaces the placeholders of the roots of c in s with their
+// directories.
+func (c *Cassette) denormalize(s string) string {
+	for _, root := range c.Roots {
+		s = strings.ReplaceAll(s, placeholder(root), root.Dir)
+	}
+	return s
+}
+
+func (c *Cassette) normalizeAll(s []string) []string {
+	normalized := make([]string, len(s))
+	for i, v := range s {
+		normalized[i] = c.normalize(v)
+	}
+	return normalized
+}
+
+func (c *Cassette) denormalizeAll(s []string) []string {
+	denormalized := make([]string, len(s))
+	for i, v := range s {
+		denormalized[i] = c.denormalize(v)
+	}
+	return denormalized
+}
+
+func placeholder(root *Root) string {
+	return "${" + root.Name + "}"
+}
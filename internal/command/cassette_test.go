@@ -0,0 +1,297 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+const cassetteScript = "echo $LIBRARIAN_TEST_VAR in $PWD; mkdir -p sub; printf 'out' > sub/out.txt; printf '\\377' > bin; rm old.txt"
+
+// newCassetteWorkspace returns a directory containing old.txt, used as the
+// output root of the cassettes in tests.
+func newCassetteWorkspace(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "old.txt"), []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestCassette_Record(t *testing.T) {
+	dir := newCassetteWorkspace(t)
+	c := &Cassette{Roots: []*Root{{Name: "workspace", Dir: dir, Output: true}}}
+	env := map[string]string{envVarName: dir}
+	got, err := runCmd(c.Record(t.Context()), dir, env, "/bin/sh", "-c", cassetteScript)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := dir + " in " + dir + "\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	want := []*Interaction{{
+		Args:   []string{"/bin/sh", "-c", cassetteScript},
+		Dir:    "${workspace}",
+		Env:    map[string]string{envVarName: "${workspace}"},
+		Stdout: "${workspace} in ${workspace}\n",
+		Files: []*RecordedFile{
+			{Path: "${workspace}/bin", Mode: 0644, Content: "/w==", Base64: true},
+			{Path: "${workspace}/old.txt", Deleted: true},
+			{Path: "${workspace}/sub/out.txt", Mode: 0644, Content: "out"},
+		},
+	}}
+	if diff := cmp.Diff(want, c.Interactions, cmpopts.IgnoreFields(Interaction{}, "InputsHash")); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCassette_Replay(t *testing.T) {
+	recordDir := newCassetteWorkspace(t)
+	recorded := &Cassette{Roots: []*Root{{Name: "workspace", Dir: recordDir, Output: true}}}
+	env := map[string]string{envVarName: recordDir}
+	if _, err := runCmd(recorded.Record(t.Context()), recordDir, env, "/bin/sh", "-c", cassetteScript); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "cassette.yaml")
+	if err := WriteCassette(path, recorded); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := ReadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := newCassetteWorkspace(t)
+	c.Roots = []*Root{{Name: "workspace", Dir: dir, Output: true}}
+	// The command must not run.
+	t.Setenv("PATH", "")
+	env = map[string]string{envVarName: dir}
+	got, err := runCmd(c.Replay(t.Context()), dir, env, "/bin/sh", "-c", cassetteScript)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := dir + " in " + dir + "\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "sub", "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "out" {
+		t.Errorf("sub/out.txt = %q, want %q", content, "out")
+	}
+	content, err = os.ReadFile(filepath.Join(dir, "bin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, []byte{0xff}) {
+		t.Errorf("bin = %q, want %q", content, []byte{0xff})
+	}
+	if _, err := os.Stat(filepath.Join(dir, "old.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("old.txt was not deleted: %v", err)
+	}
+	if unplayed := c.Unplayed(); len(unplayed) != 0 {
+		t.Errorf("Unplayed() = %v, want none", unplayed)
+	}
+
+	// Each interaction is replayed once.
+	_, err = runCmd(c.Replay(t.Context()), dir, env, "/bin/sh", "-c", cassetteScript)
+	if !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("second replay error = %v, want %v", err, ErrNotRecorded)
+	}
+}
+
+func TestCassette_ReplayNotRecorded(t *testing.T) {
+	dir := newCassetteWorkspace(t)
+	c := &Cassette{Roots: []*Root{{Name: "workspace", Dir: dir, Output: true}}}
+	ctx := c.Record(t.Context())
+	if err := RunInDir(ctx, dir, "/bin/sh", "-c", "true"); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name    string
+		args    []string
+		setup   func() error
+		wantMsg string
+	}{
+		{
+			name:    "different arguments",
+			args:    []string{"-c", "false"},
+			wantMsg: "/bin/sh -c false",
+		},
+		{
+			name: "inputs changed",
+			args: []string{"-c", "true"},
+			setup: func() error {
+				return os.WriteFile(filepath.Join(dir, "old.txt"), []byte("changed"), 0644)
+			},
+			wantMsg: "changed since it was recorded",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if test.setup != nil {
+				if err := test.setup(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := RunInDir(c.Replay(t.Context()), dir, "/bin/sh", test.args...)
+			if !errors.Is(err, ErrNotRecorded) {
+				t.Fatalf("RunInDir() error = %v, want %v", err, ErrNotRecorded)
+			}
+			if !strings.Contains(err.Error(), test.wantMsg) {
+				t.Errorf("RunInDir() error = %v, want it to contain %q", err, test.wantMsg)
+			}
+		})
+	}
+}
+
+func TestCassette_WithTools(t *testing.T) {
+	dir := newCassetteWorkspace(t)
+	toolsDir := t.TempDir()
+	binDir := filepath.Join(toolsDir, "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(binDir, "greet"), []byte("#!/bin/sh\necho hello\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	c := &Cassette{Roots: []*Root{
+		{Name: "workspace", Dir: dir, Output: true},
+		{Name: "tools", Dir: toolsDir},
+	}}
+	withTools := func(ctx context.Context, exe string) context.Context {
+		return WithTools(ctx,
+			map[string]string{"greet": exe},
+			map[string]string{envPath: binDir, "TOOL_VAR": "1"})
+	}
+	exe := filepath.Join(binDir, "greet")
+	if err := RunInDir(withTools(c.Record(t.Context()), exe), dir, "greet"); err != nil {
+		t.Fatal(err)
+	}
+	want := []*Interaction{{
+		Args:   []string{"${tools}/bin/greet"},
+		Dir:    "${workspace}",
+		Env:    map[string]string{envPath: "${tools}/bin", "TOOL_VAR": "1"},
+		Stdout: "hello\n",
+	}}
+	if diff := cmp.Diff(want, c.Interactions, cmpopts.IgnoreFields(Interaction{}, "InputsHash")); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, test := range []struct {
+		name string
+		ctx  context.Context
+	}{
+		{name: "without tools", ctx: c.Replay(t.Context())},
+		{name: "different executable", ctx: withTools(c.Replay(t.Context()), "/usr/local/bin/greet")},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := RunInDir(test.ctx, dir, "greet"); !errors.Is(err, ErrNotRecorded) {
+				t.Errorf("RunInDir() error = %v, want %v", err, ErrNotRecorded)
+			}
+		})
+	}
+	if err := RunInDir(withTools(c.Replay(t.Context()), exe), dir, "greet"); err != nil {
+		t.Errorf("RunInDir() error = %v, want the recorded interaction", err)
+	}
+}
+
+func TestCassette_InputRoot(t *testing.T) {
+	dir := t.TempDir()
+	sources := t.TempDir()
+	if err := os.WriteFile(filepath.Join(sources, "api.proto"), []byte("v1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := &Cassette{Roots: []*Root{
+		{Name: "workspace", Dir: dir, Output: true},
+		{Name: "sources", Dir: sources},
+	}}
+	script := "cp " + filepath.Join(sources, "api.proto") + " out.txt"
+	if err := RunInDir(c.Record(t.Context()), dir, "/bin/sh", "-c", script); err != nil {
+		t.Fatal(err)
+	}
+	want := []*RecordedFile{{Path: "${workspace}/out.txt", Mode: 0644, Content: "v1"}}
+	if diff := cmp.Diff(want, c.Interactions[0].Files); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	// The files of the workspace are unchanged, but the command read a
+	// source that changed since it was recorded.
+	if err := os.Remove(filepath.Join(dir, "out.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sources, "api.proto"), []byte("v2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := RunInDir(c.Replay(t.Context()), dir, "/bin/sh", "-c", script)
+	if !errors.Is(err, ErrNotRecorded) {
+		t.Fatalf("RunInDir() error = %v, want %v", err, ErrNotRecorded)
+	}
+	if want := "changed since it was recorded"; !strings.Contains(err.Error(), want) {
+		t.Errorf("RunInDir() error = %v, want it to contain %q", err, want)
+	}
+}
+
+func TestCassette_ExitCode(t *testing.T) {
+	dir := t.TempDir()
+	c := &Cassette{Roots: []*Root{{Name: "workspace", Dir: dir, Output: true}}}
+	recordErr := Run(c.Record(t.Context()), "/bin/sh", "-c", "echo oops >&2; exit 3")
+	if recordErr == nil {
+		t.Fatal("Run() error = nil, want error")
+	}
+	if got := c.Interactions[0].ExitCode; got != 3 {
+		t.Errorf("ExitCode = %d, want 3", got)
+	}
+	replayErr := Run(c.Replay(t.Context()), "/bin/sh", "-c", "echo oops >&2; exit 3")
+	if replayErr == nil {
+		t.Fatal("replayed Run() error = nil, want error")
+	}
+	if diff := cmp.Diff(recordErr.Error(), replayErr.Error()); diff != "" {
+		t.Errorf("mismatch (-recorded +replayed):\n%s", diff)
+	}
+}
+
+func TestCassette_Streaming(t *testing.T) {
+	t.Cleanup(func() {
+		stdout = os.Stdout
+		stderr = os.Stderr
+	})
+	c := &Cassette{}
+	for _, mode := range []string{"record", "replay"} {
+		var outBuf, errBuf bytes.Buffer
+		stdout = &outBuf
+		stderr = &errBuf
+		runCtx := c.Record(t.Context())
+		if mode == "replay" {
+			runCtx = c.Replay(t.Context())
+		}
+		if err := RunStreaming(runCtx, "/bin/sh", "-c", "echo out && echo >&2 err"); err != nil {
+			t.Fatalf("%s: %v", mode, err)
+		}
+		if outBuf.String() != "out\n" || errBuf.String() != "err\n" {
+			t.Errorf("%s: stdout = %q, stderr = %q, want %q and %q", mode, outBuf.String(), errBuf.String(), "out\n", "err\n")
+		}
+	}
+}
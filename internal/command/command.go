@@ -13,6 +13,7 @@
 // limitations under the License.
 
 // Package command provides helpers to execute external commands with logging.
+// The commands can be recorded in a [Cassette] and replayed in tests.
 package command
 
 import (
@@ -20,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -92,6 +94,10 @@ func RunStreaming(ctx context.Context, command string, arg ...string) error {
 // RunStreamingInDir runs the given binary in a specific directory,
 // setting its output and errors streams to those of the current process.
 func RunStreamingInDir(ctx context.Context, dir, command string, arg ...string) error {
+	if m := cassetteFrom(ctx); m != nil {
+		_, err := m.run(ctx, dir, nil, true, command, arg...)
+		return err
+	}
 	cmd := buildCmd(ctx, dir, nil, command, arg...)
 	cmd.Stderr = stderr
 	cmd.Stdout = stdout
@@ -108,6 +114,12 @@ func Output(ctx context.Context, command string, arg ...string) (string, error)
 	return OutputWithEnv(ctx, nil, command, arg...)
 }
 
+// OutputInDir executes a program (with arguments) in a specific directory and
+// returns stdout. On error, stderr is included in the error message.
+func OutputInDir(ctx context.Context, dir, command string, arg ...string) (string, error) {
+	return runCmd(ctx, dir, nil, command, arg...)
+}
+
 // OutputWithEnv executes a program (with arguments) and optional environment
 // variables and returns stdout. If env is nil or empty, the command inherits
 // the environment of the calling process. On error, stderr is included in the
@@ -116,7 +128,55 @@ func OutputWithEnv(ctx context.Context, env map[string]string, command string, a
 	return runCmd(ctx, "", env, command, arg...)
 }
 
+// toolsKey is the context key of the tools set by WithTools.
+type toolsKey struct{}
+
+// tools are the executable overrides and environment set by WithTools.
+type tools struct {
+	overrides map[string]string
+	env       map[string]string
+}
+
+// WithTools returns a copy of ctx in which the commands run with ctx are
+// resolved with GetExecutablePath using overrides, and run with env added to
+// their environment. The PATH in env is prepended to the PATH of the calling
+// process, after the PATH in the environment passed to the command, if any.
+func WithTools(ctx context.Context, overrides, env map[string]string) context.Context {
+	return context.WithValue(ctx, toolsKey{}, &tools{overrides: overrides, env: env})
+}
+
+// withToolsEnv returns env merged into the environment set by WithTools in
+// ctx. Variables in env take precedence, except PATH, which is combined.
+func withToolsEnv(ctx context.Context, env map[string]string) map[string]string {
+	t, ok := ctx.Value(toolsKey{}).(*tools)
+	if !ok || len(t.env) == 0 {
+		return env
+	}
+	merged := maps.Clone(t.env)
+	for k, v := range env {
+		if k == envPath && merged[envPath] != "" {
+			v = fmt.Sprintf("%s:%s", v, merged[envPath])
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// toolsOverrides returns the executable overrides set by WithTools in ctx.
+func toolsOverrides(ctx context.Context) map[string]string {
+	if t, ok := ctx.Value(toolsKey{}).(*tools); ok {
+		return t.overrides
+	}
+	return nil
+}
+
 func buildCmd(ctx context.Context, dir string, env map[string]string, command string, arg ...string) *exec.Cmd {
+	return newCmd(ctx, dir, withToolsEnv(ctx, env), GetExecutablePath(toolsOverrides(ctx), command), arg...)
+}
+
+// newCmd returns the command to run. Unlike buildCmd, it does not apply the
+// tools set by WithTools, which must already be resolved in command and env.
+func newCmd(ctx context.Context, dir string, env map[string]string, command string, arg ...string) *exec.Cmd {
 	// Merge system PATH env with the provided environment variables.
 	pathEnv := os.Getenv(envPath)
 	if env != nil {
@@ -171,6 +231,9 @@ func lookPath(cmdName string, pathEnv string) (string, error) {
 }
 
 func runCmd(ctx context.Context, dir string, env map[string]string, command string, arg ...string) (string, error) {
+	if m := cassetteFrom(ctx); m != nil {
+		return m.run(ctx, dir, env, false, command, arg...)
+	}
 	cmd := buildCmd(ctx, dir, env, command, arg...)
 	output, err := cmd.Output()
 	if err != nil {
@@ -98,6 +98,21 @@ func TestOutput(t *testing.T) {
 	}
 }
 
+func TestOutputInDir(t *testing.T) {
+	dir := t.TempDir()
+	got, err := OutputInDir(t.Context(), dir, "pwd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, strings.TrimSpace(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestOutput_Error(t *testing.T) {
 	_, err := Output(t.Context(), Go, invalidSubcommand)
 	if err == nil {
@@ -153,6 +168,69 @@ func TestGetExecutablePath(t *testing.T) {
 	}
 }
 
+func TestWithTools(t *testing.T) {
+	binDir := t.TempDir()
+	tool := filepath.Join(binDir, "tool-impl")
+	script := fmt.Sprintf("#!/bin/sh\necho \"$%s $OTHER_VAR\"\n", envVarName)
+	if err := os.WriteFile(tool, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithTools(t.Context(),
+		map[string]string{"librarian-test-tool": tool},
+		map[string]string{envVarName: envVarValue, envPath: binDir})
+
+	got, err := OutputWithEnv(ctx, map[string]string{"OTHER_VAR": "other"}, "librarian-test-tool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(envVarValue+" other\n", got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWithToolsEnv(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		toolsEnv map[string]string
+		env      map[string]string
+		want     map[string]string
+	}{
+		{
+			name: "no tools",
+			env:  map[string]string{"A": "a"},
+			want: map[string]string{"A": "a"},
+		},
+		{
+			name:     "env takes precedence",
+			toolsEnv: map[string]string{"A": "tools", "B": "b"},
+			env:      map[string]string{"A": "a"},
+			want:     map[string]string{"A": "a", "B": "b"},
+		},
+		{
+			name:     "path is combined",
+			toolsEnv: map[string]string{envPath: "/tools/bin"},
+			env:      map[string]string{envPath: "/env/bin"},
+			want:     map[string]string{envPath: "/env/bin:/tools/bin"},
+		},
+		{
+			name:     "nil env",
+			toolsEnv: map[string]string{envPath: "/tools/bin"},
+			want:     map[string]string{envPath: "/tools/bin"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := t.Context()
+			if test.toolsEnv != nil {
+				ctx = WithTools(ctx, nil, test.toolsEnv)
+			}
+			got := withToolsEnv(ctx, test.env)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestVerbose(t *testing.T) {
 	t.Cleanup(func() {
 		Verbose = false
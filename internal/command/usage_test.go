@@ -50,6 +50,7 @@ func TestLibrarianUsage(t *testing.T) {
 		{"publish", []string{"publish"}, "librarian publish"},
 		{"tag", []string{"tag"}, "librarian tag"},
 		{"config", []string{"config"}, "librarian config [get|set] [path] [value]"},
+		{"docs", []string{"docs"}, "librarian docs [<library> | --all]"},
 	} {
 		t.Run(test.desc, func(t *testing.T) {
 			got := runUsage(t, bin, test.args)
@@ -68,7 +69,7 @@ func TestLibrarianopsUsage(t *testing.T) {
 		want string
 	}{
 		{"root", nil, "librarianops [command]"},
-		{"generate", []string{"generate"}, "librarianops generate [<repo> | -C <dir>]"},
+		{"generate", []string{"generate"}, "librarianops generate [<repo>... | --all | -C <dir>]"},
 	} {
 		t.Run(test.desc, func(t *testing.T) {
 			got := runUsage(t, bin, test.args)
@@ -117,6 +117,9 @@ type CargoTool struct {
 
 	// Version is the version to install.
 	Version string `yaml:"version"`
+
+	// Checksum is the SHA256 checksum of the .crate file.
+	Checksum string `yaml:"checksum,omitempty"`
 }
 
 // PNPMTool defines a tool to install via pnpm.
@@ -154,6 +157,10 @@ type MavenTool struct {
 	// Classifier is the classifier of the Maven artifact.
 	Classifier string `yaml:"classifier,omitempty"`
 
+	// Checksum is the SHA256 checksum of the artifact file. It is ignored
+	// when LocalPath is set.
+	Checksum string `yaml:"checksum,omitempty"`
+
 	// Packaging is the Maven packaging. Acceptable values are lowercase "jar" and "exe".
 	// If the packaging is "exe", the wrapper script executes it directly.
 	// Otherwise, it executes the tool using "java -jar".
@@ -180,6 +187,13 @@ type PipTool struct {
 
 	// LocalPath is the path to a local Python package to install.
 	LocalPath string `yaml:"local_path,omitempty"`
+
+	// Checksum is the SHA256 checksum of the downloaded distribution file. It
+	// is ignored when LocalPath is set or Package is a URL. Set it only for
+	// tools published as a single file for all platforms; for tools published
+	// as platform-specific wheels, librarian-lock.yaml records the checksum of
+	// each wheel by file name.
+	Checksum string `yaml:"checksum,omitempty"`
 }
 
 // GoTool defines a tool to install via go.
@@ -189,6 +203,9 @@ type GoTool struct {
 
 	// Version is the version to install.
 	Version string `yaml:"version,omitempty"`
+
+	// Checksum is the SHA256 checksum of the module zip file.
+	Checksum string `yaml:"checksum,omitempty"`
 }
 
 // Default contains default settings for all libraries.
@@ -203,9 +220,22 @@ type Default struct {
 	// this is src/generated.
 	Output string `yaml:"output,omitempty"`
 
+	// PostGenerate lists the edits applied to the generated code of every
+	// library, before the edits of the library itself.
+	PostGenerate []*PostGenerateStep `yaml:"post_generate,omitempty"`
+
 	// TagFormat is the template for git tags, such as "{name}/v{version}".
 	TagFormat string `yaml:"tag_format,omitempty"`
 
+	// TemplateOverlay is a directory with templates that replace the
+	// templates embedded in the generator, for the languages generated by
+	// sidekick. The directory mirrors the layout of the embedded templates.
+	// For Rust, the overlay applies to the directory selected by
+	// rust.template_override. Rust libraries with prost or tonic modules do
+	// not support overlays. Run `librarian generate --list-template-overlays`
+	// to list the replaced templates.
+	TemplateOverlay string `yaml:"template_overlay,omitempty"`
+
 	// Language-specific fields are below.
 
 	// Dart contains Dart-specific default configuration.
@@ -281,10 +311,19 @@ type Library struct {
 	// markdown generation and release tracking passes.
 	Keep []string `yaml:"keep,omitempty"`
 
+	// Lint configures the lint rules applied to the API model of this
+	// library.
+	Lint *Lint `yaml:"lint,omitempty"`
+
 	// Output is the directory where code is written. This overrides
 	// Default.Output.
 	Output string `yaml:"output,omitempty"`
 
+	// PostGenerate lists the edits applied to the generated code after
+	// generation and before formatting, in order. They run after the edits
+	// in Default.PostGenerate.
+	PostGenerate []*PostGenerateStep `yaml:"post_generate,omitempty"`
+
 	// Roots specifies the source roots to use for generation. Defaults to googleapis.
 	Roots []string `yaml:"roots,omitempty"`
 
@@ -298,6 +337,18 @@ type Library struct {
 	// are "protobuf" (default) or "discovery".
 	SpecificationFormat string `yaml:"specification_format,omitempty"`
 
+	// TemplateOverlay is a directory with templates that replace the
+	// embedded templates when generating this library. This overrides
+	// Default.TemplateOverlay.
+	TemplateOverlay string `yaml:"template_overlay,omitempty"`
+
+	// Transforms lists the changes applied to the API model before
+	// generating code, in order. They apply to the APIs of the library, but
+	// not to the additional modules of Rust and Swift libraries. Only the
+	// Dart, Rust and Swift generators, and the sidekick generator for
+	// Node.js, support transforms.
+	Transforms []*Transform `yaml:"transforms,omitempty"`
+
 	// Language-specific fields are below.
 
 	// Dart contains Dart-specific library configuration.
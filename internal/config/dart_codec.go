@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// DartCodec contains the options of the Dart sidekick codec.
+//
+// Librarian computes these options from the [Library] and [DartPackage]
+// configuration, and from the `dart.codec` field of the library. They can
+// also be parsed from the string map used by older configurations, where each
+// field is keyed by its name in kebab-case, such as `issue-tracker-url`, and
+// the map entries are keyed as `proto:<package>`, `prefix:<package>` and
+// `package:<name>`.
+type DartCodec struct {
+	// CopyrightYear is the year when the files were first generated.
+	CopyrightYear string `yaml:"copyright_year,omitempty"`
+
+	// Version is the version of the generated package.
+	Version string `yaml:"version,omitempty"`
+
+	// NotForPublication is true for packages that are never published.
+	NotForPublication bool `yaml:"not_for_publication,omitempty"`
+
+	// TemplateOverlay is a directory with templates that replace the embedded
+	// templates.
+	TemplateOverlay string `yaml:"template_overlay,omitempty"`
+
+	// PackageNameOverride overrides the default package name.
+	PackageNameOverride string `yaml:"package_name_override,omitempty"`
+
+	// LibraryPathOverride is the path of the generated library, relative to
+	// the `lib/` directory of the package.
+	LibraryPathOverride string `yaml:"library_path_override,omitempty"`
+
+	// APIKeysEnvironmentVariables lists the environment variables that can
+	// contain an API key, such as "GOOGLE_API_KEY".
+	APIKeysEnvironmentVariables []string `yaml:"api_keys_environment_variables,omitempty"`
+
+	// IssueTrackerURL is the URL of the issue tracker for the service.
+	IssueTrackerURL string `yaml:"issue_tracker_url,omitempty"`
+
+	// RepositoryURL is the URL of the repository for the package.
+	RepositoryURL string `yaml:"repository_url,omitempty"`
+
+	// PartFile is the path to a part file included in the generated library.
+	PartFile string `yaml:"part_file,omitempty"`
+
+	// ExtraExports lists Dart `export` statements appended after the imports.
+	ExtraExports []string `yaml:"extra_exports,omitempty"`
+
+	// ExtraImports lists additional imports, such as "dart:math".
+	ExtraImports []string `yaml:"extra_imports,omitempty"`
+
+	// Dependencies lists packages added to `pubspec.yaml`, for use by
+	// handwritten code.
+	Dependencies []string `yaml:"dependencies,omitempty"`
+
+	// DevDependencies lists the development dependencies in `pubspec.yaml`.
+	DevDependencies []string `yaml:"dev_dependencies,omitempty"`
+
+	// SupportsSSE is true if the service supports Server-Sent Events.
+	SupportsSSE bool `yaml:"supports_sse,omitempty"`
+
+	// GenerateRpcSamples generates a sample for each RPC.
+	GenerateRpcSamples bool `yaml:"generate_rpc_samples,omitempty"`
+
+	// DefaultRetryPolicies sets the default timeout and retry policy of each
+	// method from the gRPC service config.
+	DefaultRetryPolicies bool `yaml:"default_retry_policies,omitempty"`
+
+	// UseWorkspace controls whether the package uses the pub workspace.
+	// Defaults to true.
+	UseWorkspace *bool `yaml:"use_workspace,omitempty"`
+
+	// SkipFormat skips running `dart format` on the generated code.
+	SkipFormat bool `yaml:"skip_format,omitempty"`
+
+	// ReadmeAfterTitleText is Markdown inserted in the README after the
+	// title.
+	ReadmeAfterTitleText string `yaml:"readme_after_title_text,omitempty"`
+
+	// ReadmeQuickstartText is Markdown used as the quickstart section of the
+	// README.
+	ReadmeQuickstartText string `yaml:"readme_quickstart_text,omitempty"`
+
+	// Protos maps protobuf packages, such as "google.protobuf", to Dart
+	// imports, such as "package:google_cloud_protobuf/protobuf.dart".
+	Protos map[string]string `yaml:"protos,omitempty"`
+
+	// Prefixes maps protobuf packages to the prefixes of their Dart imports.
+	Prefixes map[string]string `yaml:"prefixes,omitempty"`
+
+	// Packages maps Dart packages, such as "http", to version constraints,
+	// such as "^1.3.0".
+	Packages map[string]string `yaml:"packages,omitempty"`
+}
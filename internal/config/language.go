@@ -116,6 +116,12 @@ type RustDefault struct {
 
 	// ResourceNameHeuristic indicates whether to apply heuristics to identify and generate resource names.
 	ResourceNameHeuristic *bool `yaml:"resource_name_heuristic,omitempty"`
+
+	// Codec sets options of the Rust codec directly, for the options without
+	// a dedicated field. They apply to the crate, not to its modules, and
+	// take precedence over the options computed from the other fields.
+	// `librarian tidy` validates them.
+	Codec *RustCodec `yaml:"codec,omitempty"`
 }
 
 // RustModule defines a generation target within a veneer crate.
@@ -224,7 +230,10 @@ type RustCrate struct {
 	// ModulePath is the module path for the crate.
 	ModulePath string `yaml:"module_path,omitempty"`
 
-	// TemplateOverride overrides the default template.
+	// TemplateOverride selects the embedded template directory used to
+	// generate the crate, such as "templates/grpc-client". The templates in
+	// the library's template_overlay replace the templates in this
+	// directory, as they do for the default directory.
 	TemplateOverride string `yaml:"template_override,omitempty"`
 
 	// PackageNameOverride overrides the package name.
@@ -397,6 +406,11 @@ type DartPackage struct {
 	// that can contain API keys (e.g., "GOOGLE_API_KEY,GEMINI_API_KEY").
 	APIKeysEnvironmentVariables string `yaml:"api_keys_environment_variables,omitempty"`
 
+	// Codec sets options of the Dart codec directly, for the options without
+	// a dedicated field. They take precedence over the options computed from
+	// the other fields. `librarian tidy` validates them.
+	Codec *DartCodec `yaml:"codec,omitempty"`
+
 	// Dependencies is a comma-separated list of dependencies.
 	Dependencies string `yaml:"dependencies,omitempty"`
 
@@ -406,6 +420,10 @@ type DartPackage struct {
 	// ExtraImports is additional imports to include in the generated library.
 	ExtraImports string `yaml:"extra_imports,omitempty"`
 
+	// GenerateRpcSamples generates a sample for each RPC in the `example/`
+	// directory, together with the snippet metadata for these samples.
+	GenerateRpcSamples bool `yaml:"generate_rpc_samples,omitempty"`
+
 	// IncludeList is a list of proto files to include (e.g., "date.proto", "expr.proto").
 	IncludeList []string `yaml:"include_list,omitempty"`
 
@@ -760,6 +778,11 @@ type NodejsPackage struct {
 	// ExtraProtocParameters is a list of extra parameters to pass to protoc.
 	ExtraProtocParameters []string `yaml:"extra_protoc_parameters,omitempty"`
 
+	// Generator selects the code generator. When omitted, the library is
+	// generated with gapic-generator-typescript. Set to "sidekick" to
+	// generate a dependency-free fetch()-based REST client instead.
+	Generator string `yaml:"generator,omitempty"`
+
 	// HandwrittenLayer indicates the library has a handwritten layer on top
 	// of the generated code.
 	HandwrittenLayer bool `yaml:"handwritten_layer,omitempty"`
@@ -0,0 +1,36 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// Lint configures the lint rules applied to the API model of a library.
+type Lint struct {
+	// Suppress lists the lint findings to ignore for this library.
+	Suppress []*LintSuppression `yaml:"suppress,omitempty"`
+}
+
+// LintSuppression suppresses the findings of a lint rule.
+type LintSuppression struct {
+	// Rule is the name of the lint rule, such as "lro-operation-info".
+	Rule string `yaml:"rule"`
+
+	// ID restricts the suppression to an element of the API model and the
+	// elements it contains, such as ".google.cloud.secretmanager.v1" or
+	// ".google.cloud.secretmanager.v1.SecretManagerService.ListSecrets". If
+	// empty, all findings of the rule are suppressed.
+	ID string `yaml:"id,omitempty"`
+
+	// Reason explains why the findings are suppressed.
+	Reason string `yaml:"reason,omitempty"`
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// PostGenerateStep is an edit applied to the generated code of a library,
+// after generation and before formatting. Steps run in the order they are
+// listed, and a step that matches nothing fails the generation.
+//
+// Each step sets Path and exactly one action: Replace, ReplaceRegex,
+// CopyFrom, Remove, DeleteMethod, DeprecateMethod or DuplicateMethod.
+type PostGenerateStep struct {
+	// Path is the file to edit, relative to the output directory of the
+	// library.
+	Path string `yaml:"path"`
+
+	// Replace replaces all the occurrences of this text with With.
+	Replace string `yaml:"replace,omitempty"`
+
+	// ReplaceRegex replaces all the matches of this regular expression with
+	// With. The expression uses multiline mode unless it starts with flags,
+	// and With may refer to capture groups, such as "${1}".
+	ReplaceRegex string `yaml:"replace_regex,omitempty"`
+
+	// With is the replacement text for Replace and ReplaceRegex.
+	With string `yaml:"with,omitempty"`
+
+	// CopyFrom copies this file, relative to the directory containing
+	// librarian.yaml, to Path.
+	CopyFrom string `yaml:"copy_from,omitempty"`
+
+	// Remove removes the file at Path.
+	Remove bool `yaml:"remove,omitempty"`
+
+	// Method is the signature of the method changed by DeleteMethod,
+	// DeprecateMethod and DuplicateMethod, as it appears in the file. It
+	// must include the complete parameter list, such as
+	// "public Secret getSecret(String name)" in Java, except in Python where
+	// signatures often span several lines and "def get_secret(" is enough.
+	Method string `yaml:"method,omitempty"`
+
+	// DeleteMethod removes Method from the file.
+	DeleteMethod bool `yaml:"delete_method,omitempty"`
+
+	// DeprecateMethod marks Method as deprecated with this message.
+	DeprecateMethod string `yaml:"deprecate_method,omitempty"`
+
+	// DuplicateMethod adds a copy of Method with this name after the
+	// original.
+	DuplicateMethod string `yaml:"duplicate_method,omitempty"`
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// RustCodec contains the options of the Rust sidekick codec.
+//
+// Librarian computes these options from the [Library] and [RustModule]
+// configuration, and from the `rust.codec` field of the library or of the
+// defaults. They can also be parsed from the string map used by older
+// configurations, where each field is keyed by its name in kebab-case, such
+// as `package-name-override` or `include-grpc-only-methods`.
+type RustCodec struct {
+	// PackageNameOverride overrides the default package name.
+	PackageNameOverride string `yaml:"package_name_override,omitempty"`
+
+	// NameOverrides maps element IDs to new unqualified names, such as
+	// ".google.test.Service" to "Rename". Only services and oneofs are
+	// supported.
+	NameOverrides map[string]string `yaml:"name_overrides,omitempty"`
+
+	// ModulePath is the full path of the generated module within the crate.
+	// Defaults to `crate::model`.
+	ModulePath string `yaml:"module_path,omitempty"`
+
+	// CopyrightYear is the year when the files were first generated.
+	CopyrightYear string `yaml:"copyright_year,omitempty"`
+
+	// NotForPublication is true for crates that are never published.
+	NotForPublication bool `yaml:"not_for_publication,omitempty"`
+
+	// Version is the version of the generated crate.
+	Version string `yaml:"version,omitempty"`
+
+	// ReleaseLevel is the release level used in the documentation, typically
+	// "stable" or "preview". Defaults to "preview".
+	ReleaseLevel string `yaml:"release_level,omitempty"`
+
+	// Packages lists the Rust packages used by the generated code.
+	Packages []*RustCodecPackage `yaml:"packages,omitempty"`
+
+	// DisabledRustdocWarnings is a list of rustdoc warnings to disable.
+	DisabledRustdocWarnings []string `yaml:"disabled_rustdoc_warnings,omitempty"`
+
+	// DisabledClippyWarnings is a list of clippy warnings to disable.
+	DisabledClippyWarnings []string `yaml:"disabled_clippy_warnings,omitempty"`
+
+	// TemplateOverride overrides the template subdirectory.
+	TemplateOverride string `yaml:"template_override,omitempty"`
+
+	// TemplateOverlay is a directory with templates that replace the embedded
+	// templates, including those in the TemplateOverride directory.
+	TemplateOverlay string `yaml:"template_overlay,omitempty"`
+
+	// IncludeGrpcOnlyMethods includes methods without HTTP annotations.
+	IncludeGrpcOnlyMethods bool `yaml:"include_grpc_only_methods,omitempty"`
+
+	// IncludeStreamingMethods includes gRPC streaming methods.
+	IncludeStreamingMethods bool `yaml:"include_streaming_methods,omitempty"`
+
+	// PerServiceFeatures enables per-service feature flags.
+	PerServiceFeatures bool `yaml:"per_service_features,omitempty"`
+
+	// DefaultFeatures is a list of the features enabled by default, if
+	// PerServiceFeatures is true.
+	DefaultFeatures []string `yaml:"default_features,omitempty"`
+
+	// DetailedTracingAttributes includes detailed tracing attributes on HTTP
+	// requests.
+	DetailedTracingAttributes bool `yaml:"detailed_tracing_attributes,omitempty"`
+
+	// LroStubOptions includes the LRO poller options in the stub traits.
+	LroStubOptions bool `yaml:"lro_stub_options,omitempty"`
+
+	// HasVeneer indicates whether the crate has a handwritten client surface.
+	HasVeneer bool `yaml:"has_veneer,omitempty"`
+
+	// ExtraModules lists additional modules, with handwritten code.
+	ExtraModules []string `yaml:"extra_modules,omitempty"`
+
+	// InternalTypes lists the messages that are only visible within the crate.
+	InternalTypes []string `yaml:"internal_types,omitempty"`
+
+	// RoutingRequired fails requests locally if they do not yield a gRPC
+	// routing header.
+	RoutingRequired bool `yaml:"routing_required,omitempty"`
+
+	// ExtendGrpcTransport makes the transport stub extensible from outside
+	// of `transport.rs`.
+	ExtendGrpcTransport bool `yaml:"extend_grpc_transport,omitempty"`
+
+	// GenerateSetterSamples generates documentation samples for the message
+	// field setters.
+	GenerateSetterSamples bool `yaml:"generate_setter_samples,omitempty"`
+
+	// GenerateRpcSamples generates documentation samples for the RPCs.
+	GenerateRpcSamples bool `yaml:"generate_rpc_samples,omitempty"`
+
+	// InternalBuilders makes the request builders visible only within the
+	// crate.
+	InternalBuilders bool `yaml:"internal_builders,omitempty"`
+
+	// QuickstartServiceOverride overrides the service used in the
+	// package-level quickstart.
+	QuickstartServiceOverride string `yaml:"quickstart_service_override,omitempty"`
+}
+
+// RustCodecPackage describes a Rust package used by the generated code.
+type RustCodecPackage struct {
+	// Name is the name the package is imported under.
+	Name string `yaml:"name"`
+
+	// Package is what the Rust package calls itself. Required unless the
+	// package is ignored.
+	Package string `yaml:"package,omitempty"`
+
+	// Sources lists the specification packages, such as "google.protobuf",
+	// whose types are found in this package.
+	Sources []string `yaml:"sources,omitempty"`
+
+	// Features lists the features enabled for the package.
+	Features []string `yaml:"features,omitempty"`
+
+	// Ignore keeps references to the package within the crate.
+	Ignore bool `yaml:"ignore,omitempty"`
+
+	// ForceUsed forces the package to be used even if not referenced.
+	ForceUsed bool `yaml:"force_used,omitempty"`
+
+	// UsedIf lists the conditions, such as "lro" or "services", under which
+	// the package is used.
+	UsedIf []string `yaml:"used_if,omitempty"`
+}
+
+// RustProstCodec contains the options of the sidekick codec for Rust modules
+// generated with prost or tonic.
+//
+// Librarian computes these options from the [Library] and [RustModule]
+// configuration. They can also be parsed from the string map used by older
+// configurations, where each field is keyed by its name in kebab-case, such
+// as `post-process-protos`.
+type RustProstCodec struct {
+	// CopyrightYear is the year when the files were first generated.
+	CopyrightYear string `yaml:"copyright_year,omitempty"`
+
+	// PackageNameOverride overrides the default package name.
+	PackageNameOverride string `yaml:"package_name_override,omitempty"`
+
+	// PostProcessProtos is Rust code to post-process the code generated by
+	// prost.
+	PostProcessProtos string `yaml:"post_process_protos,omitempty"`
+
+	// RootName is the name of the source root for the protos, such as
+	// "protobuf-src". Defaults to "googleapis".
+	RootName string `yaml:"root_name,omitempty"`
+}
@@ -16,6 +16,12 @@ package config
 
 // SwiftDefault contains the configuration shared by all Swift libraries.
 type SwiftDefault struct {
+	// Codec sets options of the Swift codec directly. They apply to the
+	// package, not to its modules, and take precedence over the options
+	// computed from the library configuration. `librarian tidy` validates
+	// them.
+	Codec *SwiftCodec `yaml:"codec,omitempty"`
+
 	// Dependencies is a list of package dependencies.
 	Dependencies []SwiftDependency `yaml:"dependencies,omitempty"`
 }
@@ -0,0 +1,45 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// SwiftCodec contains the options of the Swift sidekick codec.
+//
+// Librarian computes these options from the [Library] configuration, and from
+// the `swift.codec` field of the library. They can also be parsed from the
+// string map used by older configurations, where each field is keyed by its
+// name in kebab-case, such as `package-name-override`.
+type SwiftCodec struct {
+	// CopyrightYear is the year when the files were first generated.
+	CopyrightYear string `yaml:"copyright_year,omitempty"`
+
+	// Version is the version of the generated package.
+	Version string `yaml:"version,omitempty"`
+
+	// PackageNameOverride overrides the default package name, such as
+	// "GoogleCloudSecretManagerV1".
+	PackageNameOverride string `yaml:"package_name_override,omitempty"`
+
+	// RootName is the name of the source root for the APIs. Defaults to
+	// "googleapis".
+	RootName string `yaml:"root_name,omitempty"`
+
+	// TemplateOverlay is a directory with templates that replace the embedded
+	// templates.
+	TemplateOverlay string `yaml:"template_overlay,omitempty"`
+
+	// Module is true when generating a module of a package, which uses a
+	// different directory structure.
+	Module bool `yaml:"module,omitempty"`
+}
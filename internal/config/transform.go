@@ -0,0 +1,70 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// Transform is a change applied to the API model of a library before any code
+// is generated. Transforms apply in the order they are listed.
+//
+// Only the generators that build the API model read transforms: Dart, Rust,
+// Swift, and Node.js libraries generated with the sidekick generator.
+// librarian tidy rejects transforms for the other languages.
+//
+// Each transform sets ID and exactly one action.
+type Transform struct {
+	// ID is the fully qualified ID of the element to change, such as
+	// ".google.cloud.secretmanager.v1.Secret" or
+	// ".google.cloud.secretmanager.v1.Secret.etag". It is an error if the ID
+	// does not match any element.
+	ID string `yaml:"id"`
+
+	// Rename changes the name of the element in the generated code. The
+	// element keeps its ID, and its representation on the wire does not
+	// change.
+	Rename string `yaml:"rename,omitempty"`
+
+	// Remove removes the field from its message.
+	Remove bool `yaml:"remove,omitempty"`
+
+	// Deprecate marks the element as deprecated.
+	Deprecate bool `yaml:"deprecate,omitempty"`
+
+	// FieldBehavior replaces the behavior of the field, for example
+	// ["REQUIRED"] or ["OUTPUT_ONLY"].
+	FieldBehavior []string `yaml:"field_behavior,omitempty"`
+
+	// Type changes the type of the field. Use the protobuf name for scalar
+	// types, such as "int64" or "string", and the ID for message and enum
+	// types, such as ".google.protobuf.Duration".
+	Type string `yaml:"type,omitempty"`
+
+	// Paginate marks the method as paginated, for methods that do not follow
+	// AIP-4233 closely enough to be detected automatically.
+	Paginate *TransformPagination `yaml:"paginate,omitempty"`
+}
+
+// TransformPagination configures the fields used to paginate a method.
+type TransformPagination struct {
+	// PageToken is the name of the page token field in the request. Defaults
+	// to "page_token".
+	PageToken string `yaml:"page_token,omitempty"`
+
+	// NextPageToken is the name of the next page token field in the response.
+	// Defaults to "next_page_token".
+	NextPageToken string `yaml:"next_page_token,omitempty"`
+
+	// ItemField is the name of the field in the response with the items of
+	// each page.
+	ItemField string `yaml:"item_field"`
+}
@@ -22,6 +22,10 @@ import (
 	"github.com/googleapis/librarian/internal/repometadata"
 )
 
+// MetadataFile is the name of the metadata file expected at the root of a
+// documentation archive.
+const MetadataFile = "docs.metadata.json"
+
 // DocUploaderMetadata represents the structure of the docs.metadata.json file
 // embedded in documentation tarballs.
 // See https://github.com/googleapis/docuploader/blob/main/docuploader/protos/metadata.proto
@@ -107,3 +111,8 @@ func (d *DocUploaderMetadata) SetUpdateTime(updateTime time.Time) *DocUploaderMe
 	d.UpdateTime = updateTime.UTC().Format(time.RFC3339)
 	return d
 }
+
+// Write writes the metadata into dir/docs.metadata.json.
+func (d *DocUploaderMetadata) Write(dir string) error {
+	return repometadata.WriteJSON(d, "  ", dir, MetadataFile)
+}
@@ -15,6 +15,8 @@
 package docuploader
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -81,3 +83,29 @@ func TestSetUpdateTime(t *testing.T) {
 		t.Errorf("mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	metadata := &DocUploaderMetadata{
+		Language:   config.LanguagePython,
+		Name:       "secretmanager",
+		UpdateTime: "2026-02-13T15:49:50Z",
+		Version:    "1.2.3",
+	}
+	if err := metadata.Write(dir); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, MetadataFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "language": "python",
+  "name": "secretmanager",
+  "updateTime": "2026-02-13T15:49:50Z",
+  "version": "1.2.3"
+}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
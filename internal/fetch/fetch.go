@@ -128,7 +128,7 @@ func Repo(ctx context.Context, repo, commit, expectedSHA256 string) (string, err
 				if err := os.MkdirAll(outDir, 0755); err != nil {
 					return "", fmt.Errorf("failed creating %q: %w", outDir, err)
 				}
-				if err := ExtractTarball(tgz, outDir, stripTopLevelDir); err == nil {
+				if err := ExtractTarball(tgz, outDir, StripTopLevelDir); err == nil {
 					return outDir, nil
 				}
 			}
@@ -149,12 +149,26 @@ func Repo(ctx context.Context, repo, commit, expectedSHA256 string) (string, err
 	if err := Download(ctx, tgz, sourceURL, expectedSHA256); err != nil {
 		return "", err
 	}
-	if err := ExtractTarball(tgz, outDir, stripTopLevelDir); err != nil {
+	if err := ExtractTarball(tgz, outDir, StripTopLevelDir); err != nil {
 		return "", fmt.Errorf("failed to extract tarball: %w", err)
 	}
 	return outDir, nil
 }
 
+// Cached reports whether Repo can return the given repo and commit without
+// downloading it, because its extracted directory or its tarball is in the
+// cache.
+func Cached(repo, commit string) (bool, error) {
+	cacheDir, err := cache.Directory()
+	if err != nil {
+		return false, err
+	}
+	if _, err := extractedDir(cacheDir, repo, commit); err == nil {
+		return true, nil
+	}
+	return fileExists(tarballPath(cacheDir, repo, commit)), nil
+}
+
 // tarballPath returns the path to a cached tarball for the given repo and
 // commit.
 //
@@ -325,6 +339,14 @@ func Download(ctx context.Context, target, url, expectedSHA256 string) error {
 	return os.Rename(tempPath, target)
 }
 
+// File downloads a file from the given url to the target path, replacing any
+// existing file. Unlike [Download], it does not verify the checksum of the
+// file, which is left to the caller. It retries up to maxDownloadRetries times
+// with exponential backoff on failure.
+func File(ctx context.Context, target, url string) error {
+	return downloadFile(ctx, target, url)
+}
+
 // downloadFile downloads a file from the given source URL to the target path.
 // It retries up to maxDownloadRetries times with exponential backoff on failure.
 func downloadFile(ctx context.Context, target, source string) error {
@@ -392,9 +414,10 @@ func fileExists(name string) bool {
 	return stat.Mode().IsRegular()
 }
 
-// stripTopLevelDir removes the top-level directory prefix (such as "{repo}-{commit}/")
-// that GitHub automatically adds to repository archive entries.
-func stripTopLevelDir(name string) (string, bool) {
+// StripTopLevelDir removes the top-level directory prefix (such as "{repo}-{commit}/")
+// that GitHub automatically adds to repository archive entries, and that
+// crates.io adds to .crate files. It is meant to be passed to [ExtractTarball].
+func StripTopLevelDir(name string) (string, bool) {
 	parts := strings.SplitN(name, "/", 2)
 	if len(parts) == 2 {
 		return parts[1], true
@@ -110,6 +110,49 @@ func TestRepo_ExtractedDirExists(t *testing.T) {
 	}
 }
 
+func TestCached(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		files []string
+		want  bool
+	}{
+		{
+			name: "not cached",
+		},
+		{
+			name:  "extracted directory",
+			files: []string{testExtractedDir + "README.md"},
+			want:  true,
+		},
+		{
+			name:  "tarball",
+			files: []string{testTarball},
+			want:  true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cachedir := t.TempDir()
+			t.Setenv(cache.EnvLibrarianCache, cachedir)
+			for _, f := range test.files {
+				path := filepath.Join(cachedir, f)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := Cached(testRepo, testCommit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("Cached() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
 func TestRepo_TarballExists(t *testing.T) {
 	cachedir := t.TempDir()
 	t.Setenv(cache.EnvLibrarianCache, cachedir)
@@ -557,7 +600,7 @@ func TestExtractTarball(t *testing.T) {
 	}
 
 	destDir := t.TempDir()
-	if err := ExtractTarball(tarballPath, destDir, stripTopLevelDir); err != nil {
+	if err := ExtractTarball(tarballPath, destDir, StripTopLevelDir); err != nil {
 		t.Fatal(err)
 	}
 
@@ -760,7 +803,7 @@ func TestExtractTarball_Error(t *testing.T) {
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
-			err := ExtractTarball(test.tarballPath(t), test.dest(t), stripTopLevelDir)
+			err := ExtractTarball(test.tarballPath(t), test.dest(t), StripTopLevelDir)
 			if !errors.Is(err, test.wantErr) {
 				t.Fatalf("got error %v, want %v", err, test.wantErr)
 			}
@@ -803,7 +846,7 @@ func TestExtractTarball_PathError(t *testing.T) {
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
-			err := ExtractTarball(test.tarballPath(t), test.dest(t), stripTopLevelDir)
+			err := ExtractTarball(test.tarballPath(t), test.dest(t), StripTopLevelDir)
 			var pathErr *fs.PathError
 			if !errors.As(err, &pathErr) {
 				t.Fatalf("got error %v, want *fs.PathError", err)
@@ -1115,7 +1158,7 @@ func TestExtractTarball_Symlink(t *testing.T) {
 	}
 
 	destDir := t.TempDir()
-	if err := ExtractTarball(tarballPath, destDir, stripTopLevelDir); err != nil {
+	if err := ExtractTarball(tarballPath, destDir, StripTopLevelDir); err != nil {
 		t.Fatal(err)
 	}
 
@@ -101,6 +101,10 @@ Examples:
 			if err != nil {
 				return err
 			}
+			ctx, err = withToolEnv(ctx, cfg)
+			if err != nil {
+				return err
+			}
 			return runBump(ctx, cfg, all, libraryName, versionOverride)
 		},
 	}
@@ -189,6 +193,19 @@ func libraryChanged(cfg *config.Config, library *config.Library, filesChanged []
 	return hasChangesIn(output, exclusion, filesChanged)
 }
 
+// ChangedLibraries returns the names of the libraries in cfg with an output
+// directory containing any of filesChanged. File paths are relative to the
+// repository root.
+func ChangedLibraries(cfg *config.Config, filesChanged []string) []string {
+	var names []string
+	for _, lib := range cfg.Libraries {
+		if libraryChanged(cfg, lib, filesChanged) {
+			names = append(names, lib.Name)
+		}
+	}
+	return names
+}
+
 func hasChangesIn(dir, exclusion string, filesChanged []string) bool {
 	if !strings.HasSuffix(dir, "/") {
 		dir += "/"
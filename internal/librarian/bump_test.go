@@ -1269,6 +1269,26 @@ func TestLibraryChanged(t *testing.T) {
 	}
 }
 
+func TestChangedLibraries(t *testing.T) {
+	cfg := &config.Config{
+		Language: config.LanguageGo,
+		Libraries: []*config.Library{
+			{Name: "accessapproval"},
+			{Name: "secretmanager"},
+			{Name: "storage", Output: "tmp/storage"},
+		},
+	}
+	got := ChangedLibraries(cfg, []string{
+		"README.md",
+		"secretmanager/apiv1/secretmanager_client.go",
+		"tmp/storage/storage.go",
+	})
+	want := []string{"secretmanager", "storage"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func writeReadmeAndCommit(t *testing.T, newContent string) {
 	writeFileAndCommit(t, testhelper.ReadmeFile, []byte(newContent), "Modified readme")
 }
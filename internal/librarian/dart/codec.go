@@ -17,17 +17,20 @@ package dart
 import (
 	"errors"
 	"fmt"
+	"maps"
 
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/serviceconfig"
 	"github.com/googleapis/librarian/internal/sidekick/api"
+	sidekickdart "github.com/googleapis/librarian/internal/sidekick/dart"
 	"github.com/googleapis/librarian/internal/sidekick/parser"
 	"github.com/googleapis/librarian/internal/sources"
 )
 
 var errInvalidSpecificationFormat = errors.New("dart generation requires protobuf specification format")
 
-func toModelConfig(library *config.Library, ch *config.API, srcs *sources.Sources) (*parser.ModelConfig, error) {
+// ModelConfig returns the parser configuration for the given API in library.
+func ModelConfig(library *config.Library, ch *config.API, srcs *sources.Sources) (*parser.ModelConfig, error) {
 	if library.SpecificationFormat != "" && library.SpecificationFormat != config.SpecProtobuf {
 		return nil, fmt.Errorf("%w, got %q", errInvalidSpecificationFormat, library.SpecificationFormat)
 	}
@@ -62,6 +65,7 @@ func toModelConfig(library *config.Library, ch *config.API, srcs *sources.Source
 		SpecificationSource: ch.Path,
 		Source:              src,
 		Codec:               buildCodec(library),
+		Transformations:     parser.Transformations(library.Transforms),
 		Override: api.ModelOverride{
 			Name:        name,
 			Description: svcConfig.Description,
@@ -82,6 +86,9 @@ func buildCodec(library *config.Library) map[string]string {
 	if library.SkipRelease {
 		codec["not-for-publication"] = "true"
 	}
+	if library.TemplateOverlay != "" {
+		codec["template-overlay"] = library.TemplateOverlay
+	}
 	if library.Dart == nil {
 		return codec
 	}
@@ -99,6 +106,9 @@ func buildCodec(library *config.Library) map[string]string {
 	if dart.ExtraImports != "" {
 		codec["extra-imports"] = dart.ExtraImports
 	}
+	if dart.GenerateRpcSamples {
+		codec["generate-rpc-samples"] = "true"
+	}
 	if dart.IssueTrackerURL != "" {
 		codec["issue-tracker-url"] = dart.IssueTrackerURL
 	}
@@ -129,5 +139,8 @@ func buildCodec(library *config.Library) map[string]string {
 	for key, value := range dart.Protos {
 		codec[key] = value
 	}
+	if dart.Codec != nil {
+		maps.Copy(codec, sidekickdart.FormatCodecOptions(dart.Codec))
+	}
 	return codec
 }
@@ -51,6 +51,15 @@ func TestBuildCodec(t *testing.T) {
 			},
 			want: map[string]string{},
 		},
+		{
+			name: "template overlay",
+			library: &config.Library{
+				TemplateOverlay: "overlays/dart",
+			},
+			want: map[string]string{
+				"template-overlay": "overlays/dart",
+			},
+		},
 		{
 			name: "dependencies",
 			library: &config.Library{
@@ -193,6 +202,17 @@ func TestBuildCodec(t *testing.T) {
 				"proto:google.protobuf": "package:protobuf/protobuf.dart",
 			},
 		},
+		{
+			name: "generate rpc samples",
+			library: &config.Library{
+				Dart: &config.DartPackage{
+					GenerateRpcSamples: true,
+				},
+			},
+			want: map[string]string{
+				"generate-rpc-samples": "true",
+			},
+		},
 		{
 			name: "supports sse",
 			library: &config.Library{
@@ -291,7 +311,7 @@ func TestBuildCodec(t *testing.T) {
 	}
 }
 
-func TestToModelConfig(t *testing.T) {
+func TestModelConfig(t *testing.T) {
 	googleapisDir := t.TempDir()
 	showcaseDir := t.TempDir()
 
@@ -536,10 +556,10 @@ func TestToModelConfig(t *testing.T) {
 			if test.showcaseDir != "" {
 				sources.Showcase = test.showcaseDir
 			}
-			got, err := toModelConfig(test.library, test.channel, sources)
+			got, err := ModelConfig(test.library, test.channel, sources)
 			if test.wantErr != nil {
 				if !errors.Is(err, test.wantErr) {
-					t.Errorf("toModelConfig() error: %v, wantErr: %v", err, test.wantErr)
+					t.Errorf("ModelConfig() error: %v, wantErr: %v", err, test.wantErr)
 				}
 				return
 			}
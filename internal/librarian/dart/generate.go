@@ -30,7 +30,7 @@ import (
 
 // Generate generates a Dart client library.
 func Generate(ctx context.Context, library *config.Library, sources *sources.Sources) error {
-	modelConfig, err := toModelConfig(library, library.APIs[0], sources)
+	modelConfig, err := ModelConfig(library, library.APIs[0], sources)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/googleapis/librarian/internal/config"
+)
+
+func TestValidate(t *testing.T) {
+	lib := &config.Library{
+		Name: "google_cloud_secretmanager_v1",
+		Dart: &config.DartPackage{
+			APIKeysEnvironmentVariables: "GOOGLE_API_KEY",
+			Packages:                    map[string]string{"package:http": "^1.3.0"},
+			Codec: &config.DartCodec{
+				SupportsSSE: true,
+				Prefixes:    map[string]string{"google.protobuf": "protobuf"},
+			},
+		},
+	}
+	if err := Validate(lib); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidate_Error(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		lib  *config.Library
+	}{
+		{
+			name: "unknown option",
+			lib: &config.Library{
+				Name: "google_cloud_secretmanager_v1",
+				Dart: &config.DartPackage{
+					Packages: map[string]string{"http": "^1.3.0"},
+				},
+			},
+		},
+		{
+			name: "invalid boolean",
+			lib: &config.Library{
+				Name: "google_cloud_secretmanager_v1",
+				Dart: &config.DartPackage{
+					Packages: map[string]string{"use-workspace": "maybe"},
+				},
+			},
+		},
+		{
+			name: "package without version",
+			lib: &config.Library{
+				Name: "google_cloud_secretmanager_v1",
+				Dart: &config.DartPackage{
+					Codec: &config.DartCodec{Packages: map[string]string{"http": ""}},
+				},
+			},
+		},
+		{
+			name: "missing template overlay",
+			lib: &config.Library{
+				Name:            "google_cloud_secretmanager_v1",
+				TemplateOverlay: "testdata/missing",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := Validate(test.lib); !errors.Is(err, errInvalidCodecOptions) {
+				t.Errorf("Validate() error = %v, want %v", err, errInvalidCodecOptions)
+			}
+		})
+	}
+}
+
+func TestValidateDefault(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		d       *config.Default
+		wantErr error
+	}{
+		{
+			name: "no codec",
+			d:    &config.Default{Dart: &config.DartPackage{}},
+		},
+		{
+			name: "valid codec",
+			d: &config.Default{Dart: &config.DartPackage{
+				Codec: &config.DartCodec{DefaultRetryPolicies: true},
+			}},
+		},
+		{
+			name: "invalid codec",
+			d: &config.Default{Dart: &config.DartPackage{
+				Codec: &config.DartCodec{Protos: map[string]string{"google.protobuf": ""}},
+			}},
+			wantErr: errInvalidCodecOptions,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ValidateDefault(test.d); !errors.Is(err, test.wantErr) {
+				t.Errorf("ValidateDefault() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/apidiff"
+	"github.com/googleapis/librarian/internal/sidekick/modeldump"
+	"github.com/googleapis/librarian/internal/yaml"
+	"github.com/urfave/cli/v3"
+)
+
+const diffFormatText = "text"
+
+var errLibraryOrAll = errors.New("must specify exactly one of library name or --all")
+
+// diffOptions holds the options for the diff command.
+type diffOptions struct {
+	// LibraryName selects a library from librarian.yaml.
+	LibraryName string
+	// All compares every library in librarian.yaml.
+	All bool
+	// From is the googleapis source for the old models.
+	From *config.Source
+	// To is the googleapis source for the new models.
+	To *config.Source
+	// Format is the output format: text, json or yaml.
+	Format string
+}
+
+// libraryDiff is the API surface diff for one API in a library.
+type libraryDiff struct {
+	Library string            `json:"library" yaml:"library"`
+	API     string            `json:"api" yaml:"api"`
+	Changes []*apidiff.Change `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+func diffCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "compare the API surface of a library between two googleapis revisions",
+		UsageText: "librarian diff [<library> | --all] [--from=<ref>] [--to=<ref>]",
+		Description: `diff builds the API model of a library at two googleapis revisions and
+prints the services, methods, fields, enums, enum values and resources that
+were added, removed, renamed or changed.
+
+Each change is classified as additive or breaking. Removing an element,
+changing the type or number of a field, reusing a field number, and removing
+a resource pattern are breaking changes.
+
+--from defaults to the googleapis source in librarian.yaml, and --to defaults
+to the latest commit on the googleapis default branch. Both flags accept a
+commit, a branch, or a local directory.
+
+Examples:
+
+	librarian diff google-cloud-secretmanager-v1
+	librarian diff --all --to=<commit>
+	librarian diff google-cloud-secretmanager-v1 --from=<commit> --to=<commit> --format=json`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "compare all libraries",
+			},
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "the googleapis commit, branch or directory for the old API surface",
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Usage: "the googleapis commit, branch or directory for the new API surface",
+				Value: sourceRepos["sources.googleapis"].Branch,
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "the output format: text, json or yaml",
+				Value: diffFormatText,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
+			if err != nil {
+				return err
+			}
+			if cfg.Sources == nil || cfg.Sources.Googleapis == nil {
+				return ErrMissingGoogleapisSource
+			}
+			opts := &diffOptions{
+				LibraryName: cmd.Args().First(),
+				All:         cmd.Bool("all"),
+				From:        cfg.Sources.Googleapis,
+				Format:      cmd.String("format"),
+			}
+			if from := cmd.String("from"); from != "" {
+				if opts.From, err = resolveGoogleapisSource(from); err != nil {
+					return err
+				}
+			}
+			if opts.To, err = resolveGoogleapisSource(cmd.String("to")); err != nil {
+				return err
+			}
+			return runDiff(ctx, cmd.Root().Writer, cfg, opts)
+		},
+	}
+}
+
+// resolveGoogleapisSource returns the googleapis source for ref, which is
+// either a local directory or a commit or branch in the googleapis repository.
+func resolveGoogleapisSource(ref string) (*config.Source, error) {
+	if info, err := os.Stat(ref); err == nil && info.IsDir() {
+		return &config.Source{Dir: ref}, nil
+	}
+	commit, sha256, err := fetchSourceCommitAndChecksum("sources.googleapis", ref)
+	if err != nil {
+		return nil, err
+	}
+	return &config.Source{Commit: commit, SHA256: sha256}, nil
+}
+
+func runDiff(ctx context.Context, w io.Writer, cfg *config.Config, opts *diffOptions) error {
+	if (opts.LibraryName == "") == !opts.All {
+		return errLibraryOrAll
+	}
+	if opts.Format != diffFormatText && opts.Format != dumpFormatJSON && opts.Format != dumpFormatYAML {
+		return fmt.Errorf("%w: %q", errUnknownDumpFormat, opts.Format)
+	}
+	libraries := cfg.Libraries
+	if !opts.All {
+		library, err := FindLibrary(cfg, opts.LibraryName)
+		if err != nil {
+			return err
+		}
+		libraries = []*config.Library{library}
+	}
+	oldSrcs, err := LoadSources(ctx, withGoogleapis(cfg.Sources, opts.From))
+	if err != nil {
+		return err
+	}
+	newSrcs, err := LoadSources(ctx, withGoogleapis(cfg.Sources, opts.To))
+	if err != nil {
+		return err
+	}
+	var diffs []*libraryDiff
+	for _, library := range libraries {
+		if library.SkipGenerate {
+			continue
+		}
+		library, err := applyDefaults(cfg.Language, library, cfg.Default)
+		if err != nil {
+			return err
+		}
+		for _, channel := range library.APIs {
+			oldModel, err := buildModel(cfg.Language, library, channel.Path, oldSrcs)
+			if err != nil {
+				return fmt.Errorf("failed to build old model for %q in %q: %w", channel.Path, library.Name, err)
+			}
+			newModel, err := buildModel(cfg.Language, library, channel.Path, newSrcs)
+			if err != nil {
+				return fmt.Errorf("failed to build new model for %q in %q: %w", channel.Path, library.Name, err)
+			}
+			report := apidiff.Compare(modeldump.New(oldModel), modeldump.New(newModel))
+			diffs = append(diffs, &libraryDiff{
+				Library: library.Name,
+				API:     channel.Path,
+				Changes: report.Changes,
+			})
+		}
+	}
+	if opts.Format != diffFormatText {
+		return writeDump(w, opts.Format, diffs)
+	}
+	return writeDiffText(w, diffs)
+}
+
+// withGoogleapis returns a copy of srcs using googleapis as the googleapis
+// source.
+func withGoogleapis(srcs *config.Sources, googleapis *config.Source) *config.Sources {
+	result := &config.Sources{}
+	if srcs != nil {
+		*result = *srcs
+	}
+	result.Googleapis = googleapis
+	return result
+}
+
+func writeDiffText(w io.Writer, diffs []*libraryDiff) error {
+	for _, d := range diffs {
+		if _, err := fmt.Fprintf(w, "%s (%s)\n", d.Library, d.API); err != nil {
+			return err
+		}
+		if len(d.Changes) == 0 {
+			if _, err := fmt.Fprintln(w, "  no changes"); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, c := range d.Changes {
+			if _, err := fmt.Fprintf(w, "  %s\n", c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
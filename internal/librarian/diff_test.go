@@ -0,0 +1,133 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/apidiff"
+)
+
+func TestRunDiff_Error(t *testing.T) {
+	cfg := &config.Config{
+		Language: config.LanguageFake,
+		Sources:  &config.Sources{Googleapis: &config.Source{Dir: t.TempDir()}},
+		Libraries: []*config.Library{
+			{Name: "google-cloud-secretmanager-v1"},
+		},
+	}
+	for _, test := range []struct {
+		name    string
+		opts    *diffOptions
+		wantErr error
+	}{
+		{
+			name:    "neither library nor all",
+			opts:    &diffOptions{Format: diffFormatText},
+			wantErr: errLibraryOrAll,
+		},
+		{
+			name:    "both library and all",
+			opts:    &diffOptions{LibraryName: "google-cloud-secretmanager-v1", All: true, Format: diffFormatText},
+			wantErr: errLibraryOrAll,
+		},
+		{
+			name:    "unknown format",
+			opts:    &diffOptions{All: true, Format: "xml"},
+			wantErr: errUnknownDumpFormat,
+		},
+		{
+			name:    "unknown library",
+			opts:    &diffOptions{LibraryName: "missing", Format: diffFormatText},
+			wantErr: ErrLibraryNotFound,
+		},
+		{
+			name:    "missing googleapis source",
+			opts:    &diffOptions{All: true, Format: diffFormatText},
+			wantErr: ErrMissingGoogleapisSource,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runDiff(t.Context(), &buf, cfg, test.opts)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("runDiff() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveGoogleapisSource_Dir(t *testing.T) {
+	dir := t.TempDir()
+	got, err := resolveGoogleapisSource(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&config.Source{Dir: dir}, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWithGoogleapis(t *testing.T) {
+	srcs := &config.Sources{
+		Googleapis: &config.Source{Commit: "old"},
+		Showcase:   &config.Source{Commit: "showcase"},
+	}
+	got := withGoogleapis(srcs, &config.Source{Commit: "new"})
+	want := &config.Sources{
+		Googleapis: &config.Source{Commit: "new"},
+		Showcase:   &config.Source{Commit: "showcase"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if srcs.Googleapis.Commit != "old" {
+		t.Errorf("withGoogleapis() modified its input, got commit %q", srcs.Googleapis.Commit)
+	}
+}
+
+func TestWriteDiffText(t *testing.T) {
+	diffs := []*libraryDiff{
+		{
+			Library: "google-cloud-secretmanager-v1",
+			API:     "google/cloud/secretmanager/v1",
+			Changes: []*apidiff.Change{
+				{ID: ".google.cloud.secretmanager.v1.Secret.tags", Element: apidiff.ElementField, Kind: apidiff.KindAdded},
+				{ID: ".google.cloud.secretmanager.v1.Secret.etag", Element: apidiff.ElementField, Kind: apidiff.KindRemoved, Breaking: true},
+			},
+		},
+		{
+			Library: "google-cloud-secretmanager-v1beta2",
+			API:     "google/cloud/secretmanager/v1beta2",
+		},
+	}
+	var buf bytes.Buffer
+	if err := writeDiffText(&buf, diffs); err != nil {
+		t.Fatal(err)
+	}
+	want := `google-cloud-secretmanager-v1 (google/cloud/secretmanager/v1)
+  additive added   field .google.cloud.secretmanager.v1.Secret.tags
+  breaking removed field .google.cloud.secretmanager.v1.Secret.etag
+google-cloud-secretmanager-v1beta2 (google/cloud/secretmanager/v1beta2)
+  no changes
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
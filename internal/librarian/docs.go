@@ -0,0 +1,191 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/docuploader"
+	"github.com/googleapis/librarian/internal/filesystem"
+	"github.com/googleapis/librarian/internal/librarian/python"
+	"github.com/googleapis/librarian/internal/repometadata"
+	"github.com/googleapis/librarian/internal/yaml"
+	"github.com/urfave/cli/v3"
+)
+
+var errDocsUnsupported = errors.New("language does not support building docs")
+
+// docsOptions configures how runDocs packages the documentation.
+type docsOptions struct {
+	// output is the directory where the archives are written.
+	output string
+	// uploadTo is a directory standing in for the staging bucket. If
+	// set, the archives are also copied there.
+	uploadTo string
+	// now is the update time recorded in docs.metadata.json.
+	now time.Time
+}
+
+func docsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "docs",
+		Usage:     "build and package reference documentation",
+		UsageText: "librarian docs [<library> | --all]",
+		Description: `docs builds the reference documentation of a library with the language's
+doc tooling and packages it for publication.
+
+For each library, docs writes docs.metadata.json into the built
+documentation, using the .repo-metadata.json file of the library, the
+library version and the current time. It then archives the documentation
+as <language>-<name>-<version>.tar.gz in the output directory.
+
+Use --upload-to to copy the archives into a local directory that stands in
+for the staging bucket, so the staging step can be tested locally.
+Libraries with skip_release set are skipped with --all.
+
+Only Python is supported.
+
+Examples:
+
+	librarian docs google-cloud-secret-manager
+	librarian docs --all --output /tmp/docs
+	librarian docs --all --upload-to /tmp/staging`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "build the docs of all libraries",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "directory to write the documentation archives to",
+				Value: "docs-output",
+			},
+			&cli.StringFlag{
+				Name:  "upload-to",
+				Usage: "directory to copy the documentation archives to, instead of a staging bucket",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			libraryName := cmd.Args().First()
+			all := cmd.Bool("all")
+			if (libraryName == "") == !all {
+				return errLibraryOrAll
+			}
+			cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
+			if err != nil {
+				return err
+			}
+			ctx, err = withToolEnv(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			var libraries []*config.Library
+			if all {
+				for _, library := range cfg.Libraries {
+					if !library.SkipRelease {
+						libraries = append(libraries, library)
+					}
+				}
+			} else {
+				library, err := FindLibrary(cfg, libraryName)
+				if err != nil {
+					return err
+				}
+				libraries = []*config.Library{library}
+			}
+			return runDocs(ctx, cfg, libraries, docsOptions{
+				output:   cmd.String("output"),
+				uploadTo: cmd.String("upload-to"),
+				now:      time.Now(),
+			})
+		},
+	}
+}
+
+// runDocs builds, describes and archives the documentation of each library.
+func runDocs(ctx context.Context, cfg *config.Config, libraries []*config.Library, opts docsOptions) error {
+	for _, dir := range []string{opts.output, opts.uploadTo} {
+		if dir == "" {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	for _, library := range libraries {
+		library, err := applyDefaults(cfg.Language, library, cfg.Default)
+		if err != nil {
+			return err
+		}
+		if err := packageDocs(ctx, cfg.Language, library, opts); err != nil {
+			return fmt.Errorf("docs for library %q (%s): %w", library.Name, cfg.Language, err)
+		}
+	}
+	return nil
+}
+
+func packageDocs(ctx context.Context, language string, library *config.Library, opts docsOptions) error {
+	docsDir, err := buildDocs(ctx, language, library)
+	if err != nil {
+		return err
+	}
+	repoMetadata, err := repometadata.Read(library.Output)
+	if err != nil {
+		return err
+	}
+	metadata := docuploader.FromRepoMetadata(repoMetadata).SetUpdateTime(opts.now)
+	metadata.Version = library.Version
+	if err := metadata.Write(docsDir); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s-%s.tar.gz", metadata.Language, metadata.Name, metadata.Version)
+	archive := filepath.Join(opts.output, name)
+	if err := docuploader.CreateArchive(ctx, tarCommand(), docsDir, archive); err != nil {
+		return err
+	}
+	if opts.uploadTo == "" {
+		return nil
+	}
+	return filesystem.CopyFile(archive, filepath.Join(opts.uploadTo, name))
+}
+
+// buildDocs delegates to language-specific code to build the documentation
+// of the library, and returns the directory containing it.
+func buildDocs(ctx context.Context, language string, library *config.Library) (string, error) {
+	switch language {
+	case config.LanguageFake:
+		return fakeBuildDocs(library)
+	case config.LanguagePython:
+		return python.BuildDocs(ctx, library)
+	default:
+		return "", fmt.Errorf("%w: %q", errDocsUnsupported, language)
+	}
+}
+
+// tarCommand returns the GNU tar executable, which is installed as gtar on
+// macOS.
+func tarCommand() string {
+	if runtime.GOOS == "darwin" {
+		return "gtar"
+	}
+	return "tar"
+}
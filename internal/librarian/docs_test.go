@@ -0,0 +1,176 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/docuploader"
+	"github.com/googleapis/librarian/internal/repometadata"
+	"github.com/googleapis/librarian/internal/testhelper"
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+func TestRunDocs(t *testing.T) {
+	testhelper.RequireCommand(t, tarCommand())
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	library := &config.Library{
+		Name:    "google-cloud-secretmanager",
+		Output:  "secretmanager",
+		Version: "1.2.3",
+	}
+	writeRepoMetadata(t, library.Output)
+	cfg := &config.Config{
+		Language:  config.LanguageFake,
+		Libraries: []*config.Library{library},
+	}
+	opts := docsOptions{
+		output:   filepath.Join(tmpDir, "output"),
+		uploadTo: filepath.Join(tmpDir, "staging"),
+		now:      time.Date(2026, 2, 13, 15, 49, 50, 0, time.UTC),
+	}
+	if err := runDocs(t.Context(), cfg, cfg.Libraries, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	const name = "fake-secretmanager-1.2.3.tar.gz"
+	output, err := command.Output(t.Context(), "tar", "tzf", filepath.Join(opts.output, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotFiles := strings.Split(strings.TrimSpace(output), "\n")
+	slices.Sort(gotFiles)
+	wantFiles := []string{"./", docuploader.MetadataFile, "index.html"}
+	if diff := cmp.Diff(wantFiles, gotFiles); diff != "" {
+		t.Errorf("archive mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := os.Stat(filepath.Join(opts.uploadTo, name)); err != nil {
+		t.Errorf("expected archive to be uploaded: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(library.Output, "docs", docuploader.MetadataFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "distributionName": "google-cloud-secretmanager",
+  "language": "fake",
+  "name": "secretmanager",
+  "updateTime": "2026-02-13T15:49:50Z",
+  "version": "1.2.3"
+}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("metadata mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunDocs_Error(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		language     string
+		repoMetadata bool
+		wantErr      error
+	}{
+		{
+			name:         "unsupported language",
+			language:     config.LanguageRust,
+			repoMetadata: true,
+			wantErr:      errDocsUnsupported,
+		},
+		{
+			name:     "missing repo metadata",
+			language: config.LanguageFake,
+			wantErr:  fs.ErrNotExist,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			library := &config.Library{Name: "google-cloud-secretmanager", Output: "secretmanager"}
+			if test.repoMetadata {
+				writeRepoMetadata(t, library.Output)
+			}
+			cfg := &config.Config{Language: test.language, Libraries: []*config.Library{library}}
+			err := runDocs(t.Context(), cfg, cfg.Libraries, docsOptions{output: "output"})
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("runDocs() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestDocsCommand_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{
+			name:    "no args",
+			args:    []string{"librarian", "docs"},
+			wantErr: errLibraryOrAll,
+		},
+		{
+			name:    "both library and all flag",
+			args:    []string{"librarian", "docs", "--all", "google-cloud-secretmanager"},
+			wantErr: errLibraryOrAll,
+		},
+		{
+			name:    "library not found",
+			args:    []string{"librarian", "docs", "google-cloud-missing"},
+			wantErr: ErrLibraryNotFound,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			cfg := &config.Config{
+				Language:  config.LanguageFake,
+				Libraries: []*config.Library{{Name: "google-cloud-secretmanager"}},
+			}
+			if err := yaml.Write(config.LibrarianYAML, cfg); err != nil {
+				t.Fatal(err)
+			}
+			err := Run(t.Context(), test.args...)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Run() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func writeRepoMetadata(t *testing.T, dir string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	metadata := &repometadata.RepoMetadata{
+		DistributionName: "google-cloud-secretmanager",
+		Language:         config.LanguageFake,
+		Name:             "secretmanager",
+	}
+	if err := metadata.Write(dir); err != nil {
+		t.Fatal(err)
+	}
+}
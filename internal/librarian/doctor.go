@@ -0,0 +1,420 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/cache"
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/fetch"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
+	"github.com/googleapis/librarian/internal/yaml"
+	"github.com/urfave/cli/v3"
+)
+
+var errDoctorProblems = errors.New("doctor found problems")
+
+const fixInstall = "run librarian install"
+
+// executable is an external executable run by librarian.
+type executable struct {
+	// name is the name of the executable, resolved with
+	// command.GetExecutablePath.
+	name string
+
+	// versionArgs are the arguments that print the version of the
+	// executable. If empty, the executable is only resolved, not run.
+	versionArgs []string
+
+	// fix tells how to install the executable. If empty, the executable is
+	// installed by librarian install.
+	fix string
+}
+
+var (
+	gitExecutable = &executable{
+		name:        command.Git,
+		versionArgs: []string{"--version"},
+		fix:         "install git from https://git-scm.com/downloads",
+	}
+	protocExecutable = &executable{
+		name:        "protoc",
+		versionArgs: []string{"--version"},
+		fix:         "install protoc from https://github.com/protocolbuffers/protobuf/releases",
+	}
+	python3Executable = &executable{
+		name:        "python3",
+		versionArgs: []string{"--version"},
+		fix:         "install Python 3 from https://www.python.org/downloads/",
+	}
+
+	// languageExecutables lists, for each language, the executables that
+	// librarian runs to generate, format and build its libraries, in
+	// addition to git.
+	languageExecutables = map[string][]*executable{
+		config.LanguageDart: {
+			{name: "dart", versionArgs: []string{"--version"}, fix: "install the Dart SDK from https://dart.dev/get-dart"},
+			protocExecutable,
+		},
+		config.LanguageGo: {
+			{name: command.Go, versionArgs: []string{"version"}, fix: "install Go from https://go.dev/dl/"},
+			protocExecutable,
+			{name: "protoc-gen-go"},
+			{name: "protoc-gen-go-grpc"},
+			{name: "protoc-gen-go_gapic"},
+			{name: "goimports"},
+		},
+		config.LanguageJava: {
+			{name: "java", fix: "install a JDK, such as from https://adoptium.net/"},
+			{name: "mvn", versionArgs: []string{"--version"}, fix: "install Maven from https://maven.apache.org/download.cgi"},
+			python3Executable,
+			protocExecutable,
+			{name: "protoc-gen-java_grpc"},
+			{name: "protoc-gen-java_gapic"},
+			{name: "google-java-format"},
+		},
+		config.LanguageNodejs: {
+			{name: "node", versionArgs: []string{"--version"}, fix: "install Node.js from https://nodejs.org/"},
+			{name: "pnpm", versionArgs: []string{"--version"}, fix: "install pnpm from https://pnpm.io/installation"},
+			protocExecutable,
+			{name: "gapic-generator-typescript"},
+			{name: "compileProtos"},
+			{name: "gapic-node-processing"},
+		},
+		config.LanguagePython: {
+			python3Executable,
+			protocExecutable,
+			{name: "protoc-gen-python_gapic"},
+			{name: "nox"},
+		},
+		config.LanguageRust: {
+			{name: command.Cargo, versionArgs: []string{"--version"}, fix: "install Rust from https://rustup.rs/"},
+			protocExecutable,
+			{name: "taplo"},
+		},
+		config.LanguageSwift: {
+			{name: "swift-format", fix: "install swift-format from https://github.com/swiftlang/swift-format"},
+		},
+	}
+)
+
+// doctorCheck is the result of one check of librarian doctor.
+type doctorCheck struct {
+	// name is what was checked, such as "executable protoc".
+	name string
+
+	// detail describes what was found.
+	detail string
+
+	// fix is the action that fixes the problem. It is empty if the check
+	// passed.
+	fix string
+}
+
+func doctorCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "doctor",
+		Usage:     "diagnose the environment used to generate libraries",
+		UsageText: "librarian doctor",
+		Description: `doctor checks that the environment of the current workspace can generate
+and build its libraries, and prints a fix for each problem it finds.
+
+For the language in librarian.yaml, doctor checks that:
+
+  - every external executable librarian runs, such as git, protoc, the
+    protoc plugins and the formatters, can be found in the tool environment
+    of the workspace or on PATH, and runs;
+  - the tools in librarian.yaml are installed in the tool environment at
+    the versions they are pinned to;
+  - the librarian cache directory ($LIBRARIAN_CACHE) and bin directory
+    ($LIBRARIAN_BIN) are writable;
+  - the sources in librarian.yaml are available, either as a local
+    directory or with a commit to download, and reports whether each one is
+    already cached.
+
+doctor only reads the environment: it does not install tools or download
+sources. It fails if any check finds a problem.
+
+Examples:
+
+	librarian doctor`,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
+			if err != nil {
+				return err
+			}
+			return runDoctor(ctx, cmd.Root().Writer, cfg)
+		},
+	}
+}
+
+// runDoctor runs the checks of librarian doctor for cfg and writes their
+// results to w. It returns errDoctorProblems if any check fails.
+func runDoctor(ctx context.Context, w io.Writer, cfg *config.Config) error {
+	tools, err := languageTools(cfg.Language, cfg.Tools)
+	if err != nil {
+		return err
+	}
+	env, err := newToolEnv(tools)
+	if err != nil {
+		return err
+	}
+	var checks []*doctorCheck
+	executableChecks, err := checkExecutables(ctx, cfg.Language, env)
+	if err != nil {
+		return err
+	}
+	checks = append(checks, executableChecks...)
+	checks = append(checks, checkTools(ctx, cfg.Language, env, tools)...)
+	checks = append(checks, checkCacheDirectories()...)
+	checks = append(checks, checkSources(cfg.Sources)...)
+
+	problems := 0
+	for _, c := range checks {
+		status := "ok"
+		if c.fix != "" {
+			status = "FAIL"
+			problems++
+		}
+		if _, err := fmt.Fprintf(w, "%-4s  %s: %s\n", status, c.name, c.detail); err != nil {
+			return err
+		}
+		if c.fix != "" {
+			if _, err := fmt.Fprintf(w, "      fix: %s\n", c.fix); err != nil {
+				return err
+			}
+		}
+	}
+	if problems > 0 {
+		return fmt.Errorf("%w: %d problems", errDoctorProblems, problems)
+	}
+	return nil
+}
+
+// checkExecutables checks that git and the executables of lang can be found,
+// with the executables of env taking precedence over PATH, and that those
+// with version arguments run.
+func checkExecutables(ctx context.Context, lang string, env *toolenv.Env) ([]*doctorCheck, error) {
+	overrides, err := env.Executables()
+	if err != nil {
+		return nil, fmt.Errorf("listing executables in %s: %w", env.Dir, err)
+	}
+	var checks []*doctorCheck
+	for _, exe := range append([]*executable{gitExecutable}, languageExecutables[lang]...) {
+		checks = append(checks, checkExecutable(ctx, overrides, exe))
+	}
+	return checks, nil
+}
+
+// checkExecutable checks that exe can be found after applying overrides and,
+// if it has version arguments, that it runs.
+func checkExecutable(ctx context.Context, overrides map[string]string, exe *executable) *doctorCheck {
+	c := &doctorCheck{name: "executable " + exe.name}
+	fix := exe.fix
+	if fix == "" {
+		fix = fixInstall
+	}
+	path, err := exec.LookPath(command.GetExecutablePath(overrides, exe.name))
+	if err != nil {
+		c.detail = "not found in the tool environment or on PATH"
+		c.fix = fix
+		return c
+	}
+	c.detail = path
+	if len(exe.versionArgs) == 0 {
+		return c
+	}
+	output, err := command.Output(ctx, path, exe.versionArgs...)
+	if err != nil {
+		c.detail = fmt.Sprintf("%s does not run: %v", path, err)
+		c.fix = fix
+		return c
+	}
+	if version, _, _ := strings.Cut(strings.TrimSpace(output), "\n"); version != "" {
+		c.detail = fmt.Sprintf("%s (%s)", path, version)
+	}
+	return c
+}
+
+// checkTools checks that the tools configured for lang are installed in env
+// at the versions they are pinned to.
+func checkTools(ctx context.Context, lang string, env *toolenv.Env, tools *config.Tools) []*doctorCheck {
+	wanted := toollock.Wanted(tools)
+	if len(wanted) == 0 {
+		return nil
+	}
+	installed, err := installedTools(ctx, lang, env, tools)
+	if err != nil {
+		return []*doctorCheck{{
+			name:   "tools",
+			detail: fmt.Sprintf("listing the tools installed in %s: %v", env.Dir, err),
+			fix:    fixInstall,
+		}}
+	}
+	installedLock := &toollock.Lock{Tools: installed}
+	var checks []*doctorCheck
+	for _, want := range wanted {
+		c := &doctorCheck{name: want.String()}
+		got := installedLock.Find(want.Kind, want.Name)
+		switch {
+		case got == nil:
+			c.detail = "not installed"
+			c.fix = fixInstall
+		case want.Version != "" && want.Version != "latest" && got.Version != "" && got.Version != want.Version:
+			c.detail = fmt.Sprintf("version %s is installed, librarian.yaml wants %s", got.Version, want.Version)
+			c.fix = fixInstall
+		case got.Version != "":
+			c.detail = "version " + got.Version
+		default:
+			c.detail = "installed"
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// checkCacheDirectories checks that the librarian cache and bin directories
+// are writable.
+func checkCacheDirectories() []*doctorCheck {
+	var checks []*doctorCheck
+	for _, d := range []struct {
+		name string
+		env  string
+		dir  func() (string, error)
+	}{
+		{"cache directory", cache.EnvLibrarianCache, cache.Directory},
+		{"bin directory", cache.EnvLibrarianBin, cache.BinDirectory},
+	} {
+		c := &doctorCheck{name: d.name}
+		dir, err := d.dir()
+		if err == nil {
+			err = checkWritable(dir)
+		}
+		if err != nil {
+			c.detail = err.Error()
+			c.fix = fmt.Sprintf("make the directory writable, or set $%s to a writable directory", d.env)
+		} else {
+			c.detail = dir
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// checkWritable checks that files can be created in dir or, if dir does not
+// exist yet, in its closest existing parent, in which dir would be created.
+func checkWritable(dir string) error {
+	existing := dir
+	for {
+		info, err := os.Stat(existing)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", existing)
+			}
+			break
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return err
+		}
+		existing = parent
+	}
+	f, err := os.CreateTemp(existing, ".librarian-doctor-")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", existing, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Remove(f.Name())
+}
+
+// checkSources checks that each source in src is a local directory, or has a
+// commit to download, and reports whether downloaded sources are cached. The
+// googleapis source is required.
+func checkSources(src *config.Sources) []*doctorCheck {
+	if src == nil {
+		src = &config.Sources{}
+	}
+	var checks []*doctorCheck
+	for _, s := range []struct {
+		name   string
+		repo   string
+		source *config.Source
+	}{
+		{"googleapis", googleapisRepo, src.Googleapis},
+		{"conformance", protobufRepo, src.Conformance},
+		{"discovery", discoveryRepo, src.Discovery},
+		{"protobuf", protobufRepo, src.ProtobufSrc},
+		{"showcase", showcaseRepo, src.Showcase},
+	} {
+		if s.source == nil && s.name != "googleapis" {
+			continue
+		}
+		checks = append(checks, checkSource(s.name, s.repo, s.source))
+	}
+	return checks
+}
+
+// checkSource checks the source with the given name, downloaded from repo.
+func checkSource(name, repo string, source *config.Source) *doctorCheck {
+	c := &doctorCheck{name: "source " + name}
+	switch {
+	case source == nil:
+		c.detail = ErrMissingGoogleapisSource.Error()
+		c.fix = fmt.Sprintf("run librarian update sources.%s, or set sources.%s.dir in %s", name, name, config.LibrarianYAML)
+	case source.Dir != "":
+		info, err := os.Stat(source.Dir)
+		switch {
+		case err != nil:
+			c.detail = err.Error()
+			c.fix = fmt.Sprintf("clone %s into %s, or remove sources.%s.dir from %s", repo, source.Dir, name, config.LibrarianYAML)
+		case !info.IsDir():
+			c.detail = fmt.Sprintf("%s is not a directory", source.Dir)
+			c.fix = fmt.Sprintf("set sources.%s.dir in %s to a clone of %s", name, config.LibrarianYAML, repo)
+		default:
+			c.detail = "local directory " + source.Dir
+		}
+	case source.Commit == "":
+		c.detail = "no commit or directory"
+		c.fix = fmt.Sprintf("run librarian update sources.%s, or set sources.%s.dir in %s", name, name, config.LibrarianYAML)
+	default:
+		cached, err := fetch.Cached(repo, source.Commit)
+		switch {
+		case err != nil:
+			c.detail = err.Error()
+			c.fix = fmt.Sprintf("set $%s to a writable directory", cache.EnvLibrarianCache)
+		case cached:
+			c.detail = fmt.Sprintf("%s@%s is cached", repo, source.Commit)
+		default:
+			c.detail = fmt.Sprintf("%s@%s is not cached, and will be downloaded", repo, source.Commit)
+		}
+	}
+	return c
+}
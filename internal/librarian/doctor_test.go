@@ -0,0 +1,261 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/cache"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/toollock"
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+// writeDoctorStub writes an executable script named name to dir.
+func writeDoctorStub(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// setupDoctor creates a workspace with a git stub on PATH and empty cache
+// and bin directories, and returns the path of the git stub.
+func setupDoctor(t *testing.T) string {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	t.Setenv(cache.EnvLibrarianCache, t.TempDir())
+	t.Setenv(cache.EnvLibrarianBin, t.TempDir())
+	stubDir := t.TempDir()
+	t.Setenv("PATH", stubDir)
+	return writeDoctorStub(t, stubDir, "git", "echo git version 2.50.0")
+}
+
+func TestRunDoctor(t *testing.T) {
+	git := setupDoctor(t)
+	googleapis := t.TempDir()
+	cfg := &config.Config{
+		Language: config.LanguageFake,
+		Sources: &config.Sources{
+			Googleapis: &config.Source{Dir: googleapis},
+		},
+		Tools: &config.Tools{
+			Go: []*config.GoTool{{Name: "example.com/cmd/tool", Version: "v1.0.0"}},
+		},
+	}
+	var out bytes.Buffer
+	err := runDoctor(t.Context(), &out, cfg)
+	if !errors.Is(err, errDoctorProblems) {
+		t.Fatalf("runDoctor() error = %v, want %v", err, errDoctorProblems)
+	}
+	for _, want := range []string{
+		fmt.Sprintf("ok    executable git: %s (git version 2.50.0)\n", git),
+		"FAIL  go tool example.com/cmd/tool: not installed\n      fix: run librarian install\n",
+		"ok    source googleapis: local directory " + googleapis + "\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("runDoctor() output missing %q, got:\n%s", want, out.String())
+		}
+	}
+
+	env, err := newToolEnv(cfg.Tools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fakeInstall(env, cfg.Tools, &toollock.Lock{}); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := runDoctor(t.Context(), &out, cfg); err != nil {
+		t.Fatalf("runDoctor() error = %v, output:\n%s", err, out.String())
+	}
+	if want := "ok    go tool example.com/cmd/tool: version v1.0.0\n"; !strings.Contains(out.String(), want) {
+		t.Errorf("runDoctor() output missing %q, got:\n%s", want, out.String())
+	}
+}
+
+func TestDoctorCommand(t *testing.T) {
+	setupDoctor(t)
+	cfg := &config.Config{Language: config.LanguageFake}
+	if err := yaml.Write(config.LibrarianYAML, cfg); err != nil {
+		t.Fatal(err)
+	}
+	err := Run(t.Context(), "librarian", "doctor")
+	if !errors.Is(err, errDoctorProblems) {
+		t.Errorf("Run() error = %v, want %v", err, errDoctorProblems)
+	}
+}
+
+func TestCheckExecutable(t *testing.T) {
+	stubDir := t.TempDir()
+	t.Setenv("PATH", stubDir)
+	onPath := writeDoctorStub(t, stubDir, "tool", "echo path 1.0")
+	broken := writeDoctorStub(t, stubDir, "broken", "exit 1")
+	override := writeDoctorStub(t, t.TempDir(), "tool", "echo override 2.0")
+	for _, test := range []struct {
+		name      string
+		overrides map[string]string
+		exe       *executable
+		want      *doctorCheck
+	}{
+		{
+			name: "on path",
+			exe:  &executable{name: "tool", versionArgs: []string{"--version"}},
+			want: &doctorCheck{name: "executable tool", detail: onPath + " (path 1.0)"},
+		},
+		{
+			name:      "override",
+			overrides: map[string]string{"tool": override},
+			exe:       &executable{name: "tool", versionArgs: []string{"--version"}},
+			want:      &doctorCheck{name: "executable tool", detail: override + " (override 2.0)"},
+		},
+		{
+			name: "no version args",
+			exe:  &executable{name: "broken"},
+			want: &doctorCheck{name: "executable broken", detail: broken},
+		},
+		{
+			name: "not found",
+			exe:  &executable{name: "missing", fix: "install missing"},
+			want: &doctorCheck{
+				name:   "executable missing",
+				detail: "not found in the tool environment or on PATH",
+				fix:    "install missing",
+			},
+		},
+		{
+			name: "installed by librarian install",
+			exe:  &executable{name: "missing"},
+			want: &doctorCheck{
+				name:   "executable missing",
+				detail: "not found in the tool environment or on PATH",
+				fix:    fixInstall,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := checkExecutable(t.Context(), test.overrides, test.exe)
+			if diff := cmp.Diff(test.want, got, cmp.AllowUnexported(doctorCheck{})); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckExecutable_DoesNotRun(t *testing.T) {
+	stubDir := t.TempDir()
+	t.Setenv("PATH", stubDir)
+	writeDoctorStub(t, stubDir, "broken", "exit 1")
+	got := checkExecutable(t.Context(), nil, &executable{name: "broken", versionArgs: []string{"--version"}, fix: "reinstall"})
+	if got.fix != "reinstall" || !strings.Contains(got.detail, "does not run") {
+		t.Errorf("checkExecutable() = %+v, want a failed check", got)
+	}
+}
+
+func TestCheckSources(t *testing.T) {
+	t.Setenv(cache.EnvLibrarianCache, t.TempDir())
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name    string
+		sources *config.Sources
+		want    []*doctorCheck
+	}{
+		{
+			name: "missing googleapis",
+			want: []*doctorCheck{{
+				name:   "source googleapis",
+				detail: ErrMissingGoogleapisSource.Error(),
+				fix:    "run librarian update sources.googleapis, or set sources.googleapis.dir in librarian.yaml",
+			}},
+		},
+		{
+			name: "local directory",
+			sources: &config.Sources{
+				Googleapis: &config.Source{Dir: dir},
+			},
+			want: []*doctorCheck{{name: "source googleapis", detail: "local directory " + dir}},
+		},
+		{
+			name: "not a directory",
+			sources: &config.Sources{
+				Googleapis: &config.Source{Dir: file},
+			},
+			want: []*doctorCheck{{
+				name:   "source googleapis",
+				detail: file + " is not a directory",
+				fix:    "set sources.googleapis.dir in librarian.yaml to a clone of github.com/googleapis/googleapis",
+			}},
+		},
+		{
+			name: "not cached",
+			sources: &config.Sources{
+				Googleapis: &config.Source{Commit: "abc123"},
+				Showcase:   &config.Source{},
+			},
+			want: []*doctorCheck{
+				{
+					name:   "source googleapis",
+					detail: "github.com/googleapis/googleapis@abc123 is not cached, and will be downloaded",
+				},
+				{
+					name:   "source showcase",
+					detail: "no commit or directory",
+					fix:    "run librarian update sources.showcase, or set sources.showcase.dir in librarian.yaml",
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := checkSources(test.sources)
+			if diff := cmp.Diff(test.want, got, cmp.AllowUnexported(doctorCheck{})); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckWritable(t *testing.T) {
+	dir := t.TempDir()
+	if err := checkWritable(filepath.Join(dir, "a", "b")); err != nil {
+		t.Errorf("checkWritable() error = %v", err)
+	}
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkWritable(filepath.Join(file, "a")); err == nil {
+		t.Error("checkWritable() error = nil, want error")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("checkWritable() left files behind: %v", entries)
+	}
+}
@@ -23,6 +23,9 @@ import (
 	"strings"
 
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
+	"github.com/googleapis/librarian/internal/yaml"
 )
 
 const fakeVersionFile = "VERSION"
@@ -99,3 +102,50 @@ func fakeAdd(lib *config.Library, version string) *config.Library {
 	lib.Version = version
 	return lib
 }
+
+// fakeBuildDocs writes a single page of documentation for the library and
+// returns the directory containing it.
+func fakeBuildDocs(library *config.Library) (string, error) {
+	dir := filepath.Join(library.Output, "docs")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	content := fmt.Sprintf("<h1>%s</h1>\n", library.Name)
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte(content), 0644); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// fakeInstalledFile is the file in the tool environment listing the tools
+// installed by fakeInstall.
+const fakeInstalledFile = "fake-tools.yaml"
+
+// fakeInstall records the tools in lock at the version and checksum
+// configured in librarian.yaml, and lists them as installed in env.
+func fakeInstall(env *toolenv.Env, tools *config.Tools, lock *toollock.Lock) error {
+	wanted := toollock.Wanted(tools)
+	for _, t := range wanted {
+		lock.Record(t.Kind, t.Name, t.Version, t.Checksum)
+	}
+	if err := os.MkdirAll(env.Dir, 0755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(wanted)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(env.Dir, fakeInstalledFile), data, 0644)
+}
+
+// fakeInstalledTools returns the tools installed in env by fakeInstall.
+func fakeInstalledTools(env *toolenv.Env) ([]*toollock.Tool, error) {
+	installed, err := yaml.Read[[]*toollock.Tool](filepath.Join(env.Dir, fakeInstalledFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return *installed, nil
+}
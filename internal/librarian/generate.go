@@ -28,6 +28,7 @@ import (
 	"github.com/googleapis/librarian/internal/librarian/python"
 	"github.com/googleapis/librarian/internal/librarian/rust"
 	"github.com/googleapis/librarian/internal/librarian/swift"
+	"github.com/googleapis/librarian/internal/postprocessing"
 	"github.com/googleapis/librarian/internal/sources"
 	"github.com/googleapis/librarian/internal/yaml"
 	"github.com/urfave/cli/v3"
@@ -61,6 +62,8 @@ Examples:
 
 	librarian generate <library>   # regenerate one library
 	librarian generate --all       # regenerate every library
+	librarian generate --all --list-template-overlays
+	                               # list the templates replaced by overlays
 
 [after-flags]
 A typical librarian workflow for regenerating every library against the
@@ -73,6 +76,10 @@ latest API definitions is:
 				Name:  "all",
 				Usage: "generate all libraries",
 			},
+			&cli.BoolFlag{
+				Name:  "list-template-overlays",
+				Usage: "list the templates replaced by template_overlay instead of generating",
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			all := cmd.Bool("all")
@@ -87,6 +94,13 @@ latest API definitions is:
 			if err != nil {
 				return err
 			}
+			if cmd.Bool("list-template-overlays") {
+				return runListTemplateOverlays(cmd.Root().Writer, cfg, all, libraryName)
+			}
+			ctx, err = withToolEnv(ctx, cfg)
+			if err != nil {
+				return err
+			}
 			return runGenerate(ctx, cfg, all, libraryName)
 		},
 	}
@@ -187,8 +201,8 @@ func generateLibraries(ctx context.Context, cfg *config.Config, libraries []*con
 		g, gctx := errgroup.WithContext(ctx)
 		for _, library := range libraries {
 			g.Go(func() error {
-				if err := dart.Generate(gctx, library, src); err != nil {
-					return fmt.Errorf("generate library %q (%s): %w", library.Name, cfg.Language, err)
+				if err := generateLibrary(gctx, cfg, library, src); err != nil {
+					return err
 				}
 				if err := dart.Format(gctx, library); err != nil {
 					return fmt.Errorf("format library %q (%s): %w", library.Name, cfg.Language, err)
@@ -199,8 +213,8 @@ func generateLibraries(ctx context.Context, cfg *config.Config, libraries []*con
 		return g.Wait()
 	case config.LanguageFake:
 		for _, library := range libraries {
-			if err := fakeGenerate(library); err != nil {
-				return fmt.Errorf("generate library %q (%s): %w", library.Name, cfg.Language, err)
+			if err := generateLibrary(ctx, cfg, library, src); err != nil {
+				return err
 			}
 			if err := fakeFormat(library); err != nil {
 				return fmt.Errorf("format library %q (%s): %w", library.Name, cfg.Language, err)
@@ -211,10 +225,7 @@ func generateLibraries(ctx context.Context, cfg *config.Config, libraries []*con
 		g, gctx := errgroup.WithContext(ctx)
 		for _, library := range libraries {
 			g.Go(func() error {
-				if err := golang.Generate(gctx, cfg, library, src); err != nil {
-					return fmt.Errorf("generate library %q (%s): %w", library.Name, cfg.Language, err)
-				}
-				return nil
+				return generateLibrary(gctx, cfg, library, src)
 			})
 		}
 		if err := g.Wait(); err != nil {
@@ -241,8 +252,8 @@ func generateLibraries(ctx context.Context, cfg *config.Config, libraries []*con
 				allMissingArtifacts = append(allMissingArtifacts, java.MissingArtifact{ID: id, Library: library})
 			}
 
-			if err := java.Generate(ctx, cfg, library, src); err != nil {
-				return fmt.Errorf("generate library %q (%s): %w", library.Name, cfg.Language, err)
+			if err := generateLibrary(ctx, cfg, library, src); err != nil {
+				return err
 			}
 			if err := java.Format(ctx, library); err != nil {
 				return fmt.Errorf("format library %q (%s): %w", library.Name, cfg.Language, err)
@@ -253,10 +264,7 @@ func generateLibraries(ctx context.Context, cfg *config.Config, libraries []*con
 		g, gctx := errgroup.WithContext(ctx)
 		for _, library := range libraries {
 			g.Go(func() error {
-				if err := nodejs.Generate(gctx, cfg, library, src); err != nil {
-					return fmt.Errorf("generate library %q (%s): %w", library.Name, cfg.Language, err)
-				}
-				return nil
+				return generateLibrary(gctx, cfg, library, src)
 			})
 		}
 		return g.Wait()
@@ -264,10 +272,11 @@ func generateLibraries(ctx context.Context, cfg *config.Config, libraries []*con
 		g, gctx := errgroup.WithContext(ctx)
 		for _, library := range libraries {
 			g.Go(func() error {
-				// TODO(https://github.com/googleapis/librarian/issues/3730):
-				// separate generation and formatting for Python.
-				if err := python.Generate(gctx, cfg, library, src); err != nil {
-					return fmt.Errorf("generate library %q (%s): %w", library.Name, cfg.Language, err)
+				if err := generateLibrary(gctx, cfg, library, src); err != nil {
+					return err
+				}
+				if err := python.Format(gctx, library); err != nil {
+					return fmt.Errorf("format library %q (%s): %w", library.Name, cfg.Language, err)
 				}
 				return nil
 			})
@@ -279,10 +288,7 @@ func generateLibraries(ctx context.Context, cfg *config.Config, libraries []*con
 		g, gctx := errgroup.WithContext(ctx)
 		for _, library := range libraries {
 			g.Go(func() error {
-				if err := rust.Generate(gctx, cfg, library, src); err != nil {
-					return fmt.Errorf("generate library %q (%s): %w", library.Name, cfg.Language, err)
-				}
-				return nil
+				return generateLibrary(gctx, cfg, library, src)
 			})
 		}
 		if err := g.Wait(); err != nil {
@@ -298,8 +304,8 @@ func generateLibraries(ctx context.Context, cfg *config.Config, libraries []*con
 		g, gctx := errgroup.WithContext(ctx)
 		for _, library := range libraries {
 			g.Go(func() error {
-				if err := swift.Generate(gctx, cfg, library, src); err != nil {
-					return fmt.Errorf("generate library %q (%s): %w", library.Name, cfg.Language, err)
+				if err := generateLibrary(gctx, cfg, library, src); err != nil {
+					return err
 				}
 				if err := swift.Format(gctx, library); err != nil {
 					return fmt.Errorf("format library %q (%s): %w", library.Name, cfg.Language, err)
@@ -313,6 +319,46 @@ func generateLibraries(ctx context.Context, cfg *config.Config, libraries []*con
 	}
 }
 
+// generateLibrary generates a library, delegating to language-specific code,
+// and applies its post_generate steps. It runs before the library is
+// formatted, so the edited code is formatted too.
+func generateLibrary(ctx context.Context, cfg *config.Config, library *config.Library, src *sources.Sources) error {
+	var err error
+	switch cfg.Language {
+	case config.LanguageDart:
+		err = dart.Generate(ctx, library, src)
+	case config.LanguageFake:
+		err = fakeGenerate(library)
+	case config.LanguageGo:
+		err = golang.Generate(ctx, cfg, library, src)
+	case config.LanguageJava:
+		err = java.Generate(ctx, cfg, library, src)
+	case config.LanguageNodejs:
+		err = nodejs.Generate(ctx, cfg, library, src)
+	case config.LanguagePython:
+		err = python.Generate(ctx, cfg, library, src)
+	case config.LanguageRust:
+		err = rust.Generate(ctx, cfg, library, src)
+	case config.LanguageSwift:
+		err = swift.Generate(ctx, cfg, library, src)
+	default:
+		err = fmt.Errorf("%w: %q", errUnsupportedLanguage, cfg.Language)
+	}
+	if err != nil {
+		return fmt.Errorf("generate library %q (%s): %w", library.Name, cfg.Language, err)
+	}
+	return postGenerate(ctx, cfg.Language, library)
+}
+
+// postGenerate applies the post_generate steps of the library to its
+// generated code.
+func postGenerate(ctx context.Context, language string, library *config.Library) error {
+	if err := postprocessing.Apply(ctx, language, library.Output, library.PostGenerate); err != nil {
+		return fmt.Errorf("post-generate library %q (%s): %w", library.Name, language, err)
+	}
+	return nil
+}
+
 func defaultOutput(language string, name, api, defaultOut string) string {
 	switch language {
 	case config.LanguageDart:
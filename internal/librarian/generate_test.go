@@ -20,6 +20,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -355,6 +356,46 @@ libraries:
 	}
 }
 
+func TestGenerateLibraries_PostGenerate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := &config.Config{Language: config.LanguageFake}
+	library := &config.Library{
+		Name:   "secretmanager",
+		Output: "out",
+		PostGenerate: []*config.PostGenerateStep{
+			{Path: "README.md", Replace: "Generated library", With: "Edited library"},
+		},
+	}
+	if err := generateLibraries(t.Context(), cfg, []*config.Library{library}, nil); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join("out", "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The edit runs before formatting.
+	want := "# secretmanager\n\nEdited library\n\n---\nFormatted\n"
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateLibraries_PostGenerateError(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := &config.Config{Language: config.LanguageFake}
+	library := &config.Library{
+		Name:   "secretmanager",
+		Output: "out",
+		PostGenerate: []*config.PostGenerateStep{
+			{Path: "README.md", Replace: "not in the file", With: "anything"},
+		},
+	}
+	err := generateLibraries(t.Context(), cfg, []*config.Library{library}, nil)
+	if err == nil || !strings.Contains(err.Error(), `post-generate library "secretmanager"`) {
+		t.Errorf("generateLibraries() error = %v, want a post-generate error", err)
+	}
+}
+
 // createGoogleapisServiceConfigs creates a mock googleapis directory structure
 // with service config files for testing purposes.
 // The configs map keys are api paths (e.g., "google/cloud/speech/v1")
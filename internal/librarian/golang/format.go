@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/googleapis/librarian/internal/command"
 	"github.com/googleapis/librarian/internal/config"
 )
 
@@ -32,7 +33,7 @@ func Format(ctx context.Context, library *config.Library) error {
 	if err != nil {
 		return err
 	}
-	return runWithEnv(ctx, nil, "goimports", args...)
+	return command.Run(ctx, "goimports", args...)
 }
 
 func buildFormatArgs(library *config.Library) ([]string, error) {
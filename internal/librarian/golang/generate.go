@@ -136,7 +136,7 @@ func Generate(ctx context.Context, cfg *config.Config, library *config.Library,
 	if toolchain != "" {
 		env = map[string]string{"GOTOOLCHAIN": toolchain}
 	}
-	return runInDirWithEnv(ctx, outDir, env, command.Go, "mod", "tidy")
+	return command.RunInDirWithEnv(ctx, outDir, env, command.Go, "mod", "tidy")
 }
 
 func generateAPI(ctx context.Context, apiPath string, goAPI *config.GoAPI, googleapisDir, version, outDir string) error {
@@ -165,7 +165,7 @@ func generateAPI(ctx context.Context, apiPath string, goAPI *config.GoAPI, googl
 		return err
 	}
 	args = append(args, protoFiles...)
-	return runWithEnv(ctx, nil, args[0], args[1:]...)
+	return command.Run(ctx, args[0], args[1:]...)
 }
 
 func buildGAPICOpts(apiPath string, goAPI *config.GoAPI, version, googleapisDir string) ([]string, error) {
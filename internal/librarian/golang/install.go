@@ -16,58 +16,155 @@ package golang
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"path/filepath"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
 
-	"github.com/googleapis/librarian/internal/cache"
 	"github.com/googleapis/librarian/internal/command"
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
 )
 
-const (
-	envGoBin = "GOBIN"
-	toolsDir = "go_tools"
-)
+const envGoBin = "GOBIN"
 
 var (
 	// errMissingToolVersion indicates a go tool entry is missing its version.
 	errMissingToolVersion = errors.New("go tool missing version")
 	// errNoToolsSpecified indicates no Go tools were provided in the configuration.
 	errNoToolsSpecified = errors.New("no tools specified in configuration")
+	// errNoModule indicates that no module provides a go tool.
+	errNoModule = errors.New("no module found for go tool")
 )
 
-// Install installs the tools required for Go library generation.
-func Install(ctx context.Context, tools *config.Tools) error {
+// Install verifies and records the checksum of the module zip of each tool
+// required for Go library generation in lock, then installs the tool from
+// that zip into the GOBIN directory of env.
+func Install(ctx context.Context, env *toolenv.Env, tools *config.Tools, lock *toollock.Lock) error {
 	if tools == nil || len(tools.Go) == 0 {
 		return errNoToolsSpecified
 	}
-	return installGoTools(ctx, tools.Go)
+	return installGoTools(ctx, env.GoBin(), tools.Go, lock)
 }
 
-func installGoTools(ctx context.Context, goTools []*config.GoTool) error {
-	installDir, err := getInstallDir()
+// InstalledTools returns the go tools installed in env by [Install], with
+// the version of their module.
+func InstalledTools(ctx context.Context, env *toolenv.Env) ([]*toollock.Tool, error) {
+	modules, err := installedModules(ctx, env.GoBin())
 	if err != nil {
-		return err
+		return nil, err
+	}
+	var tools []*toollock.Tool
+	for pkg, mod := range modules {
+		tools = append(tools, &toollock.Tool{Kind: toollock.KindGo, Name: pkg, Version: mod.Version})
 	}
+	return tools, nil
+}
+
+func installGoTools(ctx context.Context, installDir string, goTools []*config.GoTool, lock *toollock.Lock) error {
 	env := map[string]string{envGoBin: installDir}
 	for _, tool := range goTools {
 		if tool.Version == "" {
 			return fmt.Errorf("%w: %s", errMissingToolVersion, tool.Name)
 		}
-		toolStr := fmt.Sprintf("%s@%s", tool.Name, tool.Version)
-		if err := runWithEnv(ctx, env, command.Go, "install", toolStr); err != nil {
+		mod, zip, err := downloadModule(ctx, tool.Name, tool.Version)
+		if err != nil {
+			return err
+		}
+		if err := lock.Verify(toollock.KindGo, tool.Name, mod.Version, tool.Checksum, zip); err != nil {
+			return err
+		}
+		// go install builds from the module zip verified above, which is
+		// already in the module cache.
+		toolStr := fmt.Sprintf("%s@%s", tool.Name, mod.Version)
+		if err := command.RunWithEnv(ctx, env, command.Go, "install", toolStr); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// getInstallDir gets the directory where tools should be installed.
-func getInstallDir() (string, error) {
-	dir, err := cache.BinDirectory()
+// module is a Go module version.
+type module struct {
+	Path    string
+	Version string
+}
+
+// installedModules returns the module of each binary in installDir, keyed by
+// the package path of the binary.
+func installedModules(ctx context.Context, installDir string) (map[string]module, error) {
+	if _, err := os.Stat(installDir); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	output, err := command.Output(ctx, command.Go, "version", "-m", installDir)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	return parseBuildInfo(output), nil
+}
+
+// parseBuildInfo parses the output of go version -m, which lists the build
+// information of each binary:
+//
+//	/path/to/goimports: go1.25.0
+//		path	golang.org/x/tools/cmd/goimports
+//		mod	golang.org/x/tools	v0.44.0	h1:...
+func parseBuildInfo(output string) map[string]module {
+	modules := map[string]module{}
+	var pkg string
+	for line := range strings.Lines(output) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "path":
+			pkg = fields[1]
+		case "mod":
+			if pkg != "" && len(fields) >= 3 {
+				modules[pkg] = module{Path: fields[1], Version: fields[2]}
+			}
+			pkg = ""
+		}
+	}
+	return modules
+}
+
+// downloadModule downloads the module providing the package pkg at version
+// into the module cache, and returns the module with its resolved version and
+// the path of its zip file. Like go install, it uses the longest module path
+// that is a prefix of pkg.
+func downloadModule(ctx context.Context, pkg, version string) (module, string, error) {
+	var errs []error
+	for modPath := pkg; modPath != "." && modPath != ""; modPath = path.Dir(modPath) {
+		mod, zip, err := moduleZip(ctx, module{Path: modPath, Version: version})
+		if err == nil {
+			return mod, zip, nil
+		}
+		errs = append(errs, err)
+	}
+	return module{}, "", fmt.Errorf("%w: %s@%s: %w", errNoModule, pkg, version, errors.Join(errs...))
+}
+
+// moduleZip downloads mod into the module cache, if needed, and returns the
+// module with its resolved version and the path of its zip file.
+func moduleZip(ctx context.Context, mod module) (module, string, error) {
+	// Run outside of any module, so that go.mod and go.sum are not updated.
+	output, err := command.OutputInDir(ctx, os.TempDir(), command.Go, "mod", "download", "-json", mod.Path+"@"+mod.Version)
+	if err != nil {
+		return module{}, "", err
+	}
+	var info struct {
+		Path    string
+		Version string
+		Zip     string
+	}
+	if err := json.Unmarshal([]byte(output), &info); err != nil {
+		return module{}, "", fmt.Errorf("parsing go mod download output: %w", err)
 	}
-	return filepath.Abs(filepath.Join(dir, toolsDir))
+	return module{Path: info.Path, Version: info.Version}, info.Zip, nil
 }
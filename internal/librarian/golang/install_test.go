@@ -22,8 +22,9 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/googleapis/librarian/internal/cache"
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
 )
 
 func TestInstall_Error(t *testing.T) {
@@ -35,7 +36,7 @@ func TestInstall_Error(t *testing.T) {
 		{"empty tools", &config.Tools{}},
 	} {
 		t.Run(test.name, func(t *testing.T) {
-			if err := Install(t.Context(), test.tools); !errors.Is(err, errNoToolsSpecified) {
+			if err := Install(t.Context(), &toolenv.Env{Dir: t.TempDir()}, test.tools, &toollock.Lock{}); !errors.Is(err, errNoToolsSpecified) {
 				t.Fatalf("Install() error = %v, want %v", err, errNoToolsSpecified)
 			}
 		})
@@ -43,8 +44,7 @@ func TestInstall_Error(t *testing.T) {
 }
 
 func TestInstall_Success(t *testing.T) {
-	installDir := t.TempDir()
-	t.Setenv(cache.EnvLibrarianBin, installDir)
+	env := &toolenv.Env{Dir: t.TempDir()}
 	tools := &config.Tools{
 		Go: []*config.GoTool{
 			{Name: "github.com/googleapis/gapic-generator-go/cmd/protoc-gen-go_gapic", Version: "v0.58.0"},
@@ -53,9 +53,16 @@ func TestInstall_Success(t *testing.T) {
 			{Name: "google.golang.org/protobuf/cmd/protoc-gen-go", Version: "v1.36.11"},
 		},
 	}
-	if err := Install(t.Context(), tools); err != nil {
+	lock := &toollock.Lock{}
+	if err := Install(t.Context(), env, tools, lock); err != nil {
 		t.Fatal(err)
 	}
+	for _, tool := range tools.Go {
+		locked := lock.Find(toollock.KindGo, tool.Name)
+		if locked == nil || locked.Version != tool.Version || locked.Checksum == "" {
+			t.Errorf("lock.Find(%q) = %+v, want version %s with a checksum", tool.Name, locked, tool.Version)
+		}
+	}
 	suffix := ""
 	if runtime.GOOS == "windows" {
 		suffix = ".exe"
@@ -67,7 +74,7 @@ func TestInstall_Success(t *testing.T) {
 		"protoc-gen-go",
 	} {
 		t.Run(tool, func(t *testing.T) {
-			path := filepath.Join(installDir, toolsDir, tool+suffix)
+			path := filepath.Join(env.GoBin(), tool+suffix)
 			if _, err := os.Stat(path); err != nil {
 				t.Error(err)
 			}
@@ -75,34 +82,46 @@ func TestInstall_Success(t *testing.T) {
 	}
 }
 
-func TestGetInstallDir(t *testing.T) {
-	for _, test := range []struct {
-		name string
-		env  map[string]string
-		want string
-	}{
-		{
-			name: "LIBRARIAN_BIN set",
-			env:  map[string]string{cache.EnvLibrarianBin: "/custom/install/dir"},
-			want: "/custom/install/dir/go_tools",
-		},
-		{
-			name: "LIBRARIAN_BIN empty",
-			env:  map[string]string{cache.EnvLibrarianCache: "/my/home/cache"},
-			want: "/my/home/cache/bin/go_tools",
-		},
-	} {
-		t.Run(test.name, func(t *testing.T) {
-			for k, v := range test.env {
-				t.Setenv(k, v)
-			}
-			got, err := getInstallDir()
-			if err != nil {
-				t.Fatal(err)
-			}
-			if diff := cmp.Diff(test.want, got); diff != "" {
-				t.Errorf("mismatch (-want +got):\n%s", diff)
-			}
-		})
+func TestParseBuildInfo(t *testing.T) {
+	output := `/bin/goimports: go1.26.1
+	path	golang.org/x/tools/cmd/goimports
+	mod	golang.org/x/tools	v0.44.0	h1:abc=
+	dep	golang.org/x/mod	v0.30.0	h1:def=
+	build	-compiler=gc
+/bin/protoc-gen-go: go1.26.1
+	path	google.golang.org/protobuf/cmd/protoc-gen-go
+	mod	google.golang.org/protobuf	v1.36.11	h1:ghi=
+/bin/local: go1.26.1
+	path	example.com/local
+`
+	got := parseBuildInfo(output)
+	want := map[string]module{
+		"golang.org/x/tools/cmd/goimports":             {Path: "golang.org/x/tools", Version: "v0.44.0"},
+		"google.golang.org/protobuf/cmd/protoc-gen-go": {Path: "google.golang.org/protobuf", Version: "v1.36.11"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInstalledTools_NotInstalled(t *testing.T) {
+	got, err := InstalledTools(t.Context(), &toolenv.Env{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("InstalledTools() = %v, want nil", got)
+	}
+}
+
+func TestDownloadModule_Error(t *testing.T) {
+	// Every go mod download fails.
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go"), []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	if _, _, err := downloadModule(t.Context(), "example.com/mod/cmd/tool", "v1.0.0"); !errors.Is(err, errNoModule) {
+		t.Errorf("downloadModule() error = %v, want %v", err, errNoModule)
 	}
 }
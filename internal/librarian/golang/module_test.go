@@ -16,8 +16,10 @@ package golang
 
 import (
 	"errors"
+	"flag"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -26,6 +28,11 @@ import (
 	"github.com/googleapis/librarian/internal/testhelper"
 )
 
+// record is used to record the cassettes in testdata/ with the real tools,
+// when the commands run by the code under test change.
+// Usage: go test ./internal/librarian/golang -v -record.
+var record = flag.Bool("record", false, "record cassettes with the real tools")
+
 func TestFill(t *testing.T) {
 	for _, test := range []struct {
 		name    string
@@ -683,6 +690,47 @@ func TestInitModule(t *testing.T) {
 	}
 }
 
+func TestInitModule_Cassette(t *testing.T) {
+	outDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outDir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join("testdata", "init_module_cassette.yaml")
+	var c *command.Cassette
+	if *record {
+		testhelper.RequireCommand(t, command.Go)
+		c = &command.Cassette{}
+	} else {
+		var err error
+		if c, err = command.ReadCassette(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.Roots = []*command.Root{{Name: "out", Dir: outDir, Output: true}}
+	ctx := c.Replay(t.Context())
+	if *record {
+		ctx = c.Record(t.Context())
+	}
+	if err := initModule(ctx, outDir, "example.com/testmod", ""); err != nil {
+		t.Fatal(err)
+	}
+	if *record {
+		if err := command.WriteCassette(path, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if unplayed := c.Unplayed(); !*record && len(unplayed) != 0 {
+		t.Errorf("commands not run: %v", unplayed)
+	}
+	got, err := os.ReadFile(filepath.Join(outDir, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "module example.com/testmod\n"; !strings.HasPrefix(string(got), want) {
+		t.Errorf("go.mod = %q, want prefix %q", got, want)
+	}
+}
+
 func TestDefaultLibraryName(t *testing.T) {
 	for _, test := range []struct {
 		name string
@@ -0,0 +1,274 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/librarian/golang"
+	"github.com/googleapis/librarian/internal/librarian/java"
+	"github.com/googleapis/librarian/internal/librarian/nodejs"
+	"github.com/googleapis/librarian/internal/librarian/python"
+	"github.com/googleapis/librarian/internal/librarian/rust"
+	"github.com/googleapis/librarian/internal/pip"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
+	"github.com/googleapis/librarian/internal/yaml"
+	"github.com/urfave/cli/v3"
+)
+
+var errToolDrift = errors.New("installed tools do not match the lockfile")
+
+func installCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "install",
+		Usage:     "install tool dependencies for a language",
+		UsageText: "librarian install [language]",
+		Description: `install installs the language-specific tools that librarian uses to
+generate and build client libraries (for example, language SDKs and code
+generators).
+
+If [language] is omitted, the language is read from librarian.yaml in the
+current directory.
+
+Tools are installed into a tool environment private to the workspace and
+set of tools, under the librarian bin directory ($LIBRARIAN_BIN, or the bin
+subdirectory of $LIBRARIAN_CACHE): go tools into its GOBIN directory, cargo
+tools into its cargo root, pip tools into its virtualenv, pnpm tools into its
+private pnpm home and store, and Maven tools into its lib directory. The
+commands run by librarian generate and librarian bump resolve executables
+from the tool environment first, so workspaces that need different versions
+of a tool can coexist on one machine. Changing the tools in librarian.yaml
+selects a new tool environment, which must be installed again. If
+$LIBRARIAN_TOOLENV is set, it is the tool environment of every workspace and
+set of tools instead, such as in a container image that installs the tools
+once and generates libraries in a workspace mounted later.
+
+install writes librarian-lock.yaml next to librarian.yaml, recording the
+resolved version of each tool and the SHA256 checksum of the package
+downloaded for it: the module zip for go tools, the .crate file for cargo
+tools, the distribution file for pip tools, the artifact for Maven tools and
+the tarball for pnpm tools. Pip tools record the checksum of each
+distribution file by file name, as their wheels are often platform-specific.
+Each package is verified before the tool is installed from it. When a tool
+is reinstalled at the version in the lockfile, or has a checksum in
+librarian.yaml, install fails if the downloaded package does not match the
+checksum.
+
+With --print-path, install installs nothing and prints the bin directories
+of the tool environment, one per line, for use outside of librarian, such as
+appending them to PATH in CI.
+
+With --prune, install removes the tool environments of the workspace that
+were installed for earlier sets of tools, after installing the current one.
+
+With --check, install installs nothing and reports the drift between
+librarian.yaml, librarian-lock.yaml and the installed tools, failing if
+there is any.
+
+Examples:
+
+	librarian install              # use language from librarian.yaml
+	librarian install go           # install Go-specific tools
+	librarian install --check      # report drift from the lockfile
+	librarian install --prune      # remove tool environments of earlier tools
+	librarian install --print-path # print the bin directories of the tools`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "check",
+				Usage: "report drift between librarian.yaml, the lockfile and the installed tools",
+			},
+			&cli.BoolFlag{
+				Name:  "print-path",
+				Usage: "print the bin directories of the tool environment",
+			},
+			&cli.BoolFlag{
+				Name:  "prune",
+				Usage: "remove the tool environments of the workspace for earlier sets of tools",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			lang := cmd.Args().First()
+			cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
+			if err != nil && lang == "" {
+				return err
+			}
+			if lang == "" {
+				lang = cfg.Language
+			}
+			var tools *config.Tools
+			if cfg != nil {
+				tools = cfg.Tools
+			}
+			tools, err = languageTools(lang, tools)
+			if err != nil {
+				return err
+			}
+			env, err := newToolEnv(tools)
+			if err != nil {
+				return err
+			}
+			if cmd.Bool("print-path") {
+				return printPath(cmd.Root().Writer, env)
+			}
+			lock, err := toollock.Read(toollock.File)
+			if err != nil {
+				return err
+			}
+			if cmd.Bool("check") {
+				return checkInstall(ctx, cmd.Root().Writer, lang, env, tools, lock)
+			}
+			if err := install(ctx, lang, env, tools, lock); err != nil {
+				return err
+			}
+			if err := toollock.Write(toollock.File, lock); err != nil {
+				return err
+			}
+			if cmd.Bool("prune") {
+				return prune(cmd.Root().Writer, env)
+			}
+			return nil
+		},
+	}
+}
+
+// install installs the tools for lang into env, verifying and recording the
+// checksum of each tool in lock.
+func install(ctx context.Context, lang string, env *toolenv.Env, tools *config.Tools, lock *toollock.Lock) error {
+	switch lang {
+	case config.LanguageFake:
+		return fakeInstall(env, tools, lock)
+	case config.LanguageGo:
+		return golang.Install(ctx, env, tools, lock)
+	case config.LanguageJava:
+		return java.Install(ctx, env, tools, lock)
+	case config.LanguageNodejs:
+		return nodejs.Install(ctx, env, tools, lock)
+	case config.LanguagePython:
+		return python.Install(ctx, env, tools, lock)
+	case config.LanguageRust:
+		return rust.Install(ctx, env, tools, lock)
+	default:
+		return fmt.Errorf("language %q does not support install", lang)
+	}
+}
+
+// languageTools returns the tools to install for lang. Node.js and Python
+// fall back to their default tools when tools has no pnpm or pip tools.
+func languageTools(lang string, tools *config.Tools) (*config.Tools, error) {
+	switch {
+	case lang == config.LanguageNodejs && (tools == nil || len(tools.PNPM) == 0):
+		return nodejs.Tools()
+	case lang == config.LanguagePython && (tools == nil || len(tools.Pip) == 0):
+		return python.Tools()
+	default:
+		return tools, nil
+	}
+}
+
+// newToolEnv returns the tool environment of the workspace in the current
+// directory for tools.
+func newToolEnv(tools *config.Tools) (*toolenv.Env, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return toolenv.New(wd, tools)
+}
+
+// withToolEnv returns a copy of ctx in which commands resolve executables
+// from the tool environment installed by librarian install for cfg.
+func withToolEnv(ctx context.Context, cfg *config.Config) (context.Context, error) {
+	tools, err := languageTools(cfg.Language, cfg.Tools)
+	if err != nil {
+		return nil, err
+	}
+	env, err := newToolEnv(tools)
+	if err != nil {
+		return nil, err
+	}
+	return env.Context(ctx)
+}
+
+// printPath writes the bin directories of env to w, one per line.
+func printPath(w io.Writer, env *toolenv.Env) error {
+	for _, dir := range env.BinDirs() {
+		if _, err := fmt.Fprintln(w, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prune removes the tool environments of the workspace of env for other sets
+// of tools, and writes each removed directory to w.
+func prune(w io.Writer, env *toolenv.Env) error {
+	pruned, err := env.Prune()
+	for _, dir := range pruned {
+		if _, err := fmt.Fprintf(w, "removed %s\n", dir); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// checkInstall writes the drift between the tools wanted by librarian.yaml,
+// lock and the tools installed in env for lang to w. It returns errToolDrift
+// if there is any drift.
+func checkInstall(ctx context.Context, w io.Writer, lang string, env *toolenv.Env, tools *config.Tools, lock *toollock.Lock) error {
+	installed, err := installedTools(ctx, lang, env, tools)
+	if err != nil {
+		return err
+	}
+	problems := toollock.Check(toollock.Wanted(tools), lock, installed)
+	if len(problems) == 0 {
+		_, err := fmt.Fprintf(w, "tools match %s\n", toollock.File)
+		return err
+	}
+	for _, p := range problems {
+		if _, err := fmt.Fprintln(w, p); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("%w: %d problems", errToolDrift, len(problems))
+}
+
+// installedTools returns the tools installed in env for lang.
+func installedTools(ctx context.Context, lang string, env *toolenv.Env, tools *config.Tools) ([]*toollock.Tool, error) {
+	switch lang {
+	case config.LanguageFake:
+		return fakeInstalledTools(env)
+	case config.LanguageGo:
+		return golang.InstalledTools(ctx, env)
+	case config.LanguageJava:
+		return java.InstalledTools(ctx, env, tools)
+	case config.LanguageNodejs:
+		return nodejs.InstalledTools(ctx, env)
+	case config.LanguagePython:
+		return pip.InstalledTools(ctx, env.Venv())
+	case config.LanguageRust:
+		if tools == nil || len(tools.Cargo) == 0 {
+			return nil, nil
+		}
+		return rust.InstalledTools(ctx, env)
+	default:
+		return nil, fmt.Errorf("language %q does not support install", lang)
+	}
+}
@@ -15,19 +15,28 @@
 package librarian
 
 import (
+	"bytes"
 	"errors"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/cache"
+	"github.com/googleapis/librarian/internal/command"
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/librarian/nodejs"
+	"github.com/googleapis/librarian/internal/librarian/python"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
 	"github.com/googleapis/librarian/internal/yaml"
 )
 
 func TestInstallCommand_WithLanguage(t *testing.T) {
 	t.Chdir(t.TempDir())
+	t.Setenv(cache.EnvLibrarianBin, t.TempDir())
 	if err := Run(t.Context(), "librarian", "install", "fake"); err != nil {
 		t.Fatal(err)
 	}
@@ -36,6 +45,7 @@ func TestInstallCommand_WithLanguage(t *testing.T) {
 func TestInstallCommand_FromConfig(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Chdir(tmpDir)
+	t.Setenv(cache.EnvLibrarianBin, t.TempDir())
 	cfg := &config.Config{
 		Language: config.LanguageFake,
 	}
@@ -47,6 +57,272 @@ func TestInstallCommand_FromConfig(t *testing.T) {
 	}
 }
 
+func TestInstallCommand_Lock(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	t.Setenv(cache.EnvLibrarianBin, t.TempDir())
+	cfg := &config.Config{
+		Language: config.LanguageFake,
+		Tools: &config.Tools{
+			Go:  []*config.GoTool{{Name: "example.com/cmd/tool", Version: "v1.0.0", Checksum: "abc"}},
+			Pip: []*config.PipTool{{Name: "nox", Version: "2025.11.12"}},
+		},
+	}
+	if err := yaml.Write(config.LibrarianYAML, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := Run(t.Context(), "librarian", "install"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := toollock.Read(toollock.File)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &toollock.Lock{Tools: []*toollock.Tool{
+		{Kind: toollock.KindGo, Name: "example.com/cmd/tool", Version: "v1.0.0", Checksum: "abc"},
+		{Kind: toollock.KindPip, Name: "nox", Version: "2025.11.12"},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if err := Run(t.Context(), "librarian", "install", "--check"); err != nil {
+		t.Errorf("install --check after install: %v", err)
+	}
+
+	cfg.Tools.Pip[0].Version = "2026.1.1"
+	if err := yaml.Write(config.LibrarianYAML, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := Run(t.Context(), "librarian", "install", "--check"); !errors.Is(err, errToolDrift) {
+		t.Errorf("install --check after changing librarian.yaml: got %v, want %v", err, errToolDrift)
+	}
+}
+
+func TestCheckInstall(t *testing.T) {
+	env := &toolenv.Env{Dir: t.TempDir()}
+	tools := &config.Tools{
+		Cargo: []*config.CargoTool{{Name: "taplo-cli", Version: "0.10.0"}},
+	}
+	for _, test := range []struct {
+		name    string
+		lock    *toollock.Lock
+		want    string
+		wantErr error
+	}{
+		{
+			name: "not installed",
+			lock: &toollock.Lock{Tools: []*toollock.Tool{
+				{Kind: toollock.KindCargo, Name: "taplo-cli", Version: "0.10.0"},
+			}},
+			want:    "cargo tool taplo-cli is in librarian-lock.yaml but not installed\n",
+			wantErr: errToolDrift,
+		},
+		{
+			name:    "not locked",
+			lock:    &toollock.Lock{},
+			want:    "cargo tool taplo-cli is in librarian.yaml but not in librarian-lock.yaml\n",
+			wantErr: errToolDrift,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := checkInstall(t.Context(), &out, config.LanguageFake, env, tools, test.lock)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("checkInstall() error = %v, want %v", err, test.wantErr)
+			}
+			if diff := cmp.Diff(test.want, out.String()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckInstall_NoDrift(t *testing.T) {
+	env := &toolenv.Env{Dir: t.TempDir()}
+	tools := &config.Tools{
+		Cargo: []*config.CargoTool{{Name: "taplo-cli", Version: "0.10.0"}},
+	}
+	lock := &toollock.Lock{}
+	if err := fakeInstall(env, tools, lock); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := checkInstall(t.Context(), &out, config.LanguageFake, env, tools, lock); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("tools match librarian-lock.yaml\n", out.String()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInstall_UnsupportedLanguage(t *testing.T) {
+	env := &toolenv.Env{Dir: t.TempDir()}
+	if err := install(t.Context(), "cobol", env, nil, &toollock.Lock{}); err == nil {
+		t.Error("install() error = nil, want error")
+	}
+	if err := checkInstall(t.Context(), io.Discard, "cobol", env, nil, &toollock.Lock{}); err == nil {
+		t.Error("checkInstall() error = nil, want error")
+	}
+}
+
+func TestInstallCommand_ToolEnvPerWorkspace(t *testing.T) {
+	t.Setenv(cache.EnvLibrarianBin, t.TempDir())
+	cfg := &config.Config{
+		Language: config.LanguageFake,
+		Tools: &config.Tools{
+			Go: []*config.GoTool{{Name: "example.com/cmd/tool", Version: "v1.0.0"}},
+		},
+	}
+	var dirs []string
+	for range 2 {
+		t.Chdir(t.TempDir())
+		if err := yaml.Write(config.LibrarianYAML, cfg); err != nil {
+			t.Fatal(err)
+		}
+		if err := Run(t.Context(), "librarian", "install"); err != nil {
+			t.Fatal(err)
+		}
+		env, err := newToolEnv(cfg.Tools)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(filepath.Join(env.Dir, fakeInstalledFile)); err != nil {
+			t.Errorf("tools not installed into the tool environment: %v", err)
+		}
+		dirs = append(dirs, env.Dir)
+	}
+	if dirs[0] == dirs[1] {
+		t.Errorf("workspaces share tool environment %q", dirs[0])
+	}
+}
+
+func TestInstallCommand_Prune(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(cache.EnvLibrarianBin, t.TempDir())
+	var dirs []string
+	for _, version := range []string{"v1.0.0", "v1.1.0"} {
+		cfg := &config.Config{
+			Language: config.LanguageFake,
+			Tools: &config.Tools{
+				Go: []*config.GoTool{{Name: "example.com/cmd/tool", Version: version}},
+			},
+		}
+		if err := yaml.Write(config.LibrarianYAML, cfg); err != nil {
+			t.Fatal(err)
+		}
+		if err := Run(t.Context(), "librarian", "install", "--prune"); err != nil {
+			t.Fatal(err)
+		}
+		env, err := newToolEnv(cfg.Tools)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dirs = append(dirs, env.Dir)
+	}
+	if _, err := os.Stat(dirs[0]); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("tool environment of earlier tools %s was not pruned: %v", dirs[0], err)
+	}
+	if _, err := os.Stat(filepath.Join(dirs[1], fakeInstalledFile)); err != nil {
+		t.Errorf("current tool environment was pruned: %v", err)
+	}
+}
+
+func TestInstallCommand_PrintPath(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(cache.EnvLibrarianBin, t.TempDir())
+	if err := Run(t.Context(), "librarian", "install", "fake", "--print-path"); err != nil {
+		t.Fatal(err)
+	}
+	env, err := newToolEnv(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(env.Dir); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("--print-path installed tools into %s", env.Dir)
+	}
+}
+
+func TestPrintPath(t *testing.T) {
+	env := &toolenv.Env{Dir: "/env"}
+	var out bytes.Buffer
+	if err := printPath(&out, env); err != nil {
+		t.Fatal(err)
+	}
+	want := "/env/go/bin\n/env/cargo/bin\n/env/venv/bin\n/env/pnpm/bin\n/env/maven/bin\n"
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLanguageTools(t *testing.T) {
+	nodejsTools, err := nodejs.Tools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pythonTools, err := python.Tools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	configured := &config.Tools{
+		PNPM: []*config.PNPMTool{{Name: "gapic-tools", Version: "1.0.0"}},
+		Pip:  []*config.PipTool{{Name: "nox", Version: "2025.1.1"}},
+	}
+	for _, test := range []struct {
+		name  string
+		lang  string
+		tools *config.Tools
+		want  *config.Tools
+	}{
+		{"nodejs default", config.LanguageNodejs, nil, nodejsTools},
+		{"nodejs configured", config.LanguageNodejs, configured, configured},
+		{"python default", config.LanguagePython, &config.Tools{}, pythonTools},
+		{"python configured", config.LanguagePython, configured, configured},
+		{"go", config.LanguageGo, nil, nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := languageTools(test.lang, test.tools)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithToolEnv(t *testing.T) {
+	t.Setenv(cache.EnvLibrarianBin, t.TempDir())
+	t.Chdir(t.TempDir())
+	cfg := &config.Config{
+		Language: config.LanguageFake,
+		Tools: &config.Tools{
+			Go: []*config.GoTool{{Name: "example.com/cmd/librarian-test-tool", Version: "v1.0.0"}},
+		},
+	}
+	env, err := newToolEnv(cfg.Tools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(env.GoBin(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(env.GoBin(), "librarian-test-tool"), []byte("#!/bin/sh\necho isolated\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := withToolEnv(t.Context(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := command.Output(ctx, "librarian-test-tool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("isolated\n", got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestGenerate(t *testing.T) {
 	const (
 		libraryName = "test-library"
@@ -35,11 +35,7 @@ func Format(ctx context.Context, library *config.Library) error {
 		return nil
 	}
 	args := append([]string{"--replace"}, files...)
-	env, err := getToolsEnv()
-	if err != nil {
-		return err
-	}
-	if err := command.RunWithEnv(ctx, env, "google-java-format", args...); err != nil {
+	if err := command.Run(ctx, "google-java-format", args...); err != nil {
 		return fmt.Errorf("failed to format files: %w", err)
 	}
 	return nil
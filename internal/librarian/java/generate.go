@@ -48,11 +48,7 @@ var (
 		"google/rpc":   true,
 	}
 	runProtoc = func(ctx context.Context, args []string) error {
-		env, err := getToolsEnv()
-		if err != nil {
-			return err
-		}
-		return command.RunWithEnv(ctx, env, "protoc", args...)
+		return command.Run(ctx, "protoc", args...)
 	}
 )
 
@@ -23,59 +23,52 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/googleapis/librarian/internal/cache"
 	"github.com/googleapis/librarian/internal/command"
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/filesystem"
 	"github.com/googleapis/librarian/internal/pip"
-)
-
-const (
-	envPath  = "PATH"
-	toolsDir = "java_tools"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
 )
 
 // errNoToolsSpecified indicates no Java tools were provided in the configuration.
 var errNoToolsSpecified = errors.New("no tools specified in configuration")
 
-// Install installs Java tool dependencies.
-// It creates two sibling directories:
-// - bin/ ($HOME/java_tools/bin) stores the generated executable wrapper scripts.
-// - lib/ ($HOME/java_tools/lib) isolates the downloaded compiled .jar/.exe files.
-func Install(ctx context.Context, tools *config.Tools) error {
+// Install installs Java tool dependencies into env.
+// Maven tools are installed into two sibling directories:
+// - bin/ ([toolenv.Env.MavenBin]) stores the generated executable wrapper scripts.
+// - lib/ ([toolenv.Env.MavenLib]) isolates the downloaded compiled .jar/.exe files.
+//
+// Pip tools are installed into the virtualenv of env. The checksum of each
+// downloaded artifact is verified and recorded in lock.
+func Install(ctx context.Context, env *toolenv.Env, tools *config.Tools, lock *toollock.Lock) error {
 	if tools == nil || (len(tools.Maven) == 0 && len(tools.Pip) == 0) {
 		return errNoToolsSpecified
 	}
-	for _, cmd := range []string{"java", "mvn", "pip"} {
+	for _, cmd := range []string{"java", "mvn", "python3"} {
 		if _, err := exec.LookPath(cmd); err != nil {
 			return fmt.Errorf("%s is not installed or not in PATH, which is required for Java tool installation: %w", cmd, err)
 		}
 	}
 	if len(tools.Pip) > 0 {
-		if err := pip.Install(ctx, tools.Pip); err != nil {
+		if err := pip.Install(ctx, env.Venv(), tools.Pip, lock); err != nil {
 			return fmt.Errorf("failed to install pip tools: %w", err)
 		}
 	}
-	binDir, err := getBinDir()
-	if err != nil {
-		return err
-	}
+	binDir := env.MavenBin()
 	if err := os.MkdirAll(binDir, 0755); err != nil {
 		return fmt.Errorf("failed to create bin directory %q: %w", binDir, err)
 	}
-	libDir, err := getLibDir()
-	if err != nil {
-		return err
-	}
+	libDir := env.MavenLib()
 	if err := os.MkdirAll(libDir, 0755); err != nil {
 		return fmt.Errorf("failed to create lib directory %q: %w", libDir, err)
 	}
 	for _, mvnTool := range tools.Maven {
 		var err error
 		if mvnTool.LocalPath != "" {
-			err = installLocalMavenTool(ctx, mvnTool, binDir, libDir)
+			err = installLocalMavenTool(ctx, mvnTool, binDir, libDir, lock)
 		} else {
-			err = installExternalMavenTool(ctx, mvnTool, binDir, libDir)
+			err = installExternalMavenTool(ctx, mvnTool, binDir, libDir, lock)
 		}
 		if err != nil {
 			return fmt.Errorf("failed to install maven tool %s: %w", mvnTool.Name, err)
@@ -86,8 +79,9 @@ func Install(ctx context.Context, tools *config.Tools) error {
 
 // installExternalMavenTool downloads a Maven-based external tool, copies its compiled artifact
 // (.jar or .exe) to the sibling lib folder, and creates an executable wrapper script
-// in the bin folder pointing directly to that library file.
-func installExternalMavenTool(ctx context.Context, mvnTool *config.MavenTool, binDir, libDir string) error {
+// in the bin folder pointing directly to that library file. The checksum of the artifact
+// is verified in lock before it is copied.
+func installExternalMavenTool(ctx context.Context, mvnTool *config.MavenTool, binDir, libDir string, lock *toollock.Lock) error {
 	artifact, ext := getM2ArtifactSpec(mvnTool)
 	if err := downloadM2Artifact(ctx, artifact, binDir); err != nil {
 		return err
@@ -99,6 +93,9 @@ func installExternalMavenTool(ctx context.Context, mvnTool *config.MavenTool, bi
 	if _, err := os.Stat(artifactPath); err != nil {
 		return fmt.Errorf("downloaded artifact not found at %s: %w", artifactPath, err)
 	}
+	if err := lock.Verify(toollock.KindMaven, mvnTool.Name, mvnTool.Version, mvnTool.Checksum, artifactPath); err != nil {
+		return err
+	}
 	isExe := ext == "exe"
 	destPath, err := copyArtifactToLib(artifactPath, libDir, isExe)
 	if err != nil {
@@ -109,8 +106,8 @@ func installExternalMavenTool(ctx context.Context, mvnTool *config.MavenTool, bi
 
 // installLocalMavenTool compiles a local Maven project, parses its pom.xml metadata coordinates,
 // copies the built target artifact (.jar or .exe) to the sibling lib folder, and creates an executable
-// wrapper script in the bin folder.
-func installLocalMavenTool(ctx context.Context, mvnTool *config.MavenTool, binDir, libDir string) error {
+// wrapper script in the bin folder. The tool is recorded in lock without a checksum.
+func installLocalMavenTool(ctx context.Context, mvnTool *config.MavenTool, binDir, libDir string, lock *toollock.Lock) error {
 	absLocalPath, err := filepath.Abs(mvnTool.LocalPath)
 	if err != nil {
 		return fmt.Errorf("failed to resolve absolute local path for %s: %w", mvnTool.LocalPath, err)
@@ -137,9 +134,65 @@ func installLocalMavenTool(ctx context.Context, mvnTool *config.MavenTool, binDi
 	if err != nil {
 		return err
 	}
+	lock.Record(toollock.KindMaven, mvnTool.Name, proj.Version, "")
 	return createBinWrapper(mvnTool.Name, destPath, binDir, isExe, mvnTool.MainClass)
 }
 
+// InstalledTools returns the Maven tools of tools that have a wrapper script
+// in the bin directory of env, with the version of the artifact it executes,
+// and the pip packages installed in env if tools has pip tools.
+func InstalledTools(ctx context.Context, env *toolenv.Env, tools *config.Tools) ([]*toollock.Tool, error) {
+	if tools == nil {
+		return nil, nil
+	}
+	binDir := env.MavenBin()
+	var installed []*toollock.Tool
+	for _, mvnTool := range tools.Maven {
+		content, err := os.ReadFile(filepath.Join(binDir, mvnTool.Name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		installed = append(installed, &toollock.Tool{
+			Kind:    toollock.KindMaven,
+			Name:    mvnTool.Name,
+			Version: wrapperVersion(string(content), mvnTool),
+		})
+	}
+	if len(tools.Pip) > 0 {
+		pipTools, err := pip.InstalledTools(ctx, env.Venv())
+		if err != nil {
+			return nil, err
+		}
+		installed = append(installed, pipTools...)
+	}
+	return installed, nil
+}
+
+// wrapperVersion returns the version of the artifact executed by a wrapper
+// script created by createBinWrapper, or an empty string if it is unknown.
+// Artifacts are named <artifact_id>-<version>[-<classifier>].<ext>.
+func wrapperVersion(content string, mvnTool *config.MavenTool) string {
+	if mvnTool.ArtifactID == "" {
+		return ""
+	}
+	prefix := mvnTool.ArtifactID + "-"
+	for field := range strings.FieldsSeq(content) {
+		name := filepath.Base(strings.Trim(field, `"`))
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		version := strings.TrimSuffix(strings.TrimPrefix(name, prefix), filepath.Ext(name))
+		if mvnTool.Classifier != "" {
+			version = strings.TrimSuffix(version, "-"+mvnTool.Classifier)
+		}
+		return version
+	}
+	return ""
+}
+
 // getM2ArtifactSpec constructs the Maven coordinate string and returns it along with the file extension.
 func getM2ArtifactSpec(mvnTool *config.MavenTool) (string, string) {
 	ext := mvnTool.Packaging
@@ -232,40 +285,3 @@ func buildLocalMavenProject(ctx context.Context, localPath string) error {
 	}
 	return nil
 }
-
-// getInstallDir returns the absolute path of the installation directory for Java tools.
-func getInstallDir() (string, error) {
-	dir, err := cache.BinDirectory()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Abs(filepath.Join(dir, toolsDir))
-}
-
-// getBinDir returns the absolute path of the directory where Java tool wrapper scripts are stored.
-func getBinDir() (string, error) {
-	installDir, err := getInstallDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Abs(filepath.Join(installDir, "bin"))
-}
-
-// getLibDir returns the absolute path of the directory where Java tool library files (such as .jar
-// or .exe files) are stored.
-func getLibDir() (string, error) {
-	installDir, err := getInstallDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Abs(filepath.Join(installDir, "lib"))
-}
-
-// getToolsEnv returns an environment map with the Java tools bin directory prepended to the PATH.
-func getToolsEnv() (map[string]string, error) {
-	binDir, err := getBinDir()
-	if err != nil {
-		return nil, err
-	}
-	return map[string]string{envPath: binDir}, nil
-}
@@ -15,14 +15,19 @@
 package java
 
 import (
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
 )
 
 func TestInstall(t *testing.T) {
@@ -72,12 +77,18 @@ func TestInstall(t *testing.T) {
 	stubs := []struct {
 		name        string
 		logFilename string
+		script      string
 		wantArgs    string
 	}{
 		{
 			name:        "pip",
 			logFilename: "pip_invocations.log",
-			wantArgs:    "pip install PyYAML==6.0.2 jinja2==3.1.6 " + localPkgDir,
+			// pip download writes the distribution of the target, such as
+			// PyYAML==6.0.2, to PyYAML-6.0.2.tar.gz in the --dest directory.
+			script: `if [ "$1" = download ]; then echo "$5" > "$4/$(echo "$5" | sed 's/==/-/').tar.gz"; fi` + "\n",
+			wantArgs: "pip download --no-deps --dest */PyYAML PyYAML==6.0.2\n" +
+				"pip download --no-deps --dest */jinja2 jinja2==3.1.6\n" +
+				"pip install */PyYAML/PyYAML-6.0.2.tar.gz */jinja2/jinja2-3.1.6.tar.gz " + localPkgDir,
 		},
 		{
 			name:        "mvn",
@@ -94,7 +105,7 @@ func TestInstall(t *testing.T) {
 	}
 	for _, s := range stubs {
 		logPath := filepath.Join(tmpDir, s.logFilename)
-		content := fmt.Sprintf("#!/bin/sh\necho %q \"$@\" >> %q\n", s.name, logPath)
+		content := fmt.Sprintf("#!/bin/sh\necho %q \"$@\" >> %q\n", s.name, logPath) + s.script
 		if err := os.WriteFile(filepath.Join(stubDir, s.name), []byte(content), 0755); err != nil {
 			t.Fatal(err)
 		}
@@ -102,7 +113,12 @@ func TestInstall(t *testing.T) {
 	if err := os.WriteFile(filepath.Join(stubDir, "java"), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
 		t.Fatal(err)
 	}
-	t.Setenv("PATH", stubDir)
+	// python3 -m venv <dir> creates a virtualenv with the pip stub.
+	python3 := fmt.Sprintf("#!/bin/sh\nmkdir -p \"$3/bin\" && cp %q \"$3/bin/pip\"\n", filepath.Join(stubDir, "pip"))
+	if err := os.WriteFile(filepath.Join(stubDir, "python3"), []byte(python3), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", stubDir+":/bin:/usr/bin")
 	tools := &config.Tools{
 		Maven: []*config.MavenTool{
 			{
@@ -143,9 +159,10 @@ func TestInstall(t *testing.T) {
 			},
 		},
 	}
-	installDir := filepath.Join(tmpDir, "java_tools", "bin")
-	t.Setenv("LIBRARIAN_BIN", tmpDir)
-	if err := Install(t.Context(), tools); err != nil {
+	env := &toolenv.Env{Dir: filepath.Join(tmpDir, "env")}
+	installDir := env.MavenBin()
+	lock := &toollock.Lock{}
+	if err := Install(t.Context(), env, tools, lock); err != nil {
 		t.Fatal(err)
 	}
 	for _, s := range stubs {
@@ -155,11 +172,13 @@ func TestInstall(t *testing.T) {
 			t.Fatal(err)
 		}
 		got := strings.TrimSpace(string(data))
+		// Replace the temporary download directories of pip.
+		got = regexp.MustCompile(`\S*librarian-pip-[^/\s]+`).ReplaceAllString(got, "*")
 		if diff := cmp.Diff(s.wantArgs, got); diff != "" {
 			t.Errorf("mismatch (-want +got):\n%s", diff)
 		}
 	}
-	libDir := filepath.Join(tmpDir, "java_tools", "lib")
+	libDir := env.MavenLib()
 	for _, test := range []struct {
 		name        string
 		filename    string
@@ -209,103 +228,119 @@ func TestInstall(t *testing.T) {
 			}
 		})
 	}
+	wantLock := &toollock.Lock{
+		Tools: []*toollock.Tool{
+			{Kind: toollock.KindPip, Name: "PyYAML", Version: "6.0.2", Files: map[string]string{"PyYAML-6.0.2.tar.gz": sha256Hex("PyYAML==6.0.2\n")}},
+			{Kind: toollock.KindPip, Name: "jinja2", Version: "3.1.6", Files: map[string]string{"jinja2-3.1.6.tar.gz": sha256Hex("jinja2==3.1.6\n")}},
+			{Kind: toollock.KindPip, Name: "synthtool"},
+			{Kind: toollock.KindMaven, Name: "google-java-format", Version: "1.25.2", Checksum: sha256Hex("gjf jar content")},
+			{Kind: toollock.KindMaven, Name: "protoc-gen-java_grpc", Version: "1.81.0", Checksum: sha256Hex("grpc exe content")},
+			{Kind: toollock.KindMaven, Name: "protoc-gen-java_gapic", Version: "2.28.0-SNAPSHOT"},
+		},
+	}
+	if diff := cmp.Diff(wantLock, lock); diff != "" {
+		t.Errorf("lock mismatch (-want +got):\n%s", diff)
+	}
+
+	installed, err := InstalledTools(t.Context(), env, &config.Tools{Maven: tools.Maven})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantInstalled := []*toollock.Tool{
+		{Kind: toollock.KindMaven, Name: "google-java-format", Version: "1.25.2"},
+		{Kind: toollock.KindMaven, Name: "protoc-gen-java_grpc", Version: "1.81.0"},
+		{Kind: toollock.KindMaven, Name: "protoc-gen-java_gapic"},
+	}
+	if diff := cmp.Diff(wantInstalled, installed); diff != "" {
+		t.Errorf("installed mismatch (-want +got):\n%s", diff)
+	}
 }
 
-func TestGetBinDir(t *testing.T) {
-	tmpDir := t.TempDir()
+func TestInstall_ChecksumMismatch(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+	jarDir := filepath.Join(tempHome, ".m2", "repository", "com", "example", "tool", "1.0.0")
+	if err := os.MkdirAll(jarDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(jarDir, "tool-1.0.0.jar"), []byte("jar content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stubDir := t.TempDir()
+	for _, name := range []string{"java", "mvn", "python3"} {
+		if err := os.WriteFile(filepath.Join(stubDir, name), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", stubDir)
+	tool := &config.MavenTool{Name: "tool", GroupID: "com.example", ArtifactID: "tool", Version: "1.0.0"}
 	for _, test := range []struct {
-		name           string
-		librarianBin   string
-		librarianCache string
-		want           string
+		name     string
+		checksum string
+		lock     *toollock.Lock
 	}{
 		{
-			name:         "LIBRARIAN_BIN is set",
-			librarianBin: tmpDir,
-			want:         filepath.Join(tmpDir, "java_tools", "bin"),
+			name:     "librarian.yaml",
+			checksum: sha256Hex("other content"),
+			lock:     &toollock.Lock{},
 		},
 		{
-			name:           "LIBRARIAN_CACHE is set",
-			librarianCache: tmpDir,
-			want:           filepath.Join(tmpDir, "bin", "java_tools", "bin"),
+			name: "lockfile",
+			lock: &toollock.Lock{Tools: []*toollock.Tool{
+				{Kind: toollock.KindMaven, Name: "tool", Version: "1.0.0", Checksum: sha256Hex("other content")},
+			}},
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
-			t.Setenv("LIBRARIAN_BIN", test.librarianBin)
-			t.Setenv("LIBRARIAN_CACHE", test.librarianCache)
-			got, err := getBinDir()
-			if err != nil {
-				t.Fatal(err)
-			}
-			if diff := cmp.Diff(test.want, got); diff != "" {
-				t.Errorf("mismatch (-want +got):\n%s", diff)
+			tool.Checksum = test.checksum
+			err := Install(t.Context(), &toolenv.Env{Dir: t.TempDir()}, &config.Tools{Maven: []*config.MavenTool{tool}}, test.lock)
+			if !errors.Is(err, toollock.ErrChecksumMismatch) {
+				t.Errorf("Install() error = %v, want %v", err, toollock.ErrChecksumMismatch)
 			}
 		})
 	}
 }
 
-func TestGetLibDir(t *testing.T) {
-	tmpDir := t.TempDir()
+func TestWrapperVersion(t *testing.T) {
 	for _, test := range []struct {
-		name           string
-		librarianBin   string
-		librarianCache string
-		want           string
+		name    string
+		content string
+		tool    *config.MavenTool
+		want    string
 	}{
 		{
-			name:         "LIBRARIAN_BIN is set",
-			librarianBin: tmpDir,
-			want:         filepath.Join(tmpDir, "java_tools", "lib"),
+			name:    "jar with classifier",
+			content: "#!/bin/sh\nexec java -jar \"/lib/google-java-format-1.25.2-all-deps.jar\" \"$@\"\n",
+			tool:    &config.MavenTool{ArtifactID: "google-java-format", Classifier: "all-deps"},
+			want:    "1.25.2",
 		},
 		{
-			name:           "LIBRARIAN_CACHE is set",
-			librarianCache: tmpDir,
-			want:           filepath.Join(tmpDir, "bin", "java_tools", "lib"),
+			name:    "exe",
+			content: "#!/bin/sh\nexec \"/lib/protoc-gen-grpc-java-1.81.0-linux-x86_64.exe\" \"$@\"\n",
+			tool:    &config.MavenTool{ArtifactID: "protoc-gen-grpc-java", Classifier: "linux-x86_64"},
+			want:    "1.81.0",
 		},
-	} {
-		t.Run(test.name, func(t *testing.T) {
-			t.Setenv("LIBRARIAN_BIN", test.librarianBin)
-			t.Setenv("LIBRARIAN_CACHE", test.librarianCache)
-			got, err := getLibDir()
-			if err != nil {
-				t.Fatal(err)
-			}
-			if diff := cmp.Diff(test.want, got); diff != "" {
-				t.Errorf("mismatch (-want +got):\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestGetToolsEnv(t *testing.T) {
-	tmpDir := t.TempDir()
-	for _, test := range []struct {
-		name           string
-		librarianBin   string
-		librarianCache string
-		want           map[string]string
-	}{
 		{
-			name:         "LIBRARIAN_BIN is set",
-			librarianBin: tmpDir,
-			want:         map[string]string{"PATH": filepath.Join(tmpDir, "java_tools", "bin")},
+			name:    "main class",
+			content: "#!/bin/sh\nexec java -cp \"/lib/tool-2.0.0.jar\" \"com.example.Main\" \"$@\"\n",
+			tool:    &config.MavenTool{ArtifactID: "tool"},
+			want:    "2.0.0",
 		},
 		{
-			name:           "LIBRARIAN_CACHE is set",
-			librarianCache: tmpDir,
-			want:           map[string]string{"PATH": filepath.Join(tmpDir, "bin", "java_tools", "bin")},
+			name:    "local tool without artifact ID",
+			content: "#!/bin/sh\nexec java -jar \"/lib/tool-2.0.0.jar\" \"$@\"\n",
+			tool:    &config.MavenTool{},
+			want:    "",
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
-			t.Setenv("LIBRARIAN_BIN", test.librarianBin)
-			t.Setenv("LIBRARIAN_CACHE", test.librarianCache)
-			got, err := getToolsEnv()
-			if err != nil {
-				t.Fatal(err)
-			}
-			if diff := cmp.Diff(test.want, got); diff != "" {
-				t.Errorf("mismatch (-want +got):\n%s", diff)
+			if got := wrapperVersion(test.content, test.tool); got != test.want {
+				t.Errorf("wrapperVersion() = %q, want %q", got, test.want)
 			}
 		})
 	}
 }
+
+func sha256Hex(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+}
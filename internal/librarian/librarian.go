@@ -24,13 +24,6 @@ import (
 	"os"
 
 	"github.com/googleapis/librarian/internal/command"
-	"github.com/googleapis/librarian/internal/config"
-	"github.com/googleapis/librarian/internal/librarian/golang"
-	"github.com/googleapis/librarian/internal/librarian/java"
-	"github.com/googleapis/librarian/internal/librarian/nodejs"
-	"github.com/googleapis/librarian/internal/librarian/python"
-	"github.com/googleapis/librarian/internal/librarian/rust"
-	"github.com/googleapis/librarian/internal/yaml"
 	"github.com/urfave/cli/v3"
 )
 
@@ -61,8 +54,13 @@ func Run(ctx context.Context, args ...string) error {
 			generateCommand(),
 			bumpCommand(),
 			installCommand(),
+			doctorCommand(),
 			tidyCommand(),
 			updateCommand(),
+			modelCommand(),
+			diffCommand(),
+			lintCommand(),
+			docsCommand(),
 			publishCommand(),
 			tagCommand(),
 			versionCommand(),
@@ -71,56 +69,6 @@ func Run(ctx context.Context, args ...string) error {
 	return cmd.Run(ctx, args)
 }
 
-func installCommand() *cli.Command {
-	return &cli.Command{
-		Name:      "install",
-		Usage:     "install tool dependencies for a language",
-		UsageText: "librarian install [language]",
-		Description: `install installs the language-specific tools that librarian uses to
-generate and build client libraries (for example, language SDKs and code
-generators).
-
-If [language] is omitted, the language is read from librarian.yaml in the
-current directory.
-
-Examples:
-
-	librarian install              # use language from librarian.yaml
-	librarian install go           # install Go-specific tools`,
-		Action: func(ctx context.Context, cmd *cli.Command) error {
-			lang := cmd.Args().First()
-			cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
-			if err != nil && lang == "" {
-				return err
-			}
-			if lang == "" {
-				lang = cfg.Language
-			}
-			var tools *config.Tools
-			if cfg != nil {
-				tools = cfg.Tools
-			}
-
-			switch lang {
-			case config.LanguageFake:
-				return nil
-			case config.LanguageGo:
-				return golang.Install(ctx, tools)
-			case config.LanguageJava:
-				return java.Install(ctx, tools)
-			case config.LanguageNodejs:
-				return nodejs.Install(ctx)
-			case config.LanguagePython:
-				return python.Install(ctx)
-			case config.LanguageRust:
-				return rust.Install(ctx, tools)
-			default:
-				return fmt.Errorf("language %q does not support install", lang)
-			}
-		},
-	}
-}
-
 // versionCommand prints the version information.
 func versionCommand() *cli.Command {
 	return &cli.Command{
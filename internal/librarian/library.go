@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
 
 	"github.com/googleapis/librarian/internal/config"
@@ -44,6 +45,12 @@ func fillDefaults(lib *config.Library, d *config.Default) *config.Library {
 	if lib.Output == "" {
 		lib.Output = d.Output
 	}
+	if lib.TemplateOverlay == "" {
+		lib.TemplateOverlay = d.TemplateOverlay
+	}
+	if d.PostGenerate != nil {
+		lib.PostGenerate = slices.Concat(d.PostGenerate, lib.PostGenerate)
+	}
 	if d.Go != nil {
 		return fillGo(lib, d)
 	}
@@ -124,6 +131,9 @@ func fillRust(lib *config.Library, d *config.Default) *config.Library {
 	if lib.Rust.GenerateRpcSamples == "" {
 		lib.Rust.GenerateRpcSamples = d.Rust.GenerateRpcSamples
 	}
+	if lib.Rust.Codec == nil {
+		lib.Rust.Codec = d.Rust.Codec
+	}
 	for _, mod := range lib.Rust.Modules {
 		if mod.GenerateSetterSamples == "" {
 			mod.GenerateSetterSamples = lib.Rust.GenerateSetterSamples
@@ -148,6 +158,9 @@ func fillDart(lib *config.Library, d *config.Default) *config.Library {
 	if lib.Dart.IssueTrackerURL == "" {
 		lib.Dart.IssueTrackerURL = d.Dart.IssueTrackerURL
 	}
+	if lib.Dart.Codec == nil {
+		lib.Dart.Codec = d.Dart.Codec
+	}
 	lib.Dart.Packages = mergeMaps(lib.Dart.Packages, d.Dart.Packages)
 	lib.Dart.Prefixes = mergeMaps(lib.Dart.Prefixes, d.Dart.Prefixes)
 	lib.Dart.Protos = mergeMaps(lib.Dart.Protos, d.Dart.Protos)
@@ -173,6 +186,9 @@ func fillSwift(lib *config.Library, d *config.Default) *config.Library {
 	if lib.Swift == nil {
 		lib.Swift = &config.SwiftPackage{}
 	}
+	if lib.Swift.Codec == nil {
+		lib.Swift.Codec = d.Swift.Codec
+	}
 	lib.Swift.Dependencies = mergeSwiftDependencies(
 		d.Swift.Dependencies,
 		lib.Swift.Dependencies,
@@ -393,6 +409,12 @@ func ResolvePreview(lib *config.Library, language string) *config.Library {
 	if p.SpecificationFormat != "" {
 		res.SpecificationFormat = p.SpecificationFormat
 	}
+	if p.TemplateOverlay != "" {
+		res.TemplateOverlay = p.TemplateOverlay
+	}
+	if p.PostGenerate != nil {
+		res.PostGenerate = p.PostGenerate
+	}
 	switch language {
 	case config.LanguageDotnet:
 		res.Dotnet = mergeDotnet(res.Dotnet, p.Dotnet)
@@ -68,6 +68,31 @@ func TestFillDefaults(t *testing.T) {
 			lib:      &config.Library{Output: "foo/"},
 			want:     &config.Library{Output: "foo/"},
 		},
+		{
+			name:     "template overlay",
+			defaults: &config.Default{TemplateOverlay: "overlays/default"},
+			lib:      &config.Library{},
+			want:     &config.Library{TemplateOverlay: "overlays/default"},
+		},
+		{
+			name:     "library template overlay",
+			defaults: &config.Default{TemplateOverlay: "overlays/default"},
+			lib:      &config.Library{TemplateOverlay: "overlays/library"},
+			want:     &config.Library{TemplateOverlay: "overlays/library"},
+		},
+		{
+			name: "post generate",
+			defaults: &config.Default{PostGenerate: []*config.PostGenerateStep{
+				{Path: "README.md", Replace: "a", With: "b"},
+			}},
+			lib: &config.Library{PostGenerate: []*config.PostGenerateStep{
+				{Path: "client.go", Remove: true},
+			}},
+			want: &config.Library{PostGenerate: []*config.PostGenerateStep{
+				{Path: "README.md", Replace: "a", With: "b"},
+				{Path: "client.go", Remove: true},
+			}},
+		},
 		{
 			name: "dart defaults",
 			defaults: &config.Default{
@@ -397,6 +422,40 @@ func TestFillDefaults_Rust(t *testing.T) {
 	}
 }
 
+func TestFillDefaults_RustCodec(t *testing.T) {
+	defaults := &config.Default{
+		Rust: &config.RustDefault{
+			Codec: &config.RustCodec{GenerateSetterSamples: true},
+		},
+	}
+	libCodec := &config.RustCodec{CopyrightYear: "2025"}
+	for _, test := range []struct {
+		name string
+		lib  *config.Library
+		want *config.RustCodec
+	}{
+		{
+			name: "fills default codec",
+			lib:  &config.Library{},
+			want: defaults.Rust.Codec,
+		},
+		{
+			name: "library codec replaces default",
+			lib: &config.Library{
+				Rust: &config.RustCrate{RustDefault: config.RustDefault{Codec: libCodec}},
+			},
+			want: libCodec,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := fillDefaults(test.lib, defaults)
+			if diff := cmp.Diff(test.want, got.Rust.Codec); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestFillDefaults_Python(t *testing.T) {
 	for _, test := range []struct {
 		name     string
@@ -0,0 +1,149 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/lint"
+	"github.com/googleapis/librarian/internal/yaml"
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	errLintFindings    = errors.New("lint found errors")
+	errUnknownLintRule = errors.New("unknown lint rule")
+)
+
+func lintCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "lint",
+		Usage:     "check the API model of a library for generator-hostile definitions",
+		UsageText: "librarian lint [<library> | --all]",
+		Description: `lint builds the API model of a library and checks it for definitions that
+the code generators cannot handle well, such as list methods that do not
+follow AIP-4233, long-running operations without operation info, routing
+annotations that reference missing fields, and references to undefined
+resource types.
+
+Each finding has a severity. The command fails if any finding is an error.
+
+Findings can be suppressed per library in librarian.yaml:
+
+	libraries:
+	  - name: google-cloud-secretmanager-v1
+	    lint:
+	      suppress:
+	        - rule: pagination-aip-4233
+	          id: .google.cloud.secretmanager.v1.SecretManagerService.ListSecrets
+	          reason: the method predates AIP-4233
+
+Rules:
+` + lintRulesHelp() + `
+Examples:
+
+	librarian lint google-cloud-secretmanager-v1
+	librarian lint --all`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "lint all libraries",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			libraryName := cmd.Args().First()
+			all := cmd.Bool("all")
+			if (libraryName == "") == !all {
+				return errLibraryOrAll
+			}
+			cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
+			if err != nil {
+				return err
+			}
+			libraries := cfg.Libraries
+			if !all {
+				library, err := FindLibrary(cfg, libraryName)
+				if err != nil {
+					return err
+				}
+				libraries = []*config.Library{library}
+			}
+			srcs, err := LoadSources(ctx, cfg.Sources)
+			if err != nil {
+				return err
+			}
+			return runLint(cmd.Root().Writer, cfg, libraries, func(library *config.Library, apiPath string) (*api.API, error) {
+				return buildModel(cfg.Language, library, apiPath, srcs)
+			})
+		},
+	}
+}
+
+func lintRulesHelp() string {
+	var help string
+	for _, rule := range lint.Rules() {
+		help += fmt.Sprintf("\n  - %s (%s): %s\n", rule.Name, rule.Severity, rule.Description)
+	}
+	return help
+}
+
+// runLint checks the APIs of each library and prints the findings to w. The
+// model for each API is created by build. It returns an error if any finding
+// has error severity.
+func runLint(w io.Writer, cfg *config.Config, libraries []*config.Library, build func(*config.Library, string) (*api.API, error)) error {
+	rules := lint.Rules()
+	hasErrors := false
+	for _, library := range libraries {
+		if library.SkipGenerate {
+			continue
+		}
+		library, err := applyDefaults(cfg.Language, library, cfg.Default)
+		if err != nil {
+			return err
+		}
+		var suppressions []*config.LintSuppression
+		if library.Lint != nil {
+			suppressions = library.Lint.Suppress
+		}
+		for _, s := range suppressions {
+			if !slices.ContainsFunc(rules, func(r *lint.Rule) bool { return r.Name == s.Rule }) {
+				return fmt.Errorf("%w: %q in %q", errUnknownLintRule, s.Rule, library.Name)
+			}
+		}
+		for _, channel := range library.APIs {
+			model, err := build(library, channel.Path)
+			if err != nil {
+				return fmt.Errorf("failed to build model for %q in %q: %w", channel.Path, library.Name, err)
+			}
+			findings := lint.Run(model, rules, suppressions)
+			for _, f := range findings {
+				if _, err := fmt.Fprintf(w, "%s: %s\n", library.Name, f); err != nil {
+					return err
+				}
+			}
+			hasErrors = hasErrors || lint.HasErrors(findings)
+		}
+	}
+	if hasErrors {
+		return errLintFindings
+	}
+	return nil
+}
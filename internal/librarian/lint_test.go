@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func lintTestModel(*config.Library, string) (*api.API, error) {
+	request := api.NewTestMessage("CreateSecretRequest")
+	create := api.NewTestMethod("CreateSecret").WithInput(request)
+	create.OutputTypeID = ".google.longrunning.Operation"
+	list := api.NewTestMethod("ListSecrets").WithInput(api.NewTestMessage("ListSecretsRequest").WithFields(
+		api.NewTestField("page_token").WithType(api.TypezString)))
+	service := api.NewTestService("Service").WithMethods(create, list)
+	return api.NewTestAPI([]*api.Message{request, list.InputType}, nil, []*api.Service{service}), nil
+}
+
+func TestRunLint(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		library *config.Library
+		want    string
+		wantErr error
+	}{
+		{
+			name: "findings",
+			library: &config.Library{
+				Name: "google-cloud-test",
+				APIs: []*config.API{{Path: "google/cloud/test/v1"}},
+			},
+			want: `google-cloud-test: error: .test.Service.CreateSecret [lro-operation-info] the method returns an Operation but has no google.longrunning.operation_info annotation
+google-cloud-test: warning: .test.Service.ListSecrets [pagination-aip-4233] the method has a page_token field but is not paginated, missing a page_size field in the request and a next_page_token string field in the response and a repeated message or map field in the response
+`,
+			wantErr: errLintFindings,
+		},
+		{
+			name: "suppressed errors",
+			library: &config.Library{
+				Name: "google-cloud-test",
+				APIs: []*config.API{{Path: "google/cloud/test/v1"}},
+				Lint: &config.Lint{
+					Suppress: []*config.LintSuppression{
+						{Rule: "lro-operation-info", Reason: "the API is deprecated"},
+					},
+				},
+			},
+			want: `google-cloud-test: warning: .test.Service.ListSecrets [pagination-aip-4233] the method has a page_token field but is not paginated, missing a page_size field in the request and a next_page_token string field in the response and a repeated message or map field in the response
+`,
+		},
+		{
+			name: "skip generate",
+			library: &config.Library{
+				Name:         "google-cloud-test",
+				APIs:         []*config.API{{Path: "google/cloud/test/v1"}},
+				SkipGenerate: true,
+			},
+		},
+		{
+			name: "unknown rule",
+			library: &config.Library{
+				Name: "google-cloud-test",
+				APIs: []*config.API{{Path: "google/cloud/test/v1"}},
+				Lint: &config.Lint{
+					Suppress: []*config.LintSuppression{{Rule: "no-such-rule"}},
+				},
+			},
+			wantErr: errUnknownLintRule,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Language:  config.LanguageFake,
+				Default:   &config.Default{},
+				Libraries: []*config.Library{test.library},
+			}
+			var buf bytes.Buffer
+			err := runLint(&buf, cfg, cfg.Libraries, lintTestModel)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("runLint() error = %v, want %v", err, test.wantErr)
+			}
+			if diff := cmp.Diff(test.want, buf.String()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
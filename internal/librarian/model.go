@@ -0,0 +1,308 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/librarian/dart"
+	"github.com/googleapis/librarian/internal/librarian/rust"
+	"github.com/googleapis/librarian/internal/librarian/swift"
+	"github.com/googleapis/librarian/internal/serviceconfig"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/modeldump"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+	"github.com/googleapis/librarian/internal/sources"
+	"github.com/googleapis/librarian/internal/yaml"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	dumpFormatJSON = "json"
+	dumpFormatYAML = "yaml"
+)
+
+var (
+	errLibraryOrSpecificationSource = errors.New("must specify exactly one of library name or --specification-source")
+	errLibraryHasNoAPIs             = errors.New("library has no apis")
+	errUnknownAPI                   = errors.New("api not found in library")
+	errUnknownDumpFormat            = errors.New("unknown dump format")
+)
+
+// modelDumpOptions holds the options for the model dump command.
+type modelDumpOptions struct {
+	// LibraryName selects a library from librarian.yaml.
+	LibraryName string
+	// APIPath selects one of the library's APIs. Defaults to the first API.
+	APIPath string
+	// SpecificationSource is the path of an ad-hoc specification, used
+	// instead of LibraryName.
+	SpecificationSource string
+	// SpecificationFormat is the format of SpecificationSource.
+	SpecificationFormat string
+	// ServiceConfig is the service config for SpecificationSource.
+	ServiceConfig string
+	// Format is the output format, either json or yaml.
+	Format string
+	// ID restricts the output to a single element.
+	ID string
+}
+
+func modelCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "model",
+		Usage:     "inspect the parsed API model",
+		UsageText: "librarian model [dump]",
+		Commands: []*cli.Command{
+			modelDumpCommand(),
+		},
+	}
+}
+
+func modelDumpCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "dump",
+		Usage:     "print the parsed API model as JSON or YAML",
+		UsageText: "librarian model dump [<library> | --specification-source=<path>]",
+		Description: `dump runs the sidekick parser and prints the resolved API model.
+
+The model is the result of parsing the API specification and applying
+pagination overrides, cross-referencing, resource identification, and skip
+rules, exactly as the code generators see it.
+
+The library argument selects a library from librarian.yaml, and its
+language-specific configuration is used to build the model. Use --api to
+select one of the library's APIs, the first one is used by default.
+
+Alternatively, --specification-source parses an ad-hoc specification, using
+the sources from librarian.yaml if it is present.
+
+Use --id to print a single service, method, message, field, enum or enum
+value.
+
+Examples:
+
+	librarian model dump google-cloud-secretmanager-v1
+	librarian model dump google-cloud-secretmanager-v1 --format=yaml
+	librarian model dump google-cloud-secretmanager-v1 --id=.google.cloud.secretmanager.v1.Secret
+	librarian model dump --specification-source=openapi.json --specification-format=openapi`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "api",
+				Usage: "the API path within the library to dump",
+			},
+			&cli.StringFlag{
+				Name:  "specification-source",
+				Usage: "the path of a specification to dump instead of a library",
+			},
+			&cli.StringFlag{
+				Name:  "specification-format",
+				Usage: "the format of --specification-source: protobuf, openapi or discovery",
+				Value: config.SpecProtobuf,
+			},
+			&cli.StringFlag{
+				Name:  "service-config",
+				Usage: "the service config for --specification-source",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "the output format: json or yaml",
+				Value: dumpFormatJSON,
+			},
+			&cli.StringFlag{
+				Name:  "id",
+				Usage: "only print the element with this ID",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return runModelDump(ctx, cmd.Root().Writer, &modelDumpOptions{
+				LibraryName:         cmd.Args().First(),
+				APIPath:             cmd.String("api"),
+				SpecificationSource: cmd.String("specification-source"),
+				SpecificationFormat: cmd.String("specification-format"),
+				ServiceConfig:       cmd.String("service-config"),
+				Format:              cmd.String("format"),
+				ID:                  cmd.String("id"),
+			})
+		},
+	}
+}
+
+func runModelDump(ctx context.Context, w io.Writer, opts *modelDumpOptions) error {
+	if (opts.LibraryName == "") == (opts.SpecificationSource == "") {
+		return errLibraryOrSpecificationSource
+	}
+	if opts.Format != dumpFormatJSON && opts.Format != dumpFormatYAML {
+		return fmt.Errorf("%w: %q", errUnknownDumpFormat, opts.Format)
+	}
+	var (
+		model *api.API
+		err   error
+	)
+	if opts.LibraryName != "" {
+		model, err = libraryModel(ctx, opts.LibraryName, opts.APIPath)
+	} else {
+		model, err = specificationModel(ctx, opts)
+	}
+	if err != nil {
+		return err
+	}
+	var element any = modeldump.New(model)
+	if opts.ID != "" {
+		element, err = modeldump.New(model).Find(opts.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return writeDump(w, opts.Format, element)
+}
+
+func writeDump(w io.Writer, format string, v any) error {
+	var (
+		data []byte
+		err  error
+	)
+	switch format {
+	case dumpFormatYAML:
+		data, err = yaml.Marshal(v)
+	default:
+		data, err = json.MarshalIndent(v, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// libraryModel reads librarian.yaml and builds the API model for the named
+// library.
+func libraryModel(ctx context.Context, libraryName, apiPath string) (*api.API, error) {
+	cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
+	if err != nil {
+		return nil, err
+	}
+	library, err := FindLibrary(cfg, libraryName)
+	if err != nil {
+		return nil, err
+	}
+	library, err = applyDefaults(cfg.Language, library, cfg.Default)
+	if err != nil {
+		return nil, err
+	}
+	srcs, err := LoadSources(ctx, cfg.Sources)
+	if err != nil {
+		return nil, err
+	}
+	return buildModel(cfg.Language, library, apiPath, srcs)
+}
+
+// buildModel parses the API at apiPath in library. If apiPath is empty, the
+// first API in the library is used.
+func buildModel(language string, library *config.Library, apiPath string, srcs *sources.Sources) (*api.API, error) {
+	if len(library.APIs) == 0 {
+		return nil, fmt.Errorf("%w: %q", errLibraryHasNoAPIs, library.Name)
+	}
+	channel := library.APIs[0]
+	if apiPath != "" {
+		channel = nil
+		for _, a := range library.APIs {
+			if a.Path == apiPath {
+				channel = a
+				break
+			}
+		}
+		if channel == nil {
+			return nil, fmt.Errorf("%w: %q in %q", errUnknownAPI, apiPath, library.Name)
+		}
+	}
+	modelConfig, err := libraryModelConfig(language, library, channel, srcs)
+	if err != nil {
+		return nil, err
+	}
+	return parser.CreateModel(modelConfig)
+}
+
+// libraryModelConfig returns the parser configuration for a library. The
+// languages generated by sidekick use the same configuration as generation,
+// while all other languages get the default protobuf configuration.
+func libraryModelConfig(language string, library *config.Library, channel *config.API, srcs *sources.Sources) (*parser.ModelConfig, error) {
+	switch language {
+	case config.LanguageDart:
+		return dart.ModelConfig(library, channel, srcs)
+	case config.LanguageRust:
+		return rust.ModelConfig(library, channel, srcs)
+	case config.LanguageSwift:
+		return swift.ModelConfig(library, channel, srcs)
+	}
+	svcConfig, err := serviceconfig.Find(srcs.Googleapis, channel.Path, language)
+	if err != nil {
+		return nil, err
+	}
+	specFormat := config.SpecProtobuf
+	if library.SpecificationFormat != "" {
+		specFormat = library.SpecificationFormat
+	}
+	specSource := channel.Path
+	switch specFormat {
+	case config.SpecDiscovery:
+		specSource = svcConfig.Discovery
+	case config.SpecOpenAPI:
+		specSource = svcConfig.OpenAPI
+	}
+	return &parser.ModelConfig{
+		Language:            language,
+		SpecificationFormat: specFormat,
+		SpecificationSource: specSource,
+		Source:              sources.NewSourceConfig(srcs, library.Roots),
+		ServiceConfig:       svcConfig.ServiceConfig,
+		Override: api.ModelOverride{
+			Title:       svcConfig.Title,
+			Description: svcConfig.Description,
+		},
+		Transformations: parser.Transformations(library.Transforms),
+	}, nil
+}
+
+// specificationModel builds the API model for an ad-hoc specification. The
+// sources in librarian.yaml, if any, are used to resolve imports and the
+// service config.
+func specificationModel(ctx context.Context, opts *modelDumpOptions) (*api.API, error) {
+	srcs := &sources.Sources{}
+	cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	case cfg.Sources != nil:
+		srcs, err = LoadSources(ctx, cfg.Sources)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return parser.CreateModel(&parser.ModelConfig{
+		SpecificationFormat: opts.SpecificationFormat,
+		SpecificationSource: opts.SpecificationSource,
+		ServiceConfig:       opts.ServiceConfig,
+		Source:              sources.NewSourceConfig(srcs, nil),
+	})
+}
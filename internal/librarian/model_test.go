@@ -0,0 +1,152 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/modeldump"
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+func TestRunModelDump(t *testing.T) {
+	spec, err := filepath.Abs(filepath.Join("..", "testdata", "secretmanager_openapi_v1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(t.TempDir())
+	var buf bytes.Buffer
+	if err := runModelDump(t.Context(), &buf, &modelDumpOptions{
+		SpecificationSource: spec,
+		SpecificationFormat: config.SpecOpenAPI,
+		Format:              dumpFormatJSON,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var got modeldump.Model
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Services) == 0 {
+		t.Fatalf("expected at least one service in %s", buf.String())
+	}
+	if diff := cmp.Diff("Secret Manager API", got.Title); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunModelDump_ID(t *testing.T) {
+	testdata, err := filepath.Abs(filepath.Join("..", "testdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(t.TempDir())
+	var buf bytes.Buffer
+	if err := runModelDump(t.Context(), &buf, &modelDumpOptions{
+		SpecificationSource: filepath.Join(testdata, "secretmanager_openapi_v1.json"),
+		SpecificationFormat: config.SpecOpenAPI,
+		ServiceConfig:       filepath.Join(testdata, "googleapis", "google", "cloud", "secretmanager", "v1", "secretmanager_v1.yaml"),
+		Format:              dumpFormatYAML,
+		ID:                  ".google.cloud.secretmanager.v1.Secret",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := yaml.Unmarshal[modeldump.Message](buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(".google.cloud.secretmanager.v1.Secret", got.ID); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if len(got.Fields) == 0 {
+		t.Errorf("expected fields in %s", buf.String())
+	}
+}
+
+func TestRunModelDump_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		opts    *modelDumpOptions
+		wantErr error
+	}{
+		{
+			name:    "neither library nor specification",
+			opts:    &modelDumpOptions{Format: dumpFormatJSON},
+			wantErr: errLibraryOrSpecificationSource,
+		},
+		{
+			name: "both library and specification",
+			opts: &modelDumpOptions{
+				LibraryName:         "google-cloud-secretmanager-v1",
+				SpecificationSource: "openapi.json",
+				Format:              dumpFormatJSON,
+			},
+			wantErr: errLibraryOrSpecificationSource,
+		},
+		{
+			name: "unknown format",
+			opts: &modelDumpOptions{
+				LibraryName: "google-cloud-secretmanager-v1",
+				Format:      "xml",
+			},
+			wantErr: errUnknownDumpFormat,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runModelDump(t.Context(), &buf, test.opts)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("runModelDump() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildModel_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		library *config.Library
+		apiPath string
+		wantErr error
+	}{
+		{
+			name:    "no apis",
+			library: &config.Library{Name: "empty"},
+			wantErr: errLibraryHasNoAPIs,
+		},
+		{
+			name: "unknown api",
+			library: &config.Library{
+				Name: "secretmanager",
+				APIs: []*config.API{{Path: "google/cloud/secretmanager/v1"}},
+			},
+			apiPath: "google/cloud/secretmanager/v2",
+			wantErr: errUnknownAPI,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := buildModel(config.LanguageRust, test.library, test.apiPath, nil)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("buildModel() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
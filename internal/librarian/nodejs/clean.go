@@ -64,6 +64,9 @@ func Clean(lib *config.Library) error {
 	for _, k := range lib.Keep {
 		keepSet[k] = true
 	}
+	if UsesSidekick(lib) {
+		return cleanSidekick(lib, keepSet)
+	}
 	if err := cleanFiles(lib, keepSet, "protos", ".proto", nil); err != nil {
 		return err
 	}
@@ -15,17 +15,21 @@
 package nodejs
 
 import (
-	"bytes"
 	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/fetch"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
 	"github.com/googleapis/librarian/internal/yaml"
 )
 
@@ -37,68 +41,137 @@ const gapicGeneratorSubdir = "core/generator/gapic-generator-typescript"
 //go:embed librarian.yaml
 var librarianYAML []byte
 
-// Install installs Node.js tool dependencies.
-func Install(ctx context.Context) error {
+// npmRegistry is the registry from which the tarballs of pnpm tools are
+// downloaded.
+var npmRegistry = "https://registry.npmjs.org"
+
+// Install installs the pnpm tools of tools into env, which has a private
+// pnpm home and store. The tarball of each tool is downloaded and its
+// checksum verified and recorded in lock before it is installed.
+func Install(ctx context.Context, env *toolenv.Env, tools *config.Tools, lock *toollock.Lock) error {
 	for _, cmd := range []string{"node", "pnpm"} {
 		if _, err := exec.LookPath(cmd); err != nil {
 			return fmt.Errorf("%s is not installed or not in PATH, which is required for Node.js tool installation: %w", cmd, err)
 		}
 	}
-
-	cfg, err := yaml.Unmarshal[config.Config](librarianYAML)
-	if err != nil {
-		return fmt.Errorf("parsing embedded librarian.yaml: %w", err)
-	}
-	env, err := getPNPMEnv(ctx)
-	if err != nil {
-		return err
+	if tools == nil {
+		return nil
 	}
-
-	for _, tool := range cfg.Tools.PNPM {
+	environ := pnpmEnv(env)
+	for _, tool := range tools.PNPM {
 		if len(tool.Build) > 0 {
-			if err := installPNPMToolFromSource(ctx, env, tool); err != nil {
+			if err := installPNPMToolFromSource(ctx, environ, tool); err != nil {
 				return err
 			}
+			// fetch.Repo verifies the tarball against the checksum in
+			// librarian.yaml.
+			lock.Record(toollock.KindPNPM, tool.Name, tool.Version, tool.Checksum)
 			continue
 		}
-
-		pkg := tool.Package
-		if pkg == "" {
-			pkg = fmt.Sprintf("%s@%s", tool.Name, tool.Version)
-		}
-		if err := runPNPM(ctx, "", env, "add", "-g", pkg); err != nil {
+		if err := installPNPMTool(ctx, environ, tool, lock); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// getPNPMEnv resolves Node's global installation bin prefix path dynamically
-// and constructs a transient environment variable block to configure pnpm.
-//
-// This redirects all globally-installed pnpm binaries, virtual stores, and
-// content-addressable storage caches to be nested under the Node prefix folder.
-// This enables complete environment caching and restore on CI runners,
-// while permanently avoiding persistent side-effects on the host machine
-// (it does not modify the user's personal ~/.config/pnpm/rc files).
-func getPNPMEnv(ctx context.Context) ([]string, error) {
-	binOut, err := commandOutput(ctx, "node", "-e", "console.log(require('path').dirname(process.execPath))")
+// Tools returns the default tools for Node.js, used when librarian.yaml does
+// not configure any pnpm tools.
+func Tools() (*config.Tools, error) {
+	cfg, err := yaml.Unmarshal[config.Config](librarianYAML)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve node bin directory: %w", err)
+		return nil, fmt.Errorf("parsing embedded librarian.yaml: %w", err)
+	}
+	if cfg.Tools == nil {
+		return &config.Tools{}, nil
 	}
-	globalBin := strings.TrimSpace(binOut)
+	return cfg.Tools, nil
+}
 
-	// In pnpm v11+, globally installed binaries are stored in PNPM_HOME/bin.
-	// We want them to be stored directly in globalBin (node's bin directory).
-	// See https://pnpm.io/blog/releases/11.0#isolated-global-virtual-store-global-installs
-	pnpmHome := filepath.Dir(globalBin)
+// InstalledTools returns the packages installed globally with pnpm in env.
+// The version of packages installed from a local directory is empty.
+func InstalledTools(ctx context.Context, env *toolenv.Env) ([]*toollock.Tool, error) {
+	cmd := exec.CommandContext(ctx, "pnpm", "list", "-g", "--json")
+	cmd.Env = pnpmEnv(env)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("pnpm list: %w", err)
+	}
+	return parsePNPMList(output)
+}
 
-	env := os.Environ()
-	env = append(env, "PNPM_HOME="+pnpmHome)
-	env = append(env, "PNPM_CONFIG_GLOBAL_BIN_DIR="+globalBin)
-	env = append(env, "PNPM_CONFIG_GLOBAL_DIR="+filepath.Join(globalBin, "pnpm-global"))
-	env = append(env, "PNPM_CONFIG_STORE_DIR="+filepath.Join(globalBin, "pnpm-store"))
-	return env, nil
+// parsePNPMList parses the output of pnpm list --json.
+func parsePNPMList(output []byte) ([]*toollock.Tool, error) {
+	var projects []struct {
+		Dependencies map[string]struct {
+			Version string `json:"version"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(output, &projects); err != nil {
+		return nil, fmt.Errorf("parsing pnpm list output: %w", err)
+	}
+	var tools []*toollock.Tool
+	for _, p := range projects {
+		for name, dep := range p.Dependencies {
+			version := dep.Version
+			if strings.HasPrefix(version, "file:") || strings.HasPrefix(version, "link:") {
+				version = ""
+			}
+			tools = append(tools, &toollock.Tool{Kind: toollock.KindPNPM, Name: name, Version: version})
+		}
+	}
+	return tools, nil
+}
+
+// installPNPMTool downloads the tarball of tool, from the npm registry
+// unless tool.Package is set, verifies its checksum in lock and installs it
+// globally with pnpm.
+func installPNPMTool(ctx context.Context, env []string, tool *config.PNPMTool, lock *toollock.Lock) error {
+	tarball := tool.Package
+	if tarball == "" || strings.Contains(tarball, "://") {
+		dir, err := os.MkdirTemp("", "librarian-pnpm-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+		url := tool.Package
+		if url == "" {
+			url = tarballURL(tool.Name, tool.Version)
+		}
+		tarball = filepath.Join(dir, path.Base(url))
+		if err := fetch.File(ctx, tarball, url); err != nil {
+			return fmt.Errorf("downloading %s: %w", tool.Name, err)
+		}
+	}
+	if err := lock.Verify(toollock.KindPNPM, tool.Name, tool.Version, tool.Checksum, tarball); err != nil {
+		return err
+	}
+	return runPNPM(ctx, "", env, "add", "-g", tarball)
+}
+
+// tarballURL returns the URL of the tarball of a package in the npm
+// registry. The tarball of a scoped package such as "@scope/name" is named
+// after the unscoped name.
+func tarballURL(name, version string) string {
+	return fmt.Sprintf("%s/%s/-/%s-%s.tgz", npmRegistry, name, path.Base(name), version)
+}
+
+// pnpmEnv returns the environment of the calling process with the variables
+// of env, which redirect globally-installed pnpm binaries, packages and the
+// content-addressable store to env, without modifying the user's personal
+// ~/.config/pnpm/rc files. The bin directories of env are prepended to PATH,
+// as pnpm requires the global bin directory to be in PATH.
+func pnpmEnv(env *toolenv.Env) []string {
+	environ := os.Environ()
+	vars := env.Environ()
+	maps.Copy(vars, env.PNPMEnviron())
+	for k, v := range vars {
+		if k == "PATH" {
+			v = v + string(os.PathListSeparator) + os.Getenv("PATH")
+		}
+		environ = append(environ, k+"="+v)
+	}
+	return environ
 }
 
 func runPNPM(ctx context.Context, dir string, env []string, args ...string) error {
@@ -119,14 +192,6 @@ func runPNPMBuildCmd(ctx context.Context, dir string, env []string, cmdStr strin
 	return cmd.Run()
 }
 
-func commandOutput(ctx context.Context, name string, args ...string) (string, error) {
-	cmd := exec.CommandContext(ctx, name, args...)
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
-	return out.String(), err
-}
-
 func installPNPMToolFromSource(ctx context.Context, env []string, tool *config.PNPMTool) error {
 	if tool.Package == "" {
 		return fmt.Errorf("pnpm tool %s has build steps but no package URL", tool.Name)
@@ -15,11 +15,19 @@
 package nodejs
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
 	"github.com/googleapis/librarian/internal/yaml"
 )
 
@@ -54,10 +62,11 @@ func TestInstall(t *testing.T) {
 	// node_modules/.bin/tsc in the working directory during 'pnpm install'
 	// so the subsequent "./node_modules/.bin/tsc" build step finds an executable.
 	bin := t.TempDir()
-	pnpmStub := `#!/bin/sh
-# Assert that transient environmental variables are set dynamically for process lifetime
-if [ -z "$PNPM_HOME" ] || [ -z "$PNPM_CONFIG_GLOBAL_BIN_DIR" ] || [ -z "$PNPM_CONFIG_GLOBAL_DIR" ] || [ -z "$PNPM_CONFIG_STORE_DIR" ]; then
-    echo "Error: Required transient PNPM environment variables are missing!" >&2
+	env := &toolenv.Env{Dir: t.TempDir()}
+	pnpmStub := fmt.Sprintf(`#!/bin/sh
+# Assert that pnpm installs into the private home and store of the tool environment
+if [ "$PNPM_HOME" != %q ] || [ "$PNPM_CONFIG_STORE_DIR" != %q ] || [ -z "$PNPM_CONFIG_GLOBAL_BIN_DIR" ] || [ -z "$PNPM_CONFIG_GLOBAL_DIR" ]; then
+    echo "Error: PNPM environment variables do not point to the tool environment!" >&2
     exit 1
 fi
 
@@ -69,15 +78,8 @@ case "$*" in
         ;;
 esac
 exit 0
-`
-	nodeStub := `#!/bin/sh
-case "$*" in
-    *dirname*)
-        echo "/usr/local/bin"
-        ;;
-esac
-exit 0
-`
+`, env.PNPMHome(), filepath.Join(env.PNPMHome(), "store"))
+	nodeStub := "#!/bin/sh\nexit 0\n"
 	if err := os.WriteFile(filepath.Join(bin, "pnpm"), []byte(pnpmStub), 0o755); err != nil {
 		t.Fatal(err)
 	}
@@ -86,7 +88,66 @@ exit 0
 	}
 	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
 
-	if err := Install(t.Context()); err != nil {
+	// Serve the path of each tarball as its content.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.URL.Path)
+	}))
+	defer server.Close()
+	defer func(registry string) { npmRegistry = registry }(npmRegistry)
+	npmRegistry = server.URL
+
+	lock := &toollock.Lock{}
+	if err := Install(t.Context(), env, cfg.Tools, lock); err != nil {
 		t.Fatal(err)
 	}
+	want := []*toollock.Tool{
+		{Kind: toollock.KindPNPM, Name: tool.Name, Version: tool.Version, Checksum: tool.Checksum},
+	}
+	for _, tool := range cfg.Tools.PNPM[1:] {
+		path := fmt.Sprintf("/%s/-/%s-%s.tgz", tool.Name, tool.Name, tool.Version)
+		want = append(want, &toollock.Tool{
+			Kind:     toollock.KindPNPM,
+			Name:     tool.Name,
+			Version:  tool.Version,
+			Checksum: fmt.Sprintf("%x", sha256.Sum256([]byte(path))),
+		})
+	}
+	if diff := cmp.Diff(want, lock.Tools); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTarballURL(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		want string
+	}{
+		{"gapic-tools", npmRegistry + "/gapic-tools/-/gapic-tools-1.0.0.tgz"},
+		{"@scope/tool", npmRegistry + "/@scope/tool/-/tool-1.0.0.tgz"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := tarballURL(test.name, "1.0.0"); got != test.want {
+				t.Errorf("tarballURL() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParsePNPMList(t *testing.T) {
+	output := `[{"path": "/pnpm-global", "dependencies": {
+		"gapic-tools": {"from": "gapic-tools", "version": "1.0.6"},
+		"gapic-generator-typescript": {"from": "gapic-generator-typescript", "version": "link:../../generator"}
+	}}]`
+	got, err := parsePNPMList([]byte(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*toollock.Tool{
+		{Kind: toollock.KindPNPM, Name: "gapic-generator-typescript"},
+		{Kind: toollock.KindPNPM, Name: "gapic-tools", Version: "1.0.6"},
+	}
+	less := func(a, b *toollock.Tool) bool { return a.Name < b.Name }
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(less)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
 }
@@ -0,0 +1,116 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/serviceconfig"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+	sidekicktypescript "github.com/googleapis/librarian/internal/sidekick/typescript"
+	"github.com/googleapis/librarian/internal/sources"
+)
+
+// generatorSidekick selects the sidekick TypeScript codec in
+// [config.NodejsPackage.Generator].
+const generatorSidekick = "sidekick"
+
+var (
+	sidekickMarker = []byte("Code generated by sidekick. DO NOT EDIT.")
+	// Files generated by the sidekick TypeScript codec outside of src,
+	// relative to the package directory.
+	sidekickFilesToClean = []string{
+		"LICENSE",
+		"README.md",
+		"package.json",
+		"tsconfig.json",
+	}
+)
+
+// UsesSidekick reports whether the library is generated with the sidekick
+// TypeScript codec.
+func UsesSidekick(library *config.Library) bool {
+	return library.Nodejs != nil && library.Nodejs.Generator == generatorSidekick
+}
+
+// generateSidekick generates a fetch()-based REST client for the library
+// using the sidekick TypeScript codec.
+func generateSidekick(ctx context.Context, library *config.Library, src *sources.Sources) error {
+	if len(library.APIs) != 1 {
+		return fmt.Errorf("the sidekick TypeScript generator only supports a single api per library")
+	}
+	modelConfig, err := sidekickModelConfig(library, library.APIs[0], src)
+	if err != nil {
+		return err
+	}
+	model, err := parser.CreateModel(modelConfig)
+	if err != nil {
+		return err
+	}
+	return sidekicktypescript.Generate(ctx, model, library.Output, modelConfig.Codec)
+}
+
+func sidekickModelConfig(library *config.Library, api *config.API, src *sources.Sources) (*parser.ModelConfig, error) {
+	svcConfig, err := serviceconfig.Find(src.Googleapis, api.Path, config.LanguageNodejs)
+	if err != nil {
+		return nil, err
+	}
+	specFormat := config.SpecProtobuf
+	if library.SpecificationFormat != "" {
+		specFormat = library.SpecificationFormat
+	}
+	codec := map[string]string{
+		"copyright-year": library.CopyrightYear,
+		"version":        library.Version,
+	}
+	if library.TemplateOverlay != "" {
+		codec["template-overlay"] = library.TemplateOverlay
+	}
+	if library.Nodejs.PackageName != "" {
+		codec["package-name-override"] = library.Nodejs.PackageName
+	}
+	return &parser.ModelConfig{
+		Language:            config.LanguageNodejs,
+		SpecificationFormat: specFormat,
+		ServiceConfig:       svcConfig.ServiceConfig,
+		SpecificationSource: api.Path,
+		Source:              sources.NewSourceConfig(src, library.Roots),
+		Codec:               codec,
+		Transformations:     parser.Transformations(library.Transforms),
+	}, nil
+}
+
+// cleanSidekick removes the files generated by the sidekick TypeScript
+// codec, preserving handwritten sources and the files in the keep list.
+func cleanSidekick(lib *config.Library, keepSet map[string]bool) error {
+	if err := cleanFiles(lib, keepSet, "src", ".ts", sidekickMarker); err != nil {
+		return err
+	}
+	for _, file := range sidekickFilesToClean {
+		if keepSet[file] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(lib.Output, file)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
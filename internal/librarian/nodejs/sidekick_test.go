@@ -0,0 +1,162 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sources"
+	"github.com/googleapis/librarian/internal/testhelper"
+)
+
+func TestGenerate_Sidekick(t *testing.T) {
+	testhelper.RequireCommand(t, "protoc")
+
+	absGoogleapisDir, err := filepath.Abs(googleapisDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	library := &config.Library{
+		Name:          "secretmanager",
+		APIs:          []*config.API{{Path: "google/cloud/secretmanager/v1"}},
+		CopyrightYear: "2038",
+		Version:       "1.2.3",
+		Output:        t.TempDir(),
+		Nodejs: &config.NodejsPackage{
+			Generator:   generatorSidekick,
+			PackageName: "@example/secretmanager",
+		},
+	}
+	cfg := &config.Config{Language: config.LanguageNodejs}
+	if err := Generate(t.Context(), cfg, library, &sources.Sources{Googleapis: absGoogleapisDir}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"package.json", "tsconfig.json", "README.md", "LICENSE", "src/index.ts", "src/runtime.ts"} {
+		if _, err := os.Stat(filepath.Join(library.Output, name)); err != nil {
+			t.Error(err)
+		}
+	}
+	got, err := os.ReadFile(filepath.Join(library.Output, "package.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"name": "@example/secretmanager"`, `"version": "1.2.3"`} {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("expected %q in package.json, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestGenerate_SidekickMultipleAPIs(t *testing.T) {
+	library := &config.Library{
+		Name: "secretmanager",
+		APIs: []*config.API{
+			{Path: "google/cloud/secretmanager/v1"},
+			{Path: "google/cloud/secretmanager/v1beta2"},
+		},
+		Output: t.TempDir(),
+		Nodejs: &config.NodejsPackage{Generator: generatorSidekick},
+	}
+	cfg := &config.Config{Language: config.LanguageNodejs}
+	if err := Generate(t.Context(), cfg, library, &sources.Sources{Googleapis: googleapisDir}); err == nil {
+		t.Errorf("expected an error with multiple APIs")
+	}
+}
+
+func TestSidekickModelConfig(t *testing.T) {
+	absGoogleapisDir, err := filepath.Abs(googleapisDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	library := &config.Library{
+		Name:            "secretmanager",
+		APIs:            []*config.API{{Path: "google/cloud/secretmanager/v1"}},
+		CopyrightYear:   "2038",
+		Version:         "1.2.3",
+		TemplateOverlay: "overlay",
+		Nodejs: &config.NodejsPackage{
+			Generator:   generatorSidekick,
+			PackageName: "@example/secretmanager",
+		},
+	}
+	got, err := sidekickModelConfig(library, library.APIs[0], &sources.Sources{Googleapis: absGoogleapisDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"copyright-year":        "2038",
+		"version":               "1.2.3",
+		"template-overlay":      "overlay",
+		"package-name-override": "@example/secretmanager",
+	}
+	if diff := cmp.Diff(want, got.Codec); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	if got.Language != config.LanguageNodejs || got.SpecificationSource != "google/cloud/secretmanager/v1" {
+		t.Errorf("unexpected model config: %+v", got)
+	}
+	if got.ServiceConfig == "" {
+		t.Errorf("expected a service config for %q", got.SpecificationSource)
+	}
+}
+
+func TestClean_Sidekick(t *testing.T) {
+	outDir := t.TempDir()
+	files := map[string]string{
+		"LICENSE":            "license",
+		"README.md":          "readme",
+		"package.json":       "{}",
+		"tsconfig.json":      "{}",
+		"CHANGELOG.md":       "changelog",
+		"src/index.ts":       "// " + string(sidekickMarker) + "\n",
+		"src/runtime.ts":     "// " + string(sidekickMarker) + "\n",
+		"src/handwritten.ts": "export {};\n",
+		"src/kept.ts":        "// " + string(sidekickMarker) + "\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(outDir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lib := &config.Library{
+		Output: outDir,
+		Keep:   []string{"README.md", "src/kept.ts"},
+		Nodejs: &config.NodejsPackage{Generator: generatorSidekick},
+	}
+	if err := Clean(lib); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"LICENSE", "package.json", "tsconfig.json", "src/index.ts", "src/runtime.ts"} {
+		if _, err := os.Stat(filepath.Join(outDir, name)); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected %s to be deleted, got err=%v", name, err)
+		}
+	}
+	for _, name := range []string{"README.md", "CHANGELOG.md", "src/handwritten.ts", "src/kept.ts"} {
+		if _, err := os.Stat(filepath.Join(outDir, name)); err != nil {
+			t.Errorf("expected %s to be kept: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/librarian/rust"
+	sidekickdart "github.com/googleapis/librarian/internal/sidekick/dart"
+	sidekickrust "github.com/googleapis/librarian/internal/sidekick/rust"
+	sidekickswift "github.com/googleapis/librarian/internal/sidekick/swift"
+	sidekicktypescript "github.com/googleapis/librarian/internal/sidekick/typescript"
+)
+
+var errTemplateOverlayUnsupported = errors.New("language does not support template overlays")
+
+// runListTemplateOverlays prints the templates replaced by the template
+// overlay of each selected library, without generating any code.
+func runListTemplateOverlays(w io.Writer, cfg *config.Config, all bool, libraryName string) error {
+	var overridden func(string) ([]string, error)
+	switch cfg.Language {
+	case config.LanguageDart:
+		overridden = sidekickdart.OverriddenTemplates
+	case config.LanguageNodejs:
+		overridden = sidekicktypescript.OverriddenTemplates
+	case config.LanguageRust:
+		overridden = sidekickrust.OverriddenTemplates
+	case config.LanguageSwift:
+		overridden = sidekickswift.OverriddenTemplates
+	default:
+		return fmt.Errorf("%w: %q", errTemplateOverlayUnsupported, cfg.Language)
+	}
+	for _, lib := range cfg.Libraries {
+		if !shouldGenerate(lib, all, libraryName) {
+			continue
+		}
+		overlay := lib.TemplateOverlay
+		if overlay == "" && cfg.Default != nil {
+			overlay = cfg.Default.TemplateOverlay
+		}
+		if cfg.Language == config.LanguageRust {
+			if err := rust.CheckTemplateOverlay(lib, overlay); err != nil {
+				return err
+			}
+		}
+		names, err := overridden(overlay)
+		if err != nil {
+			return fmt.Errorf("library %q: %w", lib.Name, err)
+		}
+		for _, name := range names {
+			fmt.Fprintf(w, "%s: %s\n", lib.Name, name)
+		}
+	}
+	return nil
+}
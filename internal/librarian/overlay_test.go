@@ -0,0 +1,156 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/librarian/rust"
+)
+
+func TestRunListTemplateOverlays(t *testing.T) {
+	overlay := t.TempDir()
+	for _, name := range []string{"crate/README.md.mustache", "crate/Cargo.toml.mustache"} {
+		p := filepath.Join(overlay, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("overlay"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	nodejsOverlay := t.TempDir()
+	if err := os.WriteFile(filepath.Join(nodejsOverlay, "README.md.mustache"), []byte("overlay"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name        string
+		cfg         *config.Config
+		all         bool
+		libraryName string
+		want        string
+	}{
+		{
+			name: "library overlay",
+			cfg: &config.Config{
+				Language: config.LanguageRust,
+				Libraries: []*config.Library{
+					{Name: "google-cloud-secretmanager-v1", TemplateOverlay: overlay},
+					{Name: "google-cloud-storage"},
+				},
+			},
+			all: true,
+			want: "google-cloud-secretmanager-v1: templates/crate/Cargo.toml.mustache\n" +
+				"google-cloud-secretmanager-v1: templates/crate/README.md.mustache\n",
+		},
+		{
+			name: "default overlay",
+			cfg: &config.Config{
+				Language: config.LanguageRust,
+				Default:  &config.Default{TemplateOverlay: overlay},
+				Libraries: []*config.Library{
+					{Name: "google-cloud-secretmanager-v1"},
+					{Name: "google-cloud-storage"},
+				},
+			},
+			libraryName: "google-cloud-storage",
+			want: "google-cloud-storage: templates/crate/Cargo.toml.mustache\n" +
+				"google-cloud-storage: templates/crate/README.md.mustache\n",
+		},
+		{
+			name: "nodejs",
+			cfg: &config.Config{
+				Language:  config.LanguageNodejs,
+				Libraries: []*config.Library{{Name: "secretmanager", TemplateOverlay: nodejsOverlay}},
+			},
+			all:  true,
+			want: "secretmanager: templates/README.md.mustache\n",
+		},
+		{
+			name: "no overlay",
+			cfg: &config.Config{
+				Language:  config.LanguageRust,
+				Libraries: []*config.Library{{Name: "google-cloud-storage"}},
+			},
+			all:  true,
+			want: "",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runListTemplateOverlays(&buf, test.cfg, test.all, test.libraryName); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, buf.String()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunListTemplateOverlays_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		cfg     *config.Config
+		wantErr error
+	}{
+		{
+			name:    "unsupported language",
+			cfg:     &config.Config{Language: config.LanguagePython},
+			wantErr: errTemplateOverlayUnsupported,
+		},
+		{
+			name: "missing overlay",
+			cfg: &config.Config{
+				Language: config.LanguageDart,
+				Libraries: []*config.Library{
+					{Name: "google_cloud_secretmanager_v1", TemplateOverlay: filepath.Join(t.TempDir(), "missing")},
+				},
+			},
+			wantErr: os.ErrNotExist,
+		},
+		{
+			name: "rust prost module",
+			cfg: &config.Config{
+				Language: config.LanguageRust,
+				Default:  &config.Default{TemplateOverlay: t.TempDir()},
+				Libraries: []*config.Library{
+					{
+						Name: "google-cloud-storage",
+						Rust: &config.RustCrate{
+							Modules: []*config.RustModule{
+								{Output: "src/storage/src/generated/protos", Template: "prost"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: rust.ErrProstTemplateOverlay,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := runListTemplateOverlays(&bytes.Buffer{}, test.cfg, true, "")
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("runListTemplateOverlays() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
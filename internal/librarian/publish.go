@@ -59,6 +59,10 @@ Only Rust is supported.`,
 			if err != nil {
 				return err
 			}
+			ctx, err = withToolEnv(ctx, cfg)
+			if err != nil {
+				return err
+			}
 			if cfg.Language == config.LanguageRust {
 				return rustPublish(ctx, cfg, cmd)
 			}
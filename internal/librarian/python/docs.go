@@ -0,0 +1,33 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+)
+
+// BuildDocs builds the DocFX reference documentation of the library with the
+// docfx nox session, and returns the directory containing it.
+func BuildDocs(ctx context.Context, library *config.Library) (string, error) {
+	if err := command.RunInDir(ctx, library.Output, "nox", "-s", "docfx"); err != nil {
+		return "", fmt.Errorf("failed to build docs: %w", err)
+	}
+	return filepath.Join(library.Output, "docs", "_build", "html", "docfx_yaml"), nil
+}
@@ -416,14 +416,22 @@ python_mono_repo.owlbot_main(%q)
 	if err := command.RunInDir(ctx, generationRoot, "python3", "-c", pythonCode); err != nil {
 		return fmt.Errorf("failed to run post-processor: %w", err)
 	}
+	return nil
+}
 
-	// synthtool runs formatting, then applies string replacements. This leaves
-	// some files unformatted. We format again just to get everything straight.
-	// (Changing synthtool's ordering would require changes in the replacements
-	// as well... we can do all of that after migration, when we remove
-	// synthtool entirely - see
-	// https://github.com/googleapis/librarian/issues/3008)
-	if err := command.RunInDir(ctx, outDir, "nox", "-s", "format", "--no-venv", "--no-install"); err != nil {
+// Format formats the generated code of a Python library.
+//
+// synthtool runs formatting, then applies string replacements. This leaves
+// some files unformatted. We format again just to get everything straight.
+// (Changing synthtool's ordering would require changes in the replacements
+// as well... we can do all of that after migration, when we remove
+// synthtool entirely - see
+// https://github.com/googleapis/librarian/issues/3008)
+func Format(ctx context.Context, library *config.Library) error {
+	if len(library.APIs) == 0 {
+		return nil
+	}
+	if err := command.RunInDir(ctx, library.Output, "nox", "-s", "format", "--no-venv", "--no-install"); err != nil {
 		return fmt.Errorf("failed to format code after post-processing: %w", err)
 	}
 	return nil
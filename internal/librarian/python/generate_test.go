@@ -642,15 +642,6 @@ func TestRunPostProcessor_Error(t *testing.T) {
 				}
 			},
 		},
-		{
-			name: "nox failure",
-			setup: func(t *testing.T, repoRoot, outputDir string) {
-				// nox requires noxfile.py to be present
-				if err := os.Remove(filepath.Join(outputDir, "noxfile.py")); err != nil {
-					t.Fatal(err)
-				}
-			},
-		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			repoRoot := t.TempDir()
@@ -684,6 +675,32 @@ func TestRunPostProcessor_Error(t *testing.T) {
 	}
 }
 
+func TestFormat(t *testing.T) {
+	testhelper.RequireCommand(t, "nox")
+	outdir := t.TempDir()
+	createMinimalNoxFile(t, outdir)
+	library := &config.Library{Output: outdir, APIs: []*config.API{{Path: "google/cloud/secretmanager/v1"}}}
+	if err := Format(t.Context(), library); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFormat_Error(t *testing.T) {
+	testhelper.RequireCommand(t, "nox")
+	// nox requires noxfile.py to be present
+	library := &config.Library{Output: t.TempDir(), APIs: []*config.API{{Path: "google/cloud/secretmanager/v1"}}}
+	if err := Format(t.Context(), library); err == nil {
+		t.Fatal("expected error; got none")
+	}
+}
+
+func TestFormat_NoAPIs(t *testing.T) {
+	t.Setenv("PATH", "")
+	if err := Format(t.Context(), &config.Library{Output: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestGenerateAPI(t *testing.T) {
 	t.Parallel()
 	if testing.Short() {
@@ -21,20 +21,32 @@ import (
 
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/pip"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
 	"github.com/googleapis/librarian/internal/yaml"
 )
 
 //go:embed librarian.yaml
 var librarianYAML []byte
 
-// Install installs Python pip tool dependencies.
-func Install(ctx context.Context) error {
+// Install installs the pip tools of tools into the virtualenv of env, and
+// verifies and records their checksums in lock.
+func Install(ctx context.Context, env *toolenv.Env, tools *config.Tools, lock *toollock.Lock) error {
+	if tools == nil || len(tools.Pip) == 0 {
+		return nil
+	}
+	return pip.Install(ctx, env.Venv(), tools.Pip, lock)
+}
+
+// Tools returns the default tools for Python, used when librarian.yaml does
+// not configure any pip tools.
+func Tools() (*config.Tools, error) {
 	cfg, err := yaml.Unmarshal[config.Config](librarianYAML)
 	if err != nil {
-		return fmt.Errorf("parsing embedded librarian.yaml: %w", err)
+		return nil, fmt.Errorf("parsing embedded librarian.yaml: %w", err)
 	}
-	if len(cfg.Tools.Pip) == 0 {
-		return nil
+	if cfg.Tools == nil {
+		return &config.Tools{}, nil
 	}
-	return pip.Install(ctx, cfg.Tools.Pip)
+	return cfg.Tools, nil
 }
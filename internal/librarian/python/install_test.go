@@ -17,17 +17,50 @@ package python
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
 )
 
 func TestInstall(t *testing.T) {
-	bin := t.TempDir()
-	if err := os.WriteFile(filepath.Join(bin, "pip"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+	env := &toolenv.Env{Dir: t.TempDir()}
+	// pip download writes the target to a file in the --dest directory.
+	stub := `#!/bin/sh
+if [ "$1" = download ]; then echo "$5" > "$4/download.tar.gz"; fi
+`
+	venvBin := filepath.Join(env.Venv(), "bin")
+	if err := os.MkdirAll(venvBin, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(venvBin, "pip"), []byte(stub), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tools, err := Tools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock := &toollock.Lock{}
+	if err := Install(t.Context(), env, tools, lock); err != nil {
 		t.Fatal(err)
 	}
-	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	for _, tool := range tools.Pip {
+		locked := lock.Find(toollock.KindPip, tool.Name)
+		if locked == nil || locked.Version != tool.Version {
+			t.Errorf("lock.Find(%q) = %+v, want version %s", tool.Name, locked, tool.Version)
+			continue
+		}
+		// Packages installed from a URL are locked without a checksum.
+		if wantChecksum := !strings.Contains(tool.Package, "://"); wantChecksum != (len(locked.Files) > 0) {
+			t.Errorf("lock.Find(%q) files = %v, want checksum %t", tool.Name, locked.Files, wantChecksum)
+		}
+	}
+}
 
-	if err := Install(t.Context()); err != nil {
+func TestInstall_NoPipTools(t *testing.T) {
+	if err := Install(t.Context(), &toolenv.Env{Dir: t.TempDir()}, nil, &toollock.Lock{}); err != nil {
 		t.Fatal(err)
 	}
 }
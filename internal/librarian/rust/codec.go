@@ -16,16 +16,19 @@ package rust
 
 import (
 	"fmt"
+	"maps"
 	"strings"
 
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/serviceconfig"
 	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/parser"
+	sidekickrust "github.com/googleapis/librarian/internal/sidekick/rust"
 	"github.com/googleapis/librarian/internal/sources"
 )
 
-func libraryToModelConfig(library *config.Library, ch *config.API, srcs *sources.Sources) (*parser.ModelConfig, error) {
+// ModelConfig returns the parser configuration for the given API in library.
+func ModelConfig(library *config.Library, ch *config.API, srcs *sources.Sources) (*parser.ModelConfig, error) {
 	specFormat := config.SpecProtobuf
 	if library.SpecificationFormat != "" {
 		specFormat = library.SpecificationFormat
@@ -63,6 +66,7 @@ func libraryToModelConfig(library *config.Library, ch *config.API, srcs *sources
 			Title:       svcConfig.Title,
 		},
 		ResourceNameHeuristic: library.Rust != nil && library.Rust.ResourceNameHeuristic != nil && *library.Rust.ResourceNameHeuristic,
+		Transformations:       parser.Transformations(library.Transforms),
 	}
 
 	if library.Rust != nil {
@@ -163,6 +167,9 @@ func buildCodec(library *config.Library, releaseLevel string) map[string]string
 	if rust.QuickstartServiceOverride != "" {
 		codec["quickstart-service-override"] = rust.QuickstartServiceOverride
 	}
+	if rust.Codec != nil {
+		maps.Copy(codec, sidekickrust.FormatCodecOptions(rust.Codec))
+	}
 	return codec
 }
 
@@ -174,6 +181,9 @@ func newLibraryCodec(library *config.Library) map[string]string {
 	if library.Name != "" {
 		codec["package-name-override"] = library.Name
 	}
+	if library.TemplateOverlay != "" {
+		codec["template-overlay"] = library.TemplateOverlay
+	}
 	if library.Rust != nil {
 		for _, dep := range library.Rust.PackageDependencies {
 			codec["package:"+dep.Name] = formatPackageDependency(dep)
@@ -254,13 +264,17 @@ func moduleToModelConfig(library *config.Library, module *config.RustModule, src
 		resourceNameHeuristic = *module.ResourceNameHeuristic
 	}
 
+	codec := buildModuleCodec(library, module)
+	if isProstModule(module) {
+		codec = buildProstCodec(library, module)
+	}
 	modelCfg := &parser.ModelConfig{
 		Language:            config.LanguageRust,
 		SpecificationFormat: specificationFormat,
 		ServiceConfig:       module.ServiceConfig,
 		SpecificationSource: module.APIPath,
 		Source:              src,
-		Codec:               buildModuleCodec(library, module),
+		Codec:               codec,
 		Override: api.ModelOverride{
 			Title:       title,
 			IncludedIDs: module.IncludedIds,
@@ -290,6 +304,25 @@ func moduleToModelConfig(library *config.Library, module *config.RustModule, src
 	return modelCfg, nil
 }
 
+// buildProstCodec returns the options for the rust_prost codec, which
+// generates the modules using the prost or tonic templates.
+func buildProstCodec(library *config.Library, module *config.RustModule) map[string]string {
+	codec := make(map[string]string)
+	if library.CopyrightYear != "" {
+		codec["copyright-year"] = library.CopyrightYear
+	}
+	if library.Name != "" {
+		codec["package-name-override"] = library.Name
+	}
+	if module.PostProcessProtos != "" {
+		codec["post-process-protos"] = module.PostProcessProtos
+	}
+	if module.RootName != "" {
+		codec["root-name"] = module.RootName
+	}
+	return codec
+}
+
 func buildModuleCodec(library *config.Library, module *config.RustModule) map[string]string {
 	codec := newLibraryCodec(library)
 	if module.GenerateSetterSamples != "" {
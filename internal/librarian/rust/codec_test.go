@@ -49,7 +49,7 @@ func ptr[T any](v T) *T {
 	return &v
 }
 
-func TestLibraryToModelConfig(t *testing.T) {
+func TestModelConfig(t *testing.T) {
 	for _, test := range []struct {
 		name             string
 		library          *config.Library
@@ -589,7 +589,7 @@ func TestLibraryToModelConfig(t *testing.T) {
 			if test.want.Source.Sources == nil {
 				test.want.Source.Sources = srcs
 			}
-			got, err := libraryToModelConfig(test.library, test.api, srcs)
+			got, err := ModelConfig(test.library, test.api, srcs)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -1295,6 +1295,37 @@ func TestBuildModuleCodec(t *testing.T) {
 	}
 }
 
+func TestBuildProstCodec(t *testing.T) {
+	library := &config.Library{
+		Name:            "google-cloud-wkt",
+		CopyrightYear:   "2025",
+		TemplateOverlay: "overlay",
+		Rust: &config.RustCrate{
+			RustDefault: config.RustDefault{
+				PackageDependencies: []*config.RustPackageDependency{
+					{Name: "bytes", Package: "bytes"},
+				},
+			},
+		},
+	}
+	module := &config.RustModule{
+		Template:              "prost",
+		PostProcessProtos:     "post",
+		RootName:              "protobuf-src",
+		GenerateSetterSamples: "true",
+	}
+	got := buildProstCodec(library, module)
+	want := map[string]string{
+		"copyright-year":        "2025",
+		"package-name-override": "google-cloud-wkt",
+		"post-process-protos":   "post",
+		"root-name":             "protobuf-src",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestBuildCodec(t *testing.T) {
 	for _, test := range []struct {
 		name    string
@@ -1330,6 +1361,18 @@ func TestBuildCodec(t *testing.T) {
 				"release-level":         "preview",
 			},
 		},
+		{
+			name: "with template overlay",
+			library: &config.Library{
+				Name:            "google-cloud-secretmanager",
+				TemplateOverlay: "overlays/rust",
+			},
+			want: map[string]string{
+				"package-name-override": "google-cloud-secretmanager",
+				"release-level":         "stable",
+				"template-overlay":      "overlays/rust",
+			},
+		},
 		{
 			name: "with release level from service config",
 			library: &config.Library{
@@ -1402,6 +1445,29 @@ func TestBuildCodec(t *testing.T) {
 				"package:dep2":                "feature=feat2,ignore=true",
 			},
 		},
+		{
+			name: "with codec options",
+			library: &config.Library{
+				Name: "google-cloud-secretmanager",
+				Rust: &config.RustCrate{
+					RustDefault: config.RustDefault{
+						Codec: &config.RustCodec{
+							PackageNameOverride:   "google-cloud-secretmanager-v1",
+							InternalTypes:         []string{".google.test.Internal"},
+							GenerateSetterSamples: true,
+						},
+					},
+					HasVeneer: true,
+				},
+			},
+			want: map[string]string{
+				"package-name-override":   "google-cloud-secretmanager-v1",
+				"has-veneer":              "true",
+				"internal-types":          ".google.test.Internal",
+				"generate-setter-samples": "true",
+				"release-level":           "stable",
+			},
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			sc := test.sc
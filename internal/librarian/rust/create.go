@@ -27,13 +27,18 @@ func create(ctx context.Context, outputDir string) error {
 	if err := command.Run(ctx, command.Cargo, "--version"); err != nil {
 		return fmt.Errorf("got an error trying to run `cargo --version`, the instructions on https://www.rust-lang.org/learn/get-started may solve this problem: %w", err)
 	}
-	if err := command.Run(ctx, "taplo", "--version"); err != nil {
+	if err := runTaplo(ctx, "--version"); err != nil {
 		return fmt.Errorf("got an error trying to run `taplo --version`, please install using `cargo install taplo-cli`: %w", err)
 	}
 	if err := command.Run(ctx, command.Cargo, "new", "--vcs", "none", "--lib", outputDir); err != nil {
 		return err
 	}
-	return command.Run(ctx, "taplo", "fmt", "Cargo.toml")
+	return runTaplo(ctx, "fmt", "Cargo.toml")
+}
+
+// runTaplo runs taplo, which formats the Cargo.toml files.
+var runTaplo = func(ctx context.Context, arg ...string) error {
+	return command.Run(ctx, "taplo", arg...)
 }
 
 // validate does formatting and other post generation tasks to validate the library.
@@ -33,6 +33,29 @@ import (
 	"github.com/googleapis/librarian/internal/sources"
 )
 
+// ErrProstTemplateOverlay indicates that a library with prost or tonic modules
+// has a template overlay. These modules use the rust_prost codec, which does
+// not support template overlays.
+var ErrProstTemplateOverlay = errors.New("template overlays are not supported for prost and tonic modules")
+
+// CheckTemplateOverlay returns an error if overlay is set for a library with
+// prost or tonic modules.
+func CheckTemplateOverlay(lib *config.Library, overlay string) error {
+	if overlay == "" || lib.Rust == nil {
+		return nil
+	}
+	for _, module := range lib.Rust.Modules {
+		if isProstModule(module) {
+			return fmt.Errorf("%w: library %q, module %q", ErrProstTemplateOverlay, lib.Name, module.Output)
+		}
+	}
+	return nil
+}
+
+func isProstModule(module *config.RustModule) bool {
+	return module.Template == "prost" || module.Template == "tonic"
+}
+
 // IsMixedLibrary reports whether the library has handwritten code wrapping
 // generated code.
 //
@@ -65,7 +88,7 @@ func Generate(ctx context.Context, cfg *config.Config, library *config.Library,
 		return fmt.Errorf("the Rust generator only supports a single api per library")
 	}
 
-	modelConfig, err := libraryToModelConfig(library, library.APIs[0], sources)
+	modelConfig, err := ModelConfig(library, library.APIs[0], sources)
 	if err != nil {
 		return err
 	}
@@ -129,7 +152,7 @@ func UpdateWorkspace(ctx context.Context) error {
 // parallel calls cause race conditions as cargo fmt runs cargo metadata,
 // which competes for locks on the workspace Cargo.toml and Cargo.lock.
 func Format(ctx context.Context, library *config.Library) error {
-	if err := command.Run(ctx, "taplo", "fmt", filepath.Join(library.Output, "Cargo.toml")); err != nil {
+	if err := runTaplo(ctx, "fmt", filepath.Join(library.Output, "Cargo.toml")); err != nil {
 		return err
 	}
 	if err := command.Run(ctx, command.Cargo, "fmt", "-p", library.Name); err != nil {
@@ -142,6 +165,9 @@ func generateVeneer(ctx context.Context, library *config.Library, sources *sourc
 	if library.Rust == nil || len(library.Rust.Modules) == 0 {
 		return nil
 	}
+	if err := CheckTemplateOverlay(library, library.TemplateOverlay); err != nil {
+		return err
+	}
 	for _, module := range library.Rust.Modules {
 		if module.Template == "storage" {
 			return generateRustStorage(ctx, library, module.Output, sources)
@@ -157,7 +183,7 @@ func generateVeneer(ctx context.Context, library *config.Library, sources *sourc
 		if err != nil {
 			return fmt.Errorf("CreateModel %q: %w", module.Output, err)
 		}
-		if module.Template == "prost" || module.Template == "tonic" {
+		if isProstModule(module) {
 			err = rust_prost.Generate(ctx, model, module.Output, module.Template, modelConfig)
 		} else {
 			err = sidekickrust.Generate(ctx, model, module.Output, modelConfig)
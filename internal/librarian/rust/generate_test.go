@@ -17,6 +17,7 @@ package rust
 import (
 	"context"
 	"errors"
+	"flag"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -25,12 +26,18 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/command"
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/repometadata"
 	"github.com/googleapis/librarian/internal/sources"
 	"github.com/googleapis/librarian/internal/testhelper"
 )
 
+// record is used to record the cassettes in testdata/ with the real tools,
+// when the commands run by the code under test change.
+// Usage: go test ./internal/librarian/rust -run Cassette -v -record.
+var record = flag.Bool("record", false, "record cassettes with the real tools")
+
 func TestGenerateVeneer(t *testing.T) {
 	testhelper.RequireCommand(t, "protoc")
 	outDir := t.TempDir()
@@ -520,6 +527,107 @@ func TestGenerateLibrary(t *testing.T) {
 	}
 }
 
+func TestGenerate_Cassette(t *testing.T) {
+	googleapisDir, err := filepath.Abs("../../testdata/googleapis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspaceDir := t.TempDir()
+	for _, f := range []struct{ src, dst string }{
+		{filepath.Join("testdata", "Cargo.toml"), "Cargo.toml"},
+		{filepath.Join("..", "..", "testdata", "secretmanager_openapi_v1.json"), filepath.Join("testdata", "secretmanager_openapi_v1.json")},
+	} {
+		data, err := os.ReadFile(f.src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := filepath.Join(workspaceDir, f.dst)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(dst, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path, err := filepath.Abs(filepath.Join("testdata", "generate_cassette.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c *command.Cassette
+	if *record {
+		testhelper.RequireCommand(t, "cargo")
+		c = &command.Cassette{}
+	} else {
+		if c, err = command.ReadCassette(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.Roots = []*command.Root{
+		{Name: "workspace", Dir: workspaceDir, Output: true},
+		{Name: "googleapis", Dir: googleapisDir},
+	}
+	ctx := c.Replay(t.Context())
+	if *record {
+		ctx = c.Record(t.Context())
+	}
+
+	// Validation builds the crate, which needs its dependencies.
+	oldValidate := validate
+	validate = func(ctx context.Context, outputDir string) error { return nil }
+	t.Cleanup(func() { validate = oldValidate })
+	// The cassette does not record taplo, which only formats Cargo.toml.
+	oldRunTaplo := runTaplo
+	runTaplo = func(ctx context.Context, arg ...string) error { return nil }
+	t.Cleanup(func() { runTaplo = oldRunTaplo })
+
+	t.Chdir(workspaceDir)
+	library := &config.Library{
+		Name:                "google-cloud-secretmanager-v1",
+		Version:             "0.1.0",
+		Output:              "google-cloud-secretmanager-v1",
+		CopyrightYear:       "2025",
+		SpecificationFormat: config.SpecOpenAPI,
+		APIs:                []*config.API{{Path: "google/cloud/secretmanager/v1"}},
+		Rust: &config.RustCrate{
+			RustDefault: config.RustDefault{
+				PackageDependencies: []*config.RustPackageDependency{
+					{Name: "wkt", Package: "google-cloud-wkt", Source: "google.protobuf"},
+					{Name: "location", Package: "google-cloud-location", Source: "google.cloud.location"},
+				},
+			},
+		},
+	}
+	cfg := &config.Config{Language: config.LanguageRust, Repo: "google-cloud-rust"}
+	if err := Generate(ctx, cfg, library, &sources.Sources{Googleapis: googleapisDir}); err != nil {
+		t.Fatal(err)
+	}
+	if *record {
+		if err := command.WriteCassette(path, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if unplayed := c.Unplayed(); !*record && len(unplayed) != 0 {
+		t.Errorf("commands not run: %v", unplayed)
+	}
+	for _, check := range []struct {
+		path string
+		want string
+	}{
+		{filepath.Join(library.Output, "Cargo.toml"), library.Name},
+		{filepath.Join(library.Output, "src", "lib.rs"), "pub mod model;"},
+		{filepath.Join(library.Output, "src", "lib.rs"), "pub mod client;"},
+		{filepath.Join(library.Output, ".repo-metadata.json"), "secretmanager.googleapis.com"},
+	} {
+		got, err := os.ReadFile(check.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(got), check.want) {
+			t.Errorf("%s missing expected string: %q", check.path, check.want)
+		}
+	}
+}
+
 func TestDefaultLibraryName(t *testing.T) {
 	for _, test := range []struct {
 		name string
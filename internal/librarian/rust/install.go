@@ -18,16 +18,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/googleapis/librarian/internal/command"
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/fetch"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
 )
 
 // ErrMissingToolVersion indicates a cargo tool entry is missing its version.
 var ErrMissingToolVersion = errors.New("cargo tool missing version")
 
-// Install installs cargo tool dependencies defined in the tools configuration.
-func Install(ctx context.Context, tools *config.Tools) error {
+// cratesRegistry is the registry from which the .crate files of cargo tools
+// are downloaded.
+var cratesRegistry = "https://static.crates.io/crates"
+
+// Install installs cargo tool dependencies defined in the tools
+// configuration into the cargo root of env. It downloads the .crate file of
+// each tool, verifies and records its checksum in lock, and builds the tool
+// from the verified source.
+func Install(ctx context.Context, env *toolenv.Env, tools *config.Tools, lock *toollock.Lock) error {
 	if tools == nil || len(tools.Cargo) == 0 {
 		return nil
 	}
@@ -35,10 +49,71 @@ func Install(ctx context.Context, tools *config.Tools) error {
 		if tool.Version == "" {
 			return fmt.Errorf("%w: %s", ErrMissingToolVersion, tool.Name)
 		}
-		t := fmt.Sprintf("%s@%s", tool.Name, tool.Version)
-		if err := command.Run(ctx, "cargo", "install", "--locked", t); err != nil {
+		if err := installCrate(ctx, env, tool, lock); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// installCrate downloads the .crate file of tool, verifies its checksum in
+// lock, and installs the tool from the extracted source.
+func installCrate(ctx context.Context, env *toolenv.Env, tool *config.CargoTool, lock *toollock.Lock) error {
+	dir, err := os.MkdirTemp("", "librarian-cargo-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	url := crateURL(tool.Name, tool.Version)
+	crate := filepath.Join(dir, path.Base(url))
+	if err := fetch.File(ctx, crate, url); err != nil {
+		return fmt.Errorf("downloading %s: %w", tool.Name, err)
+	}
+	if err := lock.Verify(toollock.KindCargo, tool.Name, tool.Version, tool.Checksum, crate); err != nil {
+		return err
+	}
+	src := filepath.Join(dir, "src")
+	if err := fetch.ExtractTarball(crate, src, fetch.StripTopLevelDir); err != nil {
+		return fmt.Errorf("extracting %s: %w", crate, err)
+	}
+	return command.Run(ctx, command.Cargo, "install", "--locked", "--root", env.CargoRoot(), "--path", src)
+}
+
+// crateURL returns the URL of the .crate file of a package version in the
+// crates.io registry.
+func crateURL(name, version string) string {
+	return fmt.Sprintf("%s/%s/%s-%s.crate", cratesRegistry, name, name, version)
+}
+
+// InstalledTools returns the tools installed in env by [Install].
+func InstalledTools(ctx context.Context, env *toolenv.Env) ([]*toollock.Tool, error) {
+	output, err := command.Output(ctx, command.Cargo, "install", "--list", "--root", env.CargoRoot())
+	if err != nil {
+		return nil, err
+	}
+	return parseInstallList(output), nil
+}
+
+// parseInstallList parses the output of cargo install --list, which lists
+// each package followed by its indented binaries:
+//
+//	taplo-cli v0.10.0:
+//	    taplo
+func parseInstallList(output string) []*toollock.Tool {
+	var tools []*toollock.Tool
+	for line := range strings.Lines(output) {
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		name, version, ok := strings.Cut(strings.TrimSuffix(strings.TrimSpace(line), ":"), " ")
+		if !ok {
+			continue
+		}
+		tools = append(tools, &toollock.Tool{
+			Kind:    toollock.KindCargo,
+			Name:    name,
+			Version: strings.TrimPrefix(version, "v"),
+		})
+	}
+	return tools
+}
@@ -15,30 +15,167 @@
 package rust
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
 	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/toolenv"
+	"github.com/googleapis/librarian/internal/toollock"
 )
 
-func TestInstall(t *testing.T) {
+// stubCargo installs a cargo stub which logs its arguments to the returned
+// file, replacing the temporary source directory, and serves the .crate file
+// of each package from a fake registry.
+func stubCargo(t *testing.T) string {
+	t.Helper()
 	bin := t.TempDir()
-	if err := os.WriteFile(filepath.Join(bin, "cargo"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+	log := filepath.Join(bin, "cargo.log")
+	stub := fmt.Sprintf(`#!/bin/sh
+echo "$@" | sed 's|[^ ]*librarian-cargo-[^ ]*|*|' >> %q
+`, log)
+	if err := os.WriteFile(filepath.Join(bin, "cargo"), []byte(stub), 0o755); err != nil {
 		t.Fatal(err)
 	}
 	t.Setenv("PATH", bin+":"+os.Getenv("PATH"))
 
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(crateArchive(t, strings.TrimSuffix(path.Base(r.URL.Path), ".crate")))
+	}))
+	t.Cleanup(server.Close)
+	registry := cratesRegistry
+	t.Cleanup(func() { cratesRegistry = registry })
+	cratesRegistry = server.URL
+	return log
+}
+
+// crateArchive returns a .crate file, which is a gzipped tarball of the
+// package source in a top-level directory named after the package version.
+func crateArchive(t *testing.T, dir string) []byte {
+	t.Helper()
+	content := []byte("[package]\n")
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	if err := tw.WriteHeader(&tar.Header{Name: dir + "/Cargo.toml", Mode: 0o644, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestInstall(t *testing.T) {
+	log := stubCargo(t)
 	tools := &config.Tools{
 		Cargo: []*config.CargoTool{
 			{Name: "cargo-semver-checks", Version: "0.46.0"},
 			{Name: "taplo-cli", Version: "0.10.0"},
 		},
 	}
-	if err := Install(t.Context(), tools); err != nil {
+	env := &toolenv.Env{Dir: t.TempDir()}
+	lock := &toollock.Lock{}
+	if err := Install(t.Context(), env, tools, lock); err != nil {
+		t.Fatal(err)
+	}
+	want := []*toollock.Tool{
+		{Kind: toollock.KindCargo, Name: "cargo-semver-checks", Version: "0.46.0", Checksum: sha256Hex(string(crateArchive(t, "cargo-semver-checks-0.46.0")))},
+		{Kind: toollock.KindCargo, Name: "taplo-cli", Version: "0.10.0", Checksum: sha256Hex(string(crateArchive(t, "taplo-cli-0.10.0")))},
+	}
+	if diff := cmp.Diff(want, lock.Tools); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	data, err := os.ReadFile(log)
+	if err != nil {
 		t.Fatal(err)
 	}
+	wantArgs := fmt.Sprintf("install --locked --root %s --path *\ninstall --locked --root %s --path *\n", env.CargoRoot(), env.CargoRoot())
+	if diff := cmp.Diff(wantArgs, string(data)); diff != "" {
+		t.Errorf("cargo arguments mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInstall_ChecksumMismatch(t *testing.T) {
+	log := stubCargo(t)
+	tools := &config.Tools{
+		Cargo: []*config.CargoTool{
+			{Name: "taplo-cli", Version: "0.10.0", Checksum: sha256Hex("other")},
+		},
+	}
+	if err := Install(t.Context(), &toolenv.Env{Dir: t.TempDir()}, tools, &toollock.Lock{}); !errors.Is(err, toollock.ErrChecksumMismatch) {
+		t.Errorf("Install() error = %v, want %v", err, toollock.ErrChecksumMismatch)
+	}
+	if _, err := os.Stat(log); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("cargo ran for a crate that failed verification: %v", err)
+	}
+}
+
+func TestCrateURL(t *testing.T) {
+	got := crateURL("taplo-cli", "0.10.0")
+	want := "https://static.crates.io/crates/taplo-cli/taplo-cli-0.10.0.crate"
+	if got != want {
+		t.Errorf("crateURL() = %q, want %q", got, want)
+	}
+}
+
+func TestParseInstallList(t *testing.T) {
+	output := `cargo-semver-checks v0.46.0:
+    cargo-semver-checks
+taplo-cli v0.10.0:
+    taplo
+`
+	got := parseInstallList(output)
+	want := []*toollock.Tool{
+		{Kind: toollock.KindCargo, Name: "cargo-semver-checks", Version: "0.46.0"},
+		{Kind: toollock.KindCargo, Name: "taplo-cli", Version: "0.10.0"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInstalledTools(t *testing.T) {
+	bin := t.TempDir()
+	stub := `#!/bin/sh
+echo "$@" >&2
+[ "$1 $2 $3" = "install --list --root" ] || exit 1
+printf 'taplo-cli v0.10.0:\n    taplo\n'
+`
+	if err := os.WriteFile(filepath.Join(bin, "cargo"), []byte(stub), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+":"+os.Getenv("PATH"))
+	got, err := InstalledTools(t.Context(), &toolenv.Env{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*toollock.Tool{{Kind: toollock.KindCargo, Name: "taplo-cli", Version: "0.10.0"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func sha256Hex(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
 }
 
 func TestInstall_MissingVersion(t *testing.T) {
@@ -47,7 +184,7 @@ func TestInstall_MissingVersion(t *testing.T) {
 			{Name: "some-tool"},
 		},
 	}
-	err := Install(t.Context(), tools)
+	err := Install(t.Context(), &toolenv.Env{Dir: t.TempDir()}, tools, &toollock.Lock{})
 	if !errors.Is(err, ErrMissingToolVersion) {
 		t.Fatalf("got %v, want %v", err, ErrMissingToolVersion)
 	}
@@ -67,7 +204,7 @@ func TestInstall_NoCargoTools(t *testing.T) {
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
-			if err := Install(t.Context(), test.tools); err != nil {
+			if err := Install(t.Context(), &toolenv.Env{Dir: t.TempDir()}, test.tools, &toollock.Lock{}); err != nil {
 				t.Fatal(err)
 			}
 		})
@@ -44,7 +44,7 @@ func ResolveDependencies(ctx context.Context, cfg *config.Config, lib *config.Li
 func findExternalPackages(lib *config.Library, sources *sources.Sources) (map[string]bool, error) {
 	// Only resolve dependencies for the first API in the library.
 	// This is consistent with how the Rust generator works.
-	modelConfig, err := libraryToModelConfig(lib, lib.APIs[0], sources)
+	modelConfig, err := ModelConfig(lib, lib.APIs[0], sources)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create model config: %w", err)
 	}
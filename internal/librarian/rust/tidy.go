@@ -15,11 +15,17 @@
 package rust
 
 import (
+	"errors"
+	"fmt"
 	"slices"
 
 	"github.com/googleapis/librarian/internal/config"
+	sidekickrust "github.com/googleapis/librarian/internal/sidekick/rust"
+	"github.com/googleapis/librarian/internal/sidekick/rust_prost"
 )
 
+var errInvalidCodecOptions = errors.New("invalid Rust codec options")
+
 // Tidy tidies the Rust-specific configuration for a library.
 func Tidy(lib *config.Library) (*config.Library, error) {
 	if lib.Rust != nil && lib.Rust.Modules != nil {
@@ -29,6 +35,63 @@ func Tidy(lib *config.Library) (*config.Library, error) {
 	return lib, nil
 }
 
+// Validate checks that the Rust-specific configuration for a library yields
+// valid codec options, for the library and for each of its modules generated
+// by the Rust codec.
+func Validate(lib *config.Library) error {
+	opts, err := sidekickrust.ParseCodecOptions(buildCodec(lib, ""))
+	if err != nil {
+		return fmt.Errorf("%w: library %q: %w", errInvalidCodecOptions, lib.Name, err)
+	}
+	if err := sidekickrust.ValidateCodecOptions(opts); err != nil {
+		return fmt.Errorf("%w: library %q: %w", errInvalidCodecOptions, lib.Name, err)
+	}
+	if err := CheckTemplateOverlay(lib, lib.TemplateOverlay); err != nil {
+		return err
+	}
+	if lib.Rust == nil {
+		return nil
+	}
+	for _, module := range lib.Rust.Modules {
+		if isProstModule(module) {
+			if err := validateProstModule(lib, module); err != nil {
+				return fmt.Errorf("%w: library %q, module %q: %w", errInvalidCodecOptions, lib.Name, module.Output, err)
+			}
+			continue
+		}
+		if _, err := sidekickrust.ParseCodecOptions(buildModuleCodec(lib, module)); err != nil {
+			return fmt.Errorf("%w: library %q, module %q: %w", errInvalidCodecOptions, lib.Name, module.Output, err)
+		}
+	}
+	return nil
+}
+
+// validateProstModule checks the options of a module generated by the
+// rust_prost codec.
+func validateProstModule(lib *config.Library, module *config.RustModule) error {
+	opts, err := rust_prost.ParseCodecOptions(buildProstCodec(lib, module))
+	if err != nil {
+		return err
+	}
+	return rust_prost.ValidateCodecOptions(opts)
+}
+
+// ValidateDefault checks that the Rust codec options in the default
+// configuration are valid.
+func ValidateDefault(d *config.Default) error {
+	if d.Rust == nil || d.Rust.Codec == nil {
+		return nil
+	}
+	opts, err := sidekickrust.ParseCodecOptions(sidekickrust.FormatCodecOptions(d.Rust.Codec))
+	if err == nil {
+		err = sidekickrust.ValidateCodecOptions(opts)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: default: %w", errInvalidCodecOptions, err)
+	}
+	return nil
+}
+
 // isEmptyModule returns true if the module is a placeholder that can be removed.
 func isEmptyModule(module *config.RustModule) bool {
 	if module.Template == "storage" {
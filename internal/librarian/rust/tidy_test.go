@@ -15,6 +15,7 @@
 package rust
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/googleapis/librarian/internal/config"
@@ -74,3 +75,141 @@ func TestTidyLanguageConfig_Rust(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate_CodecOptions(t *testing.T) {
+	lib := &config.Library{
+		Name: "google-cloud-storage",
+		Rust: &config.RustCrate{
+			RustDefault: config.RustDefault{
+				PackageDependencies: []*config.RustPackageDependency{
+					{Name: "wkt", Package: "google-cloud-wkt", Source: "google.protobuf"},
+				},
+				GenerateSetterSamples: "true",
+			},
+			Modules: []*config.RustModule{
+				{Output: "src/storage/src/generated/gapic", GenerateSetterSamples: "false"},
+				{Output: "src/storage/src/generated/protos", Template: "prost", PostProcessProtos: "true"},
+			},
+		},
+	}
+	if err := Validate(lib); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidate_CodecOptionsError(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		lib  *config.Library
+	}{
+		{
+			name: "invalid library option",
+			lib: &config.Library{
+				Name: "google-cloud-secretmanager-v1",
+				Rust: &config.RustCrate{
+					RustDefault: config.RustDefault{GenerateSetterSamples: "yes"},
+				},
+			},
+		},
+		{
+			name: "invalid module option",
+			lib: &config.Library{
+				Name: "google-cloud-storage",
+				Rust: &config.RustCrate{
+					Modules: []*config.RustModule{
+						{Output: "src/storage/src/generated/gapic", GenerateRpcSamples: "maybe"},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid codec option",
+			lib: &config.Library{
+				Name: "google-cloud-secretmanager-v1",
+				Rust: &config.RustCrate{
+					RustDefault: config.RustDefault{
+						Codec: &config.RustCodec{TemplateOverride: "templates/missing"},
+					},
+				},
+			},
+		},
+		{
+			name: "prost module with unknown root",
+			lib: &config.Library{
+				Name: "google-cloud-wkt",
+				Rust: &config.RustCrate{
+					Modules: []*config.RustModule{
+						{Output: "src/wkt/src/generated", Template: "prost", RootName: "protobuf"},
+					},
+				},
+			},
+		},
+		{
+			name: "package dependency without package",
+			lib: &config.Library{
+				Name: "google-cloud-secretmanager-v1",
+				Rust: &config.RustCrate{
+					RustDefault: config.RustDefault{
+						PackageDependencies: []*config.RustPackageDependency{
+							{Name: "wkt", Source: "google.protobuf"},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := Validate(test.lib); !errors.Is(err, errInvalidCodecOptions) {
+				t.Errorf("Validate() error = %v, want %v", err, errInvalidCodecOptions)
+			}
+		})
+	}
+}
+
+func TestValidate_ProstTemplateOverlay(t *testing.T) {
+	lib := &config.Library{
+		Name:            "google-cloud-storage",
+		TemplateOverlay: "overlay",
+		Rust: &config.RustCrate{
+			Modules: []*config.RustModule{
+				{Output: "src/storage/src/generated/gapic"},
+				{Output: "src/storage/src/generated/protos", Template: "prost"},
+			},
+		},
+	}
+	if err := Validate(lib); !errors.Is(err, ErrProstTemplateOverlay) {
+		t.Errorf("Validate() error = %v, want %v", err, ErrProstTemplateOverlay)
+	}
+}
+
+func TestValidateDefault(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		d       *config.Default
+		wantErr error
+	}{
+		{
+			name: "no codec",
+			d:    &config.Default{Rust: &config.RustDefault{}},
+		},
+		{
+			name: "valid codec",
+			d: &config.Default{Rust: &config.RustDefault{
+				Codec: &config.RustCodec{GenerateSetterSamples: true},
+			}},
+		},
+		{
+			name: "invalid codec",
+			d: &config.Default{Rust: &config.RustDefault{
+				Codec: &config.RustCodec{Packages: []*config.RustCodecPackage{{Name: "gax"}}},
+			}},
+			wantErr: errInvalidCodecOptions,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ValidateDefault(test.d); !errors.Is(err, test.wantErr) {
+				t.Errorf("ValidateDefault() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
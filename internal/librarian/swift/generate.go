@@ -18,6 +18,7 @@ package swift
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strings"
 
 	"github.com/googleapis/librarian/internal/command"
@@ -37,7 +38,7 @@ func Generate(ctx context.Context, cfg *config.Config, library *config.Library,
 	if len(library.APIs) != 1 {
 		return fmt.Errorf("the Swift generator only supports a single api per library")
 	}
-	modelConfig, err := libraryToModelConfig(library, library.APIs[0], src)
+	modelConfig, err := ModelConfig(library, library.APIs[0], src)
 	if err != nil {
 		return err
 	}
@@ -74,7 +75,8 @@ func camelLibraryName(api string) string {
 	return name.String()
 }
 
-func libraryToModelConfig(library *config.Library, api *config.API, src *sources.Sources) (*parser.ModelConfig, error) {
+// ModelConfig returns the parser configuration for the given API in library.
+func ModelConfig(library *config.Library, api *config.API, src *sources.Sources) (*parser.ModelConfig, error) {
 	svcConfig, err := serviceconfig.Find(src.Googleapis, api.Path, config.LanguageSwift)
 	if err != nil {
 		return nil, err
@@ -95,9 +97,22 @@ func libraryToModelConfig(library *config.Library, api *config.API, src *sources
 		ServiceConfig:       svcConfig.ServiceConfig,
 		SpecificationSource: api.Path,
 		Source:              sourceConfig,
-		Codec: map[string]string{
-			"copyright-year": library.CopyrightYear,
-			"version":        library.Version,
-		},
+		Codec:               buildCodec(library),
+		Transformations:     parser.Transformations(library.Transforms),
 	}, nil
 }
+
+// buildCodec returns the Swift codec options for the package of library.
+func buildCodec(library *config.Library) map[string]string {
+	codec := map[string]string{
+		"copyright-year": library.CopyrightYear,
+		"version":        library.Version,
+	}
+	if library.TemplateOverlay != "" {
+		codec["template-overlay"] = library.TemplateOverlay
+	}
+	if library.Swift != nil && library.Swift.Codec != nil {
+		maps.Copy(codec, sidekickswift.FormatCodecOptions(library.Swift.Codec))
+	}
+	return codec
+}
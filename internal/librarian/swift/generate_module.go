@@ -47,13 +47,17 @@ func moduleToModelConfig(library *config.Library, module *config.SwiftModule, sr
 		specFormat = library.SpecificationFormat
 	}
 
+	codec := map[string]string{
+		"copyright-year": library.CopyrightYear,
+		"module":         "true",
+	}
+	if library.TemplateOverlay != "" {
+		codec["template-overlay"] = library.TemplateOverlay
+	}
 	return &parser.ModelConfig{
 		SpecificationFormat: specFormat,
 		SpecificationSource: module.APIPath,
 		Source:              sourceConfig,
-		Codec: map[string]string{
-			"copyright-year": library.CopyrightYear,
-			"module":         "true",
-		},
+		Codec:               codec,
 	}
 }
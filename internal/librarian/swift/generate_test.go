@@ -134,7 +134,7 @@ func TestFormat(t *testing.T) {
 	}
 }
 
-func TestLibraryToModelConfig(t *testing.T) {
+func TestModelConfig(t *testing.T) {
 	for _, test := range []struct {
 		name    string
 		library *config.Library
@@ -184,6 +184,28 @@ func TestLibraryToModelConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "template overlay",
+			library: &config.Library{
+				Name:            "google-cloud-secretmanager",
+				CopyrightYear:   "2038",
+				Version:         "1.2.3",
+				TemplateOverlay: "overlays/swift",
+			},
+			api: &config.API{
+				Path: "google/cloud/secretmanager/v1",
+			},
+			want: &parser.ModelConfig{
+				Language:            config.LanguageSwift,
+				SpecificationFormat: config.SpecProtobuf,
+				SpecificationSource: "google/cloud/secretmanager/v1",
+				ServiceConfig:       "google/cloud/secretmanager/v1/secretmanager_v1.yaml",
+				Codec:               map[string]string{"copyright-year": "2038", "version": "1.2.3", "template-overlay": "overlays/swift"},
+				Source: &sources.SourceConfig{
+					ActiveRoots: []string{"googleapis"},
+				},
+			},
+		},
 		{
 			name: "discovery config",
 			library: &config.Library{
@@ -219,7 +241,7 @@ func TestLibraryToModelConfig(t *testing.T) {
 			// Avoid typing this in every input
 			test.want.Source.Sources = srcs
 
-			got, err := libraryToModelConfig(test.library, test.api, srcs)
+			got, err := ModelConfig(test.library, test.api, srcs)
 			if err != nil {
 				t.Fatal(err)
 			}
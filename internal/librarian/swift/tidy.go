@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swift
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/googleapis/librarian/internal/config"
+	sidekickswift "github.com/googleapis/librarian/internal/sidekick/swift"
+)
+
+var errInvalidCodecOptions = errors.New("invalid Swift codec options")
+
+// Validate checks that the Swift-specific configuration for a library yields
+// valid codec options.
+func Validate(lib *config.Library) error {
+	opts, err := sidekickswift.ParseCodecOptions(buildCodec(lib))
+	if err == nil {
+		err = sidekickswift.ValidateCodecOptions(opts)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: library %q: %w", errInvalidCodecOptions, lib.Name, err)
+	}
+	return nil
+}
+
+// ValidateDefault checks that the Swift codec options in the default
+// configuration are valid.
+func ValidateDefault(d *config.Default) error {
+	if d.Swift == nil || d.Swift.Codec == nil {
+		return nil
+	}
+	if err := sidekickswift.ValidateCodecOptions(d.Swift.Codec); err != nil {
+		return fmt.Errorf("%w: default: %w", errInvalidCodecOptions, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swift
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/googleapis/librarian/internal/config"
+)
+
+func TestValidate(t *testing.T) {
+	lib := &config.Library{
+		Name:          "google-cloud-secretmanager-v1",
+		CopyrightYear: "2038",
+		Version:       "1.2.3",
+		Swift: &config.SwiftPackage{
+			SwiftDefault: config.SwiftDefault{
+				Codec: &config.SwiftCodec{PackageNameOverride: "GoogleCloudSecretManager"},
+			},
+		},
+	}
+	if err := Validate(lib); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidate_Error(t *testing.T) {
+	lib := &config.Library{
+		Name:            "google-cloud-secretmanager-v1",
+		TemplateOverlay: "testdata/missing",
+	}
+	if err := Validate(lib); !errors.Is(err, errInvalidCodecOptions) {
+		t.Errorf("Validate() error = %v, want %v", err, errInvalidCodecOptions)
+	}
+}
+
+func TestValidateDefault(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		d       *config.Default
+		wantErr error
+	}{
+		{
+			name: "no codec",
+			d:    &config.Default{Swift: &config.SwiftDefault{}},
+		},
+		{
+			name: "valid codec",
+			d: &config.Default{Swift: &config.SwiftDefault{
+				Codec: &config.SwiftCodec{RootName: "googleapis"},
+			}},
+		},
+		{
+			name: "invalid codec",
+			d: &config.Default{Swift: &config.SwiftDefault{
+				Codec: &config.SwiftCodec{TemplateOverlay: "testdata/missing"},
+			}},
+			wantErr: errInvalidCodecOptions,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ValidateDefault(test.d); !errors.Is(err, test.wantErr) {
+				t.Errorf("ValidateDefault() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
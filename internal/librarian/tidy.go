@@ -23,11 +23,13 @@ import (
 	"strings"
 
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/librarian/dart"
 	"github.com/googleapis/librarian/internal/librarian/golang"
 	"github.com/googleapis/librarian/internal/librarian/java"
 	"github.com/googleapis/librarian/internal/librarian/nodejs"
 	"github.com/googleapis/librarian/internal/librarian/python"
 	"github.com/googleapis/librarian/internal/librarian/rust"
+	"github.com/googleapis/librarian/internal/librarian/swift"
 	"github.com/googleapis/librarian/internal/serviceconfig"
 	"github.com/googleapis/librarian/internal/yaml"
 	"github.com/urfave/cli/v3"
@@ -37,6 +39,7 @@ var (
 	errDuplicateLibraryName  = errors.New("duplicate library name")
 	errDuplicateAPIPath      = errors.New("duplicate api path")
 	errNoGoogleapiSourceInfo = errors.New("googleapis source not configured in librarian.yaml")
+	errTransformsUnsupported = errors.New("transforms are not supported by the generator of this language")
 
 	// javaSkipDuplicatePaths lists special API paths that are allowed to appear in multiple
 	// libraries in Java without triggering the duplicate API path error.
@@ -78,6 +81,9 @@ func RunTidyOnConfig(ctx context.Context, repoDir string, cfg *config.Config) er
 	if err := validateTools(cfg); err != nil {
 		return err
 	}
+	if err := validateDefault(cfg); err != nil {
+		return err
+	}
 	if err := validateLibraries(cfg); err != nil {
 		return err
 	}
@@ -147,6 +153,22 @@ func validateTools(cfg *config.Config) error {
 	return nil
 }
 
+// validateDefault validates the language-specific default configuration.
+func validateDefault(cfg *config.Config) error {
+	if cfg.Default == nil {
+		return nil
+	}
+	switch cfg.Language {
+	case config.LanguageDart:
+		return dart.ValidateDefault(cfg.Default)
+	case config.LanguageRust:
+		return rust.ValidateDefault(cfg.Default)
+	case config.LanguageSwift:
+		return swift.ValidateDefault(cfg.Default)
+	}
+	return nil
+}
+
 func validateLibraries(cfg *config.Config) error {
 	var (
 		errs      []error
@@ -168,6 +190,9 @@ func validateLibraries(cfg *config.Config) error {
 		if err := validateLanguageConfig(lib, cfg.Language); err != nil {
 			errs = append(errs, err)
 		}
+		if err := validateTransforms(lib, cfg.Language); err != nil {
+			errs = append(errs, err)
+		}
 	}
 	for name, count := range nameCount {
 		if count > 1 {
@@ -185,10 +210,30 @@ func validateLibraries(cfg *config.Config) error {
 	return nil
 }
 
+// validateTransforms returns an error if lib has transforms, but is generated
+// by a generator that does not build the sidekick API model, and would
+// silently ignore them. Only the Dart, Rust and Swift generators, and the
+// sidekick TypeScript generator for Node.js, apply transforms.
+func validateTransforms(lib *config.Library, language string) error {
+	if len(lib.Transforms) == 0 {
+		return nil
+	}
+	switch {
+	case language == config.LanguageDart, language == config.LanguageRust, language == config.LanguageSwift:
+		return nil
+	case language == config.LanguageNodejs && nodejs.UsesSidekick(lib):
+		return nil
+	}
+	return fmt.Errorf("%w: library %s, language %q", errTransformsUnsupported, lib.Name, language)
+}
+
 // languageValidators maps a language to a function that validates the language-specific
 // configuration.
 var languageValidators = map[string]func(*config.Library) error{
-	config.LanguageJava: java.Validate,
+	config.LanguageDart:  dart.Validate,
+	config.LanguageJava:  java.Validate,
+	config.LanguageRust:  rust.Validate,
+	config.LanguageSwift: swift.Validate,
 }
 
 // validateLanguageConfig finds and executes the language-specific validator for a library.
@@ -236,6 +281,7 @@ func isDefaultEmpty(defaults *config.Default) bool {
 	return len(defaults.Keep) == 0 &&
 		defaults.Output == "" &&
 		defaults.TagFormat == "" &&
+		defaults.TemplateOverlay == "" &&
 		defaults.Dotnet == nil &&
 		defaults.Dart == nil &&
 		defaults.Java == nil &&
@@ -81,6 +81,50 @@ func TestValidateLibraries(t *testing.T) {
 			language: config.LanguagePython,
 			wantErr:  errDuplicateAPIPath,
 		},
+		{
+			name: "valid rust codec options",
+			libraries: []*config.Library{
+				{
+					Name: "google-cloud-secretmanager-v1",
+					Rust: &config.RustCrate{
+						RustDefault: config.RustDefault{GenerateSetterSamples: "true"},
+					},
+				},
+			},
+			language: config.LanguageRust,
+		},
+		{
+			name: "transforms with sidekick generator",
+			libraries: []*config.Library{
+				{
+					Name:       "google-cloud-secretmanager-v1",
+					Transforms: []*config.Transform{{ID: ".google.cloud.secretmanager.v1.Secret", Deprecate: true}},
+				},
+			},
+			language: config.LanguageRust,
+		},
+		{
+			name: "transforms with sidekick typescript generator",
+			libraries: []*config.Library{
+				{
+					Name:       "google-cloud-secretmanager-v1",
+					Nodejs:     &config.NodejsPackage{Generator: "sidekick"},
+					Transforms: []*config.Transform{{ID: ".google.cloud.secretmanager.v1.Secret", Deprecate: true}},
+				},
+			},
+			language: config.LanguageNodejs,
+		},
+		{
+			name: "transforms ignored by generator",
+			libraries: []*config.Library{
+				{
+					Name:       "google-cloud-secretmanager-v1",
+					Transforms: []*config.Transform{{ID: ".google.cloud.secretmanager.v1.Secret", Deprecate: true}},
+				},
+			},
+			language: config.LanguagePython,
+			wantErr:  errTransformsUnsupported,
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			cfg := &config.Config{
@@ -754,6 +798,18 @@ func TestTidy_UnusedSections(t *testing.T) {
 			wantTools:   true,
 			wantDefault: true,
 		},
+		{
+			name: "template overlay preserved",
+			cfg: &config.Config{
+				Language: config.LanguageRust,
+				Sources: &config.Sources{
+					Googleapis: &config.Source{Commit: "commit"},
+				},
+				Default: &config.Default{TemplateOverlay: "overlays"},
+			},
+			wantTools:   false,
+			wantDefault: true,
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			tempDir := t.TempDir()
@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarianops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// The steps of processRepo, reported in the summary when they fail.
+const (
+	stepClone        = "clone"
+	stepBranch       = "branch"
+	stepConfig       = "config"
+	stepTidy         = "tidy"
+	stepUpdate       = "update"
+	stepGenerate     = "generate"
+	stepPostGenerate = "post-generate"
+	stepCommit       = "commit"
+	stepPush         = "push"
+	stepPullRequest  = "pull-request"
+)
+
+var errBatchFailed = errors.New("failed to process repositories")
+
+// repoResult is the outcome of processing a repository.
+type repoResult struct {
+	// Repo is the name of the repository.
+	Repo string
+	// Branch is the branch with the generated changes.
+	Branch string
+	// PullRequest is the URL of the pull request, if one was created.
+	PullRequest string
+	// Libraries are the names of the libraries with generated changes.
+	Libraries []string
+	// FailedStep is the step that failed, if any.
+	FailedStep string
+	// Err is the error returned by the failed step.
+	Err error
+}
+
+// runBatch processes the repositories with at most jobs running in parallel.
+// Each repository is processed by process in its own directory, and a
+// failure does not stop the other repositories. The summary is printed to w
+// and, if summaryPath is set, written to that file.
+func runBatch(ctx context.Context, w io.Writer, repos []*repository, jobs int, summaryPath string, process func(context.Context, *repository) (*repoResult, error)) error {
+	if jobs < 1 {
+		return fmt.Errorf("jobs must be at least 1, got %d", jobs)
+	}
+	results := make([]*repoResult, len(repos))
+	var g errgroup.Group
+	g.SetLimit(jobs)
+	for i, repo := range repos {
+		g.Go(func() error {
+			result, err := process(ctx, repo)
+			if result == nil {
+				result = &repoResult{Repo: repo.Name, Err: err}
+			}
+			results[i] = result
+			return nil
+		})
+	}
+	g.Wait()
+
+	summary, err := formatSummary(results)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(summary); err != nil {
+		return err
+	}
+	if summaryPath != "" {
+		if err := os.WriteFile(summaryPath, summary, 0644); err != nil {
+			return err
+		}
+	}
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d failed", errBatchFailed, failed, len(results))
+	}
+	return nil
+}
+
+// formatSummary returns a table with a row per repository, followed by the
+// errors of the repositories that failed.
+func formatSummary(results []*repoResult) ([]byte, error) {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REPOSITORY\tBRANCH\tPULL REQUEST\tLIBRARIES CHANGED\tFAILED STEP")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n",
+			result.Repo,
+			orDash(result.Branch),
+			orDash(result.PullRequest),
+			len(result.Libraries),
+			orDash(result.FailedStep))
+	}
+	if err := tw.Flush(); err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(&buf, "\n%s: %s\n", result.Repo, strings.TrimSpace(result.Err.Error()))
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
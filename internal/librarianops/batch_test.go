@@ -0,0 +1,117 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarianops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunBatch(t *testing.T) {
+	repos := []*repository{
+		{Name: "google-cloud-go"},
+		{Name: "google-cloud-python"},
+		{Name: "google-cloud-rust"},
+	}
+	process := func(ctx context.Context, repo *repository) (*repoResult, error) {
+		if repo.Name == "google-cloud-python" {
+			err := errors.New("exit status 1")
+			return &repoResult{
+				Repo:       repo.Name,
+				Branch:     "librarianops-generateall-20260213T154950Z",
+				FailedStep: stepGenerate,
+				Err:        err,
+			}, err
+		}
+		return &repoResult{
+			Repo:        repo.Name,
+			Branch:      "librarianops-generateall-20260213T154950Z",
+			PullRequest: "https://github.com/googleapis/" + repo.Name + "/pull/1",
+			Libraries:   []string{"secretmanager", "storage"},
+		}, nil
+	}
+	summaryPath := filepath.Join(t.TempDir(), "summary.txt")
+	var out bytes.Buffer
+	err := runBatch(t.Context(), &out, repos, 2, summaryPath, process)
+	if !errors.Is(err, errBatchFailed) {
+		t.Errorf("runBatch() error = %v, wantErr %v", err, errBatchFailed)
+	}
+	want := `REPOSITORY           BRANCH                                     PULL REQUEST                                            LIBRARIES CHANGED  FAILED STEP
+google-cloud-go      librarianops-generateall-20260213T154950Z  https://github.com/googleapis/google-cloud-go/pull/1    2                  -
+google-cloud-python  librarianops-generateall-20260213T154950Z  -                                                       0                  generate
+google-cloud-rust    librarianops-generateall-20260213T154950Z  https://github.com/googleapis/google-cloud-rust/pull/1  2                  -
+
+google-cloud-python: exit status 1
+`
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("summary mismatch (-want +got):\n%s", diff)
+	}
+	got, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("summary file mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunBatch_Jobs(t *testing.T) {
+	var repos []*repository
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		repos = append(repos, &repository{Name: name})
+	}
+	var (
+		mu               sync.Mutex
+		running, maxSeen int
+		release          = make(chan struct{})
+		once             sync.Once
+	)
+	process := func(ctx context.Context, repo *repository) (*repoResult, error) {
+		mu.Lock()
+		running++
+		maxSeen = max(maxSeen, running)
+		if running == 2 {
+			once.Do(func() { close(release) })
+		}
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return &repoResult{Repo: repo.Name}, nil
+	}
+	if err := runBatch(t.Context(), &bytes.Buffer{}, repos, 2, "", process); err != nil {
+		t.Fatal(err)
+	}
+	if maxSeen != 2 {
+		t.Errorf("max parallel repositories = %d, want 2", maxSeen)
+	}
+}
+
+func TestRunBatch_InvalidJobs(t *testing.T) {
+	process := func(ctx context.Context, repo *repository) (*repoResult, error) {
+		return &repoResult{Repo: repo.Name}, nil
+	}
+	if err := runBatch(t.Context(), &bytes.Buffer{}, []*repository{{Name: "a"}}, 0, "", process); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
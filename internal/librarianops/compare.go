@@ -0,0 +1,217 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarianops
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/librarian"
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+// maxListed is the maximum number of items listed in each section of the
+// report, which is used as a pull request description.
+const maxListed = 100
+
+// librarianRunner runs the given version of librarian in repoDir.
+type librarianRunner func(ctx context.Context, repoDir, version string, args ...string) error
+
+// generation is the outcome of running tidy and generating every library
+// with one version of librarian.
+type generation struct {
+	// version is the librarian version.
+	version string
+	// tidyErr is the error returned by librarian tidy, if any.
+	tidyErr error
+	// failed maps the name of each library that failed to generate to its
+	// error.
+	failed map[string]error
+	// files maps the path of each file in the repository, relative to the
+	// repository root, to its SHA-256 checksum.
+	files map[string]string
+}
+
+// generateAll runs librarian tidy, then generates each library of the
+// repository in repoDir separately so that failures can be attributed to
+// libraries, and takes a snapshot of the result.
+func generateAll(ctx context.Context, repoDir, version string, run librarianRunner) (*generation, error) {
+	gen := &generation{version: version, failed: map[string]error{}}
+	gen.tidyErr = run(ctx, repoDir, version, "tidy")
+	cfg, err := yaml.Read[config.Config](filepath.Join(repoDir, config.LibrarianYAML))
+	if err != nil {
+		return nil, err
+	}
+	for _, library := range cfg.Libraries {
+		if library.SkipGenerate {
+			continue
+		}
+		if err := run(ctx, repoDir, version, "generate", library.Name); err != nil {
+			gen.failed[library.Name] = err
+		}
+	}
+	gen.files, err = snapshot(ctx, repoDir)
+	if err != nil {
+		return nil, err
+	}
+	return gen, nil
+}
+
+// snapshot returns the SHA-256 checksum of each file in dir, keyed by its
+// slash-separated path relative to dir. Only the files tracked by git, or
+// untracked but not ignored, are included. librarian.yaml, which records the
+// librarian version, is skipped.
+func snapshot(ctx context.Context, dir string) (map[string]string, error) {
+	output, err := command.OutputInDir(ctx, dir, command.Git, "ls-files", "-z", "--cached", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files in %s: %w", dir, err)
+	}
+	files := map[string]string{}
+	for _, rel := range strings.Split(output, "\x00") {
+		if rel == "" || rel == config.LibrarianYAML {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		if errors.Is(err, fs.ErrNotExist) {
+			// The file is in the index, but the generator removed it.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(content)
+		files[rel] = hex.EncodeToString(sum[:])
+	}
+	return files, nil
+}
+
+// upgradeReport compares the generation with the current librarian version
+// to the generation with the new version.
+type upgradeReport struct {
+	// oldVersion and newVersion are the librarian versions compared.
+	oldVersion, newVersion string
+	// tidyErr is the error of librarian tidy, if it failed only with the
+	// new version.
+	tidyErr error
+	// newFailures maps the libraries that failed only with the new version
+	// to their error.
+	newFailures map[string]error
+	// fixed lists the libraries that failed only with the old version.
+	fixed []string
+	// changedFiles lists the files added, removed or modified by the new
+	// version.
+	changedFiles []string
+	// changedLibraries lists the libraries with changed files.
+	changedLibraries []string
+}
+
+// compareGenerations compares the generations of the repository in repoDir
+// with the old and new librarian versions.
+func compareGenerations(repoDir string, old, newGen *generation) (*upgradeReport, error) {
+	report := &upgradeReport{
+		oldVersion:  old.version,
+		newVersion:  newGen.version,
+		newFailures: map[string]error{},
+	}
+	if old.tidyErr == nil {
+		report.tidyErr = newGen.tidyErr
+	}
+	for name, err := range newGen.failed {
+		if _, ok := old.failed[name]; !ok {
+			report.newFailures[name] = err
+		}
+	}
+	for name := range old.failed {
+		if _, ok := newGen.failed[name]; !ok {
+			report.fixed = append(report.fixed, name)
+		}
+	}
+	slices.Sort(report.fixed)
+	for path, sum := range newGen.files {
+		if old.files[path] != sum {
+			report.changedFiles = append(report.changedFiles, path)
+		}
+	}
+	for path := range old.files {
+		if _, ok := newGen.files[path]; !ok {
+			report.changedFiles = append(report.changedFiles, path)
+		}
+	}
+	slices.Sort(report.changedFiles)
+	cfg, err := yaml.Read[config.Config](filepath.Join(repoDir, config.LibrarianYAML))
+	if err != nil {
+		return nil, err
+	}
+	report.changedLibraries = librarian.ChangedLibraries(cfg, report.changedFiles)
+	return report, nil
+}
+
+// regressed reports whether the new version failed where the old version
+// succeeded.
+func (r *upgradeReport) regressed() bool {
+	return r.tidyErr != nil || len(r.newFailures) > 0
+}
+
+// String formats the report in Markdown, for use in pull request
+// descriptions.
+func (r *upgradeReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Upgrade librarian from %s to %s\n\n", r.oldVersion, r.newVersion)
+	if r.regressed() {
+		fmt.Fprintf(&b, "**%s regresses generation.**\n\n", r.newVersion)
+	}
+	fmt.Fprintf(&b, "- Files changed: %d\n", len(r.changedFiles))
+	fmt.Fprintf(&b, "- Libraries changed: %d\n", len(r.changedLibraries))
+	fmt.Fprintf(&b, "- Libraries failing only with %s: %d\n", r.newVersion, len(r.newFailures))
+	fmt.Fprintf(&b, "- Libraries fixed by %s: %d\n", r.newVersion, len(r.fixed))
+	if r.tidyErr != nil {
+		fmt.Fprintf(&b, "\n### librarian tidy fails with %s\n\n```\n%s\n```\n", r.newVersion, strings.TrimSpace(r.tidyErr.Error()))
+	}
+	if len(r.newFailures) > 0 {
+		fmt.Fprintf(&b, "\n### Libraries failing only with %s\n\n", r.newVersion)
+		for _, name := range slices.Sorted(maps.Keys(r.newFailures)) {
+			msg := strings.ReplaceAll(strings.TrimSpace(r.newFailures[name].Error()), "\n", " ")
+			fmt.Fprintf(&b, "- %s: `%s`\n", name, msg)
+		}
+	}
+	writeList(&b, "Libraries fixed by "+r.newVersion, r.fixed)
+	writeList(&b, "Libraries changed", r.changedLibraries)
+	writeList(&b, "Files changed", r.changedFiles)
+	return b.String()
+}
+
+func writeList(b *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n### %s\n\n", title)
+	for _, item := range items[:min(len(items), maxListed)] {
+		fmt.Fprintf(b, "- %s\n", item)
+	}
+	if len(items) > maxListed {
+		fmt.Fprintf(b, "- ... and %d more\n", len(items)-maxListed)
+	}
+}
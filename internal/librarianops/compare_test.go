@@ -0,0 +1,88 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarianops
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/testhelper"
+)
+
+func TestSnapshot(t *testing.T) {
+	testhelper.RequireCommand(t, command.Git)
+	dir := t.TempDir()
+	writeFiles := func(paths ...string) {
+		for _, path := range paths {
+			full := filepath.Join(dir, path)
+			if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(full, []byte(path), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	writeFiles(config.LibrarianYAML, ".gitignore", "README.md", "src/storage/lib.rs", "src/deleted.rs")
+	testhelper.RunGit(t, "init", "-b", config.BranchMain, dir)
+	testhelper.RunGit(t, "-C", dir, "add", ".")
+	testhelper.RunGit(t, "-C", dir, "-c", "user.name=Test User", "-c", "user.email=test@example.com", "commit", "-m", "initial commit")
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("target/\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "src/deleted.rs")); err != nil {
+		t.Fatal(err)
+	}
+	writeFiles("src/new.rs", "target/debug/build.log")
+
+	files, err := snapshot(t.Context(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := slices.Sorted(maps.Keys(files))
+	want := []string{".gitignore", "README.md", "src/new.rs", "src/storage/lib.rs"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSnapshot_Error(t *testing.T) {
+	testhelper.RequireCommand(t, command.Git)
+	if _, err := snapshot(t.Context(), t.TempDir()); err == nil {
+		t.Error("snapshot() should fail outside a git repository")
+	}
+}
+
+func TestUpgradeReport_Truncated(t *testing.T) {
+	report := &upgradeReport{oldVersion: "v0.1.0", newVersion: "v0.2.0"}
+	for i := range maxListed + 5 {
+		report.changedFiles = append(report.changedFiles, fmt.Sprintf("file%03d", i))
+	}
+	got := report.String()
+	if strings.Contains(got, fmt.Sprintf("file%03d", maxListed)) {
+		t.Errorf("expected files after the first %d to be omitted:\n%s", maxListed, got)
+	}
+	if !strings.Contains(got, "- ... and 5 more\n") {
+		t.Errorf("expected the number of omitted files:\n%s", got)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarianops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/command"
+)
+
+// forge is the hosting provider of the repositories processed by
+// librarianops. Repositories are identified by their name in the googleapis
+// organization, and dir is the local clone of the repository.
+type forge interface {
+	// Clone clones the repository into dir.
+	Clone(ctx context.Context, repo, dir string) error
+	// CreateBranch creates branch at HEAD, replacing any existing branch
+	// with the same name, and checks it out.
+	CreateBranch(ctx context.Context, dir, branch string) error
+	// Push force-pushes HEAD to branch in the hosted repository.
+	Push(ctx context.Context, repo, dir, branch string) error
+	// FindPullRequest returns the open pull request from branch, or nil if
+	// there is none.
+	FindPullRequest(ctx context.Context, repo, dir, branch string) (*openPullRequest, error)
+	// CreatePullRequest opens a pull request from branch.
+	CreatePullRequest(ctx context.Context, repo, dir, branch string, pr *pullRequest) (*openPullRequest, error)
+	// UpdatePullRequest replaces the title and body of an open pull
+	// request.
+	UpdatePullRequest(ctx context.Context, repo, dir string, number int, pr *pullRequest) error
+	// AddLabels adds labels to an open pull request.
+	AddLabels(ctx context.Context, repo, dir string, number int, labels []string) error
+}
+
+// openPullRequest identifies a pull request in a forge.
+type openPullRequest struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+}
+
+// upsertPullRequest opens a pull request from branch, or updates the pull
+// request already open from branch so that reruns do not create duplicates.
+// It returns the URL of the pull request.
+func upsertPullRequest(ctx context.Context, f forge, repo, dir, branch string, pr *pullRequest) (string, error) {
+	if pr == nil {
+		pr = &pullRequest{Title: commitTitle, Body: prBody}
+	}
+	existing, err := f.FindPullRequest(ctx, repo, dir, branch)
+	if err != nil {
+		return "", err
+	}
+	if existing == nil {
+		created, err := f.CreatePullRequest(ctx, repo, dir, branch, pr)
+		if err != nil {
+			return "", err
+		}
+		return created.URL, nil
+	}
+	if err := f.UpdatePullRequest(ctx, repo, dir, existing.Number, pr); err != nil {
+		return "", err
+	}
+	if len(pr.Labels) > 0 {
+		if err := f.AddLabels(ctx, repo, dir, existing.Number, pr.Labels); err != nil {
+			return "", err
+		}
+	}
+	return existing.URL, nil
+}
+
+// createBranch creates branch at HEAD in dir with git, replacing any
+// existing branch with the same name, and checks it out.
+func createBranch(ctx context.Context, dir, branch string) error {
+	return command.RunInDir(ctx, dir, command.Git, "checkout", "-B", branch)
+}
+
+// githubForge is the forge for repositories hosted on GitHub, using the gh
+// CLI.
+type githubForge struct{}
+
+// Clone clones the repository with gh.
+func (githubForge) Clone(ctx context.Context, repo, dir string) error {
+	return command.Run(ctx, "gh", "repo", "clone", fmt.Sprintf("googleapis/%s", repo), dir)
+}
+
+// CreateBranch creates and checks out branch with git.
+func (githubForge) CreateBranch(ctx context.Context, dir, branch string) error {
+	return createBranch(ctx, dir, branch)
+}
+
+// Push force-pushes HEAD to branch in origin.
+func (githubForge) Push(ctx context.Context, repo, dir, branch string) error {
+	return command.RunInDir(ctx, dir, command.Git, "push", "--force", "-u", "origin", "HEAD:"+branch)
+}
+
+// FindPullRequest returns the open pull request from branch with gh.
+func (githubForge) FindPullRequest(ctx context.Context, repo, dir, branch string) (*openPullRequest, error) {
+	output, err := command.OutputInDir(ctx, dir, "gh", "pr", "list",
+		"--repo", "googleapis/"+repo, "--head", branch, "--state", "open", "--json", "number,url")
+	if err != nil {
+		return nil, err
+	}
+	var prs []*openPullRequest
+	if err := json.Unmarshal([]byte(output), &prs); err != nil {
+		return nil, fmt.Errorf("parsing gh pr list output: %w", err)
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return prs[0], nil
+}
+
+// CreatePullRequest opens a pull request from branch with gh.
+func (githubForge) CreatePullRequest(ctx context.Context, repo, dir, branch string, pr *pullRequest) (*openPullRequest, error) {
+	args := []string{"pr", "create", "--repo", "googleapis/" + repo, "--head", branch, "--title", pr.Title, "--body", pr.Body}
+	for _, label := range pr.Labels {
+		args = append(args, "--label", label)
+	}
+	output, err := command.OutputInDir(ctx, dir, "gh", args...)
+	if err != nil {
+		return nil, err
+	}
+	return parsePullRequestURL(strings.TrimSpace(output))
+}
+
+// UpdatePullRequest replaces the title and body of a pull request with gh.
+func (githubForge) UpdatePullRequest(ctx context.Context, repo, dir string, number int, pr *pullRequest) error {
+	return command.RunInDir(ctx, dir, "gh", "pr", "edit", strconv.Itoa(number),
+		"--repo", "googleapis/"+repo, "--title", pr.Title, "--body", pr.Body)
+}
+
+// AddLabels adds labels to a pull request with gh.
+func (githubForge) AddLabels(ctx context.Context, repo, dir string, number int, labels []string) error {
+	return command.RunInDir(ctx, dir, "gh", "pr", "edit", strconv.Itoa(number),
+		"--repo", "googleapis/"+repo, "--add-label", strings.Join(labels, ","))
+}
+
+// forgeFor returns the forge used by the librarianops commands for repo.
+// The fake repository, used for testing, is never pushed and never gets a
+// pull request.
+func forgeFor(repo string) forge {
+	if repo == repoFake {
+		return localForge{}
+	}
+	return githubForge{}
+}
+
+// localForge is a forge that keeps the changes in the local clone. Pushing
+// and pull requests are no-ops.
+type localForge struct {
+	githubForge
+}
+
+// Push does nothing.
+func (localForge) Push(ctx context.Context, repo, dir, branch string) error {
+	return nil
+}
+
+// FindPullRequest returns no pull request.
+func (localForge) FindPullRequest(ctx context.Context, repo, dir, branch string) (*openPullRequest, error) {
+	return nil, nil
+}
+
+// CreatePullRequest returns a pull request without a number or URL.
+func (localForge) CreatePullRequest(ctx context.Context, repo, dir, branch string, pr *pullRequest) (*openPullRequest, error) {
+	return &openPullRequest{}, nil
+}
+
+// UpdatePullRequest does nothing.
+func (localForge) UpdatePullRequest(ctx context.Context, repo, dir string, number int, pr *pullRequest) error {
+	return nil
+}
+
+// AddLabels does nothing.
+func (localForge) AddLabels(ctx context.Context, repo, dir string, number int, labels []string) error {
+	return nil
+}
+
+// parsePullRequestURL returns the pull request with the given URL, such as
+// https://github.com/googleapis/google-cloud-go/pull/123.
+func parsePullRequestURL(url string) (*openPullRequest, error) {
+	_, num, ok := strings.Cut(url, "/pull/")
+	if !ok {
+		return nil, fmt.Errorf("unexpected pull request URL %q", url)
+	}
+	number, err := strconv.Atoi(num)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected pull request URL %q: %w", url, err)
+	}
+	return &openPullRequest{Number: number, URL: url}, nil
+}
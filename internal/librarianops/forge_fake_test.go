@@ -0,0 +1,144 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarianops
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/googleapis/librarian/internal/command"
+)
+
+// fakeForge is an in-process forge for testing. Each repository is a bare
+// git repository named <repo>.git in dir, and pull requests are kept in
+// memory.
+type fakeForge struct {
+	dir string
+
+	mu           sync.Mutex
+	pullRequests []*fakePullRequest
+}
+
+// fakePullRequest is a pull request in a fakeForge.
+type fakePullRequest struct {
+	Repo   string
+	Number int
+	Branch string
+	Title  string
+	Body   string
+	Labels []string
+	Open   bool
+}
+
+// newFakeForge returns a fakeForge with the bare repositories in dir.
+func newFakeForge(dir string) *fakeForge {
+	return &fakeForge{dir: dir}
+}
+
+// remote returns the path of the bare repository.
+func (f *fakeForge) remote(repo string) string {
+	return filepath.Join(f.dir, repo+".git")
+}
+
+func (f *fakeForge) url(repo string, number int) string {
+	return fmt.Sprintf("file://%s/pull/%d", f.remote(repo), number)
+}
+
+// Clone clones the bare repository.
+func (f *fakeForge) Clone(ctx context.Context, repo, dir string) error {
+	return command.Run(ctx, command.Git, "clone", f.remote(repo), dir)
+}
+
+// CreateBranch creates and checks out branch with git.
+func (f *fakeForge) CreateBranch(ctx context.Context, dir, branch string) error {
+	return createBranch(ctx, dir, branch)
+}
+
+// Push force-pushes HEAD to branch in the bare repository.
+func (f *fakeForge) Push(ctx context.Context, repo, dir, branch string) error {
+	return command.RunInDir(ctx, dir, command.Git, "push", "--force", f.remote(repo), "HEAD:refs/heads/"+branch)
+}
+
+// FindPullRequest returns the open pull request from branch.
+func (f *fakeForge) FindPullRequest(ctx context.Context, repo, dir, branch string) (*openPullRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, pr := range f.pullRequests {
+		if pr.Repo == repo && pr.Branch == branch && pr.Open {
+			return &openPullRequest{Number: pr.Number, URL: f.url(repo, pr.Number)}, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreatePullRequest records a new open pull request from branch.
+func (f *fakeForge) CreatePullRequest(ctx context.Context, repo, dir, branch string, pr *pullRequest) (*openPullRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	number := len(f.pullRequests) + 1
+	f.pullRequests = append(f.pullRequests, &fakePullRequest{
+		Repo:   repo,
+		Number: number,
+		Branch: branch,
+		Title:  pr.Title,
+		Body:   pr.Body,
+		Labels: slices.Clone(pr.Labels),
+		Open:   true,
+	})
+	return &openPullRequest{Number: number, URL: f.url(repo, number)}, nil
+}
+
+// UpdatePullRequest replaces the title and body of a pull request.
+func (f *fakeForge) UpdatePullRequest(ctx context.Context, repo, dir string, number int, pr *pullRequest) error {
+	found, err := f.find(repo, number)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	found.Title = pr.Title
+	found.Body = pr.Body
+	return nil
+}
+
+// AddLabels adds the labels missing from a pull request.
+func (f *fakeForge) AddLabels(ctx context.Context, repo, dir string, number int, labels []string) error {
+	found, err := f.find(repo, number)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, label := range labels {
+		if !slices.Contains(found.Labels, label) {
+			found.Labels = append(found.Labels, label)
+		}
+	}
+	return nil
+}
+
+func (f *fakeForge) find(repo string, number int) (*fakePullRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, pr := range f.pullRequests {
+		if pr.Repo == repo && pr.Number == number {
+			return pr, nil
+		}
+	}
+	return nil, fmt.Errorf("pull request %d not found in %s", number, repo)
+}
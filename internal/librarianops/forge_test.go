@@ -0,0 +1,173 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarianops
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/testhelper"
+)
+
+func TestUpsertPullRequest(t *testing.T) {
+	f := newFakeForge(t.TempDir())
+	dir := t.TempDir()
+	const branch = "test-branch"
+
+	first := &pullRequest{Title: "first", Body: "first body", Labels: []string{"automerge"}}
+	url, err := upsertPullRequest(t.Context(), f, repoFake, dir, branch, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := f.url(repoFake, 1); url != want {
+		t.Errorf("upsertPullRequest() = %q, want %q", url, want)
+	}
+
+	second := &pullRequest{Title: "second", Body: "second body", Labels: []string{"automerge", regressionLabel}}
+	url, err = upsertPullRequest(t.Context(), f, repoFake, dir, branch, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := f.url(repoFake, 1); url != want {
+		t.Errorf("upsertPullRequest() = %q, want %q", url, want)
+	}
+
+	want := []*fakePullRequest{
+		{
+			Repo:   repoFake,
+			Number: 1,
+			Branch: branch,
+			Title:  "second",
+			Body:   "second body",
+			Labels: []string{"automerge", regressionLabel},
+			Open:   true,
+		},
+	}
+	if diff := cmp.Diff(want, f.pullRequests); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpsertPullRequest_Default(t *testing.T) {
+	f := newFakeForge(t.TempDir())
+	if _, err := upsertPullRequest(t.Context(), f, repoFake, t.TempDir(), "test-branch", nil); err != nil {
+		t.Fatal(err)
+	}
+	got := f.pullRequests[0]
+	if got.Title != commitTitle || got.Body != prBody {
+		t.Errorf("got title %q and body %q, want %q and %q", got.Title, got.Body, commitTitle, prBody)
+	}
+}
+
+func TestUpsertPullRequest_ClosedPullRequest(t *testing.T) {
+	f := newFakeForge(t.TempDir())
+	dir := t.TempDir()
+	if _, err := upsertPullRequest(t.Context(), f, repoFake, dir, "test-branch", nil); err != nil {
+		t.Fatal(err)
+	}
+	f.pullRequests[0].Open = false
+	url, err := upsertPullRequest(t.Context(), f, repoFake, dir, "test-branch", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := f.url(repoFake, 2); url != want {
+		t.Errorf("upsertPullRequest() = %q, want %q", url, want)
+	}
+}
+
+func TestFakeForge_Push(t *testing.T) {
+	f := newFakeForge(t.TempDir())
+	testhelper.RunGit(t, "init", "--bare", "-b", config.BranchMain, f.remote(repoFake))
+	dir := t.TempDir()
+	if err := f.Clone(t.Context(), repoFake, dir); err != nil {
+		t.Fatal(err)
+	}
+	testhelper.RunGit(t, "-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com",
+		"commit", "--allow-empty", "-m", "first")
+	if err := f.CreateBranch(t.Context(), dir, "test-branch"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Push(t.Context(), repoFake, dir, "test-branch"); err != nil {
+		t.Fatal(err)
+	}
+	// Pushing a rewritten branch replaces it.
+	testhelper.RunGit(t, "-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com",
+		"commit", "--amend", "--allow-empty", "-m", "second")
+	if err := f.Push(t.Context(), repoFake, dir, "test-branch"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := command.Output(t.Context(), command.Git, "-C", f.remote(repoFake), "log", "--format=%s", "test-branch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("second\n", got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParsePullRequestURL(t *testing.T) {
+	const url = "https://github.com/googleapis/google-cloud-go/pull/123"
+	got, err := parsePullRequestURL(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &openPullRequest{Number: 123, URL: url}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParsePullRequestURL_Error(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		url  string
+	}{
+		{"no pull path", "https://github.com/googleapis/google-cloud-go"},
+		{"no number", "https://github.com/googleapis/google-cloud-go/pull/abc"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := parsePullRequestURL(test.url); err == nil {
+				t.Errorf("parsePullRequestURL(%q) error = nil, want error", test.url)
+			}
+		})
+	}
+}
+
+func TestForgeFor(t *testing.T) {
+	if _, ok := forgeFor(repoFake).(localForge); !ok {
+		t.Errorf("forgeFor(%q) = %T, want localForge", repoFake, forgeFor(repoFake))
+	}
+	if _, ok := forgeFor("google-cloud-rust").(githubForge); !ok {
+		t.Errorf("forgeFor(%q) = %T, want githubForge", "google-cloud-rust", forgeFor("google-cloud-rust"))
+	}
+}
+
+func TestLocalForge(t *testing.T) {
+	// The gh and git commands must not run.
+	t.Setenv("PATH", "")
+	f := localForge{}
+	if err := f.Push(t.Context(), repoFake, t.TempDir(), "test-branch"); err != nil {
+		t.Fatal(err)
+	}
+	url, err := upsertPullRequest(t.Context(), f, repoFake, t.TempDir(), "test-branch", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != "" {
+		t.Errorf("upsertPullRequest() = %q, want no URL", url)
+	}
+}
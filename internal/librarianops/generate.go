@@ -20,18 +20,22 @@ import (
 	"fmt"
 	"os"
 	"os/user"
+	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/googleapis/librarian/internal/command"
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/librarian"
 	"github.com/googleapis/librarian/internal/yaml"
 	"github.com/urfave/cli/v3"
 )
 
 const (
-	branchPrefix = "librarianops-generateall-"
-	commitTitle  = "feat: update API sources and regenerate"
+	// generateBranch is the branch with the regenerated libraries. It is
+	// replaced on each run, updating the pull request if one is open.
+	generateBranch = "librarianops-generateall"
+	commitTitle    = "feat: update API sources and regenerate"
+	prBody         = "Update the API sources to the latest commit and regenerate all client libraries."
 	// librarianImageTemplate is a template string to format a language and
 	// version into the name of a Docker image to run when the --docker flag
 	// has been specified.
@@ -44,23 +48,41 @@ func generateCommand() *cli.Command {
 	return &cli.Command{
 		Name:      "generate",
 		Usage:     "generate libraries across repositories",
-		UsageText: "librarianops generate [<repo> | -C <dir>]",
+		UsageText: "librarianops generate [<repo>... | --all | -C <dir>]",
 		Description: `Examples:
   librarianops generate google-cloud-rust
   librarianops generate -C ~/workspace/google-cloud-rust
+  librarianops generate google-cloud-go google-cloud-python
+  librarianops generate --all --jobs 4 --summary summary.txt
 
 Specify a repository name to clone and process, or use -C to work in a specific
 directory (repo name is inferred from the directory basename).
 
+Specify several repository names, or use --all to process every supported
+repository, to run in batch mode. Each repository is cloned and processed in
+its own temporary directory, with at most --jobs repositories in parallel. A
+failure does not stop the other repositories. When all repositories are
+done, librarianops prints a summary with the branch, pull request, number of
+libraries changed and failed step of each repository, and writes it to the
+--summary file if set.
+
 For each repository, librarianops will:
   1. Clone the repository to a temporary directory (or use existing directory with -C)
-  2. Create a branch: librarianops-generateall-YYYY-MM-DD
+  2. Create the branch librarianops-generateall, replacing any previous one
   3. Run librarian tidy
-  4. Run librarian update for configured sources (sources.discovery, sources.googleapis)
+  4. Run librarian update for the repository sources
   5. Run librarian generate --all
-  6. Run cargo update --workspace (google-cloud-rust only)
+  6. Run the repository post-generate commands
   7. Commit changes
-  8. Create a pull request`,
+  8. Force-push the branch and create a pull request with the repository title,
+     body and labels, or update the pull request already open from the branch
+
+The steps specific to each repository are read from a YAML file listing the
+supported repositories, with their sources, post_generate commands and
+pull_request title, body and labels. A default file is built into
+librarianops, use --config to replace it. When sources is empty, the
+googleapis and discovery sources are updated, if librarian.yaml configures
+them.`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:  "C",
@@ -74,30 +96,105 @@ For each repository, librarianops will:
 				Name:  "docker",
 				Usage: "run librarian in Docker",
 			},
+			&cli.StringFlag{
+				Name:  "config",
+				Usage: "read the supported repositories from `file`",
+			},
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "process all supported repositories",
+			},
+			&cli.IntFlag{
+				Name:  "jobs",
+				Usage: "maximum number of repositories processed in parallel in batch mode",
+				Value: 4,
+			},
+			&cli.StringFlag{
+				Name:  "summary",
+				Usage: "write the batch mode summary to `file`",
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Bool("all") || cmd.Args().Len() > 1 {
+				return runGenerateBatch(ctx, cmd)
+			}
 			repoName, workDir, verbose, err := parseFlags(cmd)
 			if err != nil {
 				return err
 			}
 			command.Verbose = verbose
-			return runGenerate(ctx, repoName, workDir, cmd.Bool("docker"))
+			return runGenerate(ctx, cmd.String("config"), repoName, workDir, cmd.Bool("docker"))
 		},
 	}
 }
 
-func runGenerate(ctx context.Context, repoName, repoDir string, runInDocker bool) error {
-	if !supportedRepositories[repoName] {
-		return fmt.Errorf("repository %q not found in supported repositories list", repoName)
+func runGenerate(ctx context.Context, configPath, repoName, repoDir string, runInDocker bool) error {
+	repos, err := loadRepositories(configPath)
+	if err != nil {
+		return err
+	}
+	repo, err := findRepository(repos, repoName)
+	if err != nil {
+		return err
+	}
+	_, err = processRepo(ctx, forgeFor(repo.Name), repo, repoDir, "", command.Verbose, runInDocker)
+	return err
+}
+
+// runGenerateBatch processes the repositories named in the arguments, or all
+// the supported repositories with --all, in batch mode.
+func runGenerateBatch(ctx context.Context, cmd *cli.Command) error {
+	all := cmd.Bool("all")
+	if all && cmd.Args().Len() > 0 {
+		return errors.New("cannot specify both repository names and --all")
+	}
+	if cmd.String("C") != "" {
+		return errors.New("cannot use -C in batch mode")
+	}
+	command.Verbose = cmd.Bool("v")
+	supported, err := loadRepositories(cmd.String("config"))
+	if err != nil {
+		return err
 	}
-	return processRepo(ctx, repoName, repoDir, "", command.Verbose, runInDocker)
+	var repos []*repository
+	if all {
+		for _, repo := range supported {
+			if repo.Name != repoFake {
+				repos = append(repos, repo)
+			}
+		}
+	} else {
+		for _, name := range cmd.Args().Slice() {
+			repo, err := findRepository(supported, name)
+			if err != nil {
+				return err
+			}
+			repos = append(repos, repo)
+		}
+	}
+	runInDocker := cmd.Bool("docker")
+	return runBatch(ctx, cmd.Root().Writer, repos, cmd.Int("jobs"), cmd.String("summary"),
+		func(ctx context.Context, repo *repository) (*repoResult, error) {
+			return processRepo(ctx, forgeFor(repo.Name), repo, "", "", command.Verbose, runInDocker)
+		})
 }
 
-func processRepo(ctx context.Context, repoName, repoDir, librarianBin string, verbose, runInDocker bool) (err error) {
+// processRepo runs the generation steps for a repository in repoDir, cloning
+// it from f into a temporary directory if repoDir is empty. It always returns
+// a result, recording the step that failed when it returns an error.
+func processRepo(ctx context.Context, f forge, repo *repository, repoDir, librarianBin string, verbose, runInDocker bool) (result *repoResult, err error) {
+	result = &repoResult{Repo: repo.Name}
+	step := stepClone
+	defer func() {
+		if err != nil {
+			result.FailedStep = step
+			result.Err = err
+		}
+	}()
 	if repoDir == "" {
-		repoDir, err = os.MkdirTemp("", "librarianops-"+repoName+"-*")
+		repoDir, err = os.MkdirTemp("", "librarianops-"+repo.Name+"-*")
 		if err != nil {
-			return fmt.Errorf("failed to create temp directory: %w", err)
+			return result, fmt.Errorf("failed to create temp directory: %w", err)
 		}
 		defer func() {
 			cerr := os.RemoveAll(repoDir)
@@ -105,115 +202,118 @@ func processRepo(ctx context.Context, repoName, repoDir, librarianBin string, ve
 				err = cerr
 			}
 		}()
-		if err := cloneRepo(ctx, repoDir, repoName); err != nil {
-			return err
+		if err := f.Clone(ctx, repo.Name, repoDir); err != nil {
+			return result, err
 		}
 	}
-	originalWD, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
-	if err := os.Chdir(repoDir); err != nil {
-		return fmt.Errorf("failed to change directory to %q: %w", repoDir, err)
-	}
-	defer os.Chdir(originalWD)
 
-	if err := createBranch(ctx, time.Now()); err != nil {
-		return err
+	step = stepBranch
+	if err := f.CreateBranch(ctx, repoDir, generateBranch); err != nil {
+		return result, err
 	}
-	cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
+	result.Branch = generateBranch
+	step = stepConfig
+	cfg, err := yaml.Read[config.Config](filepath.Join(repoDir, config.LibrarianYAML))
 	if err != nil {
-		return err
+		return result, err
 	}
 	if librarianBin == "" && cfg.Version == "" {
-		return errors.New("librarian.yaml must specify the librarian version")
+		return result, errors.New("librarian.yaml must specify the librarian version")
 	}
 	run := func(args ...string) error {
 		if librarianBin != "" {
-			return runLibrarianBin(ctx, librarianBin, verbose, args...)
+			return runLibrarianBin(ctx, repoDir, librarianBin, verbose, args...)
 		}
 		if runInDocker {
-			return runLibrarianInDocker(ctx, cfg.Language, cfg.Version, verbose, args...)
+			return runLibrarianInDocker(ctx, repoDir, cfg.Language, cfg.Version, verbose, args...)
 		}
-		return runLibrarianWithVersion(ctx, cfg.Version, verbose, args...)
+		return runLibrarianWithVersion(ctx, repoDir, cfg.Version, verbose, args...)
 	}
-	if repoName != repoFake {
+	if repo.Name != repoFake {
+		step = stepTidy
 		if err := run("tidy"); err != nil {
-			return err
+			return result, err
+		}
+		step = stepUpdate
+		sources := repo.Sources
+		if len(sources) == 0 {
+			sources = sourcesToUpdate(cfg)
 		}
-		sources := sourcesToUpdate(cfg)
 		if len(sources) > 0 {
 			args := append([]string{"update"}, sources...)
 			if err := run(args...); err != nil {
-				return err
+				return result, err
 			}
 		}
 	}
+	step = stepGenerate
 	if err := run("generate", "--all"); err != nil {
-		return err
+		return result, err
 	}
-	if repoName == repoRust {
-		if err := runCargoUpdate(ctx); err != nil {
-			return err
-		}
+	step = stepPostGenerate
+	if err := runPostGenerate(ctx, repoDir, repo.PostGenerate); err != nil {
+		return result, err
 	}
-	if err := commitChanges(ctx); err != nil {
-		return err
+	step = stepCommit
+	if err := commitChanges(ctx, repoDir, commitTitle); err != nil {
+		return result, err
 	}
-	if repoName != repoFake {
-		if err := pushBranch(ctx); err != nil {
-			return err
-		}
-		if err := createPR(ctx, repoName); err != nil {
-			return err
-		}
+	result.Libraries, err = changedLibraries(ctx, repoDir)
+	if err != nil {
+		return result, err
 	}
-	return nil
-}
-
-func cloneRepo(ctx context.Context, repoDir, repoName string) error {
-	return command.Run(ctx, "gh", "repo", "clone", fmt.Sprintf("googleapis/%s", repoName), repoDir)
-}
-
-func createBranch(ctx context.Context, now time.Time) error {
-	branchName := fmt.Sprintf("%s%s", branchPrefix, now.UTC().Format("20060102T150405Z"))
-	return command.Run(ctx, command.Git, "checkout", "-b", branchName)
+	step = stepPush
+	if err := f.Push(ctx, repo.Name, repoDir, generateBranch); err != nil {
+		return result, err
+	}
+	step = stepPullRequest
+	result.PullRequest, err = upsertPullRequest(ctx, f, repo.Name, repoDir, generateBranch, repo.PullRequest)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
 }
 
-func commitChanges(ctx context.Context) error {
-	if err := command.Run(ctx, command.Git, "add", "."); err != nil {
+func commitChanges(ctx context.Context, repoDir, message string) error {
+	if err := command.RunInDir(ctx, repoDir, command.Git, "add", "."); err != nil {
 		return err
 	}
-	return command.Run(ctx, command.Git, "commit", "-m", commitTitle)
-}
-
-func pushBranch(ctx context.Context) error {
-	return command.Run(ctx, command.Git, "push", "-u", "origin", "HEAD")
+	return command.RunInDir(ctx, repoDir, command.Git, "commit", "-m", message)
 }
 
-func createPR(ctx context.Context, repoName string) error {
-	sources := "googleapis"
-	if repoName == repoRust {
-		sources = "googleapis and discovery-artifact-manager"
-	}
-	title := fmt.Sprintf("feat: update %s and regenerate", sources)
-	body := fmt.Sprintf("Update %s to the latest commit and regenerate all client libraries.", sources)
-	return command.Run(ctx, "gh", "pr", "create", "--title", title, "--body", body)
+// changedLibraries returns the names of the libraries changed by the last
+// commit in repoDir.
+func changedLibraries(ctx context.Context, repoDir string) ([]string, error) {
+	output, err := command.OutputInDir(ctx, repoDir, command.Git, "diff", "--name-only", "HEAD~1", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := yaml.Read[config.Config](filepath.Join(repoDir, config.LibrarianYAML))
+	if err != nil {
+		return nil, err
+	}
+	return librarian.ChangedLibraries(cfg, strings.Fields(output)), nil
 }
 
-func runCargoUpdate(ctx context.Context) error {
-	return command.Run(ctx, command.Cargo, "update", "--workspace")
+// runPostGenerate runs the post-generate commands of a repository, in order.
+func runPostGenerate(ctx context.Context, repoDir string, steps [][]string) error {
+	for _, step := range steps {
+		if err := command.RunInDir(ctx, repoDir, step[0], step[1:]...); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func runLibrarianWithVersion(ctx context.Context, version string, verbose bool, args ...string) error {
+func runLibrarianWithVersion(ctx context.Context, repoDir, version string, verbose bool, args ...string) error {
 	if verbose {
 		args = append([]string{"-v"}, args...)
 	}
-	return command.RunStreaming(ctx, command.Go,
+	return command.RunStreamingInDir(ctx, repoDir, command.Go,
 		append([]string{"run", fmt.Sprintf("github.com/googleapis/librarian/cmd/librarian@%s", version)}, args...)...)
 }
 
-func runLibrarianInDocker(ctx context.Context, language, version string, verbose bool, args ...string) error {
+func runLibrarianInDocker(ctx context.Context, repoDir, language, version string, verbose bool, args ...string) error {
 	if verbose {
 		args = append([]string{"-v"}, args...)
 	}
@@ -245,17 +345,20 @@ func runLibrarianInDocker(ctx context.Context, language, version string, verbose
 		"/repo",
 		dockerImage,
 	}
-	return command.RunStreaming(ctx, "docker", append(dockerArgs, args...)...)
+	return command.RunStreamingInDir(ctx, repoDir, "docker", append(dockerArgs, args...)...)
 }
 
 // runLibrarianBin runs a pre-built librarian binary with the given arguments.
-func runLibrarianBin(ctx context.Context, bin string, verbose bool, args ...string) error {
+func runLibrarianBin(ctx context.Context, repoDir, bin string, verbose bool, args ...string) error {
 	if verbose {
 		args = append([]string{"-v"}, args...)
 	}
-	return command.RunStreaming(ctx, bin, args...)
+	return command.RunStreamingInDir(ctx, repoDir, bin, args...)
 }
 
+// sourcesToUpdate returns the sources updated when the repository does not
+// list any: sources.discovery and sources.googleapis, if configured. The
+// other sources are pinned, and only updated when listed explicitly.
 func sourcesToUpdate(cfg *config.Config) []string {
 	if cfg.Sources == nil {
 		return nil
@@ -16,7 +16,9 @@ package librarianops
 
 import (
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -34,6 +36,10 @@ func TestGenerateCommand(t *testing.T) {
 	if err := command.Run(t.Context(), command.Go, "build", "-o", librarianBin, "../../cmd/librarian"); err != nil {
 		t.Fatal(err)
 	}
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
 
 	for _, test := range []struct {
 		name    string
@@ -43,50 +49,71 @@ func TestGenerateCommand(t *testing.T) {
 		{"verbose", true},
 	} {
 		t.Run(test.name, func(t *testing.T) {
-			repoDir := t.TempDir()
-			testhelper.RunGit(t, "init", repoDir)
-			testhelper.RunGit(t, "-C", repoDir, "config", "user.email", "test@example.com")
-			testhelper.RunGit(t, "-C", repoDir, "config", "user.name", "Test User")
-			testhelper.RunGit(t, "-C", repoDir, "checkout", "-b", config.BranchMain)
-
-			wd, err := os.Getwd()
-			if err != nil {
-				t.Fatal(err)
-			}
-			googleapisDir := filepath.Join(wd, "..", "testdata", "googleapis")
-			cfg := sample.Config()
-			cfg.Sources.Googleapis = &config.Source{Dir: googleapisDir}
-			if err := yaml.Write(filepath.Join(repoDir, config.LibrarianYAML), cfg); err != nil {
-				t.Fatal(err)
-			}
-			testhelper.RunGit(t, "-C", repoDir, "add", ".")
-			testhelper.RunGit(t, "-C", repoDir, "commit", "-m", "initial commit")
-
-			// Rename temp dir to fake-repo so basename matches expected repo
-			// name.
-			fakeRepoDir := filepath.Join(filepath.Dir(repoDir), "fake-repo")
-			if err := os.Rename(repoDir, fakeRepoDir); err != nil {
-				t.Fatal(err)
-			}
-			repoDir = fakeRepoDir
-
+			f := newFakeForge(t.TempDir())
+			setupFakeRemote(t, f)
 			if test.verbose {
 				command.Verbose = true
 				defer func() { command.Verbose = false }()
 			}
 			runInDocker := false
-			if err := processRepo(t.Context(), repoFake, repoDir, librarianBin, test.verbose, runInDocker); err != nil {
-				t.Fatal(err)
+			repo := &repository{
+				Name:         repoFake,
+				PostGenerate: [][]string{{"touch", "POST_GENERATE"}},
+			}
+			// Run twice to check that the second run updates the pull
+			// request created by the first.
+			for range 2 {
+				result, err := processRepo(t.Context(), f, repo, "", librarianBin, test.verbose, runInDocker)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if result.Branch != generateBranch {
+					t.Errorf("result.Branch = %q, want %q", result.Branch, generateBranch)
+				}
+				if want := f.url(repoFake, 1); result.PullRequest != want {
+					t.Errorf("result.PullRequest = %q, want %q", result.PullRequest, want)
+				}
+				if !slices.Contains(result.Libraries, sample.Lib1Name) {
+					t.Errorf("result.Libraries = %v, want %q", result.Libraries, sample.Lib1Name)
+				}
+			}
+			if got := len(f.pullRequests); got != 1 {
+				t.Errorf("got %d pull requests, want 1", got)
 			}
 
-			readmePath := filepath.Join(repoDir, sample.Lib1Output, "README.md")
-			if _, err := os.Stat(readmePath); err != nil {
+			remote := f.remote(repoFake)
+			readme := generateBranch + ":" + path.Join(sample.Lib1Output, "README.md")
+			if err := command.Run(t.Context(), command.Git, "-C", remote, "cat-file", "-e", readme); err != nil {
 				t.Errorf("expected README.md to be generated: %v", err)
 			}
+			if err := command.Run(t.Context(), command.Git, "-C", remote, "cat-file", "-e", generateBranch+":POST_GENERATE"); err != nil {
+				t.Errorf("expected post-generate command to run: %v", err)
+			}
 		})
 	}
 }
 
+// setupFakeRemote creates the bare repository for repoFake in f, with a
+// librarian.yaml using the googleapis testdata.
+func setupFakeRemote(t *testing.T, f *fakeForge) {
+	t.Helper()
+	repoDir := t.TempDir()
+	testhelper.RunGit(t, "init", "-b", config.BranchMain, repoDir)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := sample.Config()
+	cfg.Sources.Googleapis = &config.Source{Dir: filepath.Join(wd, "..", "testdata", "googleapis")}
+	if err := yaml.Write(filepath.Join(repoDir, config.LibrarianYAML), cfg); err != nil {
+		t.Fatal(err)
+	}
+	testhelper.RunGit(t, "-C", repoDir, "add", ".")
+	testhelper.RunGit(t, "-C", repoDir, "commit", "-m", "initial commit")
+	testhelper.RunGit(t, "init", "--bare", "-b", config.BranchMain, f.remote(repoFake))
+	testhelper.RunGit(t, "-C", repoDir, "push", f.remote(repoFake), config.BranchMain)
+}
+
 func TestGenerateCommand_Errors(t *testing.T) {
 	for _, test := range []struct {
 		name string
@@ -104,6 +131,18 @@ func TestGenerateCommand_Errors(t *testing.T) {
 			name: "unsupported repo via C flag",
 			args: []string{"librarianops", "generate", "-C", "/tmp/unsupported-repo"},
 		},
+		{
+			name: "all flag with repo names",
+			args: []string{"librarianops", "generate", "--all", "google-cloud-go"},
+		},
+		{
+			name: "C flag in batch mode",
+			args: []string{"librarianops", "generate", "-C", "/tmp/google-cloud-go", "google-cloud-go", "google-cloud-python"},
+		},
+		{
+			name: "unsupported repo in batch mode",
+			args: []string{"librarianops", "generate", "google-cloud-go", "unsupported-repo"},
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			err := Run(t.Context(), test.args...)
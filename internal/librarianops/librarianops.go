@@ -22,19 +22,7 @@ import (
 	"github.com/urfave/cli/v3"
 )
 
-const (
-	repoRust   = "google-cloud-rust"
-	repoPython = "google-cloud-python"
-	repoGo     = "google-cloud-go"
-	repoFake   = "fake-repo" // used for testing
-)
-
-var supportedRepositories = map[string]bool{
-	repoFake:   true, // used for testing
-	repoRust:   true,
-	repoGo:     true,
-	repoPython: true,
-}
+const repoFake = "fake-repo" // used for testing
 
 // Run executes the librarianops command with the given arguments.
 func Run(ctx context.Context, args ...string) error {
@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarianops
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+var (
+	errRepoNotFound      = errors.New("repository not found in supported repositories list")
+	errInvalidRepository = errors.New("invalid repository configuration")
+
+	//go:embed repositories.yaml
+	repositoriesYAML []byte
+)
+
+// repository configures the steps librarianops runs for a repository. The
+// supported repositories are listed in repositories.yaml, which is embedded
+// into the librarianops executable and can be replaced with --config.
+type repository struct {
+	// Name is the name of the repository in the googleapis organization.
+	Name string `yaml:"name"`
+
+	// Sources lists the librarian.yaml sources to update, such as
+	// "sources.googleapis". If empty, sources.googleapis and
+	// sources.discovery are updated, if librarian.yaml configures them.
+	Sources []string `yaml:"sources,omitempty"`
+
+	// PostGenerate lists the commands to run after
+	// `librarian generate --all`, each as the program followed by its
+	// arguments.
+	PostGenerate [][]string `yaml:"post_generate,omitempty"`
+
+	// PullRequest configures the pull request with the generated changes.
+	PullRequest *pullRequest `yaml:"pull_request,omitempty"`
+}
+
+// pullRequest configures the pull request created for a repository.
+type pullRequest struct {
+	// Title is the title of the pull request.
+	Title string `yaml:"title"`
+
+	// Body is the description of the pull request.
+	Body string `yaml:"body,omitempty"`
+
+	// Labels are added to the pull request.
+	Labels []string `yaml:"labels,omitempty"`
+}
+
+// loadRepositories reads the repository configuration from path, or the
+// embedded repositories.yaml if path is empty.
+func loadRepositories(path string) ([]*repository, error) {
+	data := repositoriesYAML
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	repos, err := yaml.Unmarshal[[]*repository](data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse repository configuration: %w", err)
+	}
+	for _, repo := range *repos {
+		if err := validateRepository(repo); err != nil {
+			return nil, err
+		}
+	}
+	return *repos, nil
+}
+
+func validateRepository(repo *repository) error {
+	if repo.Name == "" {
+		return fmt.Errorf("%w: missing name", errInvalidRepository)
+	}
+	for i, step := range repo.PostGenerate {
+		if len(step) == 0 {
+			return fmt.Errorf("%w: %s: post_generate command %d is empty", errInvalidRepository, repo.Name, i)
+		}
+	}
+	if repo.PullRequest != nil && repo.PullRequest.Title == "" {
+		return fmt.Errorf("%w: %s: missing pull_request title", errInvalidRepository, repo.Name)
+	}
+	return nil
+}
+
+// findRepository returns the repository with the given name.
+func findRepository(repos []*repository, name string) (*repository, error) {
+	for _, repo := range repos {
+		if repo.Name == name {
+			return repo, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", errRepoNotFound, name)
+}
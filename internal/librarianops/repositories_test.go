@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarianops
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadRepositories_Embedded(t *testing.T) {
+	repos, err := loadRepositories("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{
+		repoFake,
+		"google-cloud-dart",
+		"google-cloud-go",
+		"google-cloud-java",
+		"google-cloud-node",
+		"google-cloud-python",
+		"google-cloud-rust",
+		"google-cloud-swift",
+	} {
+		if _, err := findRepository(repos, name); err != nil {
+			t.Error(err)
+		}
+	}
+	rust, err := findRepository(repos, "google-cloud-rust")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"cargo", "update", "--workspace"}}
+	if diff := cmp.Diff(want, rust.PostGenerate); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadRepositories_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repositories.yaml")
+	content := `- name: google-cloud-node
+  sources:
+    - sources.googleapis
+  post_generate:
+    - [npm, run, fix]
+  pull_request:
+    title: "feat: regenerate"
+    labels: [automerge]
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadRepositories(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*repository{
+		{
+			Name:         "google-cloud-node",
+			Sources:      []string{"sources.googleapis"},
+			PostGenerate: [][]string{{"npm", "run", "fix"}},
+			PullRequest: &pullRequest{
+				Title:  "feat: regenerate",
+				Labels: []string{"automerge"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadRepositories_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{
+			name:    "missing name",
+			content: "- sources: [sources.googleapis]\n",
+			wantErr: errInvalidRepository,
+		},
+		{
+			name:    "empty post-generate command",
+			content: "- name: google-cloud-node\n  post_generate:\n    - []\n",
+			wantErr: errInvalidRepository,
+		},
+		{
+			name:    "missing pull request title",
+			content: "- name: google-cloud-node\n  pull_request:\n    labels: [automerge]\n",
+			wantErr: errInvalidRepository,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "repositories.yaml")
+			if err := os.WriteFile(path, []byte(test.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := loadRepositories(path)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("loadRepositories() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindRepository_NotFound(t *testing.T) {
+	_, err := findRepository([]*repository{{Name: repoFake}}, "unsupported-repo")
+	if !errors.Is(err, errRepoNotFound) {
+		t.Errorf("findRepository() error = %v, wantErr %v", err, errRepoNotFound)
+	}
+}
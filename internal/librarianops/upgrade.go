@@ -17,15 +17,30 @@ package librarianops
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 
 	"github.com/googleapis/librarian/internal/command"
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/git"
 	"github.com/googleapis/librarian/internal/yaml"
 	"github.com/urfave/cli/v3"
 )
 
+const (
+	// upgradeBranch is the branch with the librarian upgrade. It is replaced
+	// on each run, updating the pull request if one is open.
+	upgradeBranch = "librarianops-upgrade"
+	// regressionLabel is added to upgrade pull requests when the new
+	// librarian version regresses generation.
+	regressionLabel = "librarian-regression"
+)
+
+var errUpgradeRegression = errors.New("new librarian version regresses generation")
+
 func upgradeCommand() *cli.Command {
 	return &cli.Command{
 		Name:      "upgrade",
@@ -34,11 +49,23 @@ func upgradeCommand() *cli.Command {
 		Description: `Examples:
   librarianops upgrade google-cloud-rust
   librarianops upgrade -C ~/workspace/google-cloud-rust
+  librarianops upgrade -C ~/workspace/google-cloud-rust --pr --report report.md
 
 For each repository, librarianops will:
   1. Get the latest librarian version from @main.
-  2. Update the version field in librarian.yaml.
-  3. Run 'librarian generate --all'.`,
+  2. Run 'librarian tidy' and generate each library with the current version.
+  3. Discard the changes of the current version, with 'git checkout -- .'
+     and 'git clean -fd', and update the version field in librarian.yaml.
+  4. Run 'librarian tidy' and generate each library with the new version.
+  5. Compare the files generated and the libraries that failed with each
+     version, and print the comparison report.
+  6. With --pr, commit the changes to the branch librarianops-upgrade and
+     create a pull request with the report, or update the pull request already
+     open from the branch.
+
+The upgrade is refused if the new version fails to run tidy or to generate a
+library that the current version generates. Use --label-regression to create
+the pull request anyway, with the librarian-regression label.`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:  "C",
@@ -48,38 +75,187 @@ For each repository, librarianops will:
 				Name:  "v",
 				Usage: "run librarian with verbose output",
 			},
+			&cli.BoolFlag{
+				Name:  "pr",
+				Usage: "commit the upgrade and create a pull request with the report",
+			},
+			&cli.BoolFlag{
+				Name:  "label-regression",
+				Usage: "create the pull request with a label instead of refusing when the new version regresses",
+			},
+			&cli.StringFlag{
+				Name:  "report",
+				Usage: "write the comparison report to `file`",
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			_, workDir, verbose, err := parseFlags(cmd)
+			repoName, workDir, verbose, err := parseFlags(cmd)
 			if err != nil {
 				return err
 			}
 			command.Verbose = verbose
-			_, err = runUpgrade(ctx, workDir)
+			_, err = runUpgrade(ctx, cmd.Root().Writer, workDir, upgradeOptions{
+				run:             runLibrarian,
+				forge:           forgeFor(repoName),
+				repo:            repoName,
+				reportPath:      cmd.String("report"),
+				createPR:        cmd.Bool("pr"),
+				labelRegression: cmd.Bool("label-regression"),
+			})
 			return err
 		},
 	}
 }
 
-// runUpgrade consists in getting the latest librarian version, updates the librarian.yaml file and run librarian generate.
-// It returns the new version, and an error if one occurred.
-func runUpgrade(ctx context.Context, repoDir string) (string, error) {
-	version, err := getLibrarianVersionAtMain(ctx)
+// upgradeOptions configures runUpgrade.
+type upgradeOptions struct {
+	// version is the librarian version to upgrade to. If empty, the latest
+	// version at main is used.
+	version string
+	// run runs a version of librarian.
+	run librarianRunner
+	// forge pushes the upgrade and creates the pull request.
+	forge forge
+	// repo is the name of the repository in the forge.
+	repo string
+	// reportPath is the file where the comparison report is written, if set.
+	reportPath string
+	// createPR commits the upgrade and creates a pull request.
+	createPR bool
+	// labelRegression creates the pull request with regressionLabel instead
+	// of refusing the upgrade when the new version regresses generation.
+	labelRegression bool
+}
+
+// runUpgrade upgrades the librarian version in librarian.yaml after checking
+// that the new version generates the libraries at least as well as the
+// current version. The comparison report is printed to w. It returns the new
+// version, and an error if one occurred or if the new version regresses
+// generation.
+func runUpgrade(ctx context.Context, w io.Writer, repoDir string, opts upgradeOptions) (string, error) {
+	if repoDir == "" {
+		repoDir = "."
+	}
+	version := opts.version
+	if version == "" {
+		var err error
+		version, err = getLibrarianVersionAtMain(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get latest librarian version: %w", err)
+		}
+	}
+	cfg, err := yaml.Read[config.Config](filepath.Join(repoDir, config.LibrarianYAML))
 	if err != nil {
-		return "", fmt.Errorf("failed to get latest librarian version: %w", err)
+		return "", fmt.Errorf("failed to read librarian version: %w", err)
+	}
+	if cfg.Version == "" {
+		return "", errors.New("librarian.yaml must specify the librarian version")
+	}
+	// The upgrade resets the worktree between the generations, which would
+	// discard any uncommitted changes.
+	if err := assertCleanWorktree(ctx, repoDir); err != nil {
+		return "", err
 	}
 
+	old, err := generateAll(ctx, repoDir, cfg.Version, opts.run)
+	if err != nil {
+		return "", err
+	}
+	if old.tidyErr != nil {
+		return "", fmt.Errorf("failed to run librarian tidy with %s: %w", cfg.Version, old.tidyErr)
+	}
+	// Generate with the new version from a clean tree, so the files written
+	// by the current version do not hide the changes of the new version.
+	if err := resetWorktree(ctx, repoDir); err != nil {
+		return "", fmt.Errorf("failed to reset %s: %w", repoDir, err)
+	}
 	if err := updateLibrarianVersion(version, repoDir); err != nil {
 		return "", fmt.Errorf("failed to update librarian version: %w", err)
 	}
-
-	if err := runLibrarianWithVersion(ctx, version, command.Verbose, "generate", "--all"); err != nil {
-		return "", fmt.Errorf("failed to run librarian generate: %w", err)
+	newGen, err := generateAll(ctx, repoDir, version, opts.run)
+	if err != nil {
+		return "", err
+	}
+	report, err := compareGenerations(repoDir, old, newGen)
+	if err != nil {
+		return "", err
 	}
 
+	body := report.String()
+	if _, err := fmt.Fprint(w, body); err != nil {
+		return "", err
+	}
+	if opts.reportPath != "" {
+		if err := os.WriteFile(opts.reportPath, []byte(body), 0644); err != nil {
+			return "", err
+		}
+	}
+	if report.regressed() && !opts.labelRegression {
+		return "", fmt.Errorf("%w: %s", errUpgradeRegression, version)
+	}
+	if !opts.createPR {
+		return version, nil
+	}
+	pr := &pullRequest{
+		Title: fmt.Sprintf("chore: upgrade librarian to %s", version),
+		Body:  body,
+	}
+	if report.regressed() {
+		pr.Labels = []string{regressionLabel}
+	}
+	url, err := createUpgradePR(ctx, opts.forge, opts.repo, repoDir, pr)
+	if err != nil {
+		return "", fmt.Errorf("failed to create pull request: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "\nPull request: %s\n", url); err != nil {
+		return "", err
+	}
 	return version, nil
 }
 
+// createUpgradePR commits the changes in repoDir to upgradeBranch, pushes it
+// to f and opens or updates the pull request, returning its URL.
+func createUpgradePR(ctx context.Context, f forge, repo, repoDir string, pr *pullRequest) (string, error) {
+	if err := f.CreateBranch(ctx, repoDir, upgradeBranch); err != nil {
+		return "", err
+	}
+	if err := commitChanges(ctx, repoDir, pr.Title); err != nil {
+		return "", err
+	}
+	if err := f.Push(ctx, repo, repoDir, upgradeBranch); err != nil {
+		return "", err
+	}
+	return upsertPullRequest(ctx, f, repo, repoDir, upgradeBranch, pr)
+}
+
+// assertCleanWorktree returns an error if repoDir has uncommitted changes or
+// untracked files.
+func assertCleanWorktree(ctx context.Context, repoDir string) error {
+	output, err := command.OutputInDir(ctx, repoDir, command.Git, "status", "--porcelain")
+	if err != nil {
+		return fmt.Errorf("failed to check git status of %s: %w", repoDir, err)
+	}
+	if output != "" {
+		return fmt.Errorf("%w: %s", git.ErrGitStatusUnclean, repoDir)
+	}
+	return nil
+}
+
+// resetWorktree discards the changes to the tracked files in repoDir and
+// removes the untracked files. Callers must check with assertCleanWorktree
+// that repoDir had no changes before they modified it.
+func resetWorktree(ctx context.Context, repoDir string) error {
+	if err := command.RunInDir(ctx, repoDir, command.Git, "checkout", "--", "."); err != nil {
+		return err
+	}
+	return command.RunInDir(ctx, repoDir, command.Git, "clean", "-fd")
+}
+
+// runLibrarian runs the given version of librarian with go run.
+func runLibrarian(ctx context.Context, repoDir, version string, args ...string) error {
+	return runLibrarianWithVersion(ctx, repoDir, version, command.Verbose, args...)
+}
+
 func getLibrarianVersionAtMain(ctx context.Context) (string, error) {
 	output, err := command.Output(ctx, command.Go, "list", "-m", "-json", "github.com/googleapis/librarian@main")
 	if err != nil {
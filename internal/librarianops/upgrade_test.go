@@ -15,14 +15,20 @@
 package librarianops
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/command"
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/sample"
+	"github.com/googleapis/librarian/internal/testhelper"
 	"github.com/googleapis/librarian/internal/yaml"
 	"golang.org/x/mod/semver"
 )
@@ -44,17 +50,14 @@ func TestRunUpgrade(t *testing.T) {
 		t.Fatalf("version from getLibrarianVersionAtMain %q is not a valid semantic version", wantVersion)
 	}
 
-	repoDir := t.TempDir()
-	t.Chdir(repoDir)
-	configPath := generateLibrarianConfigPath(t, repoDir)
 	initialConfig := sample.Config()
 	initialConfig.Language = config.LanguageFake
 	initialConfig.Version = "v0.1.0"
-	if err := yaml.Write(configPath, initialConfig); err != nil {
-		t.Fatal(err)
-	}
+	repoDir := newUpgradeRepo(t, initialConfig)
+	t.Chdir(repoDir)
+	configPath := generateLibrarianConfigPath(t, repoDir)
 
-	gotVersion, err := runUpgrade(t.Context(), repoDir)
+	gotVersion, err := runUpgrade(t.Context(), &bytes.Buffer{}, repoDir, upgradeOptions{run: fakeLibrarian(nil)})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -76,11 +79,10 @@ func TestRunUpgrade(t *testing.T) {
 }
 
 func TestRunUpgrade_Error(t *testing.T) {
-	t.Setenv("GOPROXY", testRetryingGoProxy)
-
 	for _, test := range []struct {
 		name           string
 		setup          func(t *testing.T) (repoDir string)
+		opts           upgradeOptions
 		wantErrMessage string
 	}{
 		{
@@ -93,9 +95,9 @@ func TestRunUpgrade_Error(t *testing.T) {
 			wantErrMessage: "failed to get latest librarian version",
 		},
 		{
-			name: "UpdateLibrarianVersion error",
+			name: "read config error",
 			setup: func(t *testing.T) string {
-				// Make writing the config file fail by creating a directory at its path.
+				// Make reading the config file fail by creating a directory at its path.
 				repoDir := t.TempDir()
 				configPath := generateLibrarianConfigPath(t, repoDir)
 				if err := os.Mkdir(configPath, 0755); err != nil {
@@ -103,27 +105,51 @@ func TestRunUpgrade_Error(t *testing.T) {
 				}
 				return repoDir
 			},
-			wantErrMessage: "failed to update librarian version",
+			opts:           upgradeOptions{version: "v0.2.0", run: fakeLibrarian(nil)},
+			wantErrMessage: "failed to read librarian version",
 		},
 		{
-			name: "runLibrarianWithVersion error",
+			name: "missing version",
 			setup: func(t *testing.T) string {
 				repoDir := t.TempDir()
-				configPath := generateLibrarianConfigPath(t, repoDir)
-				// Use an invalid config that will cause `librarian generate` to fail.
-				// An empty language should be invalid.
-				cfg := &config.Config{Language: config.LanguagePhp}
-				if err := yaml.Write(configPath, cfg); err != nil {
+				cfg := &config.Config{Language: config.LanguageFake}
+				if err := yaml.Write(generateLibrarianConfigPath(t, repoDir), cfg); err != nil {
+					t.Fatal(err)
+				}
+				return repoDir
+			},
+			opts:           upgradeOptions{version: "v0.2.0", run: fakeLibrarian(nil)},
+			wantErrMessage: "librarian.yaml must specify the librarian version",
+		},
+		{
+			name: "uncommitted changes",
+			setup: func(t *testing.T) string {
+				repoDir := newUpgradeRepo(t, sample.Config())
+				if err := os.WriteFile(filepath.Join(repoDir, "notes.txt"), []byte("keep me"), 0644); err != nil {
 					t.Fatal(err)
 				}
 				return repoDir
 			},
-			wantErrMessage: "failed to run librarian generate",
+			opts:           upgradeOptions{version: "v0.2.0", run: fakeLibrarian(nil)},
+			wantErrMessage: "git working directory is not clean",
+		},
+		{
+			name: "tidy error with current version",
+			setup: func(t *testing.T) string {
+				return newUpgradeRepo(t, sample.Config())
+			},
+			opts: upgradeOptions{
+				version: "v0.2.0",
+				run: fakeLibrarian(map[string]bool{
+					sample.LibrarianVersion + " tidy": true,
+				}),
+			},
+			wantErrMessage: "failed to run librarian tidy with v0.1.0",
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			repoDir := test.setup(t)
-			_, gotErr := runUpgrade(t.Context(), repoDir)
+			_, gotErr := runUpgrade(t.Context(), &bytes.Buffer{}, repoDir, test.opts)
 			if gotErr == nil {
 				t.Fatal("got nil, want error")
 			}
@@ -134,6 +160,209 @@ func TestRunUpgrade_Error(t *testing.T) {
 	}
 }
 
+func TestRunUpgrade_Compare(t *testing.T) {
+	const newVersion = "v0.2.0"
+	for _, test := range []struct {
+		name            string
+		fail            map[string]bool
+		labelRegression bool
+		wantErr         error
+		wantReport      string
+	}{
+		{
+			name: "no regression",
+			wantReport: `## Upgrade librarian from v0.1.0 to v0.2.0
+
+- Files changed: 2
+- Libraries changed: 2
+- Libraries failing only with v0.2.0: 0
+- Libraries fixed by v0.2.0: 0
+
+### Libraries changed
+
+- google-cloud-storage
+- gax-internal
+
+### Files changed
+
+- src/gax-internal/README.md
+- src/storage/README.md
+`,
+		},
+		{
+			name: "library fails only with new version",
+			fail: map[string]bool{
+				newVersion + " generate " + sample.Lib2Name: true,
+			},
+			wantErr: errUpgradeRegression,
+			wantReport: `## Upgrade librarian from v0.1.0 to v0.2.0
+
+**v0.2.0 regresses generation.**
+
+- Files changed: 2
+- Libraries changed: 2
+- Libraries failing only with v0.2.0: 1
+- Libraries fixed by v0.2.0: 0
+
+### Libraries failing only with v0.2.0
+
+- gax-internal: ` + "`generate gax-internal failed`" + `
+
+### Libraries changed
+
+- google-cloud-storage
+- gax-internal
+
+### Files changed
+
+- src/gax-internal/README.md
+- src/storage/README.md
+`,
+		},
+		{
+			name: "regression with label",
+			fail: map[string]bool{
+				newVersion + " tidy": true,
+			},
+			labelRegression: true,
+			wantReport: `## Upgrade librarian from v0.1.0 to v0.2.0
+
+**v0.2.0 regresses generation.**
+
+- Files changed: 2
+- Libraries changed: 2
+- Libraries failing only with v0.2.0: 0
+- Libraries fixed by v0.2.0: 0
+
+### librarian tidy fails with v0.2.0
+
+` + "```\ntidy failed\n```" + `
+
+### Libraries changed
+
+- google-cloud-storage
+- gax-internal
+
+### Files changed
+
+- src/gax-internal/README.md
+- src/storage/README.md
+`,
+		},
+		{
+			name: "library fixed by new version",
+			fail: map[string]bool{
+				sample.LibrarianVersion + " generate " + sample.Lib2Name: true,
+			},
+			wantReport: `## Upgrade librarian from v0.1.0 to v0.2.0
+
+- Files changed: 2
+- Libraries changed: 2
+- Libraries failing only with v0.2.0: 0
+- Libraries fixed by v0.2.0: 1
+
+### Libraries fixed by v0.2.0
+
+- gax-internal
+
+### Libraries changed
+
+- google-cloud-storage
+- gax-internal
+
+### Files changed
+
+- src/gax-internal/README.md
+- src/storage/README.md
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			repoDir := newUpgradeRepo(t, sample.Config())
+			reportPath := filepath.Join(t.TempDir(), "report.md")
+			var out bytes.Buffer
+			got, err := runUpgrade(t.Context(), &out, repoDir, upgradeOptions{
+				version:         newVersion,
+				run:             fakeLibrarian(test.fail),
+				reportPath:      reportPath,
+				labelRegression: test.labelRegression,
+			})
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Errorf("runUpgrade() error = %v, wantErr %v", err, test.wantErr)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			} else if got != newVersion {
+				t.Errorf("runUpgrade() = %q, want %q", got, newVersion)
+			}
+			if diff := cmp.Diff(test.wantReport, out.String()); diff != "" {
+				t.Errorf("report mismatch (-want +got):\n%s", diff)
+			}
+			report, err := os.ReadFile(reportPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.wantReport, string(report)); diff != "" {
+				t.Errorf("report file mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunUpgrade_PullRequest(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	const newVersion = "v0.2.0"
+	f := newFakeForge(t.TempDir())
+	setupFakeRemote(t, f)
+
+	// The second run regresses generation, and updates the pull request
+	// opened by the first run instead of creating another one.
+	for _, fail := range []map[string]bool{
+		nil,
+		{newVersion + " generate " + sample.Lib2Name: true},
+	} {
+		repoDir := t.TempDir()
+		if err := f.Clone(t.Context(), repoFake, repoDir); err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		if _, err := runUpgrade(t.Context(), &out, repoDir, upgradeOptions{
+			version:         newVersion,
+			run:             fakeLibrarian(fail),
+			forge:           f,
+			repo:            repoFake,
+			createPR:        true,
+			labelRegression: true,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if want := "Pull request: " + f.url(repoFake, 1); !strings.Contains(out.String(), want) {
+			t.Errorf("output = %q, want %q", out.String(), want)
+		}
+	}
+
+	if got := len(f.pullRequests); got != 1 {
+		t.Fatalf("got %d pull requests, want 1", got)
+	}
+	got := f.pullRequests[0]
+	if got.Branch != upgradeBranch {
+		t.Errorf("got branch %q, want %q", got.Branch, upgradeBranch)
+	}
+	if want := "chore: upgrade librarian to " + newVersion; got.Title != want {
+		t.Errorf("got title %q, want %q", got.Title, want)
+	}
+	if !strings.Contains(got.Body, "regresses generation") {
+		t.Errorf("got body %q, want the report of the second run", got.Body)
+	}
+	if diff := cmp.Diff([]string{regressionLabel}, got.Labels); diff != "" {
+		t.Errorf("labels mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestUpgradeCommand(t *testing.T) {
 	// Chdir is necessary because the upgrade command's -C flag defaults to the
 	// current working directory.
@@ -141,13 +370,22 @@ func TestUpgradeCommand(t *testing.T) {
 	t.Chdir(repoDir)
 	t.Setenv("GOPROXY", testRetryingGoProxy)
 
+	// Upgrade from the version at main, so that both versions are able to
+	// generate the fake libraries.
+	version, err := getLibrarianVersionAtMain(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
 	configPath := generateLibrarianConfigPath(t, ".")
 	initialConfig := sample.Config()
 	initialConfig.Language = config.LanguageFake
-	initialConfig.Version = "v0.1.0"
+	initialConfig.Version = version
 	if err := yaml.Write(configPath, initialConfig); err != nil {
 		t.Fatal(err)
 	}
+	testhelper.RunGit(t, "init", "-b", config.BranchMain, repoDir)
+	testhelper.RunGit(t, "-C", repoDir, "add", ".")
+	testhelper.RunGit(t, "-C", repoDir, "-c", "user.name=Test User", "-c", "user.email=test@example.com", "commit", "-m", "initial commit")
 
 	cmd := upgradeCommand()
 	if err := cmd.Run(t.Context(), []string{"-C", "."}); err != nil {
@@ -192,6 +430,50 @@ func TestUpgradeCommand_Error(t *testing.T) {
 	}
 }
 
+// fakeLibrarian returns a librarianRunner that writes the librarian version
+// to the README.md file of each generated library, and fails the commands
+// listed in fail as "<version> <args>".
+func fakeLibrarian(fail map[string]bool) librarianRunner {
+	return func(ctx context.Context, repoDir, version string, args ...string) error {
+		cmd := strings.Join(args, " ")
+		if fail[version+" "+cmd] {
+			return fmt.Errorf("%s failed", cmd)
+		}
+		if args[0] != "generate" {
+			return nil
+		}
+		cfg, err := yaml.Read[config.Config](filepath.Join(repoDir, config.LibrarianYAML))
+		if err != nil {
+			return err
+		}
+		for _, library := range cfg.Libraries {
+			if library.Name != args[1] {
+				continue
+			}
+			if err := os.MkdirAll(filepath.Join(repoDir, library.Output), 0755); err != nil {
+				return err
+			}
+			return os.WriteFile(filepath.Join(repoDir, library.Output, "README.md"), []byte(version), 0644)
+		}
+		return fmt.Errorf("library %q not found", args[1])
+	}
+}
+
+// newUpgradeRepo creates a git repository with cfg as its librarian.yaml and
+// returns its path.
+func newUpgradeRepo(t *testing.T, cfg *config.Config) string {
+	t.Helper()
+	testhelper.RequireCommand(t, command.Git)
+	repoDir := t.TempDir()
+	if err := yaml.Write(generateLibrarianConfigPath(t, repoDir), cfg); err != nil {
+		t.Fatal(err)
+	}
+	testhelper.RunGit(t, "init", "-b", config.BranchMain, repoDir)
+	testhelper.RunGit(t, "-C", repoDir, "add", ".")
+	testhelper.RunGit(t, "-C", repoDir, "-c", "user.name=Test User", "-c", "user.email=test@example.com", "commit", "-m", "initial commit")
+	return repoDir
+}
+
 func generateLibrarianConfigPath(t *testing.T, repoDir string) string {
 	t.Helper()
 	return filepath.Join(repoDir, config.LibrarianYAML)
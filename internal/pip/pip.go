@@ -17,13 +17,17 @@ package pip
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/googleapis/librarian/internal/command"
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/toollock"
 )
 
 var (
@@ -32,10 +36,26 @@ var (
 
 	// ErrLocalPathNotFound indicates that the specified local package path does not exist.
 	ErrLocalPathNotFound = errors.New("local pip package path not found")
+
+	// errDownload indicates that pip did not download exactly one distribution file.
+	errDownload = errors.New("expected one downloaded distribution file")
 )
 
-// Install installs a list of pip tools into the environment.
-func Install(ctx context.Context, tools []*config.PipTool) error {
+// Install installs a list of pip tools into the virtualenv at venv, creating
+// the virtualenv with python3 if it does not exist. It downloads the
+// distribution file of each tool from the package index, verifies and records
+// its checksum in lock, and installs the tool from the verified file. Tools
+// installed from a local path or a URL are recorded without a checksum.
+func Install(ctx context.Context, venv string, tools []*config.PipTool, lock *toollock.Lock) error {
+	if err := createVenv(ctx, venv); err != nil {
+		return err
+	}
+	pip := pipPath(venv)
+	downloads, err := os.MkdirTemp("", "librarian-pip-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(downloads)
 	var installTargets []string
 	for _, tool := range tools {
 		if tool.LocalPath != "" {
@@ -47,22 +67,123 @@ func Install(ctx context.Context, tools []*config.PipTool) error {
 				return fmt.Errorf("%w: %w", ErrLocalPathNotFound, err)
 			}
 			installTargets = append(installTargets, absPath)
+			lock.Record(toollock.KindPip, tool.Name, tool.Version, "")
 			continue
 		}
-		if tool.Package != "" {
+		if isURL(tool.Package) {
 			installTargets = append(installTargets, tool.Package)
+			lock.Record(toollock.KindPip, tool.Name, tool.Version, "")
 			continue
 		}
-		if tool.Version != "" {
-			installTargets = append(installTargets, fmt.Sprintf("%s==%s", tool.Name, tool.Version))
-			continue
+		target := tool.Name
+		switch {
+		case tool.Package != "":
+			target = tool.Package
+		case tool.Version != "":
+			target = fmt.Sprintf("%s==%s", tool.Name, tool.Version)
+		}
+		file, err := download(ctx, pip, filepath.Join(downloads, tool.Name), tool, target, lock)
+		if err != nil {
+			return err
 		}
-		installTargets = append(installTargets, tool.Name)
+		installTargets = append(installTargets, file)
 	}
 	args := []string{"install"}
 	args = append(args, installTargets...)
-	if err := command.RunStreaming(ctx, "pip", args...); err != nil {
+	if err := command.RunStreaming(ctx, pip, args...); err != nil {
 		return fmt.Errorf("%w: %w", ErrInstall, err)
 	}
 	return nil
 }
+
+// InstalledTools returns the pip packages installed in the virtualenv at
+// venv. It returns nil if the virtualenv does not exist.
+func InstalledTools(ctx context.Context, venv string) ([]*toollock.Tool, error) {
+	pip := pipPath(venv)
+	if _, err := os.Stat(pip); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	output, err := command.Output(ctx, pip, "list", "--format=json")
+	if err != nil {
+		return nil, err
+	}
+	var packages []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(output), &packages); err != nil {
+		return nil, fmt.Errorf("parsing pip list output: %w", err)
+	}
+	var tools []*toollock.Tool
+	for _, p := range packages {
+		tools = append(tools, &toollock.Tool{Kind: toollock.KindPip, Name: p.Name, Version: p.Version})
+	}
+	return tools, nil
+}
+
+// createVenv creates the virtualenv at venv, unless it already exists.
+func createVenv(ctx context.Context, venv string) error {
+	if _, err := os.Stat(pipPath(venv)); err == nil {
+		return nil
+	}
+	if err := command.Run(ctx, "python3", "-m", "venv", venv); err != nil {
+		return fmt.Errorf("%w: creating virtualenv: %w", ErrInstall, err)
+	}
+	return nil
+}
+
+// pipPath returns the path of the pip executable of the virtualenv at venv.
+func pipPath(venv string) string {
+	return filepath.Join(venv, "bin", "pip")
+}
+
+// download downloads the distribution file of target into dir with pip,
+// without its dependencies, and verifies its checksum in lock. The file
+// depends on the platform when the tool is published as platform-specific
+// wheels, so its checksum is recorded by file name. It returns the path of
+// the verified file.
+func download(ctx context.Context, pip, dir string, tool *config.PipTool, target string, lock *toollock.Lock) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	if err := command.Run(ctx, pip, "download", "--no-deps", "--dest", dir, target); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInstall, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) != 1 {
+		return "", fmt.Errorf("%w for %s, got %d", errDownload, target, len(entries))
+	}
+	file := entries[0].Name()
+	version := tool.Version
+	if version == "" {
+		version = versionFromFilename(file)
+	}
+	path := filepath.Join(dir, file)
+	if err := lock.VerifyFile(toollock.KindPip, tool.Name, version, tool.Checksum, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// versionFromFilename returns the version in the name of a wheel, such as
+// "ruff-0.14.14-py3-none-any.whl", or of a source distribution, such as
+// "nox-2025.11.12.tar.gz".
+func versionFromFilename(file string) string {
+	for _, ext := range []string{".whl", ".tar.gz", ".zip"} {
+		file = strings.TrimSuffix(file, ext)
+	}
+	parts := strings.Split(file, "-")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+// isURL reports whether a pip install specifier is a URL, such as
+// "git+https://github.com/..." or "pkg @ https://...".
+func isURL(spec string) bool {
+	return strings.Contains(spec, "://")
+}
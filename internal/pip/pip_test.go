@@ -15,6 +15,7 @@
 package pip
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"os"
@@ -24,23 +25,20 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/toollock"
 )
 
 func TestInstall(t *testing.T) {
 	tmpDir := t.TempDir()
 	stubLogPath := filepath.Join(tmpDir, "pip_invocations.log")
+	// The stub logs its arguments, replacing the temporary download
+	// directory, and downloads the target to <target>.tar.gz in the --dest
+	// directory.
 	stubContent := fmt.Sprintf(`#!/bin/sh
-echo "pip $@" >> %q
+echo "pip $@" | sed 's|[^ ]*librarian-pip-[^/ ]*|*|g' >> %q
+if [ "$1" = download ]; then echo "$5" > "$4/$(echo "$5" | sed 's/==/-/').tar.gz"; fi
 `, stubLogPath)
-	stubDir := filepath.Join(tmpDir, "bin")
-	if err := os.MkdirAll(stubDir, 0755); err != nil {
-		t.Fatal(err)
-	}
-	stubPath := filepath.Join(stubDir, "pip")
-	if err := os.WriteFile(stubPath, []byte(stubContent), 0755); err != nil {
-		t.Fatal(err)
-	}
-	t.Setenv("PATH", stubDir)
+	venv := stubVenv(t, stubContent)
 	localPkgPath := filepath.Join(tmpDir, "mylocalpkg")
 	if err := os.MkdirAll(localPkgPath, 0755); err != nil {
 		t.Fatal(err)
@@ -48,7 +46,8 @@ echo "pip $@" >> %q
 	for _, test := range []struct {
 		name     string
 		tools    []*config.PipTool
-		wantArgs string
+		want     []string
+		wantLock []*toollock.Tool
 	}{
 		{
 			name: "install external packages",
@@ -56,33 +55,54 @@ echo "pip $@" >> %q
 				{Name: "PyYAML", Version: "6.0.2"},
 				{Name: "jinja2", Version: "3.1.6"},
 			},
-			wantArgs: "install PyYAML==6.0.2 jinja2==3.1.6",
+			want: []string{
+				"pip download --no-deps --dest */PyYAML PyYAML==6.0.2",
+				"pip download --no-deps --dest */jinja2 jinja2==3.1.6",
+				"pip install */PyYAML/PyYAML-6.0.2.tar.gz */jinja2/jinja2-3.1.6.tar.gz",
+			},
+			wantLock: []*toollock.Tool{
+				{Kind: toollock.KindPip, Name: "PyYAML", Version: "6.0.2", Files: map[string]string{"PyYAML-6.0.2.tar.gz": sha256Hex("PyYAML==6.0.2\n")}},
+				{Kind: toollock.KindPip, Name: "jinja2", Version: "3.1.6", Files: map[string]string{"jinja2-3.1.6.tar.gz": sha256Hex("jinja2==3.1.6\n")}},
+			},
 		},
 		{
 			name: "install external packages with raw package spec",
 			tools: []*config.PipTool{
 				{Name: "synthtool", Package: "git+https://github.com/..."},
 			},
-			wantArgs: "install git+https://github.com/...",
+			want: []string{"pip install git+https://github.com/..."},
+			wantLock: []*toollock.Tool{
+				{Kind: toollock.KindPip, Name: "synthtool"},
+			},
 		},
 		{
 			name: "install package with name only (no version/package)",
 			tools: []*config.PipTool{
 				{Name: "requests"},
 			},
-			wantArgs: "install requests",
+			want: []string{
+				"pip download --no-deps --dest */requests requests",
+				"pip install */requests/requests.tar.gz",
+			},
+			wantLock: []*toollock.Tool{
+				{Kind: toollock.KindPip, Name: "requests", Files: map[string]string{"requests.tar.gz": sha256Hex("requests\n")}},
+			},
 		},
 		{
 			name: "install local package path",
 			tools: []*config.PipTool{
 				{Name: "synthtool", LocalPath: localPkgPath},
 			},
-			wantArgs: "install " + localPkgPath,
+			want: []string{"pip install " + localPkgPath},
+			wantLock: []*toollock.Tool{
+				{Kind: toollock.KindPip, Name: "synthtool"},
+			},
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			_ = os.Remove(stubLogPath)
-			err := Install(t.Context(), test.tools)
+			lock := &toollock.Lock{}
+			err := Install(t.Context(), venv, test.tools, lock)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -90,57 +110,168 @@ echo "pip $@" >> %q
 			if err != nil {
 				t.Fatal(err)
 			}
-			gotInvocations := strings.TrimSpace(string(data))
-			wantInvocation := "pip " + test.wantArgs
-			if diff := cmp.Diff(wantInvocation, gotInvocations); diff != "" {
+			got := strings.Split(strings.TrimSpace(string(data)), "\n")
+			if diff := cmp.Diff(test.want, got); diff != "" {
 				t.Errorf("mismatch (-want +got):\n%s", diff)
 			}
+			if diff := cmp.Diff(test.wantLock, lock.Tools); diff != "" {
+				t.Errorf("lock mismatch (-want +got):\n%s", diff)
+			}
 		})
 	}
 }
 
-func TestInstall_Error(t *testing.T) {
-	tmpDir := t.TempDir()
-	stubDir := filepath.Join(tmpDir, "bin")
-	if err := os.MkdirAll(stubDir, 0755); err != nil {
+func TestInstall_ChecksumMismatch(t *testing.T) {
+	venv := stubVenv(t, `#!/bin/sh
+if [ "$1" = download ]; then echo "$5" > "$4/pkg-1.0.0.tar.gz"; fi
+`)
+	tools := []*config.PipTool{{Name: "pkg", Version: "1.0.0"}}
+	lock := &toollock.Lock{Tools: []*toollock.Tool{
+		{Kind: toollock.KindPip, Name: "pkg", Version: "1.0.0", Files: map[string]string{"pkg-1.0.0.tar.gz": sha256Hex("other\n")}},
+	}}
+	if err := Install(t.Context(), venv, tools, lock); !errors.Is(err, toollock.ErrChecksumMismatch) {
+		t.Errorf("Install() error = %v, want %v", err, toollock.ErrChecksumMismatch)
+	}
+}
+
+func TestVersionFromFilename(t *testing.T) {
+	for _, test := range []struct {
+		file string
+		want string
+	}{
+		{"ruff-0.14.14-py3-none-manylinux_2_17_x86_64.whl", "0.14.14"},
+		{"nox-2025.11.12.tar.gz", "2025.11.12"},
+		{"pkg-1.0.zip", "1.0"},
+		{"unknown", ""},
+	} {
+		t.Run(test.file, func(t *testing.T) {
+			if got := versionFromFilename(test.file); got != test.want {
+				t.Errorf("versionFromFilename(%q) = %q, want %q", test.file, got, test.want)
+			}
+		})
+	}
+}
+
+func TestInstalledTools(t *testing.T) {
+	venv := stubVenv(t, `#!/bin/sh
+echo '[{"name": "PyYAML", "version": "6.0.2"}, {"name": "nox", "version": "2025.11.12"}]'
+`)
+	got, err := InstalledTools(t.Context(), venv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*toollock.Tool{
+		{Kind: toollock.KindPip, Name: "PyYAML", Version: "6.0.2"},
+		{Kind: toollock.KindPip, Name: "nox", Version: "2025.11.12"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInstalledTools_NoVenv(t *testing.T) {
+	got, err := InstalledTools(t.Context(), filepath.Join(t.TempDir(), "venv"))
+	if err != nil {
 		t.Fatal(err)
 	}
-	stubPath := filepath.Join(stubDir, "pip")
-	if err := os.WriteFile(stubPath, []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+	if got != nil {
+		t.Errorf("InstalledTools() = %v, want nil", got)
+	}
+}
+
+func TestInstall_CreatesVenv(t *testing.T) {
+	// The python3 stub creates a virtualenv whose pip only logs its
+	// arguments.
+	stubDir := t.TempDir()
+	logPath := filepath.Join(stubDir, "pip.log")
+	python3 := fmt.Sprintf(`#!/bin/sh
+mkdir -p "$3/bin"
+printf '#!/bin/sh\necho "$@" >> %s\n' > "$3/bin/pip"
+chmod +x "$3/bin/pip"
+`, logPath)
+	if err := os.WriteFile(filepath.Join(stubDir, "python3"), []byte(python3), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", stubDir+":"+os.Getenv("PATH"))
+	venv := filepath.Join(t.TempDir(), "venv")
+	localPkgPath := t.TempDir()
+	tools := []*config.PipTool{{Name: "pkg", LocalPath: localPkgPath}}
+	if err := Install(t.Context(), venv, tools, &toollock.Lock{}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(logPath)
+	if err != nil {
 		t.Fatal(err)
 	}
+	if diff := cmp.Diff("install "+localPkgPath+"\n", string(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInstall_Error(t *testing.T) {
+	tmpDir := t.TempDir()
+	failingVenv := stubVenv(t, "#!/bin/sh\nexit 1\n")
 	for _, test := range []struct {
 		name    string
+		venv    string
 		tools   []*config.PipTool
 		setup   func(t *testing.T)
 		wantErr error
 	}{
 		{
 			name: "pip command fails",
+			venv: failingVenv,
 			tools: []*config.PipTool{
 				{Name: "failpkg"},
 			},
-			setup: func(t *testing.T) {
-				t.Setenv("PATH", stubDir)
-			},
 			wantErr: ErrInstall,
 		},
 		{
 			name: "local path not found",
+			venv: failingVenv,
 			tools: []*config.PipTool{
 				{Name: "failpkg", LocalPath: filepath.Join(tmpDir, "nonexistentpkg")},
 			},
 			wantErr: ErrLocalPathNotFound,
 		},
+		{
+			name: "virtualenv creation fails",
+			venv: filepath.Join(tmpDir, "venv"),
+			tools: []*config.PipTool{
+				{Name: "failpkg"},
+			},
+			setup: func(t *testing.T) {
+				t.Setenv("PATH", t.TempDir())
+			},
+			wantErr: ErrInstall,
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			if test.setup != nil {
 				test.setup(t)
 			}
-			err := Install(t.Context(), test.tools)
+			err := Install(t.Context(), test.venv, test.tools, &toollock.Lock{})
 			if !errors.Is(err, test.wantErr) {
 				t.Errorf("Install() error = %v, wantErr = %v", err, test.wantErr)
 			}
 		})
 	}
 }
+
+// stubVenv creates a virtualenv whose pip executable is a script with the
+// given content, and returns its directory.
+func stubVenv(t *testing.T, pip string) string {
+	t.Helper()
+	venv := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(venv, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(venv, "bin", "pip"), []byte(pip), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return venv
+}
+
+func sha256Hex(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postprocessing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/googleapis/librarian/internal/config"
+)
+
+var (
+	errInvalidStep = errors.New("invalid post_generate step")
+)
+
+// Apply runs the post-generation steps, in order, on the library generated
+// in outdir. Step paths are relative to outdir, and the files copied with
+// CopyFrom are relative to the current directory.
+func Apply(ctx context.Context, language, outdir string, steps []*config.PostGenerateStep) error {
+	for i, step := range steps {
+		if err := applyStep(ctx, language, outdir, step); err != nil {
+			return fmt.Errorf("post_generate step %d on %q: %w", i, step.Path, err)
+		}
+	}
+	return nil
+}
+
+func applyStep(ctx context.Context, language, outdir string, step *config.PostGenerateStep) error {
+	if step.Path == "" {
+		return fmt.Errorf("%w: missing path", errInvalidStep)
+	}
+	if filepath.IsAbs(step.Path) || !filepath.IsLocal(step.Path) {
+		return fmt.Errorf("%w: path must be inside the library output directory", errInvalidStep)
+	}
+	if n := countActions(step); n != 1 {
+		return fmt.Errorf("%w: want exactly one action, got %d", errInvalidStep, n)
+	}
+	isMethodAction := step.DeleteMethod || step.DeprecateMethod != "" || step.DuplicateMethod != ""
+	if step.Method != "" && !isMethodAction {
+		return fmt.Errorf("%w: method is only used by delete_method, deprecate_method and duplicate_method", errInvalidStep)
+	}
+	if step.With != "" && step.Replace == "" && step.ReplaceRegex == "" {
+		return fmt.Errorf("%w: with is only used by replace and replace_regex", errInvalidStep)
+	}
+	path := filepath.Join(outdir, step.Path)
+	switch {
+	case step.Replace != "":
+		return Replace(path, step.Replace, step.With)
+	case step.ReplaceRegex != "":
+		return ReplaceRegex(path, step.ReplaceRegex, step.With)
+	case step.CopyFrom != "":
+		return CopyFile(step.CopyFrom, path)
+	case step.Remove:
+		return RemoveFile(path)
+	case step.DeleteMethod:
+		return DeleteMethod(path, step.Method, language)
+	case step.DeprecateMethod != "":
+		return DeprecateMethod(path, step.Method, step.DeprecateMethod, language)
+	default:
+		return DuplicateMethod(ctx, path, step.Method, step.DuplicateMethod, language)
+	}
+}
+
+func countActions(step *config.PostGenerateStep) int {
+	n := 0
+	for _, set := range []bool{
+		step.Replace != "",
+		step.ReplaceRegex != "",
+		step.CopyFrom != "",
+		step.Remove,
+		step.DeleteMethod,
+		step.DeprecateMethod != "",
+		step.DuplicateMethod != "",
+	} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postprocessing
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+)
+
+func TestApply(t *testing.T) {
+	outdir := t.TempDir()
+	extra := filepath.Join(t.TempDir(), "extra.go")
+	for path, content := range map[string]string{
+		"client.go": `package secretmanager
+
+// GetSecret gets a secret.
+func (c *Client) GetSecret() error {
+	return nil
+}
+
+// OldSecret is obsolete.
+func (c *Client) OldSecret() error {
+	return nil
+}
+`,
+		"README.md":   "# Secret Manager v1.2.3\n",
+		"obsolete.go": "package secretmanager\n",
+		extra:         "package extra\n",
+	} {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(outdir, path)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	steps := []*config.PostGenerateStep{
+		{Path: "client.go", Method: "func (c *Client) OldSecret()", DeleteMethod: true},
+		{Path: "client.go", Method: "func (c *Client) GetSecret()", DuplicateMethod: "FetchSecret"},
+		{Path: "client.go", Method: "func (c *Client) FetchSecret()", DeprecateMethod: "use GetSecret"},
+		{Path: "client.go", Replace: "gets a secret", With: "returns a secret"},
+		{Path: "README.md", ReplaceRegex: `v(\d+)\.\d+\.\d+`, With: "v${1}"},
+		{Path: "obsolete.go", Remove: true},
+		{Path: "extra.go", CopyFrom: extra},
+	}
+	if err := Apply(t.Context(), config.LanguageGo, outdir, steps); err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{
+		"client.go": `package secretmanager
+
+// GetSecret returns a secret.
+func (c *Client) GetSecret() error {
+	return nil
+}
+
+// Deprecated: use GetSecret
+func (c *Client) FetchSecret() error {
+	return nil
+}
+
+// OldSecret is obsolete.
+`,
+		"README.md": "# Secret Manager v1\n",
+		"extra.go":  "package extra\n",
+	} {
+		got, err := os.ReadFile(filepath.Join(outdir, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, string(got)); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", path, diff)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outdir, "obsolete.go")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected obsolete.go to be removed, got err=%v", err)
+	}
+}
+
+func TestApply_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		step    *config.PostGenerateStep
+		wantErr error
+	}{
+		{
+			name:    "missing path",
+			step:    &config.PostGenerateStep{Remove: true},
+			wantErr: errInvalidStep,
+		},
+		{
+			name:    "path outside output",
+			step:    &config.PostGenerateStep{Path: "../client.go", Remove: true},
+			wantErr: errInvalidStep,
+		},
+		{
+			name:    "no action",
+			step:    &config.PostGenerateStep{Path: "client.go"},
+			wantErr: errInvalidStep,
+		},
+		{
+			name:    "two actions",
+			step:    &config.PostGenerateStep{Path: "client.go", Remove: true, Replace: "a"},
+			wantErr: errInvalidStep,
+		},
+		{
+			name:    "method without method action",
+			step:    &config.PostGenerateStep{Path: "client.go", Method: "func F()", Remove: true},
+			wantErr: errInvalidStep,
+		},
+		{
+			name:    "with without replace",
+			step:    &config.PostGenerateStep{Path: "client.go", With: "b", Remove: true},
+			wantErr: errInvalidStep,
+		},
+		{
+			name:    "replace matches nothing",
+			step:    &config.PostGenerateStep{Path: "client.go", Replace: "missing"},
+			wantErr: errTextNotFound,
+		},
+		{
+			name:    "regex matches nothing",
+			step:    &config.PostGenerateStep{Path: "client.go", ReplaceRegex: "^missing$"},
+			wantErr: errTextNotFound,
+		},
+		{
+			name:    "method not found",
+			step:    &config.PostGenerateStep{Path: "client.go", Method: "func Missing()", DeleteMethod: true},
+			wantErr: errMethodNotFound,
+		},
+		{
+			name:    "remove missing file",
+			step:    &config.PostGenerateStep{Path: "missing.go", Remove: true},
+			wantErr: fs.ErrNotExist,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			outdir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(outdir, "client.go"), []byte("package client\n\nfunc F() {}\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			err := Apply(t.Context(), config.LanguageGo, outdir, []*config.PostGenerateStep{test.step})
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Apply() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -20,9 +20,6 @@ import (
 	"fmt"
 	"os"
 	"regexp"
-	"strings"
-
-	"github.com/googleapis/librarian/internal/config"
 )
 
 var (
@@ -42,25 +39,28 @@ type methodBounds struct {
 	end   int
 }
 
-// DeleteMethod deletes all matching methods from a Java file.
-// It handles brace counting to remove the entire method body.
+// DeleteMethod deletes all matching methods from a Go, Java, Python or
+// TypeScript file. It handles brace counting, or indentation in Python, to
+// remove the entire method body.
 //
 // Note: funcName must be the complete, single-line method signature declaration
-// (including modifiers and return type, e.g., "public void foo()"). Matching is
+// (including modifiers and return type, e.g., "public void foo()"). Python
+// signatures may stop after the opening parenthesis (e.g., "def foo("). Matching is
 // done via exact substring search, so any spacing or formatting mismatch will fail.
 func DeleteMethod(path, funcName, language string) error {
-	if language != config.LanguageJava {
-		return fmt.Errorf("%w: %s", errUnsupportedLanguage, language)
+	syntax, err := syntaxFor(language)
+	if err != nil {
+		return err
 	}
-	if !strings.Contains(funcName, "(") || !strings.Contains(funcName, ")") {
-		return fmt.Errorf("%w: %q (must contain parameter list in parentheses)", errInvalidSignature, funcName)
+	if err := syntax.validateSignature(funcName); err != nil {
+		return err
 	}
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return fmt.Errorf("reading file: %w", err)
 	}
-	cleaned := cleanJavaCode(data)
-	boundsList, err := findMethodBounds(data, cleaned, funcName)
+	cleaned := syntax.clean(data)
+	boundsList, err := syntax.bounds(data, cleaned, funcName)
 	if err != nil {
 		return fmt.Errorf("deleting method %s in %s: %w", funcName, path, err)
 	}
@@ -101,9 +101,7 @@ func findMethodBounds(data, cleaned []byte, funcName string) ([]methodBounds, er
 
 // cleanJavaCode replaces comments, strings, and char literals with spaces.
 func cleanJavaCode(content []byte) []byte {
-	return javaCleanRegex.ReplaceAllFunc(content, func(match []byte) []byte {
-		return bytes.Repeat([]byte(" "), len(match))
-	})
+	return blankMatches(javaCleanRegex, content)
 }
 
 // findOpeningBrace returns the index of the first '{' after start, or error if ';' is found first.
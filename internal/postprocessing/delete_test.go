@@ -264,6 +264,126 @@ class B {
 	}
 }
 
+func TestDeleteMethod_Languages(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		name     string
+		language string
+		content  string
+		funcName string
+		want     string
+	}{
+		{
+			name:     "go method",
+			language: "go",
+			content: `package secretmanager
+
+// GetSecret gets a secret.
+func (c *Client) GetSecret(ctx context.Context, name string) (*Secret, error) {
+	s := "}"
+	return c.get(ctx, name, s)
+}
+
+func (c *Client) ListSecrets(ctx context.Context) []*Secret {
+	return nil
+}
+`,
+			funcName: "func (c *Client) GetSecret(ctx context.Context, name string)",
+			want: `package secretmanager
+
+// GetSecret gets a secret.
+
+func (c *Client) ListSecrets(ctx context.Context) []*Secret {
+	return nil
+}
+`,
+		},
+		{
+			name:     "python method",
+			language: "python",
+			content: `class SecretManagerClient:
+    @property
+    def transport(self):
+        return self._transport
+
+    @staticmethod
+    def get_secret(
+        request: GetSecretRequest = None,
+    ) -> Secret:
+        """Gets a secret.
+
+Not indented.
+        """
+        if request is None:
+
+            request = GetSecretRequest()
+        return request
+
+    def list_secrets(self):
+        pass
+`,
+			funcName: "def get_secret(",
+			want: `class SecretManagerClient:
+    @property
+    def transport(self):
+        return self._transport
+
+
+    def list_secrets(self):
+        pass
+`,
+		},
+		{
+			name:     "python docstring only",
+			language: "python",
+			content: `def get_secret(request):
+    """Gets a secret."""
+
+def list_secrets():
+    pass
+`,
+			funcName: "def get_secret(",
+			want: `
+def list_secrets():
+    pass
+`,
+		},
+		{
+			name:     "typescript method",
+			language: "nodejs",
+			content: "export class Client {\n" +
+				"  /** Gets a secret. */\n" +
+				"  async getSecret(request: GetSecretRequest): Promise<Secret> {\n" +
+				"    const path = `/v1/${request.name}}`;\n" +
+				"    return this.call(path);\n" +
+				"  }\n" +
+				"}\n",
+			funcName: "async getSecret(request: GetSecretRequest)",
+			want: "export class Client {\n" +
+				"  /** Gets a secret. */\n" +
+				"}\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			tmpFile := filepath.Join(t.TempDir(), "test.src")
+			if err := os.WriteFile(tmpFile, []byte(test.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := DeleteMethod(tmpFile, test.funcName, test.language); err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(tmpFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, string(got)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDeleteMethod_Error(t *testing.T) {
 	t.Parallel()
 	for _, test := range []struct {
@@ -284,7 +404,7 @@ func TestDeleteMethod_Error(t *testing.T) {
 			name:     "unsupported language",
 			content:  "def m(): pass",
 			funcName: "def m()",
-			language: "python",
+			language: "rust",
 			wantErr:  errUnsupportedLanguage,
 		},
 		{
@@ -301,6 +421,20 @@ func TestDeleteMethod_Error(t *testing.T) {
 			language: "java",
 			wantErr:  errInvalidSignature,
 		},
+		{
+			name:     "python signature without parenthesis",
+			content:  "def m(): pass",
+			funcName: "def m",
+			language: "python",
+			wantErr:  errInvalidSignature,
+		},
+		{
+			name:     "python signature end not found",
+			content:  "def m(a, b",
+			funcName: "def m(",
+			language: "python",
+			wantErr:  errSignatureEndNotFound,
+		},
 		{
 			name:     "closing brace not found",
 			content:  "class T { void m() { System.out.println();",
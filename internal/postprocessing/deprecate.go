@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/googleapis/librarian/internal/config"
@@ -39,18 +40,24 @@ type methodHeader struct {
 	firstAnnotationIdx int
 }
 
-// DeprecateMethod deprecates a Java method by adding the @Deprecated annotation
-// and appending a @deprecated tag in its Javadoc block.
+// DeprecateMethod deprecates a method. In Java it adds the @Deprecated
+// annotation and appends a @deprecated tag in its Javadoc block, in
+// TypeScript it appends a @deprecated tag in its JSDoc block, in Go it adds a
+// "Deprecated:" paragraph to its doc comment, and in Python it emits a
+// DeprecationWarning at the start of the function body. Python files must
+// already import the warnings module.
 //
 // Note: funcName must be the complete, single-line method signature declaration
-// (including modifiers and return type, e.g., "public void foo()"). Matching is
+// (including modifiers and return type, e.g., "public void foo()"). Python
+// signatures may stop after the opening parenthesis (e.g., "def foo("). Matching is
 // done via exact substring search, so any spacing or formatting mismatch will fail.
 func DeprecateMethod(path, funcName, deprecationMessage, language string) error {
-	if language != config.LanguageJava {
-		return fmt.Errorf("%w: %s", errUnsupportedLanguage, language)
+	syntax, err := syntaxFor(language)
+	if err != nil {
+		return err
 	}
-	if !strings.Contains(funcName, "(") || !strings.Contains(funcName, ")") {
-		return fmt.Errorf("%w: %q", errInvalidSignature, funcName)
+	if err := syntax.validateSignature(funcName); err != nil {
+		return err
 	}
 	if strings.TrimSpace(deprecationMessage) == "" {
 		return fmt.Errorf("%w for method %q in %s", errEmptyDeprecationMessage, funcName, path)
@@ -59,26 +66,102 @@ func DeprecateMethod(path, funcName, deprecationMessage, language string) error
 	if err != nil {
 		return err
 	}
-	cleaned := cleanJavaCode(content)
-	boundsList, err := findMethodBounds(content, cleaned, funcName)
+	cleaned := syntax.clean(content)
+	boundsList, err := syntax.bounds(content, cleaned, funcName)
 	if err != nil {
 		return fmt.Errorf("deprecating method %s in %s: %w", funcName, path, err)
 	}
 	if len(boundsList) > 1 {
 		return fmt.Errorf("%w: multiple methods found matching signature %q in %s", errAmbiguousDeprecation, funcName, path)
 	}
+	b := boundsList[0]
+	sigIdx := b.start + bytes.Index(cleaned[b.start:], []byte(funcName))
 	lines := bytes.Split(content, []byte("\n"))
-	sigLineIdx := bytes.Count(content[:boundsList[0].start], []byte("\n"))
-	header := analyzeMethodHeader(lines, sigLineIdx)
-	if header.hasDeprecated && header.hasDeprecatedTag {
-		return fmt.Errorf("%w: method %q in %s", errMethodAlreadyDeprecated, funcName, path)
-	}
+	sigLineIdx := bytes.Count(content[:sigIdx], []byte("\n"))
 	indentation := trimLineIndentation(lines[sigLineIdx])
-	lines = annotateMethod(lines, sigLineIdx, indentation, header)
-	lines = addJavadocTag(lines, indentation, header, "deprecated", deprecationMessage)
+	switch language {
+	case config.LanguageGo:
+		lines, err = addGoDeprecation(lines, sigLineIdx, indentation, deprecationMessage)
+	case config.LanguagePython:
+		lines, err = addPythonDeprecation(content, cleaned, sigIdx+strings.Index(funcName, "("), b, deprecationMessage)
+	case config.LanguageNodejs:
+		header := analyzeMethodHeader(lines, sigLineIdx)
+		if header.hasDeprecatedTag {
+			return fmt.Errorf("%w: method %q in %s", errMethodAlreadyDeprecated, funcName, path)
+		}
+		lines = addJavadocTag(lines, indentation, header, "deprecated", deprecationMessage)
+	default:
+		header := analyzeMethodHeader(lines, sigLineIdx)
+		if header.hasDeprecated && header.hasDeprecatedTag {
+			return fmt.Errorf("%w: method %q in %s", errMethodAlreadyDeprecated, funcName, path)
+		}
+		lines = annotateMethod(lines, sigLineIdx, indentation, header)
+		lines = addJavadocTag(lines, indentation, header, "deprecated", deprecationMessage)
+	}
+	if err != nil {
+		return fmt.Errorf("deprecating method %s in %s: %w", funcName, path, err)
+	}
 	return os.WriteFile(path, bytes.Join(lines, []byte("\n")), 0644)
 }
 
+// addGoDeprecation adds a "Deprecated:" paragraph at the end of the doc
+// comment of the Go function declared in lines[sigLineIdx].
+func addGoDeprecation(lines [][]byte, sigLineIdx int, indentation []byte, deprecationMessage string) ([][]byte, error) {
+	hasComment := false
+	for idx := sigLineIdx - 1; idx >= 0; idx-- {
+		line := bytes.TrimSpace(lines[idx])
+		if !bytes.HasPrefix(line, []byte("//")) {
+			break
+		}
+		if bytes.HasPrefix(line, []byte("// Deprecated:")) {
+			return nil, errMethodAlreadyDeprecated
+		}
+		hasComment = true
+	}
+	var paragraph [][]byte
+	if hasComment {
+		paragraph = append(paragraph, fmt.Appendf(nil, "%s//", indentation))
+	}
+	paragraph = append(paragraph, fmt.Appendf(nil, "%s// Deprecated: %s", indentation, deprecationMessage))
+	return slices.Insert(lines, sigLineIdx, paragraph...), nil
+}
+
+// addPythonDeprecation inserts a call to warnings.warn() before the first
+// statement of the Python function in bounds b, after its docstring.
+func addPythonDeprecation(content, cleaned []byte, openParenIdx int, b methodBounds, deprecationMessage string) ([][]byte, error) {
+	if bytes.Contains(cleaned[b.start:b.end], []byte("DeprecationWarning")) {
+		return nil, errMethodAlreadyDeprecated
+	}
+	sigEnd, err := findPythonSignatureEnd(cleaned, openParenIdx)
+	if err != nil {
+		return nil, err
+	}
+	bodyStart := lineEnd(cleaned, sigEnd)
+	if len(bytes.TrimSpace(cleaned[sigEnd+1:bodyStart])) != 0 {
+		return nil, fmt.Errorf("%w: the function body must start on a new line", errInvalidSignature)
+	}
+	// Find the first line of the body, skipping blank lines.
+	first := bodyStart
+	for first < b.end && len(bytes.TrimSpace(content[first:lineEnd(content, first)])) == 0 {
+		first = lineEnd(content, first)
+	}
+	if first >= b.end {
+		return nil, fmt.Errorf("%w: the function has no body", errInvalidSignature)
+	}
+	indentation := trimLineIndentation(content[first:lineEnd(content, first)])
+	insertAt := first
+	if docstring := pythonCleanRegex.FindIndex(content[first+len(indentation):]); docstring != nil && docstring[0] == 0 &&
+		!bytes.HasPrefix(content[first+len(indentation):], []byte("#")) {
+		insertAt = lineEnd(content, first+len(indentation)+docstring[1])
+	}
+	warning := fmt.Appendf(nil, "%swarnings.warn(%s, DeprecationWarning)\n", indentation, strconv.Quote(deprecationMessage))
+	if insertAt == len(content) && !bytes.HasSuffix(content, []byte("\n")) {
+		warning = append([]byte("\n"), bytes.TrimSuffix(warning, []byte("\n"))...)
+	}
+	updated := slices.Concat(content[:insertAt], warning, content[insertAt:])
+	return bytes.Split(updated, []byte("\n")), nil
+}
+
 // analyzeMethodHeader scans the lines above a method signature to identify existing Javadoc and annotations.
 func analyzeMethodHeader(lines [][]byte, sigLineIdx int) methodHeader {
 	header := methodHeader{
@@ -202,6 +202,189 @@ public class TestClass {
 	}
 }
 
+func TestDeprecateMethod_Languages(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		name     string
+		language string
+		content  string
+		funcName string
+		want     string
+	}{
+		{
+			name:     "go with doc comment",
+			language: config.LanguageGo,
+			content: `package secretmanager
+
+	// GetSecret gets a secret.
+	func (c *Client) GetSecret(ctx context.Context) error {
+		return nil
+	}
+`,
+			funcName: "func (c *Client) GetSecret(ctx context.Context)",
+			want: `package secretmanager
+
+	// GetSecret gets a secret.
+	//
+	// Deprecated: use GetSecretV2
+	func (c *Client) GetSecret(ctx context.Context) error {
+		return nil
+	}
+`,
+		},
+		{
+			name:     "go without doc comment",
+			language: config.LanguageGo,
+			content: `package secretmanager
+
+func GetSecret() error {
+	return nil
+}
+`,
+			funcName: "func GetSecret()",
+			want: `package secretmanager
+
+// Deprecated: use GetSecretV2
+func GetSecret() error {
+	return nil
+}
+`,
+		},
+		{
+			name:     "python with docstring",
+			language: config.LanguagePython,
+			content: `class Client:
+    @staticmethod
+    def get_secret(
+        request,
+    ):
+        """Gets a secret.
+
+        Returns:
+            The secret.
+        """
+        # Build the request.
+        return request
+`,
+			funcName: "def get_secret(",
+			want: `class Client:
+    @staticmethod
+    def get_secret(
+        request,
+    ):
+        """Gets a secret.
+
+        Returns:
+            The secret.
+        """
+        warnings.warn("use get_secret_v2", DeprecationWarning)
+        # Build the request.
+        return request
+`,
+		},
+		{
+			name:     "python docstring only",
+			language: config.LanguagePython,
+			content: `def get_secret():
+    """Gets a secret."""
+`,
+			funcName: "def get_secret(",
+			want: `def get_secret():
+    """Gets a secret."""
+    warnings.warn("use get_secret_v2", DeprecationWarning)
+`,
+		},
+		{
+			name:     "typescript",
+			language: config.LanguageNodejs,
+			content: `export class Client {
+  /**
+   * Gets a secret.
+   */
+  async getSecret(request: Request): Promise<Secret> {
+    return this.call(request);
+  }
+}
+`,
+			funcName: "async getSecret(request: Request)",
+			want: `export class Client {
+  /**
+   * Gets a secret.
+   * @deprecated use getSecretV2
+   */
+  async getSecret(request: Request): Promise<Secret> {
+    return this.call(request);
+  }
+}
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			filePath := filepath.Join(t.TempDir(), "test.src")
+			if err := os.WriteFile(filePath, []byte(test.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			message := map[string]string{
+				config.LanguageGo:     "use GetSecretV2",
+				config.LanguagePython: "use get_secret_v2",
+				config.LanguageNodejs: "use getSecretV2",
+			}[test.language]
+			if err := DeprecateMethod(filePath, test.funcName, message, test.language); err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, string(got)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeprecateMethod_AlreadyDeprecated(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		name     string
+		language string
+		content  string
+		funcName string
+	}{
+		{
+			name:     "go",
+			language: config.LanguageGo,
+			content:  "// Foo does things.\n//\n// Deprecated: use Bar.\nfunc Foo() {}\n",
+			funcName: "func Foo()",
+		},
+		{
+			name:     "python",
+			language: config.LanguagePython,
+			content:  "def foo():\n    warnings.warn(\"use bar\", DeprecationWarning)\n",
+			funcName: "def foo(",
+		},
+		{
+			name:     "typescript",
+			language: config.LanguageNodejs,
+			content:  "/** @deprecated use bar */\nfoo() {}\n",
+			funcName: "foo()",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			filePath := filepath.Join(t.TempDir(), "test.src")
+			if err := os.WriteFile(filePath, []byte(test.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			err := DeprecateMethod(filePath, test.funcName, "use bar", test.language)
+			if !errors.Is(err, errMethodAlreadyDeprecated) {
+				t.Errorf("DeprecateMethod() error = %v, wantErr %v", err, errMethodAlreadyDeprecated)
+			}
+		})
+	}
+}
+
 func TestDeprecateMethod_Error(t *testing.T) {
 	t.Parallel()
 	for _, test := range []struct {
@@ -217,7 +400,7 @@ func TestDeprecateMethod_Error(t *testing.T) {
 			inputContent:       "public void foo() {}",
 			funcName:           "public void foo()",
 			deprecationMessage: "use bar",
-			language:           "python",
+			language:           "rust",
 			wantErr:            errUnsupportedLanguage,
 		},
 		{
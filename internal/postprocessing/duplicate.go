@@ -29,23 +29,26 @@ var errMethodAlreadyExists = errors.New("method already exists")
 var errAmbiguousDuplication = errors.New("ambiguous duplication")
 
 // DuplicateMethod extracts a method block, renames it, and appends it immediately after the original method.
+// It supports Go, Java, Python and TypeScript files.
 //
 // Note: funcName must be the complete, single-line method signature declaration
-// (including modifiers and return type, e.g., "public void foo()"). Matching is
+// (including modifiers and return type, e.g., "public void foo()"). Python
+// signatures may stop after the opening parenthesis (e.g., "def foo("). Matching is
 // done via exact substring search, so any spacing or formatting mismatch will fail.
 func DuplicateMethod(ctx context.Context, path, funcName, newName, language string) error {
-	if language != config.LanguageJava {
-		return fmt.Errorf("%w: %s", errUnsupportedLanguage, language)
+	syntax, err := syntaxFor(language)
+	if err != nil {
+		return err
 	}
-	if !strings.Contains(funcName, "(") || !strings.Contains(funcName, ")") {
-		return fmt.Errorf("%w: %q (must contain parameter list in parentheses)", errInvalidSignature, funcName)
+	if err := syntax.validateSignature(funcName); err != nil {
+		return err
 	}
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return err
 	}
-	cleaned := cleanJavaCode(content)
-	parenIdx := strings.Index(funcName, "(")
+	cleaned := syntax.clean(content)
+	parenIdx := parameterListIndex(funcName, language)
 	beforeParen := funcName[:parenIdx]
 	trimmedBeforeParen := strings.TrimRight(beforeParen, " \t")
 	spaceIdx := strings.LastIndexAny(trimmedBeforeParen, " \t")
@@ -59,7 +62,7 @@ func DuplicateMethod(ctx context.Context, path, funcName, newName, language stri
 		return fmt.Errorf("%w: %s; duplication rule is redundant", errMethodAlreadyExists, newSignature)
 	}
 
-	boundsList, err := findMethodBounds(content, cleaned, funcName)
+	boundsList, err := syntax.bounds(content, cleaned, funcName)
 	if err != nil {
 		return fmt.Errorf("duplicating method %s in %s: %w", funcName, path, err)
 	}
@@ -76,3 +79,16 @@ func DuplicateMethod(ctx context.Context, path, funcName, newName, language stri
 	newContent = append(newContent, content[b.end:]...)
 	return os.WriteFile(path, newContent, 0644)
 }
+
+// parameterListIndex returns the index of the parenthesis opening the
+// parameter list in funcName, skipping the receiver of Go methods.
+func parameterListIndex(funcName, language string) int {
+	if language == config.LanguageGo && strings.HasPrefix(strings.TrimSpace(funcName), "func (") {
+		if receiverEnd := strings.Index(funcName, ")"); receiverEnd != -1 {
+			if idx := strings.Index(funcName[receiverEnd:], "("); idx != -1 {
+				return receiverEnd + idx
+			}
+		}
+	}
+	return strings.Index(funcName, "(")
+}
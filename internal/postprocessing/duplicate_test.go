@@ -140,6 +140,100 @@ public class TestClass {
 	}
 }
 
+func TestDuplicateMethod_Languages(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		name     string
+		language string
+		content  string
+		funcName string
+		newName  string
+		want     string
+	}{
+		{
+			name:     "go method with receiver",
+			language: "go",
+			content: `func (c *Client) GetSecret(ctx context.Context) error {
+	return c.get(ctx)
+}
+`,
+			funcName: "func (c *Client) GetSecret(ctx context.Context)",
+			newName:  "FetchSecret",
+			want: `func (c *Client) GetSecret(ctx context.Context) error {
+	return c.get(ctx)
+}
+
+func (c *Client) FetchSecret(ctx context.Context) error {
+	return c.get(ctx)
+}
+`,
+		},
+		{
+			name:     "python",
+			language: "python",
+			content: `class Client:
+    def get_secret(self, request):
+        return request
+
+    def other(self):
+        pass
+`,
+			funcName: "def get_secret(",
+			newName:  "fetch_secret",
+			want: `class Client:
+    def get_secret(self, request):
+        return request
+
+    def fetch_secret(self, request):
+        return request
+
+    def other(self):
+        pass
+`,
+		},
+		{
+			name:     "typescript",
+			language: "nodejs",
+			content: `export class Client {
+  getSecret(request: Request): Secret {
+    return {};
+  }
+}
+`,
+			funcName: "getSecret(request: Request)",
+			newName:  "fetchSecret",
+			want: `export class Client {
+  getSecret(request: Request): Secret {
+    return {};
+  }
+
+  fetchSecret(request: Request): Secret {
+    return {};
+  }
+}
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "test.src")
+			if err := os.WriteFile(path, []byte(test.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := DuplicateMethod(t.Context(), path, test.funcName, test.newName, test.language); err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, string(got)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDuplicateMethod_Error(t *testing.T) {
 	t.Parallel()
 	for _, test := range []struct {
@@ -155,7 +249,7 @@ func TestDuplicateMethod_Error(t *testing.T) {
 			content:  "def myMethod(): pass",
 			funcName: "def myMethod()",
 			newName:  "myMethodCopy",
-			language: "python",
+			language: "rust",
 			wantErr:  errUnsupportedLanguage,
 		},
 		{
@@ -0,0 +1,193 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postprocessing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/config"
+)
+
+var (
+	errSignatureEndNotFound = errors.New("end of method signature not found")
+
+	// goCleanRegex matches comments, raw strings, interpreted strings, and rune literals.
+	goCleanRegex = regexp.MustCompile("`[^`]*`" + `|"(?:[^"\\\n]|\\.)*"|'(?:[^'\\\n]|\\.)*'|/\*[\s\S]*?\*/|//.*`)
+	// pythonCleanRegex matches comments, triple-quoted strings, and single-line strings.
+	pythonCleanRegex = regexp.MustCompile(`"""[\s\S]*?"""|'''[\s\S]*?'''|"(?:[^"\\\n]|\\.)*"|'(?:[^'\\\n]|\\.)*'|#.*`)
+	// typescriptCleanRegex matches comments, template literals, and quoted strings.
+	typescriptCleanRegex = regexp.MustCompile("`(?:[^`\\\\]|\\\\.)*`" + `|"(?:[^"\\\n]|\\.)*"|'(?:[^'\\\n]|\\.)*'|/\*[\s\S]*?\*/|//.*`)
+)
+
+// methodSyntax describes how to find the methods in the source code of a
+// language.
+type methodSyntax struct {
+	// clean blanks out comments and string literals, preserving the offset
+	// of every other byte.
+	clean func(content []byte) []byte
+	// bounds returns the bounds of all the methods matching funcName.
+	bounds func(data, cleaned []byte, funcName string) ([]methodBounds, error)
+	// partialSignature reports whether funcName may omit the closing
+	// parenthesis of the parameter list.
+	partialSignature bool
+}
+
+// syntaxFor returns the method syntax of the language.
+func syntaxFor(language string) (*methodSyntax, error) {
+	switch language {
+	case config.LanguageGo:
+		return &methodSyntax{clean: cleanGoCode, bounds: findMethodBounds}, nil
+	case config.LanguageJava:
+		return &methodSyntax{clean: cleanJavaCode, bounds: findMethodBounds}, nil
+	case config.LanguageNodejs:
+		return &methodSyntax{clean: cleanTypeScriptCode, bounds: findMethodBounds}, nil
+	case config.LanguagePython:
+		return &methodSyntax{clean: cleanPythonCode, bounds: findPythonMethodBounds, partialSignature: true}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedLanguage, language)
+	}
+}
+
+// validateSignature returns an error if funcName cannot identify a method.
+func (s *methodSyntax) validateSignature(funcName string) error {
+	if !strings.Contains(funcName, "(") {
+		return fmt.Errorf("%w: %q (must contain parameter list in parentheses)", errInvalidSignature, funcName)
+	}
+	if !s.partialSignature && !strings.Contains(funcName, ")") {
+		return fmt.Errorf("%w: %q (must contain parameter list in parentheses)", errInvalidSignature, funcName)
+	}
+	return nil
+}
+
+// blankMatches replaces the bytes of every match of re with spaces, keeping
+// newlines so line numbers are preserved.
+func blankMatches(re *regexp.Regexp, content []byte) []byte {
+	return re.ReplaceAllFunc(content, func(match []byte) []byte {
+		return bytes.Map(func(r rune) rune {
+			if r == '\n' {
+				return r
+			}
+			return ' '
+		}, match)
+	})
+}
+
+func cleanGoCode(content []byte) []byte {
+	return blankMatches(goCleanRegex, content)
+}
+
+func cleanPythonCode(content []byte) []byte {
+	return blankMatches(pythonCleanRegex, content)
+}
+
+func cleanTypeScriptCode(content []byte) []byte {
+	return blankMatches(typescriptCleanRegex, content)
+}
+
+// findPythonMethodBounds finds the bounds of all the Python functions
+// matching funcName. A function starts at its first decorator and ends at the
+// last line indented deeper than its def statement.
+func findPythonMethodBounds(data, cleaned []byte, funcName string) ([]methodBounds, error) {
+	var boundsList []methodBounds
+	searchStart := 0
+	for {
+		idx := bytes.Index(cleaned[searchStart:], []byte(funcName))
+		if idx == -1 {
+			break
+		}
+		actualIdx := searchStart + idx
+		sigEnd, err := findPythonSignatureEnd(cleaned, actualIdx+strings.Index(funcName, "("))
+		if err != nil {
+			return nil, err
+		}
+		defLine := lineStart(cleaned, actualIdx)
+		indent := indentation(cleaned[defLine:])
+		start := defLine
+		for start > 0 {
+			prev := lineStart(cleaned, start-1)
+			line := cleaned[prev : start-1]
+			if indentation(line) != indent || !bytes.HasPrefix(bytes.TrimSpace(line), []byte("@")) {
+				break
+			}
+			start = prev
+		}
+		end := lineEnd(cleaned, sigEnd)
+	body:
+		for next := end; next < len(cleaned); {
+			stop := lineEnd(cleaned, next)
+			code, text := cleaned[next:stop], data[next:stop]
+			switch {
+			case len(bytes.TrimSpace(code)) != 0:
+				if indentation(code) <= indent {
+					break body
+				}
+				end = stop
+			case len(bytes.TrimSpace(text)) != 0 && indentation(text) > indent:
+				// Docstrings and comments belong to the function only
+				// when they are indented.
+				end = stop
+			}
+			next = stop
+		}
+		boundsList = append(boundsList, methodBounds{start: start, end: end})
+		searchStart = end
+	}
+	if len(boundsList) == 0 {
+		return nil, errMethodNotFound
+	}
+	return boundsList, nil
+}
+
+// findPythonSignatureEnd returns the index of the colon ending the def
+// statement whose parameter list opens at openParenIdx.
+func findPythonSignatureEnd(cleaned []byte, openParenIdx int) (int, error) {
+	depth := 0
+	for i := openParenIdx; i < len(cleaned); i++ {
+		switch cleaned[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ':':
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return -1, errSignatureEndNotFound
+}
+
+// lineStart returns the index of the first byte in the line containing idx.
+func lineStart(content []byte, idx int) int {
+	return bytes.LastIndexByte(content[:idx], '\n') + 1
+}
+
+// lineEnd returns the index after the newline ending the line containing
+// idx, or the length of content for the last line.
+func lineEnd(content []byte, idx int) int {
+	if nl := bytes.IndexByte(content[idx:], '\n'); nl != -1 {
+		return idx + nl + 1
+	}
+	return len(content)
+}
+
+// indentation returns the number of leading spaces and tabs in line.
+func indentation(line []byte) int {
+	return len(line) - len(bytes.TrimLeft(line, " \t"))
+}
@@ -21,6 +21,7 @@ import (
 	"maps"
 	"slices"
 	"strings"
+	"time"
 )
 
 // Typez represent different field types that may be found in messages.
@@ -161,6 +162,27 @@ const (
 	FieldBehaviorIdentifier
 )
 
+var fieldBehaviorName = [...]string{
+	"FIELD_BEHAVIOR_UNSPECIFIED",
+	"OPTIONAL",
+	"REQUIRED",
+	"OUTPUT_ONLY",
+	"INPUT_ONLY",
+	"IMMUTABLE",
+	"UNORDERED_LIST",
+	"NON_EMPTY_DEFAULT",
+	"IDENTIFIER",
+}
+
+// String returns the symbolic name for the FieldBehavior, as used in the
+// `google.api.field_behavior` annotation.
+func (b FieldBehavior) String() string {
+	if b < 0 || int(b) >= len(fieldBehaviorName) {
+		return fmt.Sprintf("FieldBehavior(%d)", b)
+	}
+	return fieldBehaviorName[b]
+}
+
 const (
 	// ReservedPackageName is a package name reserved for maps and other
 	// synthetic messages that do not exist in the input specification.
@@ -327,6 +349,49 @@ func (a *API) AddResource(r *Resource) {
 	a.resourceByType[r.Type] = r
 }
 
+// SourceLocation identifies the definition of a model element in its
+// specification.
+type SourceLocation struct {
+	// File is the path of the specification file. For protobuf, this is the
+	// path relative to the source root, for example
+	// `google/cloud/secretmanager/v1/service.proto`.
+	File string
+	// StartLine is the first line (1-based) of the definition. Only set for
+	// protobuf specifications.
+	StartLine int
+	// EndLine is the last line (1-based) of the definition. Only set for
+	// protobuf specifications.
+	EndLine int
+	// Pointer is the JSON pointer of the definition, for example
+	// `/components/schemas/Secret`. Only set for OpenAPI and discovery
+	// specifications.
+	Pointer string
+}
+
+// String returns the location as `file:line` for protobuf specifications and
+// `file#pointer` for OpenAPI and discovery specifications.
+func (l *SourceLocation) String() string {
+	switch {
+	case l == nil:
+		return ""
+	case l.StartLine != 0:
+		return fmt.Sprintf("%s:%d", l.File, l.StartLine)
+	case l.Pointer != "":
+		return l.File + "#" + l.Pointer
+	}
+	return l.File
+}
+
+// JSONPointer returns the JSON pointer (RFC 6901) for the reference tokens.
+func JSONPointer(tokens ...string) string {
+	var pointer strings.Builder
+	for _, t := range tokens {
+		pointer.WriteString("/")
+		pointer.WriteString(strings.ReplaceAll(strings.ReplaceAll(t, "~", "~0"), "/", "~1"))
+	}
+	return pointer.String()
+}
+
 // Service represents a service in an API.
 type Service struct {
 	// Documentation for the service.
@@ -335,6 +400,8 @@ type Service struct {
 	Name string
 	// ID is a unique identifier.
 	ID string
+	// Location is the definition of the service in the specification.
+	Location *SourceLocation
 	// Some source specifications allow marking services as deprecated.
 	Deprecated bool
 	// Methods associated with the Service.
@@ -370,6 +437,8 @@ type Method struct {
 	Name string
 	// ID is a unique identifier.
 	ID string
+	// Location is the definition of the method in the specification.
+	Location *SourceLocation
 	// Deprecated is true if the method is deprecated.
 	Deprecated bool
 	// InputTypeID is the ID of the input type for the Method.
@@ -405,6 +474,12 @@ type Method struct {
 	DiscoveryLro *DiscoveryLro
 	// Routing contains the routing annotations, if any.
 	Routing []*RoutingInfo
+	// Timeout is the default timeout for each attempt to call the method, as
+	// defined in the gRPC service config. Zero if there is no default.
+	Timeout time.Duration
+	// RetryPolicy is the default retry policy for the method, as defined in
+	// the gRPC service config. Nil if the method is not retried by default.
+	RetryPolicy *RetryPolicy
 	// AutoPopulated contains the auto-populated (request_id) field, if any, as defined in
 	// [AIP-4235](https://google.aip.dev/client-libraries/4235)
 	//
@@ -648,6 +723,24 @@ type DiscoveryLro struct {
 	Codec any
 }
 
+// RetryPolicy contains the default retry policy for a method.
+//
+// The policy comes from the `retryPolicy` field of the gRPC service config:
+//
+// https://github.com/grpc/grpc/blob/master/doc/service_config.md
+type RetryPolicy struct {
+	// The maximum number of attempts, including the original request.
+	MaxAttempts int
+	// The delay before the first retry.
+	InitialBackoff time.Duration
+	// The maximum delay between retries.
+	MaxBackoff time.Duration
+	// The factor applied to the delay after each retry.
+	BackoffMultiplier float64
+	// The status codes that are retried, such as `UNAVAILABLE`.
+	RetryableCodes []string
+}
+
 // RoutingInfo contains normalized routing info.
 //
 // The routing information format is documented in:
@@ -844,6 +937,8 @@ type Message struct {
 	Name string
 	// ID is a unique identifier.
 	ID string
+	// Location is the definition of the message in the specification.
+	Location *SourceLocation
 	// Some source specifications allow marking messages as deprecated.
 	Deprecated bool
 	// Fields associated with the Message.
@@ -901,6 +996,8 @@ type Enum struct {
 	Name string
 	// ID is a unique identifier.
 	ID string
+	// Location is the definition of the enum in the specification.
+	Location *SourceLocation
 	// Some source specifications allow marking enums as deprecated.
 	Deprecated bool
 	// Values associated with the Enum.
@@ -953,6 +1050,11 @@ type Field struct {
 	Name string
 	// ID is a unique identifier.
 	ID string
+	// Location is the definition of the field in the specification.
+	Location *SourceLocation
+	// Number is the field number. Only protobuf specifications assign field
+	// numbers, for other specifications it is zero.
+	Number int32
 	// Typez is the datatype of the field.
 	Typez Typez
 	// TypezID is the ID of the type the field refers to. This value is populated
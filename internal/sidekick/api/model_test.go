@@ -288,6 +288,69 @@ func TestTypezString(t *testing.T) {
 	}
 }
 
+func TestSourceLocationString(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		location *SourceLocation
+		want     string
+	}{
+		{"nil", nil, ""},
+		{"protobuf", &SourceLocation{File: "google/cloud/test/v1/test.proto", StartLine: 42, EndLine: 45}, "google/cloud/test/v1/test.proto:42"},
+		{"openapi", &SourceLocation{File: "openapi.json", Pointer: "/components/schemas/Secret"}, "openapi.json#/components/schemas/Secret"},
+		{"file only", &SourceLocation{File: "openapi.json"}, "openapi.json"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, test.location.String()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	for _, test := range []struct {
+		tokens []string
+		want   string
+	}{
+		{nil, ""},
+		{[]string{"components", "schemas", "Secret"}, "/components/schemas/Secret"},
+		{[]string{"paths", "/v1/{name=projects/*}", "get"}, "/paths/~1v1~1{name=projects~1*}/get"},
+		{[]string{"a~b"}, "/a~0b"},
+	} {
+		t.Run(test.want, func(t *testing.T) {
+			if got := JSONPointer(test.tokens...); got != test.want {
+				t.Errorf("JSONPointer(%q) = %q, want %q", test.tokens, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFieldBehaviorString(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		b    FieldBehavior
+		want string
+	}{
+		{"UNSPECIFIED", FieldBehaviorUnspecified, "FIELD_BEHAVIOR_UNSPECIFIED"},
+		{"OPTIONAL", FieldBehaviorOptional, "OPTIONAL"},
+		{"REQUIRED", FieldBehaviorRequired, "REQUIRED"},
+		{"OUTPUT_ONLY", FieldBehaviorOutputOnly, "OUTPUT_ONLY"},
+		{"INPUT_ONLY", FieldBehaviorInputOnly, "INPUT_ONLY"},
+		{"IMMUTABLE", FieldBehaviorImmutable, "IMMUTABLE"},
+		{"UNORDERED_LIST", FieldBehaviorUnorderedList, "UNORDERED_LIST"},
+		{"NON_EMPTY_DEFAULT", FieldBehaviorUnorderedNonEmptyDefault, "NON_EMPTY_DEFAULT"},
+		{"IDENTIFIER", FieldBehaviorIdentifier, "IDENTIFIER"},
+		{"Default", FieldBehavior(99), "FieldBehavior(99)"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.b.String()
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestService_HasClientSideStreaming(t *testing.T) {
 	for _, test := range []struct {
 		name    string
@@ -43,8 +43,14 @@ type PaginationInfo struct {
 
 // UpdateMethodPagination marks all methods that conform to
 // [AIP-4233](https://google.aip.dev/client-libraries/4233) as pageable.
+//
+// Methods already marked as pageable, for example by a [Transformation], are
+// not changed.
 func UpdateMethodPagination(overrides []PaginationOverride, a *API) {
 	for m := range a.AllMethods() {
+		if m.Pagination != nil {
+			continue
+		}
 		reqMsg := a.Message(m.InputTypeID)
 		pageTokenField := paginationRequestInfo(reqMsg)
 		if pageTokenField == nil {
@@ -0,0 +1,279 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	defaultPageTokenField     = "page_token"
+	defaultNextPageTokenField = "next_page_token"
+)
+
+// Transformation describes a change to a single element of the model.
+//
+// Like [DocumentationOverride], transformations should be used sparingly.
+// Fixing the specification upstream is preferred. Transformations are useful
+// when the upstream fix takes a long time, or when the specification cannot
+// change without breaking existing clients.
+//
+// Each transformation sets exactly one action: Rename, Remove, Deprecate,
+// FieldBehavior, Type or Paginate.
+type Transformation struct {
+	// ID is the ID of the element to change.
+	ID string
+	// Rename is the new name of the element. The ID does not change.
+	Rename string
+	// Remove removes a field from its message.
+	Remove bool
+	// Deprecate marks the element as deprecated.
+	Deprecate bool
+	// FieldBehavior replaces the behavior of a field. The values are the
+	// names returned by [FieldBehavior.String], such as "REQUIRED".
+	FieldBehavior []string
+	// Type is the new type of a field. Either the protobuf name of a scalar
+	// type, such as "int64", or the ID of a message or enum.
+	Type string
+	// Paginate marks a method as paginated.
+	Paginate *PaginationTransformation
+}
+
+// PaginationTransformation describes the fields used to paginate a method.
+type PaginationTransformation struct {
+	// PageToken is the name of the page token field in the request. Defaults
+	// to `page_token`.
+	PageToken string
+	// NextPageToken is the name of the next page token field in the
+	// response. Defaults to `next_page_token`.
+	NextPageToken string
+	// ItemField is the name of the field with the items in the response.
+	ItemField string
+}
+
+// ApplyTransformations applies the transformations to the model, in order.
+//
+// It returns an error if a transformation does not match any element, or if
+// its action does not apply to the element it matches. This function must be
+// called before [CrossReference], the transformations only update the IDs
+// that [CrossReference] resolves.
+func ApplyTransformations(model *API, transformations []Transformation) error {
+	for i, t := range transformations {
+		if err := applyTransformation(model, &t); err != nil {
+			return fmt.Errorf("transformation %d for %s: %w", i, t.ID, err)
+		}
+	}
+	return nil
+}
+
+func applyTransformation(model *API, t *Transformation) error {
+	actions := 0
+	for _, set := range []bool{t.Rename != "", t.Remove, t.Deprecate, t.FieldBehavior != nil, t.Type != "", t.Paginate != nil} {
+		if set {
+			actions++
+		}
+	}
+	if actions != 1 {
+		return fmt.Errorf("must set exactly one action, got %d", actions)
+	}
+	element := findElement(model, t.ID)
+	if element == nil {
+		return fmt.Errorf("does not match any element")
+	}
+	switch {
+	case t.Rename != "":
+		return renameElement(element, t.Rename)
+	case t.Deprecate:
+		return deprecateElement(element)
+	case t.Remove:
+		field, ok := element.(*Field)
+		if !ok {
+			return fmt.Errorf("only fields can be removed")
+		}
+		removeField(model, t.ID, field)
+		return nil
+	case t.FieldBehavior != nil:
+		field, ok := element.(*Field)
+		if !ok {
+			return fmt.Errorf("only fields have a field behavior")
+		}
+		return setFieldBehavior(field, t.FieldBehavior)
+	case t.Type != "":
+		field, ok := element.(*Field)
+		if !ok {
+			return fmt.Errorf("only fields can change type")
+		}
+		return setFieldType(model, field, t.Type)
+	default:
+		method, ok := element.(*Method)
+		if !ok {
+			return fmt.Errorf("only methods can be paginated")
+		}
+		return paginateMethod(model, method, t.Paginate)
+	}
+}
+
+// findElement returns the service, method, message, field, enum or enum value
+// with the given ID, or nil if there is none.
+func findElement(model *API, id string) any {
+	if m := model.Message(id); m != nil {
+		return m
+	}
+	if e := model.Enum(id); e != nil {
+		return e
+	}
+	if s := model.Service(id); s != nil {
+		return s
+	}
+	if m := model.Method(id); m != nil {
+		return m
+	}
+	idx := strings.LastIndex(id, ".")
+	if idx == -1 {
+		return nil
+	}
+	parentID, name := id[:idx], id[idx+1:]
+	if m := model.Message(parentID); m != nil {
+		if i := slices.IndexFunc(m.Fields, func(f *Field) bool { return f.Name == name }); i != -1 {
+			return m.Fields[i]
+		}
+	}
+	if e := model.Enum(parentID); e != nil {
+		if i := slices.IndexFunc(e.Values, func(v *EnumValue) bool { return v.Name == name }); i != -1 {
+			return e.Values[i]
+		}
+	}
+	return nil
+}
+
+func renameElement(element any, name string) error {
+	switch e := element.(type) {
+	case *Service:
+		e.Name = name
+	case *Method:
+		e.Name = name
+	case *Message:
+		e.Name = name
+	case *Field:
+		e.Name = name
+	case *Enum:
+		e.Name = name
+	case *EnumValue:
+		e.Name = name
+	default:
+		return fmt.Errorf("cannot rename element of type %T", element)
+	}
+	return nil
+}
+
+func deprecateElement(element any) error {
+	switch e := element.(type) {
+	case *Service:
+		e.Deprecated = true
+	case *Method:
+		e.Deprecated = true
+	case *Message:
+		e.Deprecated = true
+	case *Field:
+		e.Deprecated = true
+	case *Enum:
+		e.Deprecated = true
+	case *EnumValue:
+		e.Deprecated = true
+	default:
+		return fmt.Errorf("cannot deprecate element of type %T", element)
+	}
+	return nil
+}
+
+func removeField(model *API, id string, field *Field) {
+	parent := model.Message(id[:strings.LastIndex(id, ".")])
+	parent.Fields = slices.DeleteFunc(parent.Fields, func(f *Field) bool { return f == field })
+	for _, o := range parent.OneOfs {
+		o.Fields = slices.DeleteFunc(o.Fields, func(f *Field) bool { return f == field })
+	}
+	parent.OneOfs = slices.DeleteFunc(parent.OneOfs, func(o *OneOf) bool { return len(o.Fields) == 0 })
+}
+
+func setFieldBehavior(field *Field, names []string) error {
+	var behavior []FieldBehavior
+	for _, name := range names {
+		idx := slices.Index(fieldBehaviorName[:], name)
+		if idx <= int(FieldBehaviorUnspecified) {
+			return fmt.Errorf("unknown field behavior %q for field %s", name, elementName(field.ID, field.Location))
+		}
+		behavior = append(behavior, FieldBehavior(idx))
+	}
+	field.Behavior = behavior
+	return nil
+}
+
+func setFieldType(model *API, field *Field, typ string) error {
+	if strings.HasPrefix(typ, ".") {
+		switch {
+		case model.Message(typ) != nil:
+			field.Typez = TypezMessage
+			field.Optional = !field.Repeated
+		case model.Enum(typ) != nil:
+			field.Typez = TypezEnum
+		default:
+			return fmt.Errorf("cannot find type %s for field %s", typ, elementName(field.ID, field.Location))
+		}
+		field.TypezID = typ
+		return nil
+	}
+	idx := slices.IndexFunc(typezName[:], func(name string) bool { return strings.ToLower(name) == typ })
+	switch Typez(idx) {
+	case -1, TypezUndefined, TypezGroup, TypezMessage, TypezEnum:
+		return fmt.Errorf("unknown scalar type %q for field %s", typ, elementName(field.ID, field.Location))
+	}
+	field.Typez = Typez(idx)
+	field.TypezID = ""
+	return nil
+}
+
+func paginateMethod(model *API, method *Method, p *PaginationTransformation) error {
+	request := model.Message(method.InputTypeID)
+	response := model.Message(method.OutputTypeID)
+	if request == nil || response == nil {
+		return fmt.Errorf("cannot find the request and response of method %s", elementName(method.ID, method.Location))
+	}
+	pageToken := cmp.Or(p.PageToken, defaultPageTokenField)
+	nextPageToken := cmp.Or(p.NextPageToken, defaultNextPageTokenField)
+	var fields []*Field
+	for _, lookup := range []struct {
+		message *Message
+		name    string
+	}{
+		{request, pageToken},
+		{response, nextPageToken},
+		{response, p.ItemField},
+	} {
+		idx := slices.IndexFunc(lookup.message.Fields, func(f *Field) bool { return f.Name == lookup.name })
+		if idx == -1 {
+			return fmt.Errorf("cannot find field %q in message %s", lookup.name, elementName(lookup.message.ID, lookup.message.Location))
+		}
+		fields = append(fields, lookup.message.Fields[idx])
+	}
+	method.Pagination = fields[0]
+	response.Pagination = &PaginationInfo{
+		NextPageToken: fields[1],
+		PageableItem:  fields[2],
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestApplyTransformations(t *testing.T) {
+	model := transformTestModel()
+	transformations := []Transformation{
+		{ID: ".test.Secret", Rename: "SecretData"},
+		{ID: ".test.Service.ListSecrets", Rename: "ListAllSecrets"},
+		{ID: ".test.Secret.State.ENABLED", Rename: "ACTIVE"},
+		{ID: ".test.Secret.etag", Remove: true},
+		{ID: ".test.Secret.alias", Remove: true},
+		{ID: ".test.Service", Deprecate: true},
+		{ID: ".test.Secret.State", Deprecate: true},
+		{ID: ".test.Secret.name", FieldBehavior: []string{"IDENTIFIER", "IMMUTABLE"}},
+		{ID: ".test.Secret.ttl", Type: "int64"},
+		{ID: ".test.Secret.state", Type: ".test.Secret.State"},
+		{ID: ".test.Service.ListSecrets", Paginate: &PaginationTransformation{ItemField: "secrets"}},
+	}
+	if err := ApplyTransformations(model, transformations); err != nil {
+		t.Fatal(err)
+	}
+
+	secret := model.Message(".test.Secret")
+	if secret.Name != "SecretData" {
+		t.Errorf("message name = %q, want %q", secret.Name, "SecretData")
+	}
+	var gotFields []string
+	for _, f := range secret.Fields {
+		gotFields = append(gotFields, f.Name)
+	}
+	if diff := cmp.Diff([]string{"name", "ttl", "state"}, gotFields); diff != "" {
+		t.Errorf("fields mismatch (-want +got):\n%s", diff)
+	}
+	if len(secret.OneOfs) != 0 {
+		t.Errorf("expected the empty oneof to be removed, got %v", secret.OneOfs)
+	}
+	if diff := cmp.Diff([]FieldBehavior{FieldBehaviorIdentifier, FieldBehaviorImmutable}, secret.Fields[0].Behavior); diff != "" {
+		t.Errorf("field behavior mismatch (-want +got):\n%s", diff)
+	}
+	if ttl := secret.Fields[1]; ttl.Typez != TypezInt64 || ttl.TypezID != "" {
+		t.Errorf("ttl type = (%s, %q), want (%s, %q)", ttl.Typez, ttl.TypezID, TypezInt64, "")
+	}
+	if state := secret.Fields[2]; state.Typez != TypezEnum || state.TypezID != ".test.Secret.State" {
+		t.Errorf("state type = (%s, %q), want (%s, %q)", state.Typez, state.TypezID, TypezEnum, ".test.Secret.State")
+	}
+	enum := model.Enum(".test.Secret.State")
+	if !enum.Deprecated {
+		t.Errorf("expected enum %s to be deprecated", enum.ID)
+	}
+	if got := enum.Values[1].Name; got != "ACTIVE" {
+		t.Errorf("enum value name = %q, want %q", got, "ACTIVE")
+	}
+	if !model.Service(".test.Service").Deprecated {
+		t.Errorf("expected service to be deprecated")
+	}
+
+	method := model.Method(".test.Service.ListSecrets")
+	if method.Name != "ListAllSecrets" {
+		t.Errorf("method name = %q, want %q", method.Name, "ListAllSecrets")
+	}
+	request := model.Message(".test.ListSecretsRequest")
+	response := model.Message(".test.ListSecretsResponse")
+	if method.Pagination != request.Fields[0] {
+		t.Errorf("method pagination = %v, want %v", method.Pagination, request.Fields[0])
+	}
+	checkPagination := func(t *testing.T) {
+		t.Helper()
+		got := response.Pagination
+		if got == nil || got.NextPageToken != response.Fields[1] || got.PageableItem != response.Fields[0] {
+			t.Errorf("response pagination = %v, want next_page_token and secrets fields", got)
+		}
+	}
+	checkPagination(t)
+
+	// Methods paginated by a transformation keep their pagination.
+	UpdateMethodPagination(nil, model)
+	checkPagination(t)
+}
+
+func TestApplyTransformationsError(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		transformation Transformation
+		want           string
+	}{
+		{
+			name:           "no action",
+			transformation: Transformation{ID: ".test.Secret"},
+			want:           "transformation 0 for .test.Secret: must set exactly one action, got 0",
+		},
+		{
+			name:           "two actions",
+			transformation: Transformation{ID: ".test.Secret", Rename: "Other", Deprecate: true},
+			want:           "transformation 0 for .test.Secret: must set exactly one action, got 2",
+		},
+		{
+			name:           "no match",
+			transformation: Transformation{ID: ".test.Missing", Deprecate: true},
+			want:           "transformation 0 for .test.Missing: does not match any element",
+		},
+		{
+			name:           "no match field",
+			transformation: Transformation{ID: ".test.Secret.missing", Deprecate: true},
+			want:           "transformation 0 for .test.Secret.missing: does not match any element",
+		},
+		{
+			name:           "remove message",
+			transformation: Transformation{ID: ".test.Secret", Remove: true},
+			want:           "transformation 0 for .test.Secret: only fields can be removed",
+		},
+		{
+			name:           "behavior of message",
+			transformation: Transformation{ID: ".test.Secret", FieldBehavior: []string{"REQUIRED"}},
+			want:           "transformation 0 for .test.Secret: only fields have a field behavior",
+		},
+		{
+			name:           "unknown behavior",
+			transformation: Transformation{ID: ".test.Secret.name", FieldBehavior: []string{"MANDATORY"}},
+			want:           `transformation 0 for .test.Secret.name: unknown field behavior "MANDATORY" for field .test.Secret.name (test.proto:12)`,
+		},
+		{
+			name:           "type of method",
+			transformation: Transformation{ID: ".test.Service.ListSecrets", Type: "string"},
+			want:           "transformation 0 for .test.Service.ListSecrets: only fields can change type",
+		},
+		{
+			name:           "unknown scalar",
+			transformation: Transformation{ID: ".test.Secret.ttl", Type: "group"},
+			want:           `transformation 0 for .test.Secret.ttl: unknown scalar type "group" for field .test.Secret.ttl`,
+		},
+		{
+			name:           "unknown message",
+			transformation: Transformation{ID: ".test.Secret.ttl", Type: ".test.Missing"},
+			want:           "transformation 0 for .test.Secret.ttl: cannot find type .test.Missing for field .test.Secret.ttl",
+		},
+		{
+			name:           "paginate field",
+			transformation: Transformation{ID: ".test.Secret.name", Paginate: &PaginationTransformation{ItemField: "secrets"}},
+			want:           "transformation 0 for .test.Secret.name: only methods can be paginated",
+		},
+		{
+			name:           "paginate missing field",
+			transformation: Transformation{ID: ".test.Service.ListSecrets", Paginate: &PaginationTransformation{ItemField: "items"}},
+			want:           `transformation 0 for .test.Service.ListSecrets: cannot find field "items" in message .test.ListSecretsResponse`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := ApplyTransformations(transformTestModel(), []Transformation{test.transformation})
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if diff := cmp.Diff(test.want, err.Error()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func transformTestModel() *API {
+	name := NewTestField("name").WithType(TypezString)
+	name.Location = &SourceLocation{File: "test.proto", StartLine: 12}
+	alias := NewTestField("alias").WithType(TypezString)
+	alias.IsOneOf = true
+	secret := NewTestMessage("Secret").WithFields(
+		name,
+		NewTestField("etag").WithType(TypezString),
+		NewTestField("ttl").WithType(TypezMessage),
+		NewTestField("state").WithType(TypezString),
+		alias)
+	secret.Fields[2].TypezID = ".google.protobuf.Duration"
+	secret.OneOfs = []*OneOf{{Name: "id", ID: ".test.Secret.id", Fields: []*Field{alias}}}
+	state := &Enum{
+		Name:    "State",
+		ID:      ".test.Secret.State",
+		Package: "test",
+		Values: []*EnumValue{
+			{Name: "STATE_UNSPECIFIED", Number: 0},
+			{Name: "ENABLED", Number: 1},
+		},
+	}
+	request := NewTestMessage("ListSecretsRequest").WithFields(
+		NewTestField("page_token").WithType(TypezString))
+	response := NewTestMessage("ListSecretsResponse").WithFields(
+		NewTestField("secrets").WithType(TypezMessage).WithRepeated(),
+		NewTestField("next_page_token").WithType(TypezString))
+	list := NewTestMethod("ListSecrets").WithInput(request).WithOutput(response)
+	service := NewTestService("Service").WithMethods(list)
+	return NewTestAPI([]*Message{secret, request, response}, []*Enum{state}, []*Service{service})
+}
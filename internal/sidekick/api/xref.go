@@ -41,13 +41,13 @@ func CrossReference(model *API) error {
 			case TypezMessage:
 				t := model.Message(f.TypezID)
 				if t == nil {
-					return fmt.Errorf("cannot find message type %s for field %s", f.TypezID, f.ID)
+					return fmt.Errorf("cannot find message type %s for field %s", f.TypezID, elementName(f.ID, f.Location))
 				}
 				f.MessageType = t
 			case TypezEnum:
 				t := model.Enum(f.TypezID)
 				if t == nil {
-					return fmt.Errorf("cannot find enum type %s for field %s", f.TypezID, f.ID)
+					return fmt.Errorf("cannot find enum type %s for field %s", f.TypezID, elementName(f.ID, f.Location))
 				}
 				f.EnumType = t
 			}
@@ -62,11 +62,11 @@ func CrossReference(model *API) error {
 	for m := range model.AllMethods() {
 		input := model.Message(m.InputTypeID)
 		if input == nil {
-			return fmt.Errorf("cannot find input type %s for method %s", m.InputTypeID, m.ID)
+			return fmt.Errorf("cannot find input type %s for method %s", m.InputTypeID, elementName(m.ID, m.Location))
 		}
 		output := model.Message(m.OutputTypeID)
 		if output == nil {
-			return fmt.Errorf("cannot find output type %s for method %s", m.OutputTypeID, m.ID)
+			return fmt.Errorf("cannot find output type %s for method %s", m.OutputTypeID, elementName(m.ID, m.Location))
 		}
 		m.InputType = input
 		m.OutputType = output
@@ -78,7 +78,7 @@ func CrossReference(model *API) error {
 			for _, name := range signature.Names {
 				idx := slices.IndexFunc(input.Fields, func(f *Field) bool { return f.Name == name })
 				if idx == -1 {
-					return fmt.Errorf("cannot find field %s in method signature for method %s", name, m.ID)
+					return fmt.Errorf("cannot find field %s in method signature for method %s", name, elementName(m.ID, m.Location))
 				}
 				signature.Fields = append(signature.Fields, input.Fields[idx])
 			}
@@ -789,3 +789,12 @@ func standardMethodOutputResource(m *Method) *Resource {
 	}
 	return nil
 }
+
+// elementName returns the ID of an element, followed by its location if
+// known. Use it in error messages to point at the definition of the element.
+func elementName(id string, location *SourceLocation) string {
+	if location == nil {
+		return id
+	}
+	return fmt.Sprintf("%s (%s)", id, location)
+}
@@ -195,6 +195,22 @@ func TestCrossReferenceMethod(t *testing.T) {
 	}
 }
 
+func TestCrossReferenceErrorLocation(t *testing.T) {
+	field := NewTestField("secret").WithMessageType(nil)
+	field.TypezID = ".test.Missing"
+	field.Location = &SourceLocation{File: "google/cloud/test/v1/test.proto", StartLine: 42, EndLine: 42}
+	message := NewTestMessage("Request").WithFields(field)
+	model := NewTestAPI([]*Message{message}, []*Enum{}, []*Service{})
+	err := CrossReference(model)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	want := "cannot find message type .test.Missing for field .test.Request.secret (google/cloud/test/v1/test.proto:42)"
+	if diff := cmp.Diff(want, err.Error()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestCrossReferenceService(t *testing.T) {
 	service := &Service{
 		Name: "Service",
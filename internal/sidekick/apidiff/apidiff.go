@@ -0,0 +1,461 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apidiff compares two versions of an API model and classifies each
+// difference as additive or breaking.
+//
+// The comparison works on the [modeldump.Model] representation, so it can be
+// applied to models built from two source revisions as well as to previously
+// dumped models.
+package apidiff
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/modeldump"
+)
+
+// Kind describes what happened to an element between two models.
+type Kind string
+
+const (
+	// KindAdded means the element only exists in the new model.
+	KindAdded Kind = "added"
+	// KindRemoved means the element only exists in the old model.
+	KindRemoved Kind = "removed"
+	// KindRenamed means the element exists in both models under different
+	// names, for example a field with the same number.
+	KindRenamed Kind = "renamed"
+	// KindChanged means the element exists in both models with different
+	// attributes.
+	KindChanged Kind = "changed"
+)
+
+// Element is the kind of model element a change applies to.
+type Element string
+
+const (
+	// ElementService is an [modeldump.Service].
+	ElementService Element = "service"
+	// ElementMethod is an [modeldump.Method].
+	ElementMethod Element = "method"
+	// ElementMessage is an [modeldump.Message].
+	ElementMessage Element = "message"
+	// ElementField is an [modeldump.Field].
+	ElementField Element = "field"
+	// ElementEnum is an [modeldump.Enum].
+	ElementEnum Element = "enum"
+	// ElementEnumValue is an [modeldump.EnumValue].
+	ElementEnumValue Element = "enum_value"
+	// ElementResource is an [modeldump.Resource].
+	ElementResource Element = "resource"
+)
+
+// Change is a single difference between two models.
+type Change struct {
+	// ID is the ID of the element in the old model, or in the new model for
+	// added elements. For resources, this is the resource type.
+	ID string `json:"id" yaml:"id"`
+	// Element is the kind of element that changed.
+	Element Element `json:"element" yaml:"element"`
+	// Kind describes the change.
+	Kind Kind `json:"kind" yaml:"kind"`
+	// Breaking is true if the change may break existing clients.
+	Breaking bool `json:"breaking" yaml:"breaking"`
+	// Description is a human readable explanation of the change.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// String returns a one line summary of the change.
+func (c *Change) String() string {
+	classification := "additive"
+	if c.Breaking {
+		classification = "breaking"
+	}
+	s := fmt.Sprintf("%-8s %-7s %s %s", classification, c.Kind, c.Element, c.ID)
+	if c.Description != "" {
+		s += ": " + c.Description
+	}
+	return s
+}
+
+// Report contains all the differences between two models.
+type Report struct {
+	Changes []*Change `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+// Breaking returns true if any change in the report is breaking.
+func (r *Report) Breaking() bool {
+	return slices.ContainsFunc(r.Changes, func(c *Change) bool { return c.Breaking })
+}
+
+// Compare returns the differences between the old and new models.
+//
+// Elements are matched by ID. Fields and enum values that are missing in one
+// of the models are matched by number, which detects renamed elements and
+// reused field numbers. The changes are sorted by ID.
+func Compare(old, new *modeldump.Model) *Report {
+	r := &Report{}
+	r.compareServices(old.Services, new.Services)
+	r.compareMessages(allMessages(old.Messages), allMessages(new.Messages))
+	r.compareEnums(allEnums(old), allEnums(new))
+	r.compareResources(allResources(old), allResources(new))
+	slices.SortStableFunc(r.Changes, func(a, b *Change) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return r
+}
+
+func (r *Report) add(c *Change) {
+	r.Changes = append(r.Changes, c)
+}
+
+func (r *Report) compareServices(old, new []*modeldump.Service) {
+	newByID := byID(new, func(s *modeldump.Service) string { return s.ID })
+	for _, o := range old {
+		n, ok := newByID[o.ID]
+		if !ok {
+			r.add(&Change{ID: o.ID, Element: ElementService, Kind: KindRemoved, Breaking: true})
+			continue
+		}
+		r.compareMethods(o.Methods, n.Methods)
+	}
+	oldByID := byID(old, func(s *modeldump.Service) string { return s.ID })
+	for _, n := range new {
+		if _, ok := oldByID[n.ID]; !ok {
+			r.add(&Change{ID: n.ID, Element: ElementService, Kind: KindAdded})
+		}
+	}
+}
+
+func (r *Report) compareMethods(old, new []*modeldump.Method) {
+	newByID := byID(new, func(m *modeldump.Method) string { return m.ID })
+	for _, o := range old {
+		n, ok := newByID[o.ID]
+		if !ok {
+			r.add(&Change{ID: o.ID, Element: ElementMethod, Kind: KindRemoved, Breaking: true})
+			continue
+		}
+		r.compareMethod(o, n)
+	}
+	oldByID := byID(old, func(m *modeldump.Method) string { return m.ID })
+	for _, n := range new {
+		if _, ok := oldByID[n.ID]; !ok {
+			r.add(&Change{ID: n.ID, Element: ElementMethod, Kind: KindAdded})
+		}
+	}
+}
+
+func (r *Report) compareMethod(old, new *modeldump.Method) {
+	changed := func(description string) {
+		r.add(&Change{ID: old.ID, Element: ElementMethod, Kind: KindChanged, Breaking: true, Description: description})
+	}
+	if old.InputTypeID != new.InputTypeID {
+		changed(fmt.Sprintf("input type changed from %s to %s", old.InputTypeID, new.InputTypeID))
+	}
+	if old.OutputTypeID != new.OutputTypeID {
+		changed(fmt.Sprintf("output type changed from %s to %s", old.OutputTypeID, new.OutputTypeID))
+	}
+	if old.ClientSideStreaming != new.ClientSideStreaming || old.ServerSideStreaming != new.ServerSideStreaming {
+		changed("streaming mode changed")
+	}
+	oldBinding, newBinding := primaryBinding(old), primaryBinding(new)
+	if oldBinding != newBinding {
+		changed(fmt.Sprintf("HTTP binding changed from %q to %q", oldBinding, newBinding))
+	}
+	if !old.Deprecated && new.Deprecated {
+		r.add(&Change{ID: old.ID, Element: ElementMethod, Kind: KindChanged, Description: "deprecated"})
+	}
+}
+
+func (r *Report) compareMessages(old, new map[string]*modeldump.Message) {
+	for _, id := range sortedKeys(old) {
+		o := old[id]
+		n, ok := new[id]
+		if !ok {
+			r.add(&Change{ID: id, Element: ElementMessage, Kind: KindRemoved, Breaking: true})
+			continue
+		}
+		r.compareFields(o, n)
+		if !o.Deprecated && n.Deprecated {
+			r.add(&Change{ID: id, Element: ElementMessage, Kind: KindChanged, Description: "deprecated"})
+		}
+	}
+	for _, id := range sortedKeys(new) {
+		if _, ok := old[id]; !ok {
+			r.add(&Change{ID: id, Element: ElementMessage, Kind: KindAdded})
+		}
+	}
+}
+
+func (r *Report) compareFields(old, new *modeldump.Message) {
+	newByName := byID(new.Fields, func(f *modeldump.Field) string { return f.Name })
+	oldByName := byID(old.Fields, func(f *modeldump.Field) string { return f.Name })
+	newByNumber := byNumber(new.Fields)
+	for _, o := range old.Fields {
+		if n, ok := newByName[o.Name]; ok {
+			r.compareField(o, n)
+			continue
+		}
+		n, ok := newByNumber[o.Number]
+		if !ok {
+			r.add(&Change{ID: o.ID, Element: ElementField, Kind: KindRemoved, Breaking: true})
+			continue
+		}
+		if _, kept := oldByName[n.Name]; kept {
+			// The new field took the number of a field that still exists,
+			// this is reported as a number change of that field.
+			r.add(&Change{ID: o.ID, Element: ElementField, Kind: KindRemoved, Breaking: true})
+			continue
+		}
+		if fieldType(o) == fieldType(n) {
+			r.add(&Change{
+				ID: o.ID, Element: ElementField, Kind: KindRenamed, Breaking: true,
+				Description: fmt.Sprintf("renamed to %s", n.Name),
+			})
+			continue
+		}
+		r.add(&Change{
+			ID: o.ID, Element: ElementField, Kind: KindRemoved, Breaking: true,
+			Description: fmt.Sprintf("field number %d reused by %s with type %s", o.Number, n.Name, fieldType(n)),
+		})
+	}
+	oldByNumber := byNumber(old.Fields)
+	for _, n := range new.Fields {
+		if _, ok := oldByName[n.Name]; ok {
+			continue
+		}
+		if o, ok := oldByNumber[n.Number]; ok {
+			if _, kept := newByName[o.Name]; !kept {
+				// Already reported as a rename or number reuse.
+				continue
+			}
+		}
+		c := &Change{ID: n.ID, Element: ElementField, Kind: KindAdded}
+		if slices.Contains(n.Behavior, "REQUIRED") {
+			c.Breaking = true
+			c.Description = "new field is required"
+		}
+		r.add(c)
+	}
+}
+
+func (r *Report) compareField(old, new *modeldump.Field) {
+	changed := func(breaking bool, description string) {
+		r.add(&Change{ID: old.ID, Element: ElementField, Kind: KindChanged, Breaking: breaking, Description: description})
+	}
+	if oldType, newType := fieldType(old), fieldType(new); oldType != newType {
+		changed(true, fmt.Sprintf("type changed from %s to %s", oldType, newType))
+	}
+	if old.Number != new.Number {
+		changed(true, fmt.Sprintf("field number changed from %d to %d", old.Number, new.Number))
+	}
+	if old.OneOf != new.OneOf {
+		changed(true, fmt.Sprintf("oneof changed from %q to %q", old.OneOf, new.OneOf))
+	}
+	if old.Optional != new.Optional {
+		changed(true, "presence changed")
+	}
+	wasRequired := slices.Contains(old.Behavior, "REQUIRED")
+	isRequired := slices.Contains(new.Behavior, "REQUIRED")
+	switch {
+	case !wasRequired && isRequired:
+		changed(true, "field became required")
+	case wasRequired && !isRequired:
+		changed(false, "field is no longer required")
+	}
+	if !old.Deprecated && new.Deprecated {
+		changed(false, "deprecated")
+	}
+}
+
+func (r *Report) compareEnums(old, new map[string]*modeldump.Enum) {
+	for _, id := range sortedKeys(old) {
+		o := old[id]
+		n, ok := new[id]
+		if !ok {
+			r.add(&Change{ID: id, Element: ElementEnum, Kind: KindRemoved, Breaking: true})
+			continue
+		}
+		r.compareEnumValues(o.Values, n.Values)
+	}
+	for _, id := range sortedKeys(new) {
+		if _, ok := old[id]; !ok {
+			r.add(&Change{ID: id, Element: ElementEnum, Kind: KindAdded})
+		}
+	}
+}
+
+func (r *Report) compareEnumValues(old, new []*modeldump.EnumValue) {
+	newByName := byID(new, func(v *modeldump.EnumValue) string { return v.Name })
+	oldByName := byID(old, func(v *modeldump.EnumValue) string { return v.Name })
+	newByNumber := map[int32]*modeldump.EnumValue{}
+	for _, v := range new {
+		if _, ok := newByNumber[v.Number]; !ok {
+			newByNumber[v.Number] = v
+		}
+	}
+	renamed := map[string]bool{}
+	for _, o := range old {
+		if n, ok := newByName[o.Name]; ok {
+			if o.Number != n.Number {
+				r.add(&Change{
+					ID: o.ID, Element: ElementEnumValue, Kind: KindChanged, Breaking: true,
+					Description: fmt.Sprintf("number changed from %d to %d", o.Number, n.Number),
+				})
+			}
+			continue
+		}
+		if n, ok := newByNumber[o.Number]; ok && oldByName[n.Name] == nil {
+			renamed[n.Name] = true
+			r.add(&Change{
+				ID: o.ID, Element: ElementEnumValue, Kind: KindRenamed, Breaking: true,
+				Description: fmt.Sprintf("renamed to %s", n.Name),
+			})
+			continue
+		}
+		r.add(&Change{ID: o.ID, Element: ElementEnumValue, Kind: KindRemoved, Breaking: true})
+	}
+	for _, n := range new {
+		if _, ok := oldByName[n.Name]; ok || renamed[n.Name] {
+			continue
+		}
+		r.add(&Change{ID: n.ID, Element: ElementEnumValue, Kind: KindAdded})
+	}
+}
+
+func (r *Report) compareResources(old, new map[string]*modeldump.Resource) {
+	for _, t := range sortedKeys(old) {
+		o := old[t]
+		n, ok := new[t]
+		if !ok {
+			r.add(&Change{ID: t, Element: ElementResource, Kind: KindRemoved, Breaking: true})
+			continue
+		}
+		for _, p := range o.Patterns {
+			if !slices.Contains(n.Patterns, p) {
+				r.add(&Change{
+					ID: t, Element: ElementResource, Kind: KindChanged, Breaking: true,
+					Description: fmt.Sprintf("pattern %q removed", p),
+				})
+			}
+		}
+		for _, p := range n.Patterns {
+			if !slices.Contains(o.Patterns, p) {
+				r.add(&Change{
+					ID: t, Element: ElementResource, Kind: KindChanged,
+					Description: fmt.Sprintf("pattern %q added", p),
+				})
+			}
+		}
+	}
+	for _, t := range sortedKeys(new) {
+		if _, ok := old[t]; !ok {
+			r.add(&Change{ID: t, Element: ElementResource, Kind: KindAdded})
+		}
+	}
+}
+
+// fieldType returns a description of the field type, including its
+// cardinality.
+func fieldType(f *modeldump.Field) string {
+	t := f.Type
+	if f.TypeID != "" {
+		t = f.TypeID
+	}
+	switch {
+	case f.Map:
+		return "map " + t
+	case f.Repeated:
+		return "repeated " + t
+	}
+	return t
+}
+
+func primaryBinding(m *modeldump.Method) string {
+	if m.PathInfo == nil || len(m.PathInfo.Bindings) == 0 {
+		return ""
+	}
+	b := m.PathInfo.Bindings[0]
+	return fmt.Sprintf("%s %s", b.Verb, b.PathTemplate)
+}
+
+// allMessages returns all messages, including nested messages, indexed by ID.
+func allMessages(messages []*modeldump.Message) map[string]*modeldump.Message {
+	result := map[string]*modeldump.Message{}
+	var walk func([]*modeldump.Message)
+	walk = func(messages []*modeldump.Message) {
+		for _, m := range messages {
+			result[m.ID] = m
+			walk(m.Messages)
+		}
+	}
+	walk(messages)
+	return result
+}
+
+// allEnums returns all enums, including enums nested in messages, indexed by
+// ID.
+func allEnums(model *modeldump.Model) map[string]*modeldump.Enum {
+	result := map[string]*modeldump.Enum{}
+	for _, e := range model.Enums {
+		result[e.ID] = e
+	}
+	for _, m := range allMessages(model.Messages) {
+		for _, e := range m.Enums {
+			result[e.ID] = e
+		}
+	}
+	return result
+}
+
+// allResources returns all resources, including resources defined by
+// messages, indexed by type.
+func allResources(model *modeldump.Model) map[string]*modeldump.Resource {
+	result := map[string]*modeldump.Resource{}
+	for _, r := range model.Resources {
+		result[r.Type] = r
+	}
+	for _, m := range allMessages(model.Messages) {
+		if m.Resource != nil {
+			result[m.Resource.Type] = m.Resource
+		}
+	}
+	return result
+}
+
+func byID[T any](elements []T, id func(T) string) map[string]T {
+	result := make(map[string]T, len(elements))
+	for _, e := range elements {
+		result[id(e)] = e
+	}
+	return result
+}
+
+func byNumber(fields []*modeldump.Field) map[int32]*modeldump.Field {
+	result := make(map[int32]*modeldump.Field, len(fields))
+	for _, f := range fields {
+		if f.Number != 0 {
+			result[f.Number] = f
+		}
+	}
+	return result
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	return slices.Sorted(maps.Keys(m))
+}
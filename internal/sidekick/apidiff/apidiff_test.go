@@ -0,0 +1,315 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apidiff
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/modeldump"
+)
+
+func TestCompare(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		old  *modeldump.Model
+		new  *modeldump.Model
+		want []*Change
+	}{
+		{
+			name: "no changes",
+			old:  testModel(),
+			new:  testModel(),
+		},
+		{
+			name: "service added and removed",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				m.Services[0].ID = ".test.Other"
+			}),
+			want: []*Change{
+				{ID: ".test.Other", Element: ElementService, Kind: KindAdded},
+				{ID: ".test.Service", Element: ElementService, Kind: KindRemoved, Breaking: true},
+			},
+		},
+		{
+			name: "method added",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				m.Services[0].Methods = append(m.Services[0].Methods, &modeldump.Method{ID: ".test.Service.ListSecrets"})
+			}),
+			want: []*Change{
+				{ID: ".test.Service.ListSecrets", Element: ElementMethod, Kind: KindAdded},
+			},
+		},
+		{
+			name: "method changed",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				method := m.Services[0].Methods[0]
+				method.OutputTypeID = ".test.Other"
+				method.PathInfo.Bindings[0].Verb = "POST"
+				method.Deprecated = true
+			}),
+			want: []*Change{
+				{
+					ID: ".test.Service.GetSecret", Element: ElementMethod, Kind: KindChanged, Breaking: true,
+					Description: "output type changed from .test.Secret to .test.Other",
+				},
+				{
+					ID: ".test.Service.GetSecret", Element: ElementMethod, Kind: KindChanged, Breaking: true,
+					Description: `HTTP binding changed from "GET /v1/{name}" to "POST /v1/{name}"`,
+				},
+				{ID: ".test.Service.GetSecret", Element: ElementMethod, Kind: KindChanged, Description: "deprecated"},
+			},
+		},
+		{
+			name: "field added",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				m.Messages[0].Fields = append(m.Messages[0].Fields,
+					&modeldump.Field{ID: ".test.Secret.etag", Name: "etag", Number: 3, Type: "STRING"},
+					&modeldump.Field{ID: ".test.Secret.parent", Name: "parent", Number: 4, Type: "STRING", Behavior: []string{"REQUIRED"}})
+			}),
+			want: []*Change{
+				{ID: ".test.Secret.etag", Element: ElementField, Kind: KindAdded},
+				{ID: ".test.Secret.parent", Element: ElementField, Kind: KindAdded, Breaking: true, Description: "new field is required"},
+			},
+		},
+		{
+			name: "field removed",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				m.Messages[0].Fields = m.Messages[0].Fields[:1]
+			}),
+			want: []*Change{
+				{ID: ".test.Secret.labels", Element: ElementField, Kind: KindRemoved, Breaking: true},
+			},
+		},
+		{
+			name: "field renamed",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				m.Messages[0].Fields[1].Name = "tags"
+				m.Messages[0].Fields[1].ID = ".test.Secret.tags"
+			}),
+			want: []*Change{
+				{ID: ".test.Secret.labels", Element: ElementField, Kind: KindRenamed, Breaking: true, Description: "renamed to tags"},
+			},
+		},
+		{
+			name: "field number reused",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				m.Messages[0].Fields[1] = &modeldump.Field{ID: ".test.Secret.ttl", Name: "ttl", Number: 2, Type: "INT64"}
+			}),
+			want: []*Change{
+				{
+					ID: ".test.Secret.labels", Element: ElementField, Kind: KindRemoved, Breaking: true,
+					Description: "field number 2 reused by ttl with type INT64",
+				},
+			},
+		},
+		{
+			name: "field type and number changed",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				m.Messages[0].Fields[1].Map = false
+				m.Messages[0].Fields[1].Repeated = true
+				m.Messages[0].Fields[1].Number = 7
+			}),
+			want: []*Change{
+				{
+					ID: ".test.Secret.labels", Element: ElementField, Kind: KindChanged, Breaking: true,
+					Description: "type changed from map .test.Secret.LabelsEntry to repeated .test.Secret.LabelsEntry",
+				},
+				{
+					ID: ".test.Secret.labels", Element: ElementField, Kind: KindChanged, Breaking: true,
+					Description: "field number changed from 2 to 7",
+				},
+			},
+		},
+		{
+			name: "field became required",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				m.Messages[0].Fields[0].Behavior = []string{"REQUIRED"}
+			}),
+			want: []*Change{
+				{ID: ".test.Secret.name", Element: ElementField, Kind: KindChanged, Breaking: true, Description: "field became required"},
+			},
+		},
+		{
+			name: "nested message removed",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				m.Messages[0].Messages = nil
+			}),
+			want: []*Change{
+				{ID: ".test.Secret.LabelsEntry", Element: ElementMessage, Kind: KindRemoved, Breaking: true},
+			},
+		},
+		{
+			name: "enum values",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				m.Enums[0].Values = []*modeldump.EnumValue{
+					{ID: ".test.State.STATE_UNSPECIFIED", Name: "STATE_UNSPECIFIED", Number: 0},
+					{ID: ".test.State.ACTIVE", Name: "ACTIVE", Number: 1},
+					{ID: ".test.State.DESTROYED", Name: "DESTROYED", Number: 3},
+				}
+			}),
+			want: []*Change{
+				{ID: ".test.State.DESTROYED", Element: ElementEnumValue, Kind: KindAdded},
+				{ID: ".test.State.DISABLED", Element: ElementEnumValue, Kind: KindRemoved, Breaking: true},
+				{ID: ".test.State.ENABLED", Element: ElementEnumValue, Kind: KindRenamed, Breaking: true, Description: "renamed to ACTIVE"},
+			},
+		},
+		{
+			name: "enum removed",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				m.Enums = nil
+			}),
+			want: []*Change{
+				{ID: ".test.State", Element: ElementEnum, Kind: KindRemoved, Breaking: true},
+			},
+		},
+		{
+			name: "resource patterns",
+			old:  testModel(),
+			new: testModel(func(m *modeldump.Model) {
+				m.Messages[0].Resource.Patterns = []string{"projects/{project}/locations/{location}/secrets/{secret}"}
+			}),
+			want: []*Change{
+				{
+					ID: "test.googleapis.com/Secret", Element: ElementResource, Kind: KindChanged, Breaking: true,
+					Description: `pattern "projects/{project}/secrets/{secret}" removed`,
+				},
+				{
+					ID: "test.googleapis.com/Secret", Element: ElementResource, Kind: KindChanged,
+					Description: `pattern "projects/{project}/locations/{location}/secrets/{secret}" added`,
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := Compare(test.old, test.new)
+			if diff := cmp.Diff(test.want, got.Changes); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReportBreaking(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		changes []*Change
+		want    bool
+	}{
+		{"empty", nil, false},
+		{"additive", []*Change{{Kind: KindAdded}}, false},
+		{"breaking", []*Change{{Kind: KindAdded}, {Kind: KindRemoved, Breaking: true}}, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r := &Report{Changes: test.changes}
+			if got := r.Breaking(); got != test.want {
+				t.Errorf("Breaking() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestChangeString(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		change *Change
+		want   string
+	}{
+		{
+			name:   "additive",
+			change: &Change{ID: ".test.Secret", Element: ElementMessage, Kind: KindAdded},
+			want:   "additive added   message .test.Secret",
+		},
+		{
+			name:   "breaking with description",
+			change: &Change{ID: ".test.Secret.tags", Element: ElementField, Kind: KindRenamed, Breaking: true, Description: "renamed to labels"},
+			want:   "breaking renamed field .test.Secret.tags: renamed to labels",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, test.change.String()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func testModel(opts ...func(*modeldump.Model)) *modeldump.Model {
+	m := &modeldump.Model{
+		Name:        "test",
+		PackageName: "test",
+		Services: []*modeldump.Service{
+			{
+				ID:   ".test.Service",
+				Name: "Service",
+				Methods: []*modeldump.Method{
+					{
+						ID:           ".test.Service.GetSecret",
+						Name:         "GetSecret",
+						InputTypeID:  ".test.GetSecretRequest",
+						OutputTypeID: ".test.Secret",
+						PathInfo: &modeldump.PathInfo{
+							Bindings: []*modeldump.PathBinding{{Verb: "GET", PathTemplate: "/v1/{name}"}},
+						},
+					},
+				},
+			},
+		},
+		Messages: []*modeldump.Message{
+			{
+				ID:   ".test.Secret",
+				Name: "Secret",
+				Fields: []*modeldump.Field{
+					{ID: ".test.Secret.name", Name: "name", Number: 1, Type: "STRING"},
+					{ID: ".test.Secret.labels", Name: "labels", Number: 2, Type: "MESSAGE", TypeID: ".test.Secret.LabelsEntry", Map: true},
+				},
+				Resource: &modeldump.Resource{
+					Type:     "test.googleapis.com/Secret",
+					Patterns: []string{"projects/{project}/secrets/{secret}"},
+				},
+				Messages: []*modeldump.Message{
+					{ID: ".test.Secret.LabelsEntry", Name: "LabelsEntry", IsMap: true},
+				},
+			},
+		},
+		Enums: []*modeldump.Enum{
+			{
+				ID:   ".test.State",
+				Name: "State",
+				Values: []*modeldump.EnumValue{
+					{ID: ".test.State.STATE_UNSPECIFIED", Name: "STATE_UNSPECIFIED", Number: 0},
+					{ID: ".test.State.ENABLED", Name: "ENABLED", Number: 1},
+					{ID: ".test.State.DISABLED", Name: "DISABLED", Number: 2},
+				},
+			},
+		},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
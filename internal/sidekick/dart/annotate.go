@@ -18,10 +18,12 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/googleapis/librarian/internal/license"
 	"github.com/googleapis/librarian/internal/sidekick/api"
@@ -87,6 +89,8 @@ type modelAnnotations struct {
 	ProtoPrefix string
 	// UseWorkspace whether to include the resolution: workspace line in the generated pubspec.yaml.
 	UseWorkspace bool
+	// GenerateSamples is true if the package includes a sample for each RPC.
+	GenerateSamples bool
 }
 
 // HasDocLines returns true if the generated package has doc comments.
@@ -118,6 +122,20 @@ type serviceAnnotations struct {
 	StructName  string
 	DefaultHost string
 	HasMethods  bool
+	// The methods with pagination helpers.
+	PaginatedMethods []*api.Method
+	// HasRouting is true if any method sends the `x-goog-request-params`
+	// header.
+	HasRouting bool
+	// HasRetryPolicies is true if any method has a default retry policy or
+	// timeout, from the gRPC service config.
+	HasRetryPolicies bool
+}
+
+// HasPagination returns true if any method in the service has pagination
+// helpers.
+func (s *serviceAnnotations) HasPagination() bool {
+	return len(s.PaginatedMethods) > 0
 }
 
 type messageAnnotation struct {
@@ -163,6 +181,48 @@ type methodAnnotation struct {
 	ServerSideStreaming bool // Whether the method produces a stream of results (or list if `EnableSSE` is `false`).
 	EnableSSE           bool // Whether the target API supports Server-Sent Events (SSE).
 	IsLast              bool
+	// Pagination is set for methods that conform to AIP-4233.
+	Pagination *paginationAnnotation
+	// The Dart expressions for each parameter in the `x-goog-request-params`
+	// header. Each expression has type `String?`, null values are skipped.
+	RoutingParameters []string
+	// The Dart expression for the default `RetryPolicy` of the method, empty
+	// if the method has no default timeout or retry policy.
+	RetryPolicy string
+	// UsesRetryPolicies is true if the method passes its retry policy to the
+	// service client. This is the case for all methods of a service with
+	// default retry policies.
+	UsesRetryPolicies bool
+	// Sample is set for methods with a generated sample.
+	Sample *sampleAnnotation
+}
+
+// HasRouting returns true if the method sends the `x-goog-request-params`
+// header.
+func (m *methodAnnotation) HasRouting() bool {
+	return len(m.RoutingParameters) > 0
+}
+
+// paginationAnnotation contains the information needed to generate the
+// pagination helpers of a method.
+type paginationAnnotation struct {
+	// The name of the helper returning a stream of pages, e.g. `listSecretsPages`.
+	PagesName string
+	// The name of the helper returning a stream of items, e.g. `listSecretsItems`.
+	ItemsName string
+	// The Dart type of the pageable items, e.g. `Secret` or
+	// `MapEntry<String, Secret>` for map fields.
+	ItemType string
+	// The expression returning the items in a `page`, e.g. `page.secrets` or
+	// `page.items.entries`.
+	PageItems string
+	// The name of the next page token field in the response.
+	NextPageToken string
+	// Whether the next page token field is nullable.
+	NextPageTokenNullable bool
+	// The constructor arguments for the request of the next page, copying all
+	// the fields from the initial request except the page token.
+	NextRequestArgs []string
 }
 
 // HasBody returns true if the method has a body.
@@ -248,6 +308,11 @@ type annotateModel struct {
 	dependencyConstraints map[string]string
 	// Whether the target API supports Server-Sent Events (SSE).
 	supportsSSE bool
+	// Whether to generate a sample for each RPC.
+	generateSamples bool
+	// Whether to set the default timeout and retry policy of each method from
+	// the gRPC service config.
+	defaultRetryPolicies bool
 }
 
 func newAnnotateModel(model *api.API) *annotateModel {
@@ -265,142 +330,29 @@ func newAnnotateModel(model *api.API) *annotateModel {
 // tags. For example, the Mustache tag {{#Services}} uses the
 // [Template.Services] field.
 func (annotate *annotateModel) annotateModel(options map[string]string) error {
+	opts, err := ParseCodecOptions(options)
+	if err != nil {
+		return err
+	}
+	for _, imp := range opts.ExtraImports {
+		annotate.imports[imp] = true
+	}
+	maps.Copy(annotate.packageMapping, opts.Protos)
+	maps.Copy(annotate.packagePrefixes, opts.Prefixes)
+	maps.Copy(annotate.dependencyConstraints, opts.Packages)
+	annotate.supportsSSE = opts.SupportsSSE
+	annotate.generateSamples = opts.GenerateRpcSamples
+	annotate.defaultRetryPolicies = opts.DefaultRetryPolicies
 	var (
-		packageNameOverride        string
-		generationYear             string
-		libraryPathOverride        string
-		packageVersion             string
-		partFileReference          string
-		doNotPublish               bool
-		useWorkspace               = true
-		dependencies               = []string{}
-		devDependencies            = []string{}
-		repositoryURL              string
-		readMeAfterTitleText       string
-		readMeQuickstartText       string
-		issueTrackerURL            string
-		apiKeyEnvironmentVariables = []string{}
-		exports                    = []string{}
+		useWorkspace               = opts.UseWorkspace == nil || *opts.UseWorkspace
+		dependencies               = nonNil(opts.Dependencies)
+		devDependencies            = nonNil(opts.DevDependencies)
+		apiKeyEnvironmentVariables = nonNil(opts.APIKeysEnvironmentVariables)
+		exports                    = nonNil(opts.ExtraExports)
 		protobufPrefix             string
 		pkgName                    string
 	)
 
-	for key, definition := range options {
-		switch {
-		case key == "api-keys-environment-variables":
-			// api-keys-environment-variables = "GOOGLE_API_KEY,GEMINI_API_KEY"
-			// A comma-separated list of environment variables to look for searching for
-			// a API key.
-			apiKeyEnvironmentVariables = strings.Split(definition, ",")
-			for i := range apiKeyEnvironmentVariables {
-				apiKeyEnvironmentVariables[i] = strings.TrimSpace(apiKeyEnvironmentVariables[i])
-			}
-		case key == "library-path-override":
-			// library-path-override = "src/buffers.dart"
-			// The path to use for the generated file, relative to the package's "lib/" directory.
-			libraryPathOverride = definition
-		case key == "package-name-override":
-			packageNameOverride = definition
-		case key == "copyright-year":
-			generationYear = definition
-		case key == "issue-tracker-url":
-			// issue-tracker-url = "http://www.example.com/issues"
-			// A link to the issue tracker for the service.
-			issueTrackerURL = definition
-		case key == "version":
-			packageVersion = definition
-		case key == "part-file":
-			partFileReference = definition
-		case key == "extra-exports":
-			// extra-export = "export 'package:google_cloud_gax/gax.dart' show Any; export 'package:google_cloud_gax/gax.dart' show Status;"
-			// Dart `export` statements that should be appended after any imports.
-			exports = strings.FieldsFunc(definition, func(c rune) bool { return c == ';' })
-			for i := range exports {
-				exports[i] = strings.TrimSpace(exports[i])
-			}
-		case key == "extra-imports":
-			// extra-imports = "dart:math;package:my_package/my_file.dart"
-			// Dart imports that should be included in the generated file.
-			extraImports := strings.FieldsFunc(definition, func(c rune) bool { return c == ';' })
-			for _, imp := range extraImports {
-				annotate.imports[strings.TrimSpace(imp)] = true
-			}
-		case key == "dependencies":
-			// dependencies = "http, googleapis_auth"
-			// A list of dependencies to add to pubspec.yaml. This can be used to add dependencies for hand-written code.
-			dependencies = strings.Split(definition, ",")
-			for i := range dependencies {
-				dependencies[i] = strings.TrimSpace(dependencies[i])
-			}
-		case key == "dev-dependencies":
-			devDependencies = strings.Split(definition, ",")
-		case key == "not-for-publication":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return fmt.Errorf(
-					"cannot convert `not-for-publication` value %q to boolean: %w",
-					definition,
-					err,
-				)
-			}
-			doNotPublish = value
-		case key == "supports-sse":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return fmt.Errorf(
-					"cannot convert `supports-sse` value %q to boolean: %w",
-					definition,
-					err,
-				)
-			}
-			annotate.supportsSSE = value
-		case key == "use-workspace":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return fmt.Errorf(
-					"cannot convert `use-workspace` value %q to boolean: %w",
-					definition,
-					err,
-				)
-			}
-			useWorkspace = value
-		case key == "readme-after-title-text":
-			// Markdown that will be inserted into the README.md after the title section.
-			readMeAfterTitleText = definition
-		case key == "readme-quickstart-text":
-			// Markdown that will appear as a "Quickstart" section of README.md. Does not include
-			// the section title, i.e., you probably want it to start with "## Getting Started"`
-			// or similar.
-			readMeQuickstartText = definition
-		case key == "repository-url":
-			repositoryURL = definition
-		case strings.HasPrefix(key, "proto:"):
-			// "proto:google.protobuf" = "package:google_cloud_protobuf/protobuf.dart"
-			keys := strings.Split(key, ":")
-			if len(keys) != 2 {
-				return fmt.Errorf("key should be in the format proto:<proto-package>, got=%q", key)
-			}
-			protoPackage := keys[1]
-			annotate.packageMapping[protoPackage] = definition
-		case strings.HasPrefix(key, "prefix:"):
-			// 'prefix:google.protobuf' = 'protobuf'
-			keys := strings.Split(key, ":")
-			if len(keys) != 2 {
-				return fmt.Errorf("key should be in the format prefix:<proto-package>, got=%q", key)
-			}
-			protoPackage := keys[1]
-			annotate.packagePrefixes[protoPackage] = definition
-		case strings.HasPrefix(key, "package:"):
-			// Version constraints for a package.
-			//
-			// Expressed as: 'package:<package name>' = '<version constraint>'
-			// For example: 'package:http' = '^1.3.0'
-			//
-			// If the package is needed as a dependency, then this contract is used.
-			annotate.dependencyConstraints[strings.TrimPrefix(key, "package:")] = definition
-		}
-	}
-
 	// Register any missing WKTs.
 	registerMissingWkt(annotate.model)
 
@@ -445,11 +397,11 @@ func (annotate *annotateModel) annotateModel(options map[string]string) error {
 		return errors.New("all packages that define a service must define 'api-keys-environment-variables'")
 	}
 
-	if issueTrackerURL == "" {
+	if opts.IssueTrackerURL == "" {
 		return errors.New("all packages must define 'issue-tracker-url'")
 	}
 
-	pkgName = packageName(model, packageNameOverride)
+	pkgName = packageName(model, opts.PackageNameOverride)
 	importedPackages := calculatePubPackages(annotate.imports)
 	for _, d := range dependencies {
 		importedPackages[d] = true
@@ -461,8 +413,11 @@ func (annotate *annotateModel) annotateModel(options map[string]string) error {
 	}
 
 	mainFileNameWithExtension := strcase.ToSnake(model.Name) + ".dart"
-	if libraryPathOverride != "" {
-		mainFileNameWithExtension = libraryPathOverride
+	if opts.LibraryPathOverride != "" {
+		mainFileNameWithExtension = opts.LibraryPathOverride
+	}
+	if annotate.generateSamples {
+		annotate.annotateSamples(pkgName, mainFileNameWithExtension)
 	}
 
 	slices.Sort(devDependencies)
@@ -470,9 +425,9 @@ func (annotate *annotateModel) annotateModel(options map[string]string) error {
 	ann := &modelAnnotations{
 		Parent:                    model,
 		PackageName:               pkgName,
-		PackageVersion:            packageVersion,
+		PackageVersion:            opts.Version,
 		MainFileNameWithExtension: mainFileNameWithExtension,
-		CopyrightYear:             generationYear,
+		CopyrightYear:             opts.CopyrightYear,
 		BoilerPlate: append(license.HeaderBulk(),
 			"",
 			" Code generated by sidekick. DO NOT EDIT."),
@@ -484,25 +439,34 @@ func (annotate *annotateModel) annotateModel(options map[string]string) error {
 		}(),
 		DocLines:                   docLines,
 		Imports:                    calculateImports(annotate.imports, pkgName, mainFileNameWithExtension),
-		PartFileReference:          partFileReference,
+		PartFileReference:          opts.PartFile,
 		PackageDependencies:        packageDependencies,
 		DevDependencies:            devDependencies,
-		DoNotPublish:               doNotPublish,
-		RepositoryURL:              repositoryURL,
-		IssueTrackerURL:            issueTrackerURL,
-		ReadMeAfterTitleText:       readMeAfterTitleText,
-		ReadMeQuickstartText:       readMeQuickstartText,
+		DoNotPublish:               opts.NotForPublication,
+		RepositoryURL:              opts.RepositoryURL,
+		IssueTrackerURL:            opts.IssueTrackerURL,
+		ReadMeAfterTitleText:       opts.ReadmeAfterTitleText,
+		ReadMeQuickstartText:       opts.ReadmeQuickstartText,
 		ApiKeyEnvironmentVariables: apiKeyEnvironmentVariables,
 		Exports:                    exports,
 		FakeList:                   strings.Join(fakes, ", "),
 		ProtoPrefix:                protobufPrefix,
 		UseWorkspace:               useWorkspace,
+		GenerateSamples:            annotate.generateSamples,
 	}
 
 	model.Codec = ann
 	return nil
 }
 
+// nonNil returns values, or an empty slice if values is nil.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 // calculatePubPackages returns a set of package names (e.g. "http"), given a
 // set of imports (e.g. "package:http/http.dart as http").
 func calculatePubPackages(imports map[string]bool) map[string]bool {
@@ -626,9 +590,21 @@ func (annotate *annotateModel) annotateService(s *api.Service) {
 		return shouldGenerateMethod(m)
 	})
 
+	var paginated []*api.Method
+	hasRouting := false
+	hasRetryPolicies := false
 	for i, m := range methods {
 		annotate.annotateMethod(m)
-		m.Codec.(*methodAnnotation).IsLast = (i == len(methods)-1)
+		codec := m.Codec.(*methodAnnotation)
+		codec.IsLast = (i == len(methods)-1)
+		if codec.Pagination != nil {
+			paginated = append(paginated, m)
+		}
+		hasRouting = hasRouting || codec.HasRouting()
+		hasRetryPolicies = hasRetryPolicies || codec.RetryPolicy != ""
+	}
+	for _, m := range methods {
+		m.Codec.(*methodAnnotation).UsesRetryPolicies = hasRetryPolicies
 	}
 	ann := &serviceAnnotations{
 		Name:        s.Name,
@@ -638,6 +614,10 @@ func (annotate *annotateModel) annotateService(s *api.Service) {
 		StructName:  s.Name,
 		DefaultHost: s.DefaultHost,
 		HasMethods:  len(methods) > 0,
+
+		PaginatedMethods: paginated,
+		HasRouting:       hasRouting,
+		HasRetryPolicies: hasRetryPolicies,
 	}
 	s.Codec = ann
 }
@@ -761,7 +741,7 @@ func (annotate *annotateModel) annotateMethod(method *api.Method) {
 
 	annotation := &methodAnnotation{
 		Parent:              method,
-		Name:                strcase.ToLowerCamel(method.Name),
+		Name:                methodName(method),
 		RequestMethod:       strings.ToLower(method.PathInfo.Bindings[0].Verb),
 		RequestType:         annotate.resolveMessageName(method.InputType, true),
 		ResponseType:        annotate.resolveMessageName(method.OutputType, true),
@@ -772,10 +752,160 @@ func (annotate *annotateModel) annotateMethod(method *api.Method) {
 		IsLROGetOperation:   isGetOperation,
 		ServerSideStreaming: method.ServerSideStreaming,
 		EnableSSE:           method.ServerSideStreaming && annotate.supportsSSE,
+		Pagination:          annotate.paginationAnnotation(method, methodName(method)),
+		RoutingParameters:   annotate.routingParameters(method),
+		RetryPolicy:         annotate.retryPolicy(method),
 	}
 	method.Codec = annotation
 }
 
+// retryPolicy returns the Dart expression for the default `RetryPolicy` of
+// method, or an empty string if the method has no default timeout or retry
+// policy, or if the `default-retry-policies` option is not set.
+func (annotate *annotateModel) retryPolicy(method *api.Method) string {
+	if !annotate.defaultRetryPolicies {
+		return ""
+	}
+	var args []string
+	if method.Timeout != 0 {
+		args = append(args, fmt.Sprintf("timeout: %s", dartDuration(method.Timeout)))
+	}
+	if p := method.RetryPolicy; p != nil {
+		var codes []string
+		for _, code := range p.RetryableCodes {
+			codes = append(codes, fmt.Sprintf("'%s'", code))
+		}
+		args = append(args,
+			fmt.Sprintf("maxAttempts: %d", p.MaxAttempts),
+			fmt.Sprintf("initialBackoff: %s", dartDuration(p.InitialBackoff)),
+			fmt.Sprintf("maxBackoff: %s", dartDuration(p.MaxBackoff)),
+			fmt.Sprintf("backoffMultiplier: %s", dartDouble(p.BackoffMultiplier)),
+			fmt.Sprintf("retryableCodes: {%s}", strings.Join(codes, ", ")),
+		)
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("RetryPolicy(%s)", strings.Join(args, ", "))
+}
+
+func dartDuration(d time.Duration) string {
+	return fmt.Sprintf("Duration(milliseconds: %d)", d.Milliseconds())
+}
+
+func dartDouble(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// routingParameters returns the Dart expressions used to build the
+// `x-goog-request-params` header of method.
+//
+// Implicit parameters call the `_implicitRoutingParameter` helper with the
+// full value of the field, as in the request path, the parameter is omitted
+// if any field in the path is null. Explicit parameters call the
+// `_routingParameter` helper with all the variants, the first variant that
+// matches wins.
+func (annotate *annotateModel) routingParameters(method *api.Method) []string {
+	var params []string
+	for _, p := range language.RoutingParameters(method) {
+		if p.Implicit {
+			accessor := annotate.routingAccessor(method.InputType, p.Variants[0].FieldPath, "?.")
+			params = append(params, fmt.Sprintf("_implicitRoutingParameter('%s', %s)", p.Name, accessor))
+			continue
+		}
+		var variants []string
+		for _, v := range p.Variants {
+			accessor := annotate.routingAccessor(method.InputType, v.FieldPath, "?.")
+			variants = append(variants, fmt.Sprintf("(%s, RegExp(r'%s'))", accessor, v.Pattern))
+		}
+		params = append(params, fmt.Sprintf("_routingParameter('%s', [%s])", p.Name, strings.Join(variants, ", ")))
+	}
+	return params
+}
+
+// routingAccessor returns the Dart expression to access fieldPath in the
+// request. The nullDeref separator is used after nullable fields.
+func (annotate *annotateModel) routingAccessor(message *api.Message, fieldPath []string, nullDeref string) string {
+	var builder strings.Builder
+	builder.WriteString("request")
+	deref := "."
+	for _, name := range fieldPath {
+		builder.WriteString(deref)
+		var field *api.Field
+		if message != nil {
+			if idx := slices.IndexFunc(message.Fields, func(f *api.Field) bool { return f.Name == name }); idx != -1 {
+				field = message.Fields[idx]
+			}
+		}
+		if field == nil {
+			builder.WriteString(strcase.ToLowerCamel(name))
+			deref = nullDeref
+			message = nil
+			continue
+		}
+		if field.Codec == nil {
+			annotate.annotateField(field)
+		}
+		builder.WriteString(fieldName(field))
+		deref = "."
+		if field.Codec.(*fieldAnnotation).Nullable {
+			deref = nullDeref
+		}
+		message = annotate.model.Message(field.TypezID)
+		if field.Typez == api.TypezEnum {
+			builder.WriteString(deref)
+			builder.WriteString("value")
+		}
+	}
+	return builder.String()
+}
+
+// paginationAnnotation returns the annotations for the pagination helpers of
+// method, named after name, the name of the method in the generated code, or
+// nil if the method is not paginated.
+func (annotate *annotateModel) paginationAnnotation(method *api.Method, name string) *paginationAnnotation {
+	if method.Pagination == nil || method.OutputType.Pagination == nil || method.ServerSideStreaming {
+		return nil
+	}
+	info := method.OutputType.Pagination
+	itemField := info.PageableItem
+	itemName := itemField.Codec.(*fieldAnnotation).Name
+	var itemType, pageItems string
+	if itemField.Map {
+		message := annotate.model.Message(itemField.TypezID)
+		itemType = fmt.Sprintf("MapEntry<%s, %s>", annotate.fieldType(message.Fields[0]), annotate.fieldType(message.Fields[1]))
+		pageItems = fmt.Sprintf("page.%s.entries", itemName)
+	} else {
+		item := *itemField
+		item.Repeated = false
+		itemType = annotate.fieldType(&item)
+		pageItems = fmt.Sprintf("page.%s", itemName)
+	}
+	var args []string
+	for _, f := range method.InputType.Fields {
+		field := f.Codec.(*fieldAnnotation).Name
+		if f == method.Pagination {
+			args = append(args, fmt.Sprintf("%s: nextPageToken", field))
+			continue
+		}
+		args = append(args, fmt.Sprintf("%s: request.%s", field, field))
+	}
+	nextPageToken := info.NextPageToken.Codec.(*fieldAnnotation)
+	return &paginationAnnotation{
+		PagesName:             name + "Pages",
+		ItemsName:             name + "Items",
+		ItemType:              itemType,
+		PageItems:             pageItems,
+		NextPageToken:         nextPageToken.Name,
+		NextPageTokenNullable: nextPageToken.Nullable,
+		NextRequestArgs:       args,
+	}
+}
+
 func (annotate *annotateModel) annotateOperationInfo(operationInfo *api.OperationInfo) {
 	response := annotate.model.Message(operationInfo.ResponseTypeID)
 	metadata := annotate.model.Message(operationInfo.MetadataTypeID)
@@ -18,6 +18,7 @@ import (
 	"maps"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/librarian/internal/sample"
@@ -236,7 +237,7 @@ func TestAnnotateModel_Options(t *testing.T) {
 			},
 		},
 		{
-			map[string]string{"google_cloud_rpc": "^1.2.3", "package:http": "1.2.0"},
+			map[string]string{"package:google_cloud_rpc": "^1.2.3", "package:http": "1.2.0"},
 			func(t *testing.T, am *annotateModel) {
 				if diff := cmp.Diff(map[string]string{
 					"google_cloud_rpc":      "^1.2.3",
@@ -415,6 +416,375 @@ func TestAnnotateMethod_IsLast(t *testing.T) {
 	}
 }
 
+func TestAnnotateMethod_Pagination(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		request  []*api.Field
+		response []*api.Field
+		extra    []*api.Message
+		want     *paginationAnnotation
+	}{
+		{
+			name: "repeated",
+			request: []*api.Field{
+				api.NewTestField("parent").WithType(api.TypezString),
+				api.NewTestField("page_size").WithType(api.TypezInt32),
+				api.NewTestField("page_token").WithType(api.TypezString),
+			},
+			response: []*api.Field{
+				messageField(api.NewTestField("secrets"), ".test.Secret").WithRepeated(),
+				api.NewTestField("next_page_token").WithType(api.TypezString),
+			},
+			extra: []*api.Message{api.NewTestMessage("Secret")},
+			want: &paginationAnnotation{
+				PagesName:     "listSecretsPages",
+				ItemsName:     "listSecretsItems",
+				ItemType:      "Secret",
+				PageItems:     "page.secrets",
+				NextPageToken: "nextPageToken",
+				NextRequestArgs: []string{
+					"parent: request.parent",
+					"pageSize: request.pageSize",
+					"pageToken: nextPageToken",
+				},
+			},
+		},
+		{
+			name: "max results and optional token",
+			request: []*api.Field{
+				api.NewTestField("project").WithType(api.TypezString),
+				optionalField(api.NewTestField("max_results").WithType(api.TypezUint32)),
+				optionalField(api.NewTestField("page_token").WithType(api.TypezString)),
+			},
+			response: []*api.Field{
+				messageField(api.NewTestField("items"), ".test.Secret").WithRepeated(),
+				optionalField(api.NewTestField("next_page_token").WithType(api.TypezString)),
+			},
+			extra: []*api.Message{api.NewTestMessage("Secret")},
+			want: &paginationAnnotation{
+				PagesName:             "listSecretsPages",
+				ItemsName:             "listSecretsItems",
+				ItemType:              "Secret",
+				PageItems:             "page.items",
+				NextPageToken:         "nextPageToken",
+				NextPageTokenNullable: true,
+				NextRequestArgs: []string{
+					"project: request.project",
+					"maxResults: request.maxResults",
+					"pageToken: nextPageToken",
+				},
+			},
+		},
+		{
+			name: "map",
+			request: []*api.Field{
+				api.NewTestField("page_size").WithType(api.TypezInt32),
+				api.NewTestField("page_token").WithType(api.TypezString),
+			},
+			response: []*api.Field{
+				mapField(api.NewTestField("secrets"), ".test.ListSecretsResponse.SecretsEntry"),
+				api.NewTestField("next_page_token").WithType(api.TypezString),
+			},
+			extra: []*api.Message{
+				{
+					Name:    "SecretsEntry",
+					ID:      ".test.ListSecretsResponse.SecretsEntry",
+					Package: "test",
+					IsMap:   true,
+					Fields: []*api.Field{
+						api.NewTestField("key").WithType(api.TypezString),
+						api.NewTestField("value").WithType(api.TypezInt64),
+					},
+				},
+			},
+			want: &paginationAnnotation{
+				PagesName:     "listSecretsPages",
+				ItemsName:     "listSecretsItems",
+				ItemType:      "MapEntry<String, int>",
+				PageItems:     "page.secrets.entries",
+				NextPageToken: "nextPageToken",
+				NextRequestArgs: []string{
+					"pageSize: request.pageSize",
+					"pageToken: nextPageToken",
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			request := api.NewTestMessage("ListSecretsRequest").WithFields(test.request...)
+			response := api.NewTestMessage("ListSecretsResponse").WithFields(test.response...)
+			method := api.NewTestMethod("ListSecrets").WithInput(request).WithOutput(response).WithVerb("GET")
+			method.PathInfo.Bindings[0].PathTemplate = (&api.PathTemplate{}).WithLiteral("v1").WithLiteral("secrets")
+			service := api.NewTestService("Service").WithMethods(method)
+			model := api.NewTestAPI(append([]*api.Message{request, response}, test.extra...), []*api.Enum{}, []*api.Service{service})
+			if err := api.CrossReference(model); err != nil {
+				t.Fatal(err)
+			}
+			api.UpdateMethodPagination(nil, model)
+			if method.Pagination == nil {
+				t.Fatalf("expected method %s to be paginated", method.ID)
+			}
+			annotate := newAnnotateModel(model)
+			if err := annotate.annotateModel(requiredConfig); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, method.Codec.(*methodAnnotation).Pagination); diff != "" {
+				t.Errorf("mismatch (-want, +got)\n:%s", diff)
+			}
+			if got := service.Codec.(*serviceAnnotations).PaginatedMethods; len(got) != 1 || got[0] != method {
+				t.Errorf("expected %s in PaginatedMethods, got=%v", method.ID, got)
+			}
+		})
+	}
+}
+
+func TestAnnotateMethod_NotPaginated(t *testing.T) {
+	method := sample.MethodListSecretVersions()
+	service := &api.Service{
+		Name:    sample.ServiceName,
+		Methods: []*api.Method{method},
+		Package: sample.Package,
+	}
+	model := api.NewTestAPI(
+		[]*api.Message{sample.ListSecretVersionsRequest(), sample.ListSecretVersionsResponse(),
+			sample.Secret(), sample.SecretVersion(), sample.Replication(), sample.Automatic(),
+			sample.CustomerManagedEncryption()},
+		[]*api.Enum{sample.EnumState()},
+		[]*api.Service{service},
+	)
+	api.Validate(model)
+	annotate := newAnnotateModel(model)
+	if err := annotate.annotateModel(requiredConfig); err != nil {
+		t.Fatal(err)
+	}
+	if got := method.Codec.(*methodAnnotation).Pagination; got != nil {
+		t.Errorf("expected no pagination annotations, got=%v", got)
+	}
+	if service.Codec.(*serviceAnnotations).HasPagination() {
+		t.Errorf("expected HasPagination to be false")
+	}
+}
+
+func TestAnnotateMethod_Routing(t *testing.T) {
+	profile := api.NewTestMessage("AppProfile").WithFields(
+		api.NewTestField("id").WithType(api.TypezString))
+	request := api.NewTestMessage("ReadRowsRequest").WithFields(
+		api.NewTestField("name").WithType(api.TypezString),
+		optionalField(messageField(api.NewTestField("app_profile"), ".test.AppProfile")),
+		api.NewTestField("state").WithType(api.TypezEnum))
+	request.Fields[2].TypezID = ".test.State"
+	state := &api.Enum{Name: "State", ID: ".test.State", Package: "test",
+		Values: []*api.EnumValue{{Name: "STATE_UNSPECIFIED"}}}
+	response := api.NewTestMessage("ReadRowsResponse")
+	newMethod := func(name string) *api.Method {
+		return api.NewTestMethod(name).WithInput(request).WithOutput(response).WithVerb("GET").
+			WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("name").
+				WithLiteral("states").WithVariableNamed("state"))
+	}
+	implicit := newMethod("Implicit")
+	nested := api.NewTestMethod("Nested").WithInput(request).WithOutput(response).WithVerb("GET").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("app_profile", "id"))
+	explicit := newMethod("Explicit")
+	explicit.Routing = []*api.RoutingInfo{
+		{
+			Name: "table",
+			Variants: []*api.RoutingInfoVariant{
+				{
+					FieldPath: []string{"name"},
+					Prefix:    api.RoutingPathSpec{Segments: []string{"projects", "*"}},
+					Matching:  api.RoutingPathSpec{Segments: []string{"tables", "*"}},
+				},
+			},
+		},
+		{
+			Name: "app_profile_id",
+			Variants: []*api.RoutingInfoVariant{
+				{
+					FieldPath: []string{"app_profile", "id"},
+					Matching:  api.RoutingPathSpec{Segments: []string{"**"}},
+				},
+			},
+		},
+	}
+	disabled := newMethod("Disabled")
+	disabled.Routing = []*api.RoutingInfo{{}}
+	service := api.NewTestService("Service").WithMethods(implicit, nested, explicit, disabled)
+	model := api.NewTestAPI([]*api.Message{profile, request, response}, []*api.Enum{state}, []*api.Service{service})
+	model.PackageName = "test"
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	annotate := newAnnotateModel(model)
+	if err := annotate.annotateModel(requiredConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		method *api.Method
+		want   []string
+	}{
+		{
+			method: implicit,
+			want: []string{
+				`_implicitRoutingParameter('name', request.name)`,
+				`_implicitRoutingParameter('state', request.state.value)`,
+			},
+		},
+		{
+			method: nested,
+			want: []string{
+				`_implicitRoutingParameter('app_profile.id', request.appProfile?.id)`,
+			},
+		},
+		{
+			method: explicit,
+			want: []string{
+				`_routingParameter('table', [(request.name, RegExp(r'^projects/[^/]+/(tables/[^/]+)$'))])`,
+				`_routingParameter('app_profile_id', [(request.appProfile?.id, RegExp(r'^(.*)$'))])`,
+			},
+		},
+		{
+			method: disabled,
+		},
+	} {
+		t.Run(test.method.Name, func(t *testing.T) {
+			got := test.method.Codec.(*methodAnnotation).RoutingParameters
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+	if !service.Codec.(*serviceAnnotations).HasRouting {
+		t.Errorf("expected service to have routing")
+	}
+}
+
+func TestAnnotateMethod_RetryPolicy(t *testing.T) {
+	request := api.NewTestMessage("Request")
+	response := api.NewTestMessage("Response")
+	newMethod := func(name string) *api.Method {
+		return api.NewTestMethod(name).WithInput(request).WithOutput(response).WithVerb("GET").
+			WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1"))
+	}
+	timeout := newMethod("Timeout")
+	timeout.Timeout = 60 * time.Second
+	retry := newMethod("Retry")
+	retry.Timeout = 30500 * time.Millisecond
+	retry.RetryPolicy = &api.RetryPolicy{
+		MaxAttempts:       5,
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        60 * time.Second,
+		BackoffMultiplier: 2,
+		RetryableCodes:    []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"},
+	}
+	none := newMethod("None")
+	service := api.NewTestService("Service").WithMethods(timeout, retry, none)
+	model := api.NewTestAPI([]*api.Message{request, response}, nil, []*api.Service{service})
+	model.PackageName = "test"
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	annotate := newAnnotateModel(model)
+	options := maps.Clone(requiredConfig)
+	options["default-retry-policies"] = "true"
+	if err := annotate.annotateModel(options); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		method *api.Method
+		want   string
+	}{
+		{
+			method: timeout,
+			want:   "RetryPolicy(timeout: Duration(milliseconds: 60000))",
+		},
+		{
+			method: retry,
+			want: "RetryPolicy(timeout: Duration(milliseconds: 30500), maxAttempts: 5, " +
+				"initialBackoff: Duration(milliseconds: 100), maxBackoff: Duration(milliseconds: 60000), " +
+				"backoffMultiplier: 2.0, retryableCodes: {'UNAVAILABLE', 'RESOURCE_EXHAUSTED'})",
+		},
+		{
+			method: none,
+		},
+	} {
+		t.Run(test.method.Name, func(t *testing.T) {
+			codec := test.method.Codec.(*methodAnnotation)
+			if diff := cmp.Diff(test.want, codec.RetryPolicy); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+			if !codec.UsesRetryPolicies {
+				t.Errorf("expected method to use the retry policies")
+			}
+		})
+	}
+	if !service.Codec.(*serviceAnnotations).HasRetryPolicies {
+		t.Errorf("expected service to have retry policies")
+	}
+}
+
+func TestAnnotateMethod_NoRetryPolicy(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		timeout time.Duration
+		options map[string]string
+	}{
+		{
+			name:    "no defaults",
+			options: map[string]string{"default-retry-policies": "true"},
+		},
+		{
+			name:    "disabled by default",
+			timeout: 60 * time.Second,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			request := api.NewTestMessage("Request")
+			response := api.NewTestMessage("Response")
+			method := api.NewTestMethod("Method").WithInput(request).WithOutput(response).WithVerb("GET").
+				WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1"))
+			method.Timeout = test.timeout
+			service := api.NewTestService("Service").WithMethods(method)
+			model := api.NewTestAPI([]*api.Message{request, response}, nil, []*api.Service{service})
+			model.PackageName = "test"
+			if err := api.CrossReference(model); err != nil {
+				t.Fatal(err)
+			}
+			annotate := newAnnotateModel(model)
+			options := maps.Clone(requiredConfig)
+			maps.Copy(options, test.options)
+			if err := annotate.annotateModel(options); err != nil {
+				t.Fatal(err)
+			}
+			if service.Codec.(*serviceAnnotations).HasRetryPolicies {
+				t.Errorf("expected service without retry policies")
+			}
+			if method.Codec.(*methodAnnotation).UsesRetryPolicies {
+				t.Errorf("expected method to not use the retry policies")
+			}
+		})
+	}
+}
+
+func optionalField(f *api.Field) *api.Field {
+	f.Optional = true
+	return f
+}
+
+func messageField(f *api.Field, id string) *api.Field {
+	f.Typez = api.TypezMessage
+	f.TypezID = id
+	return f
+}
+
+func mapField(f *api.Field, entryID string) *api.Field {
+	f.Typez = api.TypezMessage
+	f.TypezID = entryID
+	f.Map = true
+	return f
+}
+
 func TestCalculatePubPackages(t *testing.T) {
 	for _, test := range []struct {
 		imports map[string]bool
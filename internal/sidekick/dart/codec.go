@@ -0,0 +1,224 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/language"
+)
+
+// ParseCodecOptions converts the string map used by older configurations to
+// the typed options of the Dart codec.
+//
+// It returns an error for unknown keys and malformed values.
+func ParseCodecOptions(options map[string]string) (*config.DartCodec, error) {
+	opts := &config.DartCodec{}
+	parseBool := func(key, definition string) (bool, error) {
+		value, err := strconv.ParseBool(definition)
+		if err != nil {
+			return false, fmt.Errorf("cannot convert `%s` value %q to boolean: %w", key, definition, err)
+		}
+		return value, nil
+	}
+	for key, definition := range options {
+		var err error
+		switch {
+		case key == "copyright-year":
+			opts.CopyrightYear = definition
+		case key == "version":
+			opts.Version = definition
+		case key == "not-for-publication":
+			opts.NotForPublication, err = parseBool(key, definition)
+		case key == "template-overlay":
+			opts.TemplateOverlay = definition
+		case key == "package-name-override":
+			opts.PackageNameOverride = definition
+		case key == "library-path-override":
+			// library-path-override = "src/buffers.dart"
+			// The path to use for the generated file, relative to the package's "lib/" directory.
+			opts.LibraryPathOverride = definition
+		case key == "api-keys-environment-variables":
+			// api-keys-environment-variables = "GOOGLE_API_KEY,GEMINI_API_KEY"
+			// A comma-separated list of environment variables to look for searching for
+			// a API key.
+			opts.APIKeysEnvironmentVariables = splitAndTrim(definition, ',')
+		case key == "issue-tracker-url":
+			opts.IssueTrackerURL = definition
+		case key == "repository-url":
+			opts.RepositoryURL = definition
+		case key == "part-file":
+			opts.PartFile = definition
+		case key == "extra-exports":
+			// extra-export = "export 'package:google_cloud_gax/gax.dart' show Any; export 'package:google_cloud_gax/gax.dart' show Status;"
+			// Dart `export` statements that should be appended after any imports.
+			opts.ExtraExports = splitAndTrim(definition, ';')
+		case key == "extra-imports":
+			// extra-imports = "dart:math;package:my_package/my_file.dart"
+			// Dart imports that should be included in the generated file.
+			opts.ExtraImports = splitAndTrim(definition, ';')
+		case key == "dependencies":
+			// dependencies = "http, googleapis_auth"
+			// A list of dependencies to add to pubspec.yaml. This can be used to add dependencies for hand-written code.
+			opts.Dependencies = splitAndTrim(definition, ',')
+		case key == "dev-dependencies":
+			opts.DevDependencies = strings.Split(definition, ",")
+		case key == "supports-sse":
+			opts.SupportsSSE, err = parseBool(key, definition)
+		case key == "generate-rpc-samples":
+			opts.GenerateRpcSamples, err = parseBool(key, definition)
+		case key == "default-retry-policies":
+			// This requires a version of `google_cloud_gax` where
+			// `ServiceClient` accepts a `RetryPolicy`.
+			opts.DefaultRetryPolicies, err = parseBool(key, definition)
+		case key == "use-workspace":
+			var value bool
+			value, err = parseBool(key, definition)
+			opts.UseWorkspace = &value
+		case key == "skip-format":
+			opts.SkipFormat, err = parseBool(key, definition)
+		case key == "readme-after-title-text":
+			opts.ReadmeAfterTitleText = definition
+		case key == "readme-quickstart-text":
+			opts.ReadmeQuickstartText = definition
+		case strings.HasPrefix(key, "proto:"):
+			// "proto:google.protobuf" = "package:google_cloud_protobuf/protobuf.dart"
+			opts.Protos, err = setMapOption(opts.Protos, key, definition)
+		case strings.HasPrefix(key, "prefix:"):
+			// 'prefix:google.protobuf' = 'protobuf'
+			opts.Prefixes, err = setMapOption(opts.Prefixes, key, definition)
+		case strings.HasPrefix(key, "package:"):
+			// 'package:http' = '^1.3.0'
+			if opts.Packages == nil {
+				opts.Packages = map[string]string{}
+			}
+			opts.Packages[strings.TrimPrefix(key, "package:")] = definition
+		default:
+			return nil, fmt.Errorf("unknown Dart codec option %q", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return opts, nil
+}
+
+// setMapOption adds the value of a `proto:<package>` or `prefix:<package>`
+// option to m, keyed by the protobuf package.
+func setMapOption(m map[string]string, key, definition string) (map[string]string, error) {
+	keys := strings.Split(key, ":")
+	if len(keys) != 2 {
+		return nil, fmt.Errorf("key should be in the format %s:<proto-package>, got=%q", keys[0], key)
+	}
+	if m == nil {
+		m = map[string]string{}
+	}
+	m[keys[1]] = definition
+	return m, nil
+}
+
+// splitAndTrim splits definition at each sep, and removes the spaces around
+// each value.
+func splitAndTrim(definition string, sep rune) []string {
+	values := strings.FieldsFunc(definition, func(c rune) bool { return c == sep })
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values
+}
+
+// FormatCodecOptions converts the typed options of the Dart codec to the
+// string map consumed by the codec. It is the inverse of
+// [ParseCodecOptions], and omits the options with zero values.
+func FormatCodecOptions(opts *config.DartCodec) map[string]string {
+	options := map[string]string{}
+	setString := func(key, value string) {
+		if value != "" {
+			options[key] = value
+		}
+	}
+	setBool := func(key string, value bool) {
+		if value {
+			options[key] = "true"
+		}
+	}
+	setList := func(key string, values []string, sep string) {
+		if len(values) > 0 {
+			options[key] = strings.Join(values, sep)
+		}
+	}
+	setMap := func(prefix string, m map[string]string) {
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			options[prefix+k] = m[k]
+		}
+	}
+	setString("copyright-year", opts.CopyrightYear)
+	setString("version", opts.Version)
+	setBool("not-for-publication", opts.NotForPublication)
+	setString("template-overlay", opts.TemplateOverlay)
+	setString("package-name-override", opts.PackageNameOverride)
+	setString("library-path-override", opts.LibraryPathOverride)
+	setList("api-keys-environment-variables", opts.APIKeysEnvironmentVariables, ",")
+	setString("issue-tracker-url", opts.IssueTrackerURL)
+	setString("repository-url", opts.RepositoryURL)
+	setString("part-file", opts.PartFile)
+	setList("extra-exports", opts.ExtraExports, ";")
+	setList("extra-imports", opts.ExtraImports, ";")
+	setList("dependencies", opts.Dependencies, ",")
+	setList("dev-dependencies", opts.DevDependencies, ",")
+	setBool("supports-sse", opts.SupportsSSE)
+	setBool("generate-rpc-samples", opts.GenerateRpcSamples)
+	setBool("default-retry-policies", opts.DefaultRetryPolicies)
+	if opts.UseWorkspace != nil {
+		options["use-workspace"] = strconv.FormatBool(*opts.UseWorkspace)
+	}
+	setBool("skip-format", opts.SkipFormat)
+	setString("readme-after-title-text", opts.ReadmeAfterTitleText)
+	setString("readme-quickstart-text", opts.ReadmeQuickstartText)
+	setMap("proto:", opts.Protos)
+	setMap("prefix:", opts.Prefixes)
+	setMap("package:", opts.Packages)
+	return options
+}
+
+// ValidateCodecOptions returns an error if the options cannot be used to
+// generate code, such as a package without a version constraint or a
+// template overlay with templates unknown to the codec.
+func ValidateCodecOptions(opts *config.DartCodec) error {
+	for _, name := range slices.Sorted(maps.Keys(opts.Protos)) {
+		if opts.Protos[name] == "" {
+			return fmt.Errorf("missing Dart import for protobuf package %q", name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(opts.Prefixes)) {
+		if opts.Prefixes[name] == "" {
+			return fmt.Errorf("missing Dart import prefix for protobuf package %q", name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(opts.Packages)) {
+		if opts.Packages[name] == "" {
+			return fmt.Errorf("missing version constraint for Dart package %q", name)
+		}
+	}
+	if _, err := language.OverriddenTemplates(dartTemplates, opts.TemplateOverlay); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+)
+
+func TestParseCodecOptions(t *testing.T) {
+	got, err := ParseCodecOptions(map[string]string{
+		"api-keys-environment-variables": "GOOGLE_API_KEY, GEMINI_API_KEY",
+		"extra-imports":                  "dart:math;package:my_package/my_file.dart",
+		"supports-sse":                   "true",
+		"use-workspace":                  "false",
+		"proto:google.protobuf":          "package:google_cloud_protobuf/protobuf.dart",
+		"prefix:google.protobuf":         "protobuf",
+		"package:http":                   "^1.3.0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	useWorkspace := false
+	want := &config.DartCodec{
+		APIKeysEnvironmentVariables: []string{"GOOGLE_API_KEY", "GEMINI_API_KEY"},
+		ExtraImports:                []string{"dart:math", "package:my_package/my_file.dart"},
+		SupportsSSE:                 true,
+		UseWorkspace:                &useWorkspace,
+		Protos:                      map[string]string{"google.protobuf": "package:google_cloud_protobuf/protobuf.dart"},
+		Prefixes:                    map[string]string{"google.protobuf": "protobuf"},
+		Packages:                    map[string]string{"http": "^1.3.0"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseCodecOptions_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		options map[string]string
+	}{
+		{name: "unknown key", options: map[string]string{"google_cloud_rpc": "^1.0.0"}},
+		{name: "invalid boolean", options: map[string]string{"supports-sse": "yes"}},
+		{name: "invalid proto key", options: map[string]string{"proto:a:b": "package:a/a.dart"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ParseCodecOptions(test.options); err == nil {
+				t.Errorf("ParseCodecOptions(%v) should fail", test.options)
+			}
+		})
+	}
+}
+
+func TestFormatCodecOptions(t *testing.T) {
+	useWorkspace := true
+	opts := &config.DartCodec{
+		CopyrightYear:        "2025",
+		Version:              "1.2.3",
+		NotForPublication:    true,
+		Dependencies:         []string{"http", "googleapis_auth"},
+		ExtraExports:         []string{"export 'package:google_cloud_gax/gax.dart' show Any"},
+		DefaultRetryPolicies: true,
+		UseWorkspace:         &useWorkspace,
+		ReadmeAfterTitleText: "> [!NOTE]",
+		Packages:             map[string]string{"http": "^1.3.0", "googleapis_auth": "^2.0.0"},
+	}
+	got, err := ParseCodecOptions(FormatCodecOptions(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(opts, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if got := FormatCodecOptions(&config.DartCodec{}); len(got) != 0 {
+		t.Errorf("FormatCodecOptions() = %v, want no options", got)
+	}
+}
+
+func TestValidateCodecOptions(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		opts    *config.DartCodec
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			opts: &config.DartCodec{
+				Protos:   map[string]string{"google.protobuf": "package:google_cloud_protobuf/protobuf.dart"},
+				Packages: map[string]string{"http": "^1.3.0"},
+			},
+		},
+		{
+			name:    "proto without import",
+			opts:    &config.DartCodec{Protos: map[string]string{"google.protobuf": ""}},
+			wantErr: true,
+		},
+		{
+			name:    "prefix without value",
+			opts:    &config.DartCodec{Prefixes: map[string]string{"google.protobuf": ""}},
+			wantErr: true,
+		},
+		{
+			name:    "package without version",
+			opts:    &config.DartCodec{Packages: map[string]string{"http": ""}},
+			wantErr: true,
+		},
+		{
+			name:    "missing template overlay",
+			opts:    &config.DartCodec{TemplateOverlay: "testdata/missing"},
+			wantErr: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateCodecOptions(test.opts)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("ValidateCodecOptions() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
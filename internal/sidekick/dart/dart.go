@@ -157,6 +157,14 @@ func fieldName(field *api.Field) string {
 	return name
 }
 
+func methodName(method *api.Method) string {
+	name := strcase.ToLowerCamel(method.Name)
+	if _, hasConflict := reservedNames[name]; hasConflict {
+		name = name + deconflictChar
+	}
+	return name
+}
+
 func enumName(e *api.Enum) string {
 	name := strcase.ToCamel(e.Name)
 	if e.Parent != nil {
@@ -246,7 +254,8 @@ func packageName(api *api.API, packageNameOverride string) string {
 
 func shouldGenerateMethod(m *api.Method) bool {
 	// Ignore methods without HTTP annotations; we cannot generate working RPCs
-	// for them.
+	// for them. Client-side and bidirectional streaming methods would need a
+	// gRPC transport, and `package:google_cloud_rpc` only has an HTTP client.
 	// TODO(#499) Switch to explicitly excluding such functions.
 	if m.ClientSideStreaming || m.PathInfo == nil {
 		return false
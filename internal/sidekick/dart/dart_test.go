@@ -55,6 +55,23 @@ func TestMessageNames(t *testing.T) {
 	}
 }
 
+func TestMethodNames(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		want string
+	}{
+		{name: "ListSecrets", want: "listSecrets"},
+		{name: "New", want: "new$"},
+		{name: "Continue", want: "continue$"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := methodName(api.NewTestMethod(test.name)); got != test.want {
+				t.Errorf("mismatched method name, got=%q, want=%q", got, test.want)
+			}
+		})
+	}
+}
+
 func TestEnumNames(t *testing.T) {
 	parent := &api.Message{
 		Name:    "SecretVersion",
@@ -626,3 +643,32 @@ func TestHttpPathFmt(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldGenerateMethod(t *testing.T) {
+	clientStreaming := sample.MethodCreate()
+	clientStreaming.ClientSideStreaming = true
+	bidiStreaming := sample.MethodCreate()
+	bidiStreaming.ClientSideStreaming = true
+	bidiStreaming.ServerSideStreaming = true
+	serverStreaming := sample.MethodCreate()
+	serverStreaming.ServerSideStreaming = true
+	noHTTP := sample.MethodCreate()
+	noHTTP.PathInfo = nil
+	for _, test := range []struct {
+		name   string
+		method *api.Method
+		want   bool
+	}{
+		{name: "unary", method: sample.MethodCreate(), want: true},
+		{name: "server streaming", method: serverStreaming, want: true},
+		{name: "client streaming", method: clientStreaming, want: false},
+		{name: "bidi streaming", method: bidiStreaming, want: false},
+		{name: "no http annotation", method: noHTTP, want: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := shouldGenerateMethod(test.method); got != test.want {
+				t.Errorf("shouldGenerateMethod() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"embed"
 	"path/filepath"
+	"slices"
 
 	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/language"
@@ -33,27 +34,34 @@ func Generate(ctx context.Context, model *api.API, outdir string, codec map[stri
 		return err
 	}
 
-	provider := templatesProvider()
-	err := language.GenerateFromModel(outdir, model, provider, generatedFiles(model))
-	if err == nil {
-		// Check if we're configured to skip formatting.
-		skipFormat := codec["skip-format"]
-		if skipFormat != "true" {
-			err = formatDirectory(ctx, outdir)
+	provider, err := language.OverlayProvider(dartTemplates, codec["template-overlay"])
+	if err != nil {
+		return err
+	}
+	if err := language.GenerateFromModel(outdir, model, provider, generatedFiles(model)); err != nil {
+		return err
+	}
+	withSamples := model.Codec.(*modelAnnotations).GenerateSamples
+	if withSamples {
+		if err := generateSamples(outdir, model, provider); err != nil {
+			return err
 		}
 	}
-	return err
-}
-
-func templatesProvider() language.TemplateProvider {
-	return func(name string) (string, error) {
-		name = filepath.ToSlash(name)
-		contents, err := dartTemplates.ReadFile(name)
-		if err != nil {
-			return "", err
+	// Check if we're configured to skip formatting.
+	if codec["skip-format"] != "true" {
+		if err := formatDirectory(ctx, outdir); err != nil {
+			return err
 		}
-		return string(contents), nil
 	}
+	if withSamples {
+		return writeSnippetMetadata(outdir, model)
+	}
+	return nil
+}
+
+// OverriddenTemplates returns the templates replaced by the overlay directory.
+func OverriddenTemplates(overlay string) ([]string, error) {
+	return language.OverriddenTemplates(dartTemplates, overlay)
 }
 
 func generatedFiles(model *api.API) []language.GeneratedFile {
@@ -61,6 +69,10 @@ func generatedFiles(model *api.API) []language.GeneratedFile {
 	mainFileNameWithExtension := codec.MainFileNameWithExtension
 
 	files := language.WalkTemplatesDir(dartTemplates, "templates")
+	// The samples are generated once per method, see generateSamples.
+	files = slices.DeleteFunc(files, func(f language.GeneratedFile) bool {
+		return f.TemplatePath == methodSampleTemplate
+	})
 
 	for index, fileInfo := range files {
 		// Replace 'main.dart' with '{servicename}.dart'
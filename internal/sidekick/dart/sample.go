@@ -0,0 +1,369 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/language"
+	"github.com/googleapis/librarian/internal/snippetmetadata"
+	"github.com/iancoleman/strcase"
+)
+
+const (
+	// The directory, relative to the package root, containing the samples.
+	samplesDir = "example"
+	// The template used to generate the sample for each method.
+	methodSampleTemplate = "templates/example/method.dart.mustache"
+)
+
+var apiVersionRegexp = regexp.MustCompile(`^v\d+`)
+
+// sampleAnnotation contains the information needed to generate the sample
+// for a method.
+type sampleAnnotation struct {
+	// The name of the sample file, relative to the `example/` directory, e.g.
+	// `secret_manager_service_get_secret.dart`.
+	FileName string
+	// The region tag delimiting the sample code, e.g.
+	// `secretmanager_v1_generated_SecretManagerService_GetSecret_async`.
+	RegionTag string
+	// The name of the service class, e.g. `SecretManagerService`.
+	ServiceName string
+	// The import statements of the sample.
+	Imports []string
+	// The parameters of the sample function, e.g. `projectId`.
+	Parameters []string
+	// The named arguments used to construct the request, e.g.
+	// `name: 'projects/$projectId/secrets/$secretId'`.
+	RequestArgs []string
+}
+
+// annotateSamples annotates the methods that get a generated sample.
+//
+// This runs after all the services are annotated, as the sample imports
+// depend on the package name and main file.
+func (annotate *annotateModel) annotateSamples(pkgName, mainFileName string) {
+	for _, s := range annotate.model.Services {
+		for _, m := range s.Codec.(*serviceAnnotations).Methods {
+			if !annotate.hasSample(m) {
+				continue
+			}
+			m.Codec.(*methodAnnotation).Sample = annotate.sampleAnnotation(s, m, pkgName, mainFileName)
+		}
+	}
+}
+
+// hasSample returns true if the method gets a generated sample.
+//
+// The generic `GetOperation` mixin and methods whose request is defined in a
+// different package are skipped.
+func (annotate *annotateModel) hasSample(m *api.Method) bool {
+	codec := m.Codec.(*methodAnnotation)
+	if codec.IsLROGetOperation {
+		return false
+	}
+	return m.InputType.Package == annotate.model.PackageName
+}
+
+func (annotate *annotateModel) sampleAnnotation(s *api.Service, m *api.Method, pkgName, mainFileName string) *sampleAnnotation {
+	imports := map[string]bool{
+		fmt.Sprintf("package:%s/%s", pkgName, mainFileName): true,
+	}
+	var (
+		parameters  []string
+		requestArgs []string
+	)
+	if si := m.SampleInfo; si != nil {
+		if si.ResourceNameField != nil {
+			value, params := sampleResourceName(si.ResourceNameField)
+			parameters = append(parameters, params...)
+			if si.IsMessageResourceName && si.MessageField != nil {
+				typeName := annotate.sampleTypeName(si.MessageField, imports)
+				requestArgs = append(requestArgs, fmt.Sprintf("%s: %s(%s: %s)",
+					fieldName(si.MessageField), typeName, fieldName(si.ResourceNameField), value))
+			} else {
+				requestArgs = append(requestArgs, fmt.Sprintf("%s: %s", fieldName(si.ResourceNameField), value))
+			}
+		}
+		if si.ResourceIDField != nil {
+			requestArgs = append(requestArgs, fmt.Sprintf("%s: '[RESOURCE ID]'", fieldName(si.ResourceIDField)))
+		}
+		if si.MessageField != nil && !si.IsMessageResourceName {
+			typeName := annotate.sampleTypeName(si.MessageField, imports)
+			requestArgs = append(requestArgs, fmt.Sprintf("%s: %s()", fieldName(si.MessageField), typeName))
+		}
+		if si.UpdateMaskField != nil {
+			typeName := annotate.sampleTypeName(si.UpdateMaskField, imports)
+			requestArgs = append(requestArgs, fmt.Sprintf("%s: %s(paths: ['field.path1', 'field.path2'])",
+				fieldName(si.UpdateMaskField), typeName))
+		}
+	}
+	return &sampleAnnotation{
+		FileName:    strcase.ToSnake(s.Name) + "_" + strcase.ToSnake(m.Name) + ".dart",
+		RegionTag:   regionTag(s, m),
+		ServiceName: s.Codec.(*serviceAnnotations).Name,
+		Imports:     calculateImports(imports, "", ""),
+		Parameters:  parameters,
+		RequestArgs: requestArgs,
+	}
+}
+
+// sampleResourceName returns the Dart expression for a resource name field
+// and the sample parameters used in the expression.
+func sampleResourceName(field *api.Field) (string, []string) {
+	pattern := field.ResourceNamePattern
+	if pattern == nil || len(pattern.Segments) == 0 {
+		name := fieldName(field)
+		return name, []string{name}
+	}
+	var (
+		segments   []string
+		parameters []string
+	)
+	for _, s := range pattern.Segments {
+		if s.Literal != "" {
+			segments = append(segments, s.Literal)
+		}
+		if s.Variable != "" {
+			arg := s.Variable
+			if !strings.HasSuffix(arg, "_id") && !strings.HasSuffix(arg, "_name") {
+				arg += "_id"
+			}
+			arg = strcase.ToLowerCamel(arg)
+			segments = append(segments, "$"+arg)
+			parameters = append(parameters, arg)
+		}
+	}
+	return "'" + strings.Join(segments, "/") + "'", parameters
+}
+
+// sampleTypeName returns the Dart name for the message type of field, adding
+// any import needed to reference it.
+func (annotate *annotateModel) sampleTypeName(field *api.Field, imports map[string]bool) string {
+	message := annotate.model.Message(field.TypezID)
+	if message == nil {
+		return ""
+	}
+	name := messageName(message)
+	if message.Package == annotate.model.PackageName {
+		return name
+	}
+	if dartImport, ok := annotate.packageMapping[message.Package]; ok {
+		if prefix, ok := annotate.packagePrefixes[message.Package]; ok {
+			dartImport += " as " + prefix
+			name = prefix + "." + name
+		}
+		imports[dartImport] = true
+	}
+	return name
+}
+
+// regionTag returns the region tag for the sample of a method, following the
+// `{api}_{version}_generated_{Service}_{Method}_async` convention.
+func regionTag(s *api.Service, m *api.Method) string {
+	parts := []string{strings.Split(s.DefaultHost, ".")[0]}
+	if version := apiVersion(s.Package); version != "" {
+		parts = append(parts, version)
+	}
+	parts = append(parts, "generated", s.Name, m.Name, "async")
+	return strings.Join(parts, "_")
+}
+
+// apiVersion returns the version of a protobuf package, e.g. `v1` for
+// `google.cloud.secretmanager.v1`, or an empty string if the package is not
+// versioned.
+func apiVersion(pkg string) string {
+	version := strings.TrimPrefix(path.Ext(pkg), ".")
+	if !apiVersionRegexp.MatchString(version) {
+		return ""
+	}
+	return version
+}
+
+// generateSamples generates the sample for each method.
+func generateSamples(outdir string, model *api.API, provider language.TemplateProvider) error {
+	for _, s := range model.Services {
+		for _, m := range s.Codec.(*serviceAnnotations).Methods {
+			sample := m.Codec.(*methodAnnotation).Sample
+			if sample == nil {
+				continue
+			}
+			generated := language.GeneratedFile{
+				TemplatePath: methodSampleTemplate,
+				OutputPath:   filepath.Join(samplesDir, sample.FileName),
+			}
+			if err := language.GenerateMethod(outdir, m, provider, generated); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// snippetIndex is the snippet metadata for all the samples in a package.
+type snippetIndex struct {
+	ClientLibrary *snippetClientLibrary `json:"clientLibrary"`
+	Snippets      []*snippet            `json:"snippets"`
+}
+
+type snippetClientLibrary struct {
+	Name     string        `json:"name"`
+	Version  string        `json:"version"`
+	Language string        `json:"language"`
+	APIs     []*snippetAPI `json:"apis"`
+}
+
+type snippetAPI struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+type snippet struct {
+	RegionTag    string               `json:"regionTag"`
+	Title        string               `json:"title"`
+	Description  string               `json:"description"`
+	File         string               `json:"file"`
+	Language     string               `json:"language"`
+	ClientMethod *snippetClientMethod `json:"clientMethod"`
+	Canonical    bool                 `json:"canonical"`
+	Origin       string               `json:"origin"`
+	Segments     []*snippetSegment    `json:"segments"`
+}
+
+type snippetClientMethod struct {
+	ShortName  string              `json:"shortName"`
+	FullName   string              `json:"fullName"`
+	Async      bool                `json:"async"`
+	Parameters []*snippetParameter `json:"parameters"`
+	ResultType string              `json:"resultType"`
+	Client     *snippetName        `json:"client"`
+	Method     *snippetMethod      `json:"method"`
+}
+
+type snippetParameter struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type snippetName struct {
+	ShortName string `json:"shortName"`
+	FullName  string `json:"fullName"`
+}
+
+type snippetMethod struct {
+	ShortName string       `json:"shortName"`
+	FullName  string       `json:"fullName"`
+	Service   *snippetName `json:"service"`
+}
+
+type snippetSegment struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Type  string `json:"type"`
+}
+
+// writeSnippetMetadata writes the snippet metadata for the generated samples.
+//
+// The segments refer to line numbers in the samples, so this must run after
+// the samples are formatted.
+func writeSnippetMetadata(outdir string, model *api.API) error {
+	codec := model.Codec.(*modelAnnotations)
+	index := &snippetIndex{
+		ClientLibrary: &snippetClientLibrary{
+			Name:     codec.PackageName,
+			Version:  codec.PackageVersion,
+			Language: "DART",
+			APIs:     []*snippetAPI{{ID: model.PackageName, Version: apiVersion(model.PackageName)}},
+		},
+		Snippets: []*snippet{},
+	}
+	for _, s := range model.Services {
+		for _, m := range s.Codec.(*serviceAnnotations).Methods {
+			methodCodec := m.Codec.(*methodAnnotation)
+			sample := methodCodec.Sample
+			if sample == nil {
+				continue
+			}
+			segments, err := snippetSegments(filepath.Join(outdir, samplesDir, sample.FileName))
+			if err != nil {
+				return err
+			}
+			resultType := fmt.Sprintf("Future<%s>", methodCodec.ResponseType)
+			if m.ServerSideStreaming {
+				resultType = fmt.Sprintf("Stream<%s>", methodCodec.ResponseType)
+			}
+			serviceName := &snippetName{ShortName: s.Name, FullName: strings.TrimPrefix(s.ID, ".")}
+			index.Snippets = append(index.Snippets, &snippet{
+				RegionTag:   sample.RegionTag,
+				Title:       fmt.Sprintf("Sample for %s", m.Name),
+				Description: fmt.Sprintf("Sample for %s", m.Name),
+				File:        sample.FileName,
+				Language:    "DART",
+				ClientMethod: &snippetClientMethod{
+					ShortName:  methodCodec.Name,
+					FullName:   fmt.Sprintf("%s.%s.%s", codec.PackageName, sample.ServiceName, methodCodec.Name),
+					Async:      true,
+					Parameters: []*snippetParameter{{Type: methodCodec.RequestType, Name: "request"}},
+					ResultType: resultType,
+					Client: &snippetName{
+						ShortName: sample.ServiceName,
+						FullName:  fmt.Sprintf("%s.%s", codec.PackageName, sample.ServiceName),
+					},
+					Method: &snippetMethod{
+						ShortName: m.Name,
+						FullName:  strings.TrimPrefix(m.ID, "."),
+						Service:   serviceName,
+					},
+				},
+				Canonical: true,
+				Origin:    "API_DEFINITION",
+				Segments:  segments,
+			})
+		}
+	}
+	filename := filepath.Join(outdir, samplesDir, fmt.Sprintf("snippet_metadata_%s.json", model.PackageName))
+	return snippetmetadata.Write(filename, index)
+}
+
+// snippetSegments returns the segments of a sample file: the full file, and
+// the code between the region tags.
+func snippetSegments(filename string) ([]*snippetSegment, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSuffix(string(contents), "\n"), "\n")
+	segments := []*snippetSegment{{Start: 1, End: len(lines), Type: "FULL"}}
+	short := &snippetSegment{Type: "SHORT"}
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "// [START "):
+			short.Start = i + 2
+		case strings.HasPrefix(line, "// [END "):
+			short.End = i
+		}
+	}
+	if short.Start == 0 || short.End < short.Start {
+		return nil, fmt.Errorf("missing region tags in sample %s", filename)
+	}
+	return append(segments, short), nil
+}
@@ -0,0 +1,272 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func newSampleTestModel(t *testing.T) *api.API {
+	t.Helper()
+	secret := api.NewTestMessage("Secret").WithFields(
+		api.NewTestField("name").WithType(api.TypezString))
+	request := api.NewTestMessage("GetSecretRequest").WithFields(
+		api.NewTestField("name").WithType(api.TypezString))
+	get := api.NewTestMethod("GetSecret").WithInput(request).WithOutput(secret).WithVerb("GET").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("name"))
+	remove := api.NewTestMethod("DeleteSecret").WithInput(request).WithOutput(secret).WithVerb("DELETE").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("name"))
+	service := api.NewTestService("SecretManagerService").WithMethods(get, remove)
+	service.DefaultHost = "secretmanager.googleapis.com"
+	model := api.NewTestAPI([]*api.Message{secret, request}, nil, []*api.Service{service})
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	get.SampleInfo = &api.SampleInfo{
+		ResourceNameField: &api.Field{
+			Name: "name",
+			ResourceNamePattern: &api.ResourceNamePattern{
+				Segments: []api.ResourceNameSegment{
+					{Literal: "projects"},
+					{Variable: "project"},
+					{Literal: "secrets"},
+					{Variable: "secret"},
+				},
+			},
+		},
+		IsRequestResourceName: true,
+	}
+	remove.ReturnsEmpty = true
+	return model
+}
+
+func TestGenerateSamples(t *testing.T) {
+	model := newSampleTestModel(t)
+	options := maps.Clone(requiredConfig)
+	maps.Copy(options, map[string]string{
+		"copyright-year":       "2026",
+		"version":              "0.1.0",
+		"skip-format":          "true",
+		"generate-rpc-samples": "true",
+	})
+	outDir := t.TempDir()
+	if err := Generate(t.Context(), model, outDir, options); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(outDir, "example", "secret_manager_service_get_secret.dart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"// [START secretmanager_generated_SecretManagerService_GetSecret_async]",
+		"import 'package:google_cloud_test/test.dart';",
+		"Future<void> sample(SecretManagerService service, String projectId, String secretId) async {",
+		"name: 'projects/$projectId/secrets/$secretId',",
+		"final response = await service.getSecret(request);",
+		"// [END secretmanager_generated_SecretManagerService_GetSecret_async]",
+		"final service = SecretManagerService.fromApiKey();",
+		"await sample(service, '[projectId]', '[secretId]');",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("missing %q in sample:\n%s", want, got)
+		}
+	}
+	got, err = os.ReadFile(filepath.Join(outDir, "example", "secret_manager_service_delete_secret.dart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "await service.deleteSecret(request);"; !strings.Contains(string(got), want) {
+		t.Errorf("missing %q in sample:\n%s", want, got)
+	}
+
+	contents, err := os.ReadFile(filepath.Join(outDir, "example", "snippet_metadata_test.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index snippetIndex
+	if err := json.Unmarshal(contents, &index); err != nil {
+		t.Fatal(err)
+	}
+	wantLibrary := &snippetClientLibrary{
+		Name:     "google_cloud_test",
+		Version:  "0.1.0",
+		Language: "DART",
+		APIs:     []*snippetAPI{{ID: "test"}},
+	}
+	if diff := cmp.Diff(wantLibrary, index.ClientLibrary); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	var gotFiles []string
+	for _, s := range index.Snippets {
+		gotFiles = append(gotFiles, s.File)
+	}
+	wantFiles := []string{"secret_manager_service_get_secret.dart", "secret_manager_service_delete_secret.dart"}
+	if diff := cmp.Diff(wantFiles, gotFiles); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	wantMethod := &snippetClientMethod{
+		ShortName:  "getSecret",
+		FullName:   "google_cloud_test.SecretManagerService.getSecret",
+		Async:      true,
+		Parameters: []*snippetParameter{{Type: "GetSecretRequest", Name: "request"}},
+		ResultType: "Future<Secret>",
+		Client: &snippetName{
+			ShortName: "SecretManagerService",
+			FullName:  "google_cloud_test.SecretManagerService",
+		},
+		Method: &snippetMethod{
+			ShortName: "GetSecret",
+			FullName:  "test.SecretManagerService.GetSecret",
+			Service: &snippetName{
+				ShortName: "SecretManagerService",
+				FullName:  "test.SecretManagerService",
+			},
+		},
+	}
+	if diff := cmp.Diff(wantMethod, index.Snippets[0].ClientMethod); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestGenerateSamples_Disabled(t *testing.T) {
+	model := newSampleTestModel(t)
+	options := maps.Clone(requiredConfig)
+	options["skip-format"] = "true"
+	outDir := t.TempDir()
+	if err := Generate(t.Context(), model, outDir, options); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "example")); !os.IsNotExist(err) {
+		t.Errorf("expected no samples, got err=%v", err)
+	}
+}
+
+func TestAnnotateModel_GenerateSamplesError(t *testing.T) {
+	model := api.NewTestAPI(nil, nil, nil)
+	options := maps.Clone(requiredConfig)
+	options["generate-rpc-samples"] = "yes please"
+	if err := newAnnotateModel(model).annotateModel(options); err == nil {
+		t.Error("expected an error parsing `generate-rpc-samples`")
+	}
+}
+
+func TestRegionTag(t *testing.T) {
+	for _, test := range []struct {
+		pkg  string
+		want string
+	}{
+		{"google.cloud.secretmanager.v1", "secretmanager_v1_generated_SecretManagerService_GetSecret_async"},
+		{"google.cloud.secretmanager.v1beta2", "secretmanager_v1beta2_generated_SecretManagerService_GetSecret_async"},
+		{"google.cloud.secretmanager", "secretmanager_generated_SecretManagerService_GetSecret_async"},
+	} {
+		t.Run(test.pkg, func(t *testing.T) {
+			service := api.NewTestService("SecretManagerService").WithPackage(test.pkg)
+			service.DefaultHost = "secretmanager.googleapis.com"
+			got := regionTag(service, api.NewTestMethod("GetSecret"))
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSampleResourceName(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		field          *api.Field
+		wantValue      string
+		wantParameters []string
+	}{
+		{
+			name: "pattern",
+			field: &api.Field{
+				Name: "parent",
+				ResourceNamePattern: &api.ResourceNamePattern{
+					Segments: []api.ResourceNameSegment{
+						{Literal: "projects"},
+						{Variable: "project"},
+						{Literal: "locations"},
+						{Variable: "location_id"},
+					},
+				},
+			},
+			wantValue:      "'projects/$projectId/locations/$locationId'",
+			wantParameters: []string{"projectId", "locationId"},
+		},
+		{
+			name:           "no pattern",
+			field:          &api.Field{Name: "resource_name"},
+			wantValue:      "resourceName",
+			wantParameters: []string{"resourceName"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			gotValue, gotParameters := sampleResourceName(test.field)
+			if diff := cmp.Diff(test.wantValue, gotValue); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantParameters, gotParameters); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSnippetSegments(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "sample.dart")
+	contents := `// Copyright 2026 Google LLC
+
+// [START region]
+import 'package:test/test.dart';
+
+void sample() {}
+// [END region]
+
+void main() {}
+`
+	if err := os.WriteFile(filename, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := snippetSegments(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*snippetSegment{
+		{Start: 1, End: 9, Type: "FULL"},
+		{Start: 4, End: 6, Type: "SHORT"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestSnippetSegments_Error(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "sample.dart")
+	if err := os.WriteFile(filename, []byte("void main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := snippetSegments(filename); err == nil {
+		t.Error("expected an error for a sample without region tags")
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+)
+
+// templatesRoot is the directory embedded by all the codecs.
+const templatesRoot = "templates"
+
+var (
+	errUnknownTemplate = errors.New("template overlay does not replace any template")
+	errUnknownRoot     = errors.New("unknown template directory")
+)
+
+// Templates returns the provider and the files to generate for the templates
+// in root, a directory of fsys, with the templates in overlayDir replacing the
+// embedded ones.
+//
+// Codecs that let users select the template directory, like the Rust
+// `template-override` option, use this function so that the selected
+// directory and the overlay are resolved together.
+func Templates(fsys fs.FS, root, overlayDir string) (TemplateProvider, []GeneratedFile, error) {
+	if info, err := fs.Stat(fsys, root); err != nil || !info.IsDir() {
+		return nil, nil, fmt.Errorf("%w: %s", errUnknownRoot, root)
+	}
+	provider, err := OverlayProvider(fsys, overlayDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return provider, WalkTemplatesDir(fsys, root), nil
+}
+
+// OverlayProvider returns a TemplateProvider that prefers the templates in
+// overlayDir over the templates embedded in fsys.
+//
+// The overlay directory mirrors the `templates` directory of the codec. For
+// example, `overlay/common/service.swift.mustache` replaces
+// `templates/common/service.swift.mustache`. Partials are resolved through the
+// same provider, so they can be replaced too. Every `.mustache` file in the
+// overlay must replace an embedded template, which catches typos in the file
+// names.
+//
+// If overlayDir is empty the provider only uses fsys.
+func OverlayProvider(fsys fs.FS, overlayDir string) (TemplateProvider, error) {
+	overridden, err := OverriddenTemplates(fsys, overlayDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range overridden {
+		slog.Info("using template overlay", "template", name, "overlay", overlayDir)
+	}
+	return func(name string) (string, error) {
+		name = filepath.ToSlash(name)
+		var (
+			contents []byte
+			err      error
+		)
+		if _, found := slices.BinarySearch(overridden, name); found {
+			contents, err = os.ReadFile(overlayPath(overlayDir, name))
+		} else {
+			contents, err = fs.ReadFile(fsys, name)
+		}
+		if err != nil {
+			return "", err
+		}
+		return string(contents), nil
+	}, nil
+}
+
+// OverriddenTemplates returns the sorted names of the templates in fsys that
+// have a replacement in overlayDir.
+func OverriddenTemplates(fsys fs.FS, overlayDir string) ([]string, error) {
+	if overlayDir == "" {
+		return nil, nil
+	}
+	var result []string
+	err := filepath.WalkDir(overlayDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(p) != ".mustache" {
+			return nil
+		}
+		rel, err := filepath.Rel(overlayDir, p)
+		if err != nil {
+			return err
+		}
+		name := path.Join(templatesRoot, filepath.ToSlash(rel))
+		if _, err := fs.Stat(fsys, name); err != nil {
+			return fmt.Errorf("%w: %s", errUnknownTemplate, p)
+		}
+		result = append(result, name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(result)
+	return result, nil
+}
+
+func overlayPath(overlayDir, name string) string {
+	rel, _ := filepath.Rel(templatesRoot, filepath.FromSlash(name))
+	return filepath.Join(overlayDir, rel)
+}
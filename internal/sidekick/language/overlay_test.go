@@ -0,0 +1,183 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var overlayTestFS = fstest.MapFS{
+	"templates/README.md.mustache":       {Data: []byte("embedded readme {{> common/header}}")},
+	"templates/common/header.mustache":   {Data: []byte("embedded header")},
+	"templates/src/lib.rs.mustache":      {Data: []byte("embedded lib")},
+	"templates/src/unused.txt.mustache":  {Data: []byte("embedded unused")},
+	"templates/common/footer.mustache":   {Data: []byte("embedded footer")},
+	"templates/common/not-a-template.md": {Data: []byte("not a template")},
+}
+
+func writeOverlay(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, contents := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestOverlayProvider(t *testing.T) {
+	overlay := writeOverlay(t, map[string]string{
+		"README.md.mustache":     "overlay readme {{> common/header}}",
+		"common/header.mustache": "overlay header",
+		"notes.txt":              "ignored, not a template",
+	})
+	provider, err := OverlayProvider(overlayTestFS, overlay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name string
+		want string
+	}{
+		{"templates/README.md.mustache", "overlay readme {{> common/header}}"},
+		{"templates/common/header.mustache", "overlay header"},
+		{"templates/src/lib.rs.mustache", "embedded lib"},
+		{"templates/common/footer.mustache", "embedded footer"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := provider(test.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOverlayProvider_Partials(t *testing.T) {
+	overlay := writeOverlay(t, map[string]string{
+		"common/header.mustache": "overlay header",
+	})
+	provider, err := OverlayProvider(overlayTestFS, overlay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	gen := GeneratedFile{TemplatePath: "templates/README.md.mustache", OutputPath: "README.md"}
+	if err := GenerateFromModel(outDir, nil, provider, []GeneratedFile{gen}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(outDir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("embedded readme overlay header", string(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOverlayProvider_NoOverlay(t *testing.T) {
+	provider, err := OverlayProvider(overlayTestFS, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := provider("templates/src/lib.rs.mustache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("embedded lib", got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOverlayProvider_UnknownTemplate(t *testing.T) {
+	overlay := writeOverlay(t, map[string]string{
+		"common/haeder.mustache": "typo",
+	})
+	if _, err := OverlayProvider(overlayTestFS, overlay); !errors.Is(err, errUnknownTemplate) {
+		t.Errorf("OverlayProvider() error = %v, want %v", err, errUnknownTemplate)
+	}
+}
+
+func TestOverlayProvider_MissingDirectory(t *testing.T) {
+	if _, err := OverlayProvider(overlayTestFS, filepath.Join(t.TempDir(), "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("OverlayProvider() error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestOverriddenTemplates(t *testing.T) {
+	overlay := writeOverlay(t, map[string]string{
+		"src/lib.rs.mustache":    "overlay lib",
+		"README.md.mustache":     "overlay readme",
+		"common/header.mustache": "overlay header",
+	})
+	got, err := OverriddenTemplates(overlayTestFS, overlay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"templates/README.md.mustache",
+		"templates/common/header.mustache",
+		"templates/src/lib.rs.mustache",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	overlay := writeOverlay(t, map[string]string{
+		"src/lib.rs.mustache": "overlay lib",
+	})
+	provider, files, err := Templates(overlayTestFS, "templates/src", overlay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []GeneratedFile{
+		{TemplatePath: "templates/src/lib.rs.mustache", OutputPath: "/lib.rs"},
+		{TemplatePath: "templates/src/unused.txt.mustache", OutputPath: "/unused.txt"},
+	}
+	if diff := cmp.Diff(want, files); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	got, err := provider("templates/src/lib.rs.mustache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "overlay lib" {
+		t.Errorf("provider() = %q, want %q", got, "overlay lib")
+	}
+}
+
+func TestTemplates_UnknownRoot(t *testing.T) {
+	for _, root := range []string{"templates/missing", "templates/README.md.mustache"} {
+		if _, _, err := Templates(overlayTestFS, root, ""); !errors.Is(err, errUnknownRoot) {
+			t.Errorf("Templates(%q) error = %v, want %v", root, err, errUnknownRoot)
+		}
+	}
+}
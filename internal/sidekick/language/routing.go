@@ -0,0 +1,146 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+// RoutingParameter describes a single key in the `x-goog-request-params`
+// header.
+//
+// See [AIP-4222](https://google.aip.dev/client-libraries/4222) for details.
+type RoutingParameter struct {
+	// The key in `x-goog-request-params`.
+	Name string
+	// Implicit is true if the parameter comes from the path template of the
+	// method. Implicit parameters have a single variant and use the full
+	// value of the field.
+	Implicit bool
+	// The variants for this key. The first variant that matches wins.
+	Variants []*RoutingVariant
+}
+
+// RoutingVariant describes one of the fields, and the pattern it must match,
+// used to compute the value of a routing parameter.
+type RoutingVariant struct {
+	// The sequence of field names accessed to get the value.
+	FieldPath []string
+	// A regular expression with a single capturing group. If the field value
+	// matches the expression, the captured text is the parameter value.
+	//
+	// The expression only uses syntax common to Go, Dart (`RegExp`), and
+	// Swift (`NSRegularExpression`). It is empty for implicit parameters.
+	Pattern string
+}
+
+// FieldName returns the field path as a string.
+func (v *RoutingVariant) FieldName() string {
+	return strings.Join(v.FieldPath, ".")
+}
+
+// RoutingParameters returns the parameters for the `x-goog-request-params`
+// header of a method.
+//
+// AIP-4222 requires the explicit routing annotations, if present, to take
+// precedence over the implicit routing parameters from the `google.api.http`
+// annotation. An empty `google.api.routing` annotation disables the routing
+// header.
+func RoutingParameters(m *api.Method) []*RoutingParameter {
+	if m.HasRouting() {
+		return explicitRoutingParameters(m)
+	}
+	return implicitRoutingParameters(m)
+}
+
+func explicitRoutingParameters(m *api.Method) []*RoutingParameter {
+	var params []*RoutingParameter
+	for _, info := range m.Routing {
+		if info.Name == "" {
+			// The special marker for an empty routing annotation.
+			return nil
+		}
+		param := &RoutingParameter{Name: info.Name}
+		for _, v := range info.Variants {
+			param.Variants = append(param.Variants, &RoutingVariant{
+				FieldPath: v.FieldPath,
+				Pattern:   routingPattern(v),
+			})
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+func implicitRoutingParameters(m *api.Method) []*RoutingParameter {
+	if m.PathInfo == nil || len(m.PathInfo.Bindings) == 0 || m.PathInfo.Bindings[0].PathTemplate == nil {
+		return nil
+	}
+	var params []*RoutingParameter
+	for _, segment := range m.PathInfo.Bindings[0].PathTemplate.Segments {
+		if segment.Variable == nil {
+			continue
+		}
+		name := strings.Join(segment.Variable.FieldPath, ".")
+		if slices.ContainsFunc(params, func(p *RoutingParameter) bool { return p.Name == name }) {
+			continue
+		}
+		params = append(params, &RoutingParameter{
+			Name:     name,
+			Implicit: true,
+			Variants: []*RoutingVariant{{FieldPath: segment.Variable.FieldPath}},
+		})
+	}
+	return params
+}
+
+// routingPattern returns a regular expression matching the full field value,
+// capturing the portion matched by `v.Matching`.
+func routingPattern(v *api.RoutingInfoVariant) string {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	if len(v.Prefix.Segments) != 0 {
+		pattern.WriteString(routingSegments(v.Prefix.Segments))
+		pattern.WriteString("/")
+	}
+	pattern.WriteString("(")
+	pattern.WriteString(routingSegments(v.Matching.Segments))
+	pattern.WriteString(")")
+	if len(v.Suffix.Segments) != 0 {
+		pattern.WriteString("/")
+		pattern.WriteString(routingSegments(v.Suffix.Segments))
+	}
+	pattern.WriteString("$")
+	return pattern.String()
+}
+
+func routingSegments(segments []string) string {
+	var parts []string
+	for _, s := range segments {
+		switch s {
+		case api.SingleSegmentWildcard:
+			parts = append(parts, "[^/]+")
+		case api.MultiSegmentWildcard:
+			parts = append(parts, ".*")
+		default:
+			parts = append(parts, regexp.QuoteMeta(s))
+		}
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func TestRoutingParameters(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		method *api.Method
+		want   []*RoutingParameter
+	}{
+		{
+			name: "implicit",
+			method: api.NewTestMethod("GetSecret").WithPathTemplate((&api.PathTemplate{}).
+				WithLiteral("v1").
+				WithVariable(api.NewPathVariable("name").WithLiteral("projects").WithMatch().WithLiteral("secrets").WithMatch())),
+			want: []*RoutingParameter{
+				{Name: "name", Implicit: true, Variants: []*RoutingVariant{{FieldPath: []string{"name"}}}},
+			},
+		},
+		{
+			name: "implicit nested and duplicated",
+			method: api.NewTestMethod("UpdateSecret").WithPathTemplate((&api.PathTemplate{}).
+				WithLiteral("v1").
+				WithVariableNamed("secret", "name").
+				WithLiteral("versions").
+				WithVariableNamed("secret", "name")),
+			want: []*RoutingParameter{
+				{Name: "secret.name", Implicit: true, Variants: []*RoutingVariant{{FieldPath: []string{"secret", "name"}}}},
+			},
+		},
+		{
+			name:   "implicit without variables",
+			method: api.NewTestMethod("ListLocations").WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1")),
+		},
+		{
+			name:   "no path template",
+			method: api.NewTestMethod("Stream"),
+		},
+		{
+			name: "explicit",
+			method: withRouting(api.NewTestMethod("GetSecret").WithPathTemplate((&api.PathTemplate{}).
+				WithLiteral("v1").WithVariableNamed("name")),
+				&api.RoutingInfo{
+					Name: "project",
+					Variants: []*api.RoutingInfoVariant{
+						{
+							FieldPath: []string{"name"},
+							Matching:  api.RoutingPathSpec{Segments: []string{"projects", "*"}},
+							Suffix:    api.RoutingPathSpec{Segments: []string{"**"}},
+						},
+					},
+				},
+				&api.RoutingInfo{
+					Name: "location",
+					Variants: []*api.RoutingInfoVariant{
+						{
+							FieldPath: []string{"name"},
+							Prefix:    api.RoutingPathSpec{Segments: []string{"projects", "*"}},
+							Matching:  api.RoutingPathSpec{Segments: []string{"locations", "*"}},
+							Suffix:    api.RoutingPathSpec{Segments: []string{"**"}},
+						},
+						{
+							FieldPath: []string{"app_profile", "id"},
+							Matching:  api.RoutingPathSpec{Segments: []string{"**"}},
+						},
+					},
+				}),
+			want: []*RoutingParameter{
+				{
+					Name: "project",
+					Variants: []*RoutingVariant{
+						{FieldPath: []string{"name"}, Pattern: `^(projects/[^/]+)/.*$`},
+					},
+				},
+				{
+					Name: "location",
+					Variants: []*RoutingVariant{
+						{FieldPath: []string{"name"}, Pattern: `^projects/[^/]+/(locations/[^/]+)/.*$`},
+						{FieldPath: []string{"app_profile", "id"}, Pattern: `^(.*)$`},
+					},
+				},
+			},
+		},
+		{
+			name: "explicit empty",
+			method: withRouting(api.NewTestMethod("GetSecret").WithPathTemplate((&api.PathTemplate{}).
+				WithLiteral("v1").WithVariableNamed("name")),
+				&api.RoutingInfo{}),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := RoutingParameters(test.method)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRoutingPattern(t *testing.T) {
+	for _, test := range []struct {
+		variant *api.RoutingInfoVariant
+		value   string
+		want    string
+	}{
+		{
+			variant: &api.RoutingInfoVariant{
+				Matching: api.RoutingPathSpec{Segments: []string{"projects", "*"}},
+				Suffix:   api.RoutingPathSpec{Segments: []string{"**"}},
+			},
+			value: "projects/p1/locations/l1",
+			want:  "projects/p1",
+		},
+		{
+			variant: &api.RoutingInfoVariant{
+				Matching: api.RoutingPathSpec{Segments: []string{"projects", "*"}},
+				Suffix:   api.RoutingPathSpec{Segments: []string{"**"}},
+			},
+			value: "organizations/o1/locations/l1",
+		},
+		{
+			variant: &api.RoutingInfoVariant{
+				Prefix:   api.RoutingPathSpec{Segments: []string{"projects", "*"}},
+				Matching: api.RoutingPathSpec{Segments: []string{"instances", "*"}},
+			},
+			value: "projects/p1/instances/i1",
+			want:  "instances/i1",
+		},
+		{
+			variant: &api.RoutingInfoVariant{
+				Prefix:   api.RoutingPathSpec{Segments: []string{"projects", "*"}},
+				Matching: api.RoutingPathSpec{Segments: []string{"instances", "*"}},
+			},
+			value: "projects/p1/instances/i1/tables/t1",
+		},
+		{
+			variant: &api.RoutingInfoVariant{
+				Matching: api.RoutingPathSpec{Segments: []string{"v1.2", "*"}},
+			},
+			value: "v1x2/a",
+		},
+		{
+			variant: &api.RoutingInfoVariant{
+				Matching: api.RoutingPathSpec{Segments: []string{"**"}},
+			},
+			value: "any/value",
+			want:  "any/value",
+		},
+	} {
+		t.Run(test.value, func(t *testing.T) {
+			re := regexp.MustCompile(routingPattern(test.variant))
+			var got string
+			if m := re.FindStringSubmatch(test.value); m != nil {
+				got = m[1]
+			}
+			if got != test.want {
+				t.Errorf("routingPattern(%v) on %q = %q, want %q", test.variant, test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func withRouting(m *api.Method, info ...*api.RoutingInfo) *api.Method {
+	m.Routing = info
+	return m
+}
@@ -0,0 +1,303 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks a parsed [api.API] for definitions that the code
+// generators cannot handle well.
+//
+// [api.Validate] rejects models that the codecs cannot use at all. The rules
+// in this package find definitions that are accepted, but that result in
+// missing features or worse code, such as list methods that are not
+// recognized as paginated.
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+// Severity is the severity of a lint finding.
+type Severity int
+
+const (
+	// SeverityWarning findings result in degraded, but working, code.
+	SeverityWarning Severity = iota
+	// SeverityError findings result in missing or broken code.
+	SeverityError
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Rule is a named check over the API model.
+type Rule struct {
+	// Name identifies the rule in findings and suppressions.
+	Name string
+	// Description explains what the rule checks.
+	Description string
+	// Severity is the severity of the findings reported by the rule.
+	Severity Severity
+	// Check returns the problems found in the model. The rule name and
+	// severity of the findings are set by [Run].
+	Check func(model *api.API) []*Finding
+}
+
+// Finding is a problem found by a lint rule.
+type Finding struct {
+	// Rule is the name of the rule that reported the finding.
+	Rule string
+	// Severity is the severity of the rule.
+	Severity Severity
+	// ID is the ID of the element with the problem.
+	ID string
+	// Message describes the problem.
+	Message string
+	// Location is the definition of the element in the specification, if
+	// known.
+	Location string
+}
+
+// String returns a one line description of the finding.
+func (f *Finding) String() string {
+	s := fmt.Sprintf("%s: %s [%s] %s", f.Severity, f.ID, f.Rule, f.Message)
+	if f.Location != "" {
+		s += fmt.Sprintf(" (%s)", f.Location)
+	}
+	return s
+}
+
+// Rules returns all the lint rules.
+func Rules() []*Rule {
+	return []*Rule{
+		{
+			Name:        "pagination-aip-4233",
+			Description: "methods with a page token that are not recognized as paginated per AIP-4233",
+			Severity:    SeverityWarning,
+			Check:       checkPagination,
+		},
+		{
+			Name:        "lro-operation-info",
+			Description: "methods returning a long-running operation without valid operation info",
+			Severity:    SeverityError,
+			Check:       checkOperationInfo,
+		},
+		{
+			Name:        "routing-field-missing",
+			Description: "routing annotations that reference fields missing in the request",
+			Severity:    SeverityError,
+			Check:       checkRoutingFields,
+		},
+		{
+			Name:        "resource-reference-unresolved",
+			Description: "resource references to resource types that are not defined",
+			Severity:    SeverityWarning,
+			Check:       checkResourceReferences,
+		},
+	}
+}
+
+// Run applies rules to model and returns the findings that are not
+// suppressed, sorted by element ID and rule name.
+func Run(model *api.API, rules []*Rule, suppressions []*config.LintSuppression) []*Finding {
+	var findings []*Finding
+	for _, rule := range rules {
+		for _, f := range rule.Check(model) {
+			f.Rule = rule.Name
+			f.Severity = rule.Severity
+			if !suppressed(f, suppressions) {
+				findings = append(findings, f)
+			}
+		}
+	}
+	slices.SortStableFunc(findings, func(a, b *Finding) int {
+		if c := strings.Compare(a.ID, b.ID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Rule, b.Rule)
+	})
+	return findings
+}
+
+// HasErrors returns true if any finding has [SeverityError].
+func HasErrors(findings []*Finding) bool {
+	return slices.ContainsFunc(findings, func(f *Finding) bool { return f.Severity == SeverityError })
+}
+
+func suppressed(f *Finding, suppressions []*config.LintSuppression) bool {
+	for _, s := range suppressions {
+		if s.Rule != f.Rule {
+			continue
+		}
+		if s.ID == "" || s.ID == f.ID || strings.HasPrefix(f.ID, s.ID+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPagination finds methods that have a page token in the request, but
+// that [api.UpdateMethodPagination] did not recognize as paginated.
+func checkPagination(model *api.API) []*Finding {
+	var findings []*Finding
+	for m := range model.AllMethods() {
+		if m.Pagination != nil || m.InputType == nil {
+			continue
+		}
+		if !slices.ContainsFunc(m.InputType.Fields, func(f *api.Field) bool { return f.JSONName == "pageToken" }) {
+			continue
+		}
+		var missing []string
+		if !slices.ContainsFunc(m.InputType.Fields, func(f *api.Field) bool {
+			return f.JSONName == "pageSize" || f.JSONName == "maxResults"
+		}) {
+			missing = append(missing, "a page_size field in the request")
+		}
+		if m.OutputType == nil || !slices.ContainsFunc(m.OutputType.Fields, func(f *api.Field) bool {
+			return f.JSONName == "nextPageToken" && f.Typez == api.TypezString
+		}) {
+			missing = append(missing, "a next_page_token string field in the response")
+		}
+		if m.OutputType == nil || !slices.ContainsFunc(m.OutputType.Fields, func(f *api.Field) bool {
+			return f.Map || (f.Repeated && f.Typez == api.TypezMessage)
+		}) {
+			missing = append(missing, "a repeated message or map field in the response")
+		}
+		message := "the method has a page_token field but is not paginated"
+		if len(missing) != 0 {
+			message += ", missing " + strings.Join(missing, " and ")
+		}
+		findings = append(findings, &Finding{ID: m.ID, Message: message, Location: m.Location.String()})
+	}
+	return findings
+}
+
+// checkOperationInfo finds methods returning a `google.longrunning.Operation`
+// without a resolvable response and metadata type.
+func checkOperationInfo(model *api.API) []*Finding {
+	var findings []*Finding
+	for m := range model.AllMethods() {
+		if m.OutputTypeID != ".google.longrunning.Operation" || isMixin(model, m) {
+			continue
+		}
+		info := m.OperationInfo
+		if info == nil {
+			findings = append(findings, &Finding{ID: m.ID, Message: "the method returns an Operation but has no google.longrunning.operation_info annotation", Location: m.Location.String()})
+			continue
+		}
+		for _, t := range []struct{ name, id string }{
+			{"response_type", info.ResponseTypeID},
+			{"metadata_type", info.MetadataTypeID},
+		} {
+			switch {
+			case t.id == "":
+				findings = append(findings, &Finding{ID: m.ID, Message: fmt.Sprintf("the operation_info annotation has no %s", t.name), Location: m.Location.String()})
+			case model.Message(t.id) == nil:
+				findings = append(findings, &Finding{ID: m.ID, Message: fmt.Sprintf("the operation_info %s %q is not defined", t.name, t.id), Location: m.Location.String()})
+			}
+		}
+	}
+	return findings
+}
+
+// checkRoutingFields finds routing annotations whose field path cannot be
+// resolved in the request message.
+func checkRoutingFields(model *api.API) []*Finding {
+	var findings []*Finding
+	for m := range model.AllMethods() {
+		for _, info := range m.Routing {
+			for _, variant := range info.Variants {
+				if field := resolveField(model, m.InputType, variant.FieldPath); field == nil {
+					findings = append(findings, &Finding{
+						ID:       m.ID,
+						Message:  fmt.Sprintf("routing parameter %q references field %q, which is not in the request", info.Name, strings.Join(variant.FieldPath, ".")),
+						Location: m.Location.String(),
+					})
+				} else if field.Typez != api.TypezString {
+					findings = append(findings, &Finding{
+						ID:       m.ID,
+						Message:  fmt.Sprintf("routing parameter %q references field %q, which is not a string", info.Name, strings.Join(variant.FieldPath, ".")),
+						Location: m.Location.String(),
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// checkResourceReferences finds fields with a resource reference to a type
+// that is not defined in the model. These references cannot be used to
+// identify the target resource of a method.
+func checkResourceReferences(model *api.API) []*Finding {
+	var findings []*Finding
+	for msg := range model.AllMessages() {
+		if msg.Package != model.PackageName {
+			continue
+		}
+		for _, f := range msg.Fields {
+			ref := f.ResourceReference
+			if ref == nil {
+				continue
+			}
+			for _, t := range []string{ref.Type, ref.ChildType} {
+				if t == "" || t == "*" || model.Resource(t) != nil {
+					continue
+				}
+				findings = append(findings, &Finding{
+					ID:       f.ID,
+					Message:  fmt.Sprintf("the resource reference %q is not defined", t),
+					Location: f.Location.String(),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+func resolveField(model *api.API, msg *api.Message, path []string) *api.Field {
+	var field *api.Field
+	for _, name := range path {
+		if msg == nil {
+			return nil
+		}
+		idx := slices.IndexFunc(msg.Fields, func(f *api.Field) bool { return f.Name == name })
+		if idx == -1 {
+			return nil
+		}
+		field = msg.Fields[idx]
+		msg = model.Message(field.TypezID)
+	}
+	return field
+}
+
+// isMixin returns true if the method is part of a mixin service, such as
+// google.longrunning.Operations. Problems in mixins cannot be fixed by the
+// API producer.
+func isMixin(model *api.API, m *api.Method) bool {
+	if m.SourceServiceID != "" && m.Service != nil && m.SourceServiceID != m.Service.ID {
+		return true
+	}
+	return m.Service != nil && m.Service.Package != model.PackageName
+}
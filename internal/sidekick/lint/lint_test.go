@@ -0,0 +1,254 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func TestRun(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		model        func() *api.API
+		suppressions []*config.LintSuppression
+		want         []*Finding
+	}{
+		{
+			name:  "clean",
+			model: func() *api.API { return testModel(nil, nil) },
+		},
+		{
+			name: "pagination",
+			model: func() *api.API {
+				request := api.NewTestMessage("ListSecretsRequest").WithFields(
+					api.NewTestField("page_token").WithType(api.TypezString))
+				response := api.NewTestMessage("ListSecretsResponse").WithFields(
+					api.NewTestField("next_page_token").WithType(api.TypezString))
+				return testModel([]*api.Method{api.NewTestMethod("ListSecrets").WithInput(request).WithOutput(response)}, []*api.Message{request, response})
+			},
+			want: []*Finding{
+				{
+					Rule:     "pagination-aip-4233",
+					Severity: SeverityWarning,
+					ID:       ".test.Service.ListSecrets",
+					Message:  "the method has a page_token field but is not paginated, missing a page_size field in the request and a repeated message or map field in the response",
+				},
+			},
+		},
+		{
+			name: "operation without info",
+			model: func() *api.API {
+				request := api.NewTestMessage("CreateSecretRequest")
+				method := api.NewTestMethod("CreateSecret").WithInput(request)
+				method.OutputTypeID = ".google.longrunning.Operation"
+				return testModel([]*api.Method{method}, []*api.Message{request})
+			},
+			want: []*Finding{
+				{
+					Rule:     "lro-operation-info",
+					Severity: SeverityError,
+					ID:       ".test.Service.CreateSecret",
+					Message:  "the method returns an Operation but has no google.longrunning.operation_info annotation",
+				},
+			},
+		},
+		{
+			name: "operation with undefined types",
+			model: func() *api.API {
+				request := api.NewTestMessage("CreateSecretRequest")
+				method := api.NewTestMethod("CreateSecret").WithInput(request)
+				method.OutputTypeID = ".google.longrunning.Operation"
+				method.OperationInfo = &api.OperationInfo{ResponseTypeID: ".test.Missing"}
+				return testModel([]*api.Method{method}, []*api.Message{request})
+			},
+			want: []*Finding{
+				{
+					Rule:     "lro-operation-info",
+					Severity: SeverityError,
+					ID:       ".test.Service.CreateSecret",
+					Message:  `the operation_info response_type ".test.Missing" is not defined`,
+				},
+				{
+					Rule:     "lro-operation-info",
+					Severity: SeverityError,
+					ID:       ".test.Service.CreateSecret",
+					Message:  "the operation_info annotation has no metadata_type",
+				},
+			},
+		},
+		{
+			name: "routing",
+			model: func() *api.API {
+				request := api.NewTestMessage("GetSecretRequest").WithFields(
+					api.NewTestField("name").WithType(api.TypezString),
+					api.NewTestField("version").WithType(api.TypezInt32))
+				method := api.NewTestMethod("GetSecret").WithInput(request)
+				method.Routing = []*api.RoutingInfo{
+					{Name: "project", Variants: []*api.RoutingInfoVariant{{FieldPath: []string{"name"}}}},
+					{Name: "location", Variants: []*api.RoutingInfoVariant{{FieldPath: []string{"secret", "location"}}}},
+					{Name: "version", Variants: []*api.RoutingInfoVariant{{FieldPath: []string{"version"}}}},
+				}
+				return testModel([]*api.Method{method}, []*api.Message{request})
+			},
+			want: []*Finding{
+				{
+					Rule:     "routing-field-missing",
+					Severity: SeverityError,
+					ID:       ".test.Service.GetSecret",
+					Message:  `routing parameter "location" references field "secret.location", which is not in the request`,
+				},
+				{
+					Rule:     "routing-field-missing",
+					Severity: SeverityError,
+					ID:       ".test.Service.GetSecret",
+					Message:  `routing parameter "version" references field "version", which is not a string`,
+				},
+			},
+		},
+		{
+			name: "resource reference",
+			model: func() *api.API {
+				request := api.NewTestMessage("GetSecretRequest").WithFields(
+					api.NewTestField("name").WithType(api.TypezString).WithResourceReference("test.googleapis.com/Secret"),
+					api.NewTestField("parent").WithType(api.TypezString).WithChildTypeReference("test.googleapis.com/Project"),
+					api.NewTestField("any").WithType(api.TypezString).WithResourceReference("*"))
+				return testModel([]*api.Method{api.NewTestMethod("GetSecret").WithInput(request)}, []*api.Message{request})
+			},
+			want: []*Finding{
+				{
+					Rule:     "resource-reference-unresolved",
+					Severity: SeverityWarning,
+					ID:       ".test.GetSecretRequest.parent",
+					Message:  `the resource reference "test.googleapis.com/Project" is not defined`,
+				},
+			},
+		},
+		{
+			name: "suppressed",
+			model: func() *api.API {
+				request := api.NewTestMessage("CreateSecretRequest")
+				method := api.NewTestMethod("CreateSecret").WithInput(request)
+				method.OutputTypeID = ".google.longrunning.Operation"
+				other := api.NewTestMethod("UpdateSecret").WithInput(request)
+				other.OutputTypeID = ".google.longrunning.Operation"
+				return testModel([]*api.Method{method, other}, []*api.Message{request})
+			},
+			suppressions: []*config.LintSuppression{
+				{Rule: "lro-operation-info", ID: ".test.Service.CreateSecret"},
+				{Rule: "pagination-aip-4233"},
+			},
+			want: []*Finding{
+				{
+					Rule:     "lro-operation-info",
+					Severity: SeverityError,
+					ID:       ".test.Service.UpdateSecret",
+					Message:  "the method returns an Operation but has no google.longrunning.operation_info annotation",
+				},
+			},
+		},
+		{
+			name: "suppressed by parent",
+			model: func() *api.API {
+				request := api.NewTestMessage("CreateSecretRequest")
+				method := api.NewTestMethod("CreateSecret").WithInput(request)
+				method.OutputTypeID = ".google.longrunning.Operation"
+				return testModel([]*api.Method{method}, []*api.Message{request})
+			},
+			suppressions: []*config.LintSuppression{
+				{Rule: "lro-operation-info", ID: ".test.Service"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := Run(test.model(), Rules(), test.suppressions)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHasErrors(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		findings []*Finding
+		want     bool
+	}{
+		{"empty", nil, false},
+		{"warnings", []*Finding{{Severity: SeverityWarning}}, false},
+		{"errors", []*Finding{{Severity: SeverityWarning}, {Severity: SeverityError}}, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := HasErrors(test.findings); got != test.want {
+				t.Errorf("HasErrors() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFindingString(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		location string
+		want     string
+	}{
+		{"without location", "", "error: .test.Service.CreateSecret [lro-operation-info] missing info"},
+		{"with location", "test.proto:42", "error: .test.Service.CreateSecret [lro-operation-info] missing info (test.proto:42)"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			f := &Finding{
+				Rule:     "lro-operation-info",
+				Severity: SeverityError,
+				ID:       ".test.Service.CreateSecret",
+				Message:  "missing info",
+				Location: test.location,
+			}
+			if diff := cmp.Diff(test.want, f.String()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSeverityString(t *testing.T) {
+	for _, test := range []struct {
+		severity Severity
+		want     string
+	}{
+		{SeverityWarning, "warning"},
+		{SeverityError, "error"},
+		{Severity(7), "Severity(7)"},
+	} {
+		t.Run(test.want, func(t *testing.T) {
+			if got := test.severity.String(); got != test.want {
+				t.Errorf("String() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// testModel returns a model with a Secret resource and a single service
+// containing methods.
+func testModel(methods []*api.Method, messages []*api.Message) *api.API {
+	secret := api.NewTestMessage("Secret").
+		WithFields(api.NewTestField("name").WithType(api.TypezString)).
+		WithResource(api.NewTestResource("test.googleapis.com/Secret"))
+	service := api.NewTestService("Service").WithMethods(methods...)
+	return api.NewTestAPI(append([]*api.Message{secret}, messages...), nil, []*api.Service{service})
+}
@@ -0,0 +1,540 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package modeldump converts a parsed [api.API] into a stable, serializable
+// representation.
+//
+// The [api.API] model contains back-references (for example, from a method to
+// its service) and language specific annotations, which make it unsuitable for
+// direct serialization. The types in this package contain only the data
+// produced by the parser, with references to other elements replaced by their
+// IDs.
+package modeldump
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+// ErrElementNotFound is returned when the requested element ID is not in the
+// model.
+var ErrElementNotFound = errors.New("element not found in model")
+
+// Model is the serializable representation of an [api.API]. Its JSON and
+// YAML forms use the same snake_case keys, so dumps in either format can be
+// compared.
+type Model struct {
+	Name        string      `json:"name" yaml:"name"`
+	PackageName string      `json:"package_name,omitempty" yaml:"package_name,omitempty"`
+	Title       string      `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Revision    string      `json:"revision,omitempty" yaml:"revision,omitempty"`
+	Services    []*Service  `json:"services,omitempty" yaml:"services,omitempty"`
+	Messages    []*Message  `json:"messages,omitempty" yaml:"messages,omitempty"`
+	Enums       []*Enum     `json:"enums,omitempty" yaml:"enums,omitempty"`
+	Resources   []*Resource `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// Service is the serializable representation of an [api.Service].
+type Service struct {
+	ID            string    `json:"id" yaml:"id"`
+	Name          string    `json:"name" yaml:"name"`
+	Location      string    `json:"location,omitempty" yaml:"location,omitempty"`
+	Package       string    `json:"package,omitempty" yaml:"package,omitempty"`
+	DefaultHost   string    `json:"default_host,omitempty" yaml:"default_host,omitempty"`
+	Deprecated    bool      `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Documentation string    `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+	Methods       []*Method `json:"methods,omitempty" yaml:"methods,omitempty"`
+}
+
+// Method is the serializable representation of an [api.Method].
+type Method struct {
+	ID                  string         `json:"id" yaml:"id"`
+	Name                string         `json:"name" yaml:"name"`
+	Location            string         `json:"location,omitempty" yaml:"location,omitempty"`
+	SourceServiceID     string         `json:"source_service_id,omitempty" yaml:"source_service_id,omitempty"`
+	Deprecated          bool           `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Documentation       string         `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+	InputTypeID         string         `json:"input_type_id" yaml:"input_type_id"`
+	OutputTypeID        string         `json:"output_type_id" yaml:"output_type_id"`
+	ReturnsEmpty        bool           `json:"returns_empty,omitempty" yaml:"returns_empty,omitempty"`
+	ClientSideStreaming bool           `json:"client_side_streaming,omitempty" yaml:"client_side_streaming,omitempty"`
+	ServerSideStreaming bool           `json:"server_side_streaming,omitempty" yaml:"server_side_streaming,omitempty"`
+	APIVersion          string         `json:"api_version,omitempty" yaml:"api_version,omitempty"`
+	PathInfo            *PathInfo      `json:"path_info,omitempty" yaml:"path_info,omitempty"`
+	Pagination          string         `json:"pagination,omitempty" yaml:"pagination,omitempty"`
+	OperationInfo       *OperationInfo `json:"operation_info,omitempty" yaml:"operation_info,omitempty"`
+	DiscoveryLro        *DiscoveryLro  `json:"discovery_lro,omitempty" yaml:"discovery_lro,omitempty"`
+	Routing             []*Routing     `json:"routing,omitempty" yaml:"routing,omitempty"`
+	AutoPopulated       []string       `json:"auto_populated,omitempty" yaml:"auto_populated,omitempty"`
+}
+
+// PathInfo is the serializable representation of an [api.PathInfo].
+type PathInfo struct {
+	Bindings      []*PathBinding `json:"bindings,omitempty" yaml:"bindings,omitempty"`
+	BodyFieldPath string         `json:"body_field_path,omitempty" yaml:"body_field_path,omitempty"`
+}
+
+// PathBinding is the serializable representation of an [api.PathBinding].
+type PathBinding struct {
+	Verb            string          `json:"verb" yaml:"verb"`
+	PathTemplate    string          `json:"path_template" yaml:"path_template"`
+	QueryParameters []string        `json:"query_parameters,omitempty" yaml:"query_parameters,omitempty"`
+	TargetResource  *TargetResource `json:"target_resource,omitempty" yaml:"target_resource,omitempty"`
+}
+
+// TargetResource is the serializable representation of an
+// [api.TargetResource].
+type TargetResource struct {
+	FieldPaths []string `json:"field_paths,omitempty" yaml:"field_paths,omitempty"`
+	Template   string   `json:"template,omitempty" yaml:"template,omitempty"`
+}
+
+// OperationInfo is the serializable representation of an
+// [api.OperationInfo].
+type OperationInfo struct {
+	MetadataTypeID string `json:"metadata_type_id" yaml:"metadata_type_id"`
+	ResponseTypeID string `json:"response_type_id" yaml:"response_type_id"`
+}
+
+// DiscoveryLro is the serializable representation of an [api.DiscoveryLro].
+type DiscoveryLro struct {
+	PollingPathParameters []string `json:"polling_path_parameters,omitempty" yaml:"polling_path_parameters,omitempty"`
+}
+
+// Routing is the serializable representation of an [api.RoutingInfo].
+type Routing struct {
+	Name     string            `json:"name" yaml:"name"`
+	Variants []*RoutingVariant `json:"variants,omitempty" yaml:"variants,omitempty"`
+}
+
+// RoutingVariant is the serializable representation of an
+// [api.RoutingInfoVariant].
+type RoutingVariant struct {
+	FieldPath string `json:"field_path" yaml:"field_path"`
+	Prefix    string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Matching  string `json:"matching,omitempty" yaml:"matching,omitempty"`
+	Suffix    string `json:"suffix,omitempty" yaml:"suffix,omitempty"`
+}
+
+// Message is the serializable representation of an [api.Message].
+type Message struct {
+	ID                 string      `json:"id" yaml:"id"`
+	Name               string      `json:"name" yaml:"name"`
+	Location           string      `json:"location,omitempty" yaml:"location,omitempty"`
+	Package            string      `json:"package,omitempty" yaml:"package,omitempty"`
+	Deprecated         bool        `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Documentation      string      `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+	IsMap              bool        `json:"is_map,omitempty" yaml:"is_map,omitempty"`
+	SyntheticRequest   bool        `json:"synthetic_request,omitempty" yaml:"synthetic_request,omitempty"`
+	ServicePlaceholder bool        `json:"service_placeholder,omitempty" yaml:"service_placeholder,omitempty"`
+	Fields             []*Field    `json:"fields,omitempty" yaml:"fields,omitempty"`
+	OneOfs             []*OneOf    `json:"one_ofs,omitempty" yaml:"one_ofs,omitempty"`
+	Pagination         *Pagination `json:"pagination,omitempty" yaml:"pagination,omitempty"`
+	Resource           *Resource   `json:"resource,omitempty" yaml:"resource,omitempty"`
+	Messages           []*Message  `json:"messages,omitempty" yaml:"messages,omitempty"`
+	Enums              []*Enum     `json:"enums,omitempty" yaml:"enums,omitempty"`
+}
+
+// Field is the serializable representation of an [api.Field].
+type Field struct {
+	ID                string             `json:"id" yaml:"id"`
+	Name              string             `json:"name" yaml:"name"`
+	Location          string             `json:"location,omitempty" yaml:"location,omitempty"`
+	Number            int32              `json:"number,omitempty" yaml:"number,omitempty"`
+	JSONName          string             `json:"json_name,omitempty" yaml:"json_name,omitempty"`
+	Type              string             `json:"type" yaml:"type"`
+	TypeID            string             `json:"type_id,omitempty" yaml:"type_id,omitempty"`
+	Optional          bool               `json:"optional,omitempty" yaml:"optional,omitempty"`
+	Repeated          bool               `json:"repeated,omitempty" yaml:"repeated,omitempty"`
+	Map               bool               `json:"map,omitempty" yaml:"map,omitempty"`
+	Deprecated        bool               `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Recursive         bool               `json:"recursive,omitempty" yaml:"recursive,omitempty"`
+	AutoPopulated     bool               `json:"auto_populated,omitempty" yaml:"auto_populated,omitempty"`
+	OneOf             string             `json:"one_of,omitempty" yaml:"one_of,omitempty"`
+	Behavior          []string           `json:"behavior,omitempty" yaml:"behavior,omitempty"`
+	ResourceReference *ResourceReference `json:"resource_reference,omitempty" yaml:"resource_reference,omitempty"`
+	Documentation     string             `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+}
+
+// OneOf is the serializable representation of an [api.OneOf].
+type OneOf struct {
+	ID     string   `json:"id" yaml:"id"`
+	Name   string   `json:"name" yaml:"name"`
+	Fields []string `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// Pagination is the serializable representation of an [api.PaginationInfo].
+type Pagination struct {
+	NextPageToken string `json:"next_page_token" yaml:"next_page_token"`
+	PageableItem  string `json:"pageable_item" yaml:"pageable_item"`
+}
+
+// ResourceReference is the serializable representation of an
+// [api.ResourceReference].
+type ResourceReference struct {
+	Type      string `json:"type,omitempty" yaml:"type,omitempty"`
+	ChildType string `json:"child_type,omitempty" yaml:"child_type,omitempty"`
+}
+
+// Enum is the serializable representation of an [api.Enum].
+type Enum struct {
+	ID            string       `json:"id" yaml:"id"`
+	Name          string       `json:"name" yaml:"name"`
+	Location      string       `json:"location,omitempty" yaml:"location,omitempty"`
+	Package       string       `json:"package,omitempty" yaml:"package,omitempty"`
+	Deprecated    bool         `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Documentation string       `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+	Values        []*EnumValue `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// EnumValue is the serializable representation of an [api.EnumValue].
+type EnumValue struct {
+	ID         string `json:"id" yaml:"id"`
+	Name       string `json:"name" yaml:"name"`
+	Number     int32  `json:"number" yaml:"number"`
+	Deprecated bool   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+// Resource is the serializable representation of an [api.Resource].
+type Resource struct {
+	Type     string   `json:"type" yaml:"type"`
+	Patterns []string `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+	Plural   string   `json:"plural,omitempty" yaml:"plural,omitempty"`
+	Singular string   `json:"singular,omitempty" yaml:"singular,omitempty"`
+	Self     string   `json:"self,omitempty" yaml:"self,omitempty"`
+}
+
+// New returns the serializable representation of model.
+func New(model *api.API) *Model {
+	m := &Model{
+		Name:        model.Name,
+		PackageName: model.PackageName,
+		Title:       model.Title,
+		Description: model.Description,
+		Revision:    model.Revision,
+	}
+	for _, s := range model.Services {
+		m.Services = append(m.Services, newService(s))
+	}
+	for _, msg := range model.Messages {
+		m.Messages = append(m.Messages, newMessage(msg))
+	}
+	for _, e := range model.Enums {
+		m.Enums = append(m.Enums, newEnum(e))
+	}
+	for _, r := range model.ResourceDefinitions {
+		m.Resources = append(m.Resources, newResource(r))
+	}
+	return m
+}
+
+// Find returns the element in the model with the given ID. The result is one
+// of [*Service], [*Method], [*Message], [*Field], [*Enum] or [*EnumValue].
+func (m *Model) Find(id string) (any, error) {
+	for _, s := range m.Services {
+		if s.ID == id {
+			return s, nil
+		}
+		for _, method := range s.Methods {
+			if method.ID == id {
+				return method, nil
+			}
+		}
+	}
+	for _, e := range m.Enums {
+		if got := findInEnum(e, id); got != nil {
+			return got, nil
+		}
+	}
+	for _, msg := range m.Messages {
+		if got := findInMessage(msg, id); got != nil {
+			return got, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrElementNotFound, id)
+}
+
+func findInMessage(m *Message, id string) any {
+	if m.ID == id {
+		return m
+	}
+	for _, f := range m.Fields {
+		if f.ID == id {
+			return f
+		}
+	}
+	for _, e := range m.Enums {
+		if got := findInEnum(e, id); got != nil {
+			return got
+		}
+	}
+	for _, child := range m.Messages {
+		if got := findInMessage(child, id); got != nil {
+			return got
+		}
+	}
+	return nil
+}
+
+func findInEnum(e *Enum, id string) any {
+	if e.ID == id {
+		return e
+	}
+	for _, v := range e.Values {
+		if v.ID == id {
+			return v
+		}
+	}
+	return nil
+}
+
+func newService(s *api.Service) *Service {
+	result := &Service{
+		ID:            s.ID,
+		Name:          s.Name,
+		Location:      s.Location.String(),
+		Package:       s.Package,
+		DefaultHost:   s.DefaultHost,
+		Deprecated:    s.Deprecated,
+		Documentation: s.Documentation,
+	}
+	for _, m := range s.Methods {
+		result.Methods = append(result.Methods, newMethod(m))
+	}
+	return result
+}
+
+func newMethod(m *api.Method) *Method {
+	result := &Method{
+		ID:                  m.ID,
+		Name:                m.Name,
+		Location:            m.Location.String(),
+		Deprecated:          m.Deprecated,
+		Documentation:       m.Documentation,
+		InputTypeID:         m.InputTypeID,
+		OutputTypeID:        m.OutputTypeID,
+		ReturnsEmpty:        m.ReturnsEmpty,
+		ClientSideStreaming: m.ClientSideStreaming,
+		ServerSideStreaming: m.ServerSideStreaming,
+		APIVersion:          m.APIVersion,
+		PathInfo:            newPathInfo(m.PathInfo),
+	}
+	if m.SourceServiceID != "" && (m.Service == nil || m.SourceServiceID != m.Service.ID) {
+		result.SourceServiceID = m.SourceServiceID
+	}
+	if m.Pagination != nil {
+		result.Pagination = m.Pagination.ID
+	}
+	if m.OperationInfo != nil {
+		result.OperationInfo = &OperationInfo{
+			MetadataTypeID: m.OperationInfo.MetadataTypeID,
+			ResponseTypeID: m.OperationInfo.ResponseTypeID,
+		}
+	}
+	if m.DiscoveryLro != nil {
+		result.DiscoveryLro = &DiscoveryLro{
+			PollingPathParameters: m.DiscoveryLro.PollingPathParameters,
+		}
+	}
+	for _, r := range m.Routing {
+		routing := &Routing{Name: r.Name}
+		for _, v := range r.Variants {
+			routing.Variants = append(routing.Variants, &RoutingVariant{
+				FieldPath: v.FieldName(),
+				Prefix:    strings.Join(v.Prefix.Segments, "/"),
+				Matching:  strings.Join(v.Matching.Segments, "/"),
+				Suffix:    strings.Join(v.Suffix.Segments, "/"),
+			})
+		}
+		result.Routing = append(result.Routing, routing)
+	}
+	for _, f := range m.AutoPopulated {
+		result.AutoPopulated = append(result.AutoPopulated, f.ID)
+	}
+	return result
+}
+
+func newPathInfo(info *api.PathInfo) *PathInfo {
+	if info == nil {
+		return nil
+	}
+	result := &PathInfo{BodyFieldPath: info.BodyFieldPath}
+	for _, b := range info.Bindings {
+		binding := &PathBinding{
+			Verb:         b.Verb,
+			PathTemplate: FormatPathTemplate(b.PathTemplate),
+		}
+		for name, ok := range b.QueryParameters {
+			if ok {
+				binding.QueryParameters = append(binding.QueryParameters, name)
+			}
+		}
+		slices.Sort(binding.QueryParameters)
+		if b.TargetResource != nil {
+			target := &TargetResource{Template: formatSegments(b.TargetResource.Template)}
+			for _, path := range b.TargetResource.FieldPaths {
+				target.FieldPaths = append(target.FieldPaths, strings.Join(path, "."))
+			}
+			binding.TargetResource = target
+		}
+		result.Bindings = append(result.Bindings, binding)
+	}
+	return result
+}
+
+func newMessage(m *api.Message) *Message {
+	result := &Message{
+		ID:                 m.ID,
+		Name:               m.Name,
+		Location:           m.Location.String(),
+		Package:            m.Package,
+		Deprecated:         m.Deprecated,
+		Documentation:      m.Documentation,
+		IsMap:              m.IsMap,
+		SyntheticRequest:   m.SyntheticRequest,
+		ServicePlaceholder: m.ServicePlaceholder,
+	}
+	for _, f := range m.Fields {
+		result.Fields = append(result.Fields, newField(f))
+	}
+	for _, o := range m.OneOfs {
+		oneOf := &OneOf{ID: o.ID, Name: o.Name}
+		for _, f := range o.Fields {
+			oneOf.Fields = append(oneOf.Fields, f.ID)
+		}
+		result.OneOfs = append(result.OneOfs, oneOf)
+	}
+	if m.Pagination != nil {
+		result.Pagination = &Pagination{
+			NextPageToken: m.Pagination.NextPageToken.ID,
+			PageableItem:  m.Pagination.PageableItem.ID,
+		}
+	}
+	if m.Resource != nil {
+		result.Resource = newResource(m.Resource)
+	}
+	for _, child := range m.Messages {
+		result.Messages = append(result.Messages, newMessage(child))
+	}
+	for _, e := range m.Enums {
+		result.Enums = append(result.Enums, newEnum(e))
+	}
+	return result
+}
+
+func newField(f *api.Field) *Field {
+	result := &Field{
+		ID:            f.ID,
+		Name:          f.Name,
+		Location:      f.Location.String(),
+		Number:        f.Number,
+		JSONName:      f.JSONName,
+		Type:          f.Typez.String(),
+		TypeID:        f.TypezID,
+		Optional:      f.Optional,
+		Repeated:      f.Repeated,
+		Map:           f.Map,
+		Deprecated:    f.Deprecated,
+		Recursive:     f.Recursive,
+		AutoPopulated: f.AutoPopulated,
+		Documentation: f.Documentation,
+	}
+	if f.IsOneOf && f.Group != nil {
+		result.OneOf = f.Group.ID
+	}
+	for _, b := range f.Behavior {
+		result.Behavior = append(result.Behavior, b.String())
+	}
+	if f.ResourceReference != nil {
+		result.ResourceReference = &ResourceReference{
+			Type:      f.ResourceReference.Type,
+			ChildType: f.ResourceReference.ChildType,
+		}
+	}
+	return result
+}
+
+func newEnum(e *api.Enum) *Enum {
+	result := &Enum{
+		ID:            e.ID,
+		Name:          e.Name,
+		Location:      e.Location.String(),
+		Package:       e.Package,
+		Deprecated:    e.Deprecated,
+		Documentation: e.Documentation,
+	}
+	for _, v := range e.Values {
+		result.Values = append(result.Values, &EnumValue{
+			ID:         v.ID,
+			Name:       v.Name,
+			Number:     v.Number,
+			Deprecated: v.Deprecated,
+		})
+	}
+	return result
+}
+
+func newResource(r *api.Resource) *Resource {
+	result := &Resource{
+		Type:     r.Type,
+		Plural:   r.Plural,
+		Singular: r.Singular,
+	}
+	for _, p := range r.Patterns {
+		result.Patterns = append(result.Patterns, formatSegments(p))
+	}
+	if r.Self != nil {
+		result.Self = r.Self.ID
+	}
+	return result
+}
+
+// FormatPathTemplate returns the canonical string representation of a path
+// template, for example `/v1/{name=projects/*/secrets/*}:enable`.
+func FormatPathTemplate(t *api.PathTemplate) string {
+	if t == nil {
+		return ""
+	}
+	result := "/" + formatSegments(t.Segments)
+	if t.Verb != "" {
+		result += ":" + t.Verb
+	}
+	return result
+}
+
+func formatSegments(segments []api.PathSegment) string {
+	var parts []string
+	for _, s := range segments {
+		switch {
+		case s.Variable != nil:
+			parts = append(parts, formatVariable(s.Variable))
+		default:
+			parts = append(parts, s.Literal)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func formatVariable(v *api.PathVariable) string {
+	name := strings.Join(v.FieldPath, ".")
+	if len(v.Segments) == 0 || slices.Equal(v.Segments, []string{api.SingleSegmentWildcard}) {
+		return "{" + name + "}"
+	}
+	return "{" + name + "=" + strings.Join(v.Segments, "/") + "}"
+}
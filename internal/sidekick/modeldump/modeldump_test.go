@@ -0,0 +1,296 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modeldump
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+func testModel() *api.API {
+	name := api.NewTestField("name").WithType(api.TypezString).WithBehavior(api.FieldBehaviorIdentifier)
+	state := &api.Enum{
+		Name:    "State",
+		ID:      ".test.Secret.State",
+		Package: "test",
+		Values: []*api.EnumValue{
+			{Name: "STATE_UNSPECIFIED", ID: ".test.Secret.State.STATE_UNSPECIFIED", Number: 0},
+			{Name: "ENABLED", ID: ".test.Secret.State.ENABLED", Number: 1},
+		},
+	}
+	secret := api.NewTestMessage("Secret").
+		WithFields(name).
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").
+			WithPatterns(api.ParseTemplateForTest("projects/{project}/secrets/{secret}")).
+			WithSingular("secret"))
+	secret.Location = &api.SourceLocation{File: "test.proto", StartLine: 20, EndLine: 30}
+	request := api.NewTestMessage("GetSecretRequest").WithFields(
+		api.NewTestField("name").WithType(api.TypezString).
+			WithBehavior(api.FieldBehaviorRequired).
+			WithResourceReference("test.googleapis.com/Secret"))
+	get := api.NewTestMethod("GetSecret").
+		WithInput(request).
+		WithOutput(secret).
+		WithVerb("GET").
+		WithPathTemplate((&api.PathTemplate{}).
+			WithLiteral("v1").
+			WithVariable(api.NewPathVariable("name").
+				WithLiteral("projects").WithMatch().
+				WithLiteral("secrets").WithMatch()))
+	get.PathInfo.Bindings[0].QueryParameters = map[string]bool{"b": true, "a": true}
+	get.Routing = []*api.RoutingInfo{
+		{
+			Name: "project",
+			Variants: []*api.RoutingInfoVariant{
+				{
+					FieldPath: []string{"name"},
+					Matching:  api.RoutingPathSpec{Segments: []string{"projects", "*"}},
+					Suffix:    api.RoutingPathSpec{Segments: []string{"**"}},
+				},
+			},
+		},
+	}
+	service := api.NewTestService("SecretManager").WithMethods(get)
+	return api.NewTestAPI([]*api.Message{secret, request}, []*api.Enum{state}, []*api.Service{service})
+}
+
+func TestNew(t *testing.T) {
+	got := New(testModel())
+	want := &Model{
+		Name:        "Test",
+		PackageName: "test",
+		Services: []*Service{
+			{
+				ID:      ".test.SecretManager",
+				Name:    "SecretManager",
+				Package: "test",
+				Methods: []*Method{
+					{
+						ID:           ".test.SecretManager.GetSecret",
+						Name:         "GetSecret",
+						InputTypeID:  ".test.GetSecretRequest",
+						OutputTypeID: ".test.Secret",
+						PathInfo: &PathInfo{
+							Bindings: []*PathBinding{
+								{
+									Verb:            "GET",
+									PathTemplate:    "/v1/{name=projects/*/secrets/*}",
+									QueryParameters: []string{"a", "b"},
+								},
+							},
+						},
+						Routing: []*Routing{
+							{
+								Name: "project",
+								Variants: []*RoutingVariant{
+									{FieldPath: "name", Matching: "projects/*", Suffix: "**"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Messages: []*Message{
+			{
+				ID:       ".test.Secret",
+				Name:     "Secret",
+				Location: "test.proto:20",
+				Package:  "test",
+				Fields: []*Field{
+					{
+						ID:       ".test.Secret.name",
+						Name:     "name",
+						JSONName: "name",
+						Type:     "STRING",
+						Behavior: []string{"IDENTIFIER"},
+					},
+				},
+				Resource: &Resource{
+					Type:     "test.googleapis.com/Secret",
+					Patterns: []string{"projects/{project}/secrets/{secret}"},
+					Singular: "secret",
+					Self:     ".test.Secret",
+				},
+				Enums: []*Enum{
+					{
+						ID:      ".test.Secret.State",
+						Name:    "State",
+						Package: "test",
+						Values: []*EnumValue{
+							{ID: ".test.Secret.State.STATE_UNSPECIFIED", Name: "STATE_UNSPECIFIED", Number: 0},
+							{ID: ".test.Secret.State.ENABLED", Name: "ENABLED", Number: 1},
+						},
+					},
+				},
+			},
+			{
+				ID:      ".test.GetSecretRequest",
+				Name:    "GetSecretRequest",
+				Package: "test",
+				Fields: []*Field{
+					{
+						ID:                ".test.GetSecretRequest.name",
+						Name:              "name",
+						JSONName:          "name",
+						Type:              "STRING",
+						Behavior:          []string{"REQUIRED"},
+						ResourceReference: &ResourceReference{Type: "test.googleapis.com/Secret"},
+					},
+				},
+			},
+		},
+		Enums: []*Enum{
+			{
+				ID:      ".test.Secret.State",
+				Name:    "State",
+				Package: "test",
+				Values: []*EnumValue{
+					{ID: ".test.Secret.State.STATE_UNSPECIFIED", Name: "STATE_UNSPECIFIED", Number: 0},
+					{ID: ".test.Secret.State.ENABLED", Name: "ENABLED", Number: 1},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestJSONAndYAMLKeys(t *testing.T) {
+	model := New(testModel())
+	data, err := json.Marshal(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromJSON any
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	data, err = yaml.Marshal(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromYAML, err := yaml.Unmarshal[any](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Numbers decode as float64 from JSON and as int from YAML, so only the
+	// keys are compared.
+	if diff := cmp.Diff(keys(fromJSON), keys(*fromYAML)); diff != "" {
+		t.Errorf("JSON and YAML keys mismatch (-json +yaml):\n%s", diff)
+	}
+}
+
+// keys returns the paths of the keys of the maps in v, which is decoded
+// JSON or YAML.
+func keys(v any) []string {
+	var paths []string
+	var walk func(prefix string, v any)
+	walk = func(prefix string, v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for k, e := range v {
+				paths = append(paths, prefix+"."+k)
+				walk(prefix+"."+k, e)
+			}
+		case []any:
+			for i, e := range v {
+				walk(fmt.Sprintf("%s[%d]", prefix, i), e)
+			}
+		}
+	}
+	walk("", v)
+	slices.Sort(paths)
+	return paths
+}
+
+func TestFind(t *testing.T) {
+	model := New(testModel())
+	for _, test := range []struct {
+		name string
+		id   string
+		want string
+	}{
+		{"service", ".test.SecretManager", "*modeldump.Service"},
+		{"method", ".test.SecretManager.GetSecret", "*modeldump.Method"},
+		{"message", ".test.Secret", "*modeldump.Message"},
+		{"field", ".test.GetSecretRequest.name", "*modeldump.Field"},
+		{"enum", ".test.Secret.State", "*modeldump.Enum"},
+		{"enum value", ".test.Secret.State.ENABLED", "*modeldump.EnumValue"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := model.Find(test.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, fmt.Sprintf("%T", got)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFind_Error(t *testing.T) {
+	_, err := New(testModel()).Find(".test.Missing")
+	if !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("Find() error = %v, want %v", err, ErrElementNotFound)
+	}
+}
+
+func TestFormatPathTemplate(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		template *api.PathTemplate
+		want     string
+	}{
+		{
+			name: "nil",
+			want: "",
+		},
+		{
+			name: "simple variable",
+			template: (&api.PathTemplate{}).
+				WithLiteral("v1").
+				WithVariableNamed("project").
+				WithLiteral("zones"),
+			want: "/v1/{project}/zones",
+		},
+		{
+			name: "nested variable with verb",
+			template: (&api.PathTemplate{}).
+				WithLiteral("v1").
+				WithVariable(api.NewPathVariable("secret", "name").
+					WithLiteral("projects").WithMatch().
+					WithLiteral("secrets").WithMatchRecursive()).
+				WithVerb("enable"),
+			want: "/v1/{secret.name=projects/*/secrets/**}:enable",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := FormatPathTemplate(test.template)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
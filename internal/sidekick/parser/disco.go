@@ -38,5 +38,6 @@ func ParseDisco(cfg *ModelConfig) (*api.API, error) {
 		return nil, err
 	}
 	updateAutoPopulatedFields(serviceConfig, result)
+	setLocationFile(result, cfg.SpecificationSource)
 	return result, nil
 }
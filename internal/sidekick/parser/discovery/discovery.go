@@ -74,11 +74,15 @@ func NewAPI(serviceConfig *serviceconfig.Service, contents []byte, discoveryConf
 			ID:            id,
 			Package:       packageName,
 			Documentation: schema.Description,
+			Location:      &api.SourceLocation{Pointer: api.JSONPointer("schemas", name)},
 		}
 		err := makeMessageFields(result, message, schema)
 		if err != nil {
 			return nil, err
 		}
+		for _, field := range message.Fields {
+			field.Location = &api.SourceLocation{Pointer: api.JSONPointer("schemas", name, "properties", field.JSONName)}
+		}
 		result.Messages = append(result.Messages, message)
 		result.AddMessage(message)
 	}
@@ -382,6 +386,15 @@ type resource struct {
 	Deprecated bool
 }
 
+// pointer returns the JSON pointer of the resource in the discovery document.
+func (r *resource) pointer() string {
+	var tokens []string
+	for _, name := range strings.Split(strings.TrimPrefix(r.FullName, "."), ".") {
+		tokens = append(tokens, "resources", name)
+	}
+	return api.JSONPointer(tokens...)
+}
+
 func (r *resource) init(parentFullName string, topLevelSchemas map[string]*schema) error {
 	r.FullName = fmt.Sprintf("%s.%s", parentFullName, r.Name)
 	for _, m := range r.Methods {
@@ -185,6 +185,7 @@ func TestDeprecatedField(t *testing.T) {
 		Name:          "port",
 		JSONName:      "port",
 		ID:            "..BackendService.port",
+		Location:      &api.SourceLocation{Pointer: "/schemas/BackendService/properties/port"},
 		Deprecated:    true,
 		Documentation: gotField.Documentation,
 		Typez:         api.TypezInt32,
@@ -241,3 +242,59 @@ func ComputeDiscoWithLros(t *testing.T, discoveryConfig *api.Discovery) (*api.AP
 	}
 	return NewAPI(nil, contents, discoveryConfig)
 }
+
+func TestLocations(t *testing.T) {
+	contents := []byte(`{
+  "rootUrl": "https://test.googleapis.com/",
+  "servicePath": "",
+  "schemas": {
+    "Zone": {
+      "id": "Zone",
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"}
+      }
+    }
+  },
+  "resources": {
+    "projects": {
+      "resources": {
+        "zones": {
+          "methods": {
+            "get": {
+              "path": "projects/{project}/zones/{zone}",
+              "httpMethod": "GET",
+              "parameters": {
+                "project": {"location": "path", "required": true, "type": "string"},
+                "zone": {"location": "path", "required": true, "type": "string"}
+              },
+              "response": {"$ref": "Zone"}
+            }
+          }
+        }
+      }
+    }
+  }
+}`)
+	model, err := NewAPI(nil, contents, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name string
+		got  *api.SourceLocation
+		want string
+	}{
+		{"service", model.Service("..zones").Location, "/resources/projects/resources/zones"},
+		{"method", model.Method("..zones.get").Location, "/resources/projects/resources/zones/methods/get"},
+		{"message", model.Message("..Zone").Location, "/schemas/Zone"},
+		{"field", model.Message("..Zone").Fields[0].Location, "/schemas/Zone/properties/name"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			want := &api.SourceLocation{Pointer: test.want}
+			if diff := cmp.Diff(want, test.got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
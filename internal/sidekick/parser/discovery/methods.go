@@ -38,6 +38,7 @@ func makeServiceMethods(model *api.API, service *api.Service, doc *document, res
 		if err != nil {
 			return err
 		}
+		method.Location = &api.SourceLocation{Pointer: resource.pointer() + api.JSONPointer("methods", input.Name)}
 		model.AddMethod(method)
 		service.Methods = append(service.Methods, method)
 	}
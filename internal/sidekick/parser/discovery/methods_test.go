@@ -34,6 +34,7 @@ func TestMakeServiceMethods(t *testing.T) {
 	}
 	want := &api.Method{
 		ID:            "..zones.get",
+		Location:      &api.SourceLocation{Pointer: "/resources/zones/methods/get"},
 		Name:          "get",
 		Documentation: "Returns the specified Zone resource.",
 		InputTypeID:   "..zones.getRequest",
@@ -73,6 +74,7 @@ func TestMakeServiceMethodsReturnsEmpty(t *testing.T) {
 	}
 	want := &api.Method{
 		ID:            "..zoneOperations.delete",
+		Location:      &api.SourceLocation{Pointer: "/resources/zoneOperations/methods/delete"},
 		Name:          "delete",
 		Documentation: "Deletes the specified zone-specific Operations resource.",
 		InputTypeID:   "..zoneOperations.deleteRequest",
@@ -115,6 +117,7 @@ func TestMakeServiceMethodsDeprecated(t *testing.T) {
 	}
 	want := &api.Method{
 		ID:            "..projects.moveInstance",
+		Location:      &api.SourceLocation{Pointer: "/resources/projects/methods/moveInstance"},
 		Name:          "moveInstance",
 		Documentation: "Moves an instance and its attached persistent disks from one zone to another. *Note*: Moving VMs or disks by using this method might cause unexpected behavior. For more information, see the [known issue](/compute/docs/troubleshooting/known-issues#moving_vms_or_disks_using_the_moveinstance_api_or_the_causes_unexpected_behavior). [Deprecated] This method is deprecated. See [moving instance across zones](/compute/docs/instances/moving-instance-across-zones) instead.",
 		Deprecated:    true,
@@ -0,0 +1,223 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/serviceconfig"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+var errInvalidGRPCServiceConfig = errors.New("invalid gRPC service config")
+
+// statusCodes are the names of the gRPC status codes, as used in the
+// `retryableStatusCodes` field of the gRPC service config.
+var statusCodes = []string{
+	"OK",
+	"CANCELLED",
+	"UNKNOWN",
+	"INVALID_ARGUMENT",
+	"DEADLINE_EXCEEDED",
+	"NOT_FOUND",
+	"ALREADY_EXISTS",
+	"PERMISSION_DENIED",
+	"RESOURCE_EXHAUSTED",
+	"FAILED_PRECONDITION",
+	"ABORTED",
+	"OUT_OF_RANGE",
+	"UNIMPLEMENTED",
+	"INTERNAL",
+	"UNAVAILABLE",
+	"DATA_LOSS",
+	"UNAUTHENTICATED",
+}
+
+// grpcServiceConfig is the subset of the gRPC service config used by the
+// parser.
+//
+// See https://github.com/grpc/grpc/blob/master/doc/service_config.md
+type grpcServiceConfig struct {
+	MethodConfig []*grpcMethodConfig `json:"methodConfig"`
+}
+
+type grpcMethodConfig struct {
+	Name        []grpcMethodName `json:"name"`
+	Timeout     string           `json:"timeout"`
+	RetryPolicy *grpcRetryPolicy `json:"retryPolicy"`
+}
+
+type grpcMethodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method"`
+}
+
+type grpcRetryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+// findGRPCServiceConfigPath returns the path of the gRPC service config file
+// for the current parser configuration, or an empty string if there is none.
+//
+// An explicit `cfg.GRPCServiceConfig` is resolved relative to the source
+// roots. Otherwise, protobuf specifications look for a
+// `*_grpc_service_config.json` file in the specification directory.
+func findGRPCServiceConfigPath(cfg *ModelConfig) (string, error) {
+	if cfg.GRPCServiceConfig != "" {
+		return cfg.Source.Resolve(cfg.GRPCServiceConfig), nil
+	}
+	if cfg.SpecificationFormat != config.SpecProtobuf || cfg.Source == nil {
+		return "", nil
+	}
+	for _, root := range cfg.Source.ActiveRoots {
+		rootPath := cfg.Source.Root(root)
+		if rootPath == "" {
+			continue
+		}
+		path, err := serviceconfig.FindGRPCServiceConfig(rootPath, cfg.SpecificationSource)
+		if err != nil {
+			return "", err
+		}
+		if path != "" {
+			return cfg.Source.Resolve(path), nil
+		}
+	}
+	return "", nil
+}
+
+// loadGRPCServiceConfig reads the gRPC service config for the current parser
+// configuration. It returns nil if there is no gRPC service config.
+func loadGRPCServiceConfig(cfg *ModelConfig) (*grpcServiceConfig, error) {
+	path, err := findGRPCServiceConfigPath(cfg)
+	if err != nil || path == "" {
+		return nil, err
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	result := &grpcServiceConfig{}
+	if err := json.Unmarshal(contents, result); err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", errInvalidGRPCServiceConfig, path, err)
+	}
+	return result, nil
+}
+
+// applyGRPCServiceConfig sets the default timeout and retry policy of each
+// method in the model.
+//
+// As in gRPC, a configuration naming the method takes precedence over a
+// configuration naming only its service.
+func applyGRPCServiceConfig(model *api.API, sc *grpcServiceConfig) error {
+	if sc == nil {
+		return nil
+	}
+	for _, s := range model.Services {
+		serviceName := s.Name
+		if s.Package != "" {
+			serviceName = s.Package + "." + s.Name
+		}
+		for _, m := range s.Methods {
+			mc := sc.find(serviceName, m.Name)
+			if mc == nil {
+				continue
+			}
+			if err := applyMethodConfig(m, mc); err != nil {
+				return fmt.Errorf("%w: method %s: %w", errInvalidGRPCServiceConfig, m.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (sc *grpcServiceConfig) find(service, method string) *grpcMethodConfig {
+	var serviceMatch *grpcMethodConfig
+	for _, mc := range sc.MethodConfig {
+		for _, name := range mc.Name {
+			if name.Service != service {
+				continue
+			}
+			if name.Method == method {
+				return mc
+			}
+			if name.Method == "" && serviceMatch == nil {
+				serviceMatch = mc
+			}
+		}
+	}
+	return serviceMatch
+}
+
+func applyMethodConfig(m *api.Method, mc *grpcMethodConfig) error {
+	timeout, err := parseDuration(mc.Timeout)
+	if err != nil {
+		return err
+	}
+	m.Timeout = timeout
+	if mc.RetryPolicy == nil {
+		return nil
+	}
+	p := mc.RetryPolicy
+	initial, err := parseDuration(p.InitialBackoff)
+	if err != nil {
+		return err
+	}
+	maximum, err := parseDuration(p.MaxBackoff)
+	if err != nil {
+		return err
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if !slices.Contains(statusCodes, code) {
+			return fmt.Errorf("unknown status code %q", code)
+		}
+	}
+	m.RetryPolicy = &api.RetryPolicy{
+		MaxAttempts:       p.MaxAttempts,
+		InitialBackoff:    initial,
+		MaxBackoff:        maximum,
+		BackoffMultiplier: p.BackoffMultiplier,
+		RetryableCodes:    p.RetryableStatusCodes,
+	}
+	return nil
+}
+
+// parseDuration parses a duration in the JSON format for
+// `google.protobuf.Duration`, such as "1.5s".
+func parseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	seconds, ok := strings.CutSuffix(value, "s")
+	if !ok {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	f, err := strconv.ParseFloat(seconds, 64)
+	if err != nil || f < 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return time.Duration(f * float64(time.Second)), nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sources"
+)
+
+func TestLoadGRPCServiceConfig(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		cfg  *ModelConfig
+	}{
+		{
+			name: "explicit",
+			cfg: &ModelConfig{
+				SpecificationFormat: config.SpecOpenAPI,
+				GRPCServiceConfig:   "google/cloud/secretmanager/v1/secretmanager_grpc_service_config.json",
+				Source:              grpcServiceConfigSource(),
+			},
+		},
+		{
+			name: "found in specification directory",
+			cfg: &ModelConfig{
+				SpecificationFormat: config.SpecProtobuf,
+				SpecificationSource: "google/cloud/secretmanager/v1",
+				Source:              grpcServiceConfigSource(),
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := loadGRPCServiceConfig(test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got == nil {
+				t.Fatal("expected a gRPC service config")
+			}
+			mc := got.find("google.cloud.secretmanager.v1.SecretManagerService", "AccessSecretVersion")
+			if mc == nil || mc.RetryPolicy == nil {
+				t.Fatalf("expected a retry policy for AccessSecretVersion, got %v", mc)
+			}
+		})
+	}
+}
+
+func TestLoadGRPCServiceConfigNone(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		cfg  *ModelConfig
+	}{
+		{
+			name: "openapi",
+			cfg: &ModelConfig{
+				SpecificationFormat: config.SpecOpenAPI,
+				SpecificationSource: "google/cloud/secretmanager/v1",
+				Source:              grpcServiceConfigSource(),
+			},
+		},
+		{
+			name: "no file",
+			cfg: &ModelConfig{
+				SpecificationFormat: config.SpecProtobuf,
+				SpecificationSource: "google/type",
+				Source:              grpcServiceConfigSource(),
+			},
+		},
+		{
+			name: "no source",
+			cfg: &ModelConfig{
+				SpecificationFormat: config.SpecProtobuf,
+				SpecificationSource: "google/cloud/secretmanager/v1",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := loadGRPCServiceConfig(test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != nil {
+				t.Errorf("expected no gRPC service config, got %v", got)
+			}
+		})
+	}
+}
+
+func TestLoadGRPCServiceConfigError(t *testing.T) {
+	file := path.Join(t.TempDir(), "bad_grpc_service_config.json")
+	if err := os.WriteFile(file, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := loadGRPCServiceConfig(&ModelConfig{GRPCServiceConfig: file})
+	if !errors.Is(err, errInvalidGRPCServiceConfig) {
+		t.Errorf("loadGRPCServiceConfig() error = %v, want %v", err, errInvalidGRPCServiceConfig)
+	}
+}
+
+func TestApplyGRPCServiceConfig(t *testing.T) {
+	get := api.NewTestMethod("GetSecret")
+	access := api.NewTestMethod("AccessSecretVersion")
+	other := api.NewTestMethod("Other")
+	service := api.NewTestService("SecretManagerService").WithMethods(get, access)
+	otherService := api.NewTestService("OtherService").WithMethods(other)
+	model := api.NewTestAPI(nil, nil, []*api.Service{service, otherService})
+
+	sc := &grpcServiceConfig{
+		MethodConfig: []*grpcMethodConfig{
+			{
+				Name:    []grpcMethodName{{Service: "test.SecretManagerService"}},
+				Timeout: "60s",
+			},
+			{
+				Name:    []grpcMethodName{{Service: "test.SecretManagerService", Method: "AccessSecretVersion"}},
+				Timeout: "30.5s",
+				RetryPolicy: &grpcRetryPolicy{
+					MaxAttempts:          5,
+					InitialBackoff:       "0.1s",
+					MaxBackoff:           "60s",
+					BackoffMultiplier:    1.3,
+					RetryableStatusCodes: []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"},
+				},
+			},
+		},
+	}
+	if err := applyGRPCServiceConfig(model, sc); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		method      *api.Method
+		wantTimeout time.Duration
+		wantPolicy  *api.RetryPolicy
+	}{
+		{method: get, wantTimeout: 60 * time.Second},
+		{
+			method:      access,
+			wantTimeout: 30500 * time.Millisecond,
+			wantPolicy: &api.RetryPolicy{
+				MaxAttempts:       5,
+				InitialBackoff:    100 * time.Millisecond,
+				MaxBackoff:        60 * time.Second,
+				BackoffMultiplier: 1.3,
+				RetryableCodes:    []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"},
+			},
+		},
+		{method: other},
+	} {
+		t.Run(test.method.Name, func(t *testing.T) {
+			if test.method.Timeout != test.wantTimeout {
+				t.Errorf("timeout = %v, want %v", test.method.Timeout, test.wantTimeout)
+			}
+			if diff := cmp.Diff(test.wantPolicy, test.method.RetryPolicy); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyGRPCServiceConfigError(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		config *grpcMethodConfig
+	}{
+		{
+			name:   "bad timeout",
+			config: &grpcMethodConfig{Timeout: "60"},
+		},
+		{
+			name:   "negative timeout",
+			config: &grpcMethodConfig{Timeout: "-1s"},
+		},
+		{
+			name:   "bad initial backoff",
+			config: &grpcMethodConfig{RetryPolicy: &grpcRetryPolicy{InitialBackoff: "1m"}},
+		},
+		{
+			name:   "bad max backoff",
+			config: &grpcMethodConfig{RetryPolicy: &grpcRetryPolicy{MaxBackoff: "xs"}},
+		},
+		{
+			name:   "unknown code",
+			config: &grpcMethodConfig{RetryPolicy: &grpcRetryPolicy{RetryableStatusCodes: []string{"unavailable"}}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			method := api.NewTestMethod("GetSecret")
+			service := api.NewTestService("Service").WithMethods(method)
+			model := api.NewTestAPI(nil, nil, []*api.Service{service})
+			test.config.Name = []grpcMethodName{{Service: "test.Service"}}
+			sc := &grpcServiceConfig{MethodConfig: []*grpcMethodConfig{test.config}}
+			if err := applyGRPCServiceConfig(model, sc); !errors.Is(err, errInvalidGRPCServiceConfig) {
+				t.Errorf("applyGRPCServiceConfig() error = %v, want %v", err, errInvalidGRPCServiceConfig)
+			}
+		})
+	}
+}
+
+func grpcServiceConfigSource() *sources.SourceConfig {
+	return sources.NewSourceConfig(&sources.Sources{
+		Googleapis: filepath.Join(mainTestdataDir, "googleapis"),
+	}, nil)
+}
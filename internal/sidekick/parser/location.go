@@ -0,0 +1,43 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import "github.com/googleapis/librarian/internal/sidekick/api"
+
+// setLocationFile sets the file of the source locations in model. OpenAPI and
+// discovery specifications are a single file, and the parsers only record
+// the JSON pointer of each element.
+func setLocationFile(model *api.API, file string) {
+	set := func(l *api.SourceLocation) {
+		if l != nil && l.File == "" {
+			l.File = file
+		}
+	}
+	for s := range model.AllServices() {
+		set(s.Location)
+	}
+	for m := range model.AllMethods() {
+		set(m.Location)
+	}
+	for m := range model.AllMessages() {
+		set(m.Location)
+		for _, f := range m.Fields {
+			set(f.Location)
+		}
+	}
+	for e := range model.AllEnums() {
+		set(e.Location)
+	}
+}
@@ -47,7 +47,12 @@ func ParseOpenAPI(cfg *ModelConfig) (*api.API, error) {
 	if err != nil {
 		return nil, err
 	}
-	return makeAPIForOpenAPI(serviceConfig, model)
+	result, err := makeAPIForOpenAPI(serviceConfig, model)
+	if err != nil {
+		return nil, err
+	}
+	setLocationFile(result, cfg.SpecificationSource)
+	return result, nil
 }
 
 func createDocModel(contents []byte) (*libopenapi.DocumentModel[v3.Document], error) {
@@ -110,6 +115,10 @@ func makeAPIForOpenAPI(serviceConfig *serviceconfig.Service, model *libopenapi.D
 			Deprecated:    msg.Schema().Deprecated != nil && *msg.Schema().Deprecated,
 			Documentation: msg.Schema().Description,
 			Fields:        fields,
+			Location:      &api.SourceLocation{Pointer: api.JSONPointer("components", "schemas", name)},
+		}
+		for _, field := range fields {
+			field.Location = &api.SourceLocation{Pointer: api.JSONPointer("components", "schemas", name, "properties", field.JSONName)}
 		}
 
 		result.Messages = append(result.Messages, message)
@@ -138,6 +147,7 @@ func makeServices(a *api.API, model *libopenapi.DocumentModel[v3.Document], pack
 		Package:       packageName,
 		Documentation: a.Description,
 		DefaultHost:   defaultHost(model),
+		Location:      &api.SourceLocation{Pointer: api.JSONPointer("paths")},
 	}
 	err := makeMethods(a, service, model, packageName, sID)
 	if err != nil {
@@ -232,6 +242,7 @@ func makeMethods(a *api.API, service *api.Service, model *libopenapi.DocumentMod
 				InputTypeID:   requestMessage.ID,
 				OutputTypeID:  responseMessage.ID,
 				PathInfo:      pathInfo,
+				Location:      &api.SourceLocation{Pointer: api.JSONPointer("paths", pattern, strings.ToLower(op.Verb))},
 			}
 			a.AddMethod(m)
 			service.Methods = append(service.Methods, m)
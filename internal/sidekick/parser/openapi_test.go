@@ -716,7 +716,7 @@ func TestOpenAPI_MakeAPI(t *testing.T) {
 	wantService.Package = ""
 	wantService.Name = "Service"
 	wantService.ID = "..Service"
-	if diff := cmp.Diff(wantService, service, cmpopts.IgnoreFields(api.Service{}, "Methods")); diff != "" {
+	if diff := cmp.Diff(wantService, service, cmpopts.IgnoreFields(api.Service{}, "Methods", "Location")); diff != "" {
 		t.Errorf("mismatched service attributes (-want, +got):\n%s", diff)
 	}
 
@@ -1282,6 +1282,55 @@ func TestOpenAPI_ParseBadFiles(t *testing.T) {
 	}
 }
 
+func TestOpenAPI_Locations(t *testing.T) {
+	model, err := ParseOpenAPI(&ModelConfig{
+		SpecificationSource: openAPIFile,
+		ServiceConfig:       secretManagerYamlFullPath,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name string
+		got  func() *api.SourceLocation
+		want string
+	}{
+		{
+			name: "service",
+			got: func() *api.SourceLocation {
+				return model.Service(".google.cloud.secretmanager.v1.SecretManagerService").Location
+			},
+			want: "/paths",
+		},
+		{
+			name: "method",
+			got: func() *api.SourceLocation {
+				return model.Method(".google.cloud.secretmanager.v1.SecretManagerService.ListLocations").Location
+			},
+			want: "/paths/~1v1~1projects~1{project}~1locations/get",
+		},
+		{
+			name: "message",
+			got:  func() *api.SourceLocation { return model.Message(".google.cloud.secretmanager.v1.Location").Location },
+			want: "/components/schemas/Location",
+		},
+		{
+			name: "field",
+			got: func() *api.SourceLocation {
+				return model.Message(".google.cloud.secretmanager.v1.Location").Fields[1].Location
+			},
+			want: "/components/schemas/Location/properties/locationId",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			want := &api.SourceLocation{File: openAPIFile, Pointer: test.want}
+			if diff := cmp.Diff(want, test.got()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 const openAPISingleMessagePreamble = `
 {
   "openapi": "3.0.3",
@@ -43,6 +43,11 @@ type ModelConfig struct {
 
 	// Service config
 	ServiceConfig string
+	// GRPCServiceConfig is the path of the gRPC service config file, relative
+	// to the source roots. If empty, protobuf specifications use the
+	// `*_grpc_service_config.json` file in the specification directory, if
+	// any.
+	GRPCServiceConfig string
 
 	// Codec configuration
 	Codec map[string]string
@@ -59,6 +64,35 @@ type ModelConfig struct {
 
 	// Model overrides
 	Override api.ModelOverride
+
+	// Transformations applied to the model, in order, before any other
+	// changes.
+	Transformations []api.Transformation
+}
+
+// Transformations converts the transforms in a library configuration to the
+// transformations applied by [CreateModel].
+func Transformations(transforms []*config.Transform) []api.Transformation {
+	var result []api.Transformation
+	for _, t := range transforms {
+		transformation := api.Transformation{
+			ID:            t.ID,
+			Rename:        t.Rename,
+			Remove:        t.Remove,
+			Deprecate:     t.Deprecate,
+			FieldBehavior: t.FieldBehavior,
+			Type:          t.Type,
+		}
+		if t.Paginate != nil {
+			transformation.Paginate = &api.PaginationTransformation{
+				PageToken:     t.Paginate.PageToken,
+				NextPageToken: t.Paginate.NextPageToken,
+				ItemField:     t.Paginate.ItemField,
+			}
+		}
+		result = append(result, transformation)
+	}
+	return result
 }
 
 // CreateModel parses the service specification referenced in `config`,
@@ -82,6 +116,16 @@ func CreateModel(cfg *ModelConfig) (*api.API, error) {
 	if err != nil {
 		return nil, err
 	}
+	grpcServiceConfig, err := loadGRPCServiceConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyGRPCServiceConfig(model, grpcServiceConfig); err != nil {
+		return nil, err
+	}
+	if err := api.ApplyTransformations(model, cfg.Transformations); err != nil {
+		return nil, err
+	}
 	api.UpdateMethodPagination(cfg.PaginationOverrides, model)
 	api.LabelRecursiveFields(model)
 	if err := api.CrossReference(model); err != nil {
@@ -17,6 +17,7 @@ package parser
 import (
 	"path"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -220,3 +221,56 @@ func TestCreateModelBadParse(t *testing.T) {
 		t.Errorf("expected error with bad specification, got=%v", got)
 	}
 }
+
+func TestCreateModelTransformations(t *testing.T) {
+	cfg := &ModelConfig{
+		SpecificationFormat: config.SpecOpenAPI,
+		ServiceConfig:       secretManagerYamlFullPath,
+		SpecificationSource: openAPIFile,
+		Transformations: Transformations([]*config.Transform{
+			{ID: ".google.cloud.secretmanager.v1.Secret", Rename: "SecretData"},
+			{ID: ".google.cloud.secretmanager.v1.Secret.etag", Remove: true},
+		}),
+	}
+	model, err := CreateModel(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := model.Message(".google.cloud.secretmanager.v1.Secret")
+	if secret.Name != "SecretData" {
+		t.Errorf("mismatched name got=%q, want=%q", secret.Name, "SecretData")
+	}
+	if idx := slices.IndexFunc(secret.Fields, func(f *api.Field) bool { return f.Name == "etag" }); idx != -1 {
+		t.Errorf("expected etag field to be removed, got=%v", secret.Fields[idx])
+	}
+}
+
+func TestCreateModelTransformationsError(t *testing.T) {
+	cfg := &ModelConfig{
+		SpecificationFormat: config.SpecOpenAPI,
+		ServiceConfig:       secretManagerYamlFullPath,
+		SpecificationSource: openAPIFile,
+		Transformations: Transformations([]*config.Transform{
+			{ID: ".google.cloud.secretmanager.v1.Missing", Deprecate: true},
+		}),
+	}
+	if got, err := CreateModel(cfg); err == nil {
+		t.Errorf("expected error with unmatched transformation, got=%v", got)
+	}
+}
+
+func TestTransformations(t *testing.T) {
+	got := Transformations([]*config.Transform{
+		{ID: ".test.Secret", Rename: "SecretData"},
+		{ID: ".test.Secret.name", FieldBehavior: []string{"REQUIRED"}},
+		{ID: ".test.Service.ListSecrets", Paginate: &config.TransformPagination{ItemField: "secrets", PageToken: "token"}},
+	})
+	want := []api.Transformation{
+		{ID: ".test.Secret", Rename: "SecretData"},
+		{ID: ".test.Secret.name", FieldBehavior: []string{"REQUIRED"}},
+		{ID: ".test.Service.ListSecrets", Paginate: &api.PaginationTransformation{ItemField: "secrets", PageToken: "token"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -396,6 +396,12 @@ func makeAPIForProtobuf(serviceConfig *serviceconfig.Service, req *pluginpb.Code
 		result.Services = append(result.Services, fileServices...)
 	}
 
+	// Add the source locations, including the locations of elements in
+	// imported files.
+	for _, f := range req.GetProtoFile() {
+		addLocations(result, f)
+	}
+
 	// Add the mixin methods to the existing services.
 	for _, service := range result.Services {
 		for _, f := range mixinFileDesc {
@@ -620,6 +626,7 @@ func processMessage(model *api.API, m *descriptorpb.DescriptorProto, mFQN, packa
 		field := &api.Field{
 			Name:          mf.GetName(),
 			ID:            mFQN + "." + mf.GetName(),
+			Number:        mf.GetNumber(),
 			JSONName:      mf.GetJsonName(),
 			Deprecated:    mf.GetOptions().GetDeprecated(),
 			Optional:      isProtoOptional,
@@ -781,6 +788,76 @@ func processEnum(model *api.API, e *descriptorpb.EnumDescriptorProto, eFQN, pack
 	return enum
 }
 
+// addLocations sets the source location of the services, methods, messages,
+// fields, and enums defined in f.
+func addLocations(model *api.API, f *descriptorpb.FileDescriptorProto) {
+	fFQN := "." + f.GetPackage()
+	for _, loc := range f.GetSourceCodeInfo().GetLocation() {
+		p := loc.GetPath()
+		if len(p) < 2 {
+			continue
+		}
+		location := newSourceLocation(f.GetName(), loc.GetSpan())
+		switch p[0] {
+		case fileDescriptorMessageType:
+			m := f.MessageType[p[1]]
+			addMessageLocation(model, m, p[2:], location, fFQN+"."+m.GetName())
+		case fileDescriptorEnumType:
+			if e := model.Enum(fFQN + "." + f.EnumType[p[1]].GetName()); e != nil && len(p) == 2 {
+				e.Location = location
+			}
+		case fileDescriptorService:
+			s := f.Service[p[1]]
+			sFQN := fFQN + "." + s.GetName()
+			switch {
+			case len(p) == 2:
+				if service := model.Service(sFQN); service != nil {
+					service.Location = location
+				}
+			case len(p) == 4 && p[2] == serviceDescriptorProtoMethod:
+				if method := model.Method(sFQN + "." + s.Method[p[3]].GetName()); method != nil {
+					method.Location = location
+				}
+			}
+		}
+	}
+}
+
+func addMessageLocation(model *api.API, m *descriptorpb.DescriptorProto, p []int32, location *api.SourceLocation, mFQN string) {
+	message := model.Message(mFQN)
+	if message == nil {
+		return
+	}
+	switch {
+	case len(p) == 0:
+		message.Location = location
+	case p[0] == messageDescriptorNestedType && len(p) >= 2:
+		nmsg := m.GetNestedType()[p[1]]
+		addMessageLocation(model, nmsg, p[2:], location, mFQN+"."+nmsg.GetName())
+	case p[0] == messageDescriptorField && len(p) == 2:
+		message.Fields[p[1]].Location = location
+	case p[0] == messageDescriptorEnum && len(p) == 2:
+		if e := model.Enum(mFQN + "." + m.GetEnumType()[p[1]].GetName()); e != nil {
+			e.Location = location
+		}
+	}
+}
+
+// newSourceLocation converts a protobuf span, which has 0-based line numbers,
+// to a source location.
+func newSourceLocation(file string, span []int32) *api.SourceLocation {
+	location := &api.SourceLocation{File: file}
+	if len(span) < 3 {
+		return location
+	}
+	location.StartLine = int(span[0]) + 1
+	location.EndLine = location.StartLine
+	if len(span) == 4 {
+		location.EndLine = int(span[2]) + 1
+	}
+	return location
+}
+
 func addServiceDocumentation(model *api.API, p []int32, doc string, sFQN string) {
 	switch {
 	case len(p) == 0:
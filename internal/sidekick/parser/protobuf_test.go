@@ -73,6 +73,96 @@ func TestProtobuf_PartialInfo(t *testing.T) {
 	}
 }
 
+func TestProtobuf_FieldNumber(t *testing.T) {
+	requireProtoc(t)
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "scalar.proto"))
+	if err != nil {
+		t.Fatalf("Failed to make API for Protobuf %v", err)
+	}
+	message := test.Message(".test.Fake")
+	if message == nil {
+		t.Fatalf("Cannot find message %s in API State", ".test.Fake")
+	}
+	got := map[string]int32{}
+	for _, f := range message.Fields {
+		got[f.Name] = f.Number
+	}
+	want := map[string]int32{
+		"f_double":   1,
+		"f_float":    2,
+		"f_int64":    3,
+		"f_uint64":   4,
+		"f_int32":    5,
+		"f_fixed64":  6,
+		"f_fixed32":  7,
+		"f_bool":     8,
+		"f_string":   9,
+		"f_bytes":    12,
+		"f_uint32":   13,
+		"f_sfixed32": 15,
+		"f_sfixed64": 16,
+		"f_sint32":   17,
+		"f_sint64":   18,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProtobuf_Locations(t *testing.T) {
+	requireProtoc(t)
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "test_service.proto"))
+	if err != nil {
+		t.Fatalf("Failed to make API for Protobuf %v", err)
+	}
+	service := test.Service(".test.TestService")
+	if service == nil {
+		t.Fatalf("Cannot find service %s in API State", ".test.TestService")
+	}
+	message := test.Message(".test.Foo")
+	if message == nil {
+		t.Fatalf("Cannot find message %s in API State", ".test.Foo")
+	}
+	method := test.Method(".test.TestService.GetFoo")
+	if method == nil {
+		t.Fatalf("Cannot find method %s in API State", ".test.TestService.GetFoo")
+	}
+	for _, test := range []struct {
+		name string
+		got  *api.SourceLocation
+		want *api.SourceLocation
+	}{
+		{"service", service.Location, &api.SourceLocation{File: "test_service.proto", StartLine: 25, EndLine: 66}},
+		{"method", method.Location, &api.SourceLocation{File: "test_service.proto", StartLine: 31, EndLine: 36}},
+		{"message", message.Location, &api.SourceLocation{File: "test_service.proto", StartLine: 69, EndLine: 81}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, test.got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewSourceLocation(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		span []int32
+		want *api.SourceLocation
+	}{
+		{"single line", []int32{4, 2, 30}, &api.SourceLocation{File: "test.proto", StartLine: 5, EndLine: 5}},
+		{"multiple lines", []int32{4, 0, 9, 1}, &api.SourceLocation{File: "test.proto", StartLine: 5, EndLine: 10}},
+		{"invalid span", nil, &api.SourceLocation{File: "test.proto"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := newSourceLocation("test.proto", test.span)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestProtobuf_Scalar(t *testing.T) {
 	requireProtoc(t)
 	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "scalar.proto"))
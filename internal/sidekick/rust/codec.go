@@ -54,6 +54,14 @@ var commentUrlRegex = regexp.MustCompile(
 		`(/[-a-zA-Z0-9@:%_\+.~#?&/={}\$]*)?`) // Accept just about anything on the query and URL fragments
 
 func newCodec(specificationFormat string, options map[string]string) (*codec, error) {
+	opts, err := ParseCodecOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	return newCodecFromOptions(specificationFormat, opts), nil
+}
+
+func newCodecFromOptions(specificationFormat string, opts *libconfig.RustCodec) *codec {
 	var sysParams []systemParameter
 	if specificationFormat == libconfig.SpecProtobuf {
 		sysParams = append(sysParams, systemParameter{
@@ -76,131 +84,263 @@ func newCodec(specificationFormat string, options map[string]string) (*codec, er
 		systemParameters:        sysParams,
 		serializeEnumsAsStrings: specificationFormat != libconfig.SpecProtobuf,
 		bytesUseUrlSafeAlphabet: specificationFormat == libconfig.SpecDiscovery,
+
+		packageNameOverride:       opts.PackageNameOverride,
+		nameOverrides:             opts.NameOverrides,
+		doNotPublish:              opts.NotForPublication,
+		disabledRustdocWarnings:   opts.DisabledRustdocWarnings,
+		disabledClippyWarnings:    opts.DisabledClippyWarnings,
+		templateOverride:          opts.TemplateOverride,
+		templateOverlay:           opts.TemplateOverlay,
+		includeGrpcOnlyMethods:    opts.IncludeGrpcOnlyMethods,
+		includeStreamingMethods:   opts.IncludeStreamingMethods,
+		perServiceFeatures:        opts.PerServiceFeatures,
+		defaultFeatures:           opts.DefaultFeatures,
+		detailedTracingAttributes: opts.DetailedTracingAttributes,
+		lroStubOptions:            opts.LroStubOptions,
+		hasVeneer:                 opts.HasVeneer,
+		extraModules:              opts.ExtraModules,
+		internalTypes:             opts.InternalTypes,
+		routingRequired:           opts.RoutingRequired,
+		extendGrpcTransport:       opts.ExtendGrpcTransport,
+		generateSetterSamples:     opts.GenerateSetterSamples,
+		generateRpcSamples:        opts.GenerateRpcSamples,
+		internalBuilders:          opts.InternalBuilders,
+		quickstartServiceOverride: opts.QuickstartServiceOverride,
+	}
+	if opts.ModulePath != "" {
+		codec.modulePath = opts.ModulePath
+	}
+	if opts.CopyrightYear != "" {
+		codec.generationYear = opts.CopyrightYear
+	}
+	if opts.Version != "" {
+		codec.version = opts.Version
+	}
+	if opts.ReleaseLevel != "" {
+		codec.releaseLevel = opts.ReleaseLevel
+	}
+	for _, p := range opts.Packages {
+		pkg := &packagez{
+			name:        p.Name,
+			ignore:      p.Ignore,
+			packageName: p.Package,
+			features:    p.Features,
+			used:        p.ForceUsed,
+			usedIf:      p.UsedIf,
+		}
+		codec.extraPackages = append(codec.extraPackages, pkg)
+		for _, source := range p.Sources {
+			codec.packageMapping[source] = pkg
+		}
 	}
+	return codec
+}
 
+// ParseCodecOptions converts the string map used by older configurations to
+// the typed options of the Rust codec.
+//
+// It returns an error for unknown keys and malformed values.
+func ParseCodecOptions(options map[string]string) (*libconfig.RustCodec, error) {
+	opts := &libconfig.RustCodec{}
+	parseBool := func(key, definition string) (bool, error) {
+		value, err := strconv.ParseBool(definition)
+		if err != nil {
+			return false, fmt.Errorf("cannot convert `%s` value %q to boolean: %w", key, definition, err)
+		}
+		return value, nil
+	}
 	for key, definition := range options {
+		var err error
 		switch {
 		case key == "package-name-override":
-			codec.packageNameOverride = definition
+			opts.PackageNameOverride = definition
 		case key == "name-overrides":
-			codec.nameOverrides = make(map[string]string)
+			opts.NameOverrides = make(map[string]string)
 			for _, override := range strings.Split(definition, ",") {
 				tokens := strings.Split(override, "=")
 				if len(tokens) != 2 {
 					return nil, fmt.Errorf("cannot parse `name-overrides`. Expected input in the form of: 'n1=r1,n2=r2': %q", definition)
 				}
-				codec.nameOverrides[tokens[0]] = tokens[1]
+				opts.NameOverrides[tokens[0]] = tokens[1]
 			}
 		case key == "module-path":
-			codec.modulePath = definition
+			opts.ModulePath = definition
 		case key == "copyright-year":
-			codec.generationYear = definition
+			opts.CopyrightYear = definition
 		case key == "not-for-publication":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `not-for-publication` value %q to boolean: %w", definition, err)
-			}
-			codec.doNotPublish = value
+			opts.NotForPublication, err = parseBool(key, definition)
 		case key == "version":
-			codec.version = definition
+			opts.Version = definition
 		case key == "release-level":
-			codec.releaseLevel = definition
+			opts.ReleaseLevel = definition
 		case strings.HasPrefix(key, "package:"):
-			pkgOption, err := parsePackageOption(key, definition)
+			pkg, err := parsePackageOption(key, definition)
 			if err != nil {
 				return nil, err
 			}
-			codec.extraPackages = append(codec.extraPackages, pkgOption.pkg)
-			for _, source := range pkgOption.otherNames {
-				codec.packageMapping[source] = pkgOption.pkg
-			}
+			opts.Packages = append(opts.Packages, pkg)
 		case key == "disabled-rustdoc-warnings":
-			codec.disabledRustdocWarnings = splitOption(definition)
+			opts.DisabledRustdocWarnings = splitOption(definition)
 		case key == "disabled-clippy-warnings":
-			codec.disabledClippyWarnings = splitOption(definition)
+			opts.DisabledClippyWarnings = splitOption(definition)
 		case key == "template-override":
-			codec.templateOverride = definition
+			opts.TemplateOverride = definition
+		case key == "template-overlay":
+			opts.TemplateOverlay = definition
 		case key == "include-grpc-only-methods":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `include-grpc-only-methods` value %q to boolean: %w", definition, err)
-			}
-			codec.includeGrpcOnlyMethods = value
+			opts.IncludeGrpcOnlyMethods, err = parseBool(key, definition)
 		case key == "include-streaming-methods":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `include-streaming-methods` value %q to boolean: %w", definition, err)
-			}
-			codec.includeStreamingMethods = value
+			opts.IncludeStreamingMethods, err = parseBool(key, definition)
 		case key == "per-service-features":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `per-service-features` value %q to boolean: %w", definition, err)
-			}
-			codec.perServiceFeatures = value
+			opts.PerServiceFeatures, err = parseBool(key, definition)
 		case key == "default-features":
-			codec.defaultFeatures = splitOption(definition)
+			opts.DefaultFeatures = splitOption(definition)
 		case key == "detailed-tracing-attributes":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `detailed-tracing-attributes` value %q to boolean: %w", definition, err)
-			}
-			codec.detailedTracingAttributes = value
+			opts.DetailedTracingAttributes, err = parseBool(key, definition)
 		case key == "lro-stub-options":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `lro-stub-options` value %q to boolean: %w", definition, err)
-			}
-			codec.lroStubOptions = value
+			opts.LroStubOptions, err = parseBool(key, definition)
 		case key == "has-veneer":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `has-veneer` value %q to boolean: %w", definition, err)
-			}
-			codec.hasVeneer = value
+			opts.HasVeneer, err = parseBool(key, definition)
 		case key == "extra-modules":
-			codec.extraModules = splitOption(definition)
+			opts.ExtraModules = splitOption(definition)
 		case key == "internal-types":
-			codec.internalTypes = splitOption(definition)
+			opts.InternalTypes = splitOption(definition)
 		case key == "routing-required":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `routing-required` value %q to boolean: %w", definition, err)
-			}
-			codec.routingRequired = value
+			opts.RoutingRequired, err = parseBool(key, definition)
 		case key == "extend-grpc-transport":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `extend-grpc-transport` value %q to boolean: %w", definition, err)
-			}
-			codec.extendGrpcTransport = value
+			opts.ExtendGrpcTransport, err = parseBool(key, definition)
 		case key == "generate-setter-samples":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `generate-setter-samples` value %q to boolean: %w", definition, err)
-			}
-			codec.generateSetterSamples = value
+			opts.GenerateSetterSamples, err = parseBool(key, definition)
 		case key == "generate-rpc-samples":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `generate-rpc-samples` value %q to boolean: %w", definition, err)
-			}
-			codec.generateRpcSamples = value
+			opts.GenerateRpcSamples, err = parseBool(key, definition)
 		case key == "internal-builders":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `internal-builders` value %q to boolean: %w", definition, err)
-			}
-			codec.internalBuilders = value
+			opts.InternalBuilders, err = parseBool(key, definition)
 		case key == "quickstart-service-override":
-			codec.quickstartServiceOverride = definition
+			opts.QuickstartServiceOverride = definition
 		default:
 			return nil, fmt.Errorf("unknown Rust codec option %q", key)
 		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	slices.SortFunc(opts.Packages, func(a, b *libconfig.RustCodecPackage) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return opts, nil
+}
+
+// FormatCodecOptions converts the typed options of the Rust codec to the
+// string map consumed by the codec. It is the inverse of
+// [ParseCodecOptions], and omits the options with zero values.
+func FormatCodecOptions(opts *libconfig.RustCodec) map[string]string {
+	options := map[string]string{}
+	setString := func(key, value string) {
+		if value != "" {
+			options[key] = value
+		}
+	}
+	setBool := func(key string, value bool) {
+		if value {
+			options[key] = "true"
+		}
+	}
+	setList := func(key string, values []string) {
+		if len(values) > 0 {
+			options[key] = strings.Join(values, ",")
+		}
 	}
-	return codec, nil
+	setString("package-name-override", opts.PackageNameOverride)
+	if len(opts.NameOverrides) > 0 {
+		var overrides []string
+		for _, id := range slices.Sorted(maps.Keys(opts.NameOverrides)) {
+			overrides = append(overrides, id+"="+opts.NameOverrides[id])
+		}
+		options["name-overrides"] = strings.Join(overrides, ",")
+	}
+	setString("module-path", opts.ModulePath)
+	setString("copyright-year", opts.CopyrightYear)
+	setBool("not-for-publication", opts.NotForPublication)
+	setString("version", opts.Version)
+	setString("release-level", opts.ReleaseLevel)
+	for _, pkg := range opts.Packages {
+		options["package:"+pkg.Name] = formatPackageOption(pkg)
+	}
+	setList("disabled-rustdoc-warnings", opts.DisabledRustdocWarnings)
+	setList("disabled-clippy-warnings", opts.DisabledClippyWarnings)
+	setString("template-override", opts.TemplateOverride)
+	setString("template-overlay", opts.TemplateOverlay)
+	setBool("include-grpc-only-methods", opts.IncludeGrpcOnlyMethods)
+	setBool("include-streaming-methods", opts.IncludeStreamingMethods)
+	setBool("per-service-features", opts.PerServiceFeatures)
+	setList("default-features", opts.DefaultFeatures)
+	setBool("detailed-tracing-attributes", opts.DetailedTracingAttributes)
+	setBool("lro-stub-options", opts.LroStubOptions)
+	setBool("has-veneer", opts.HasVeneer)
+	setList("extra-modules", opts.ExtraModules)
+	setList("internal-types", opts.InternalTypes)
+	setBool("routing-required", opts.RoutingRequired)
+	setBool("extend-grpc-transport", opts.ExtendGrpcTransport)
+	setBool("generate-setter-samples", opts.GenerateSetterSamples)
+	setBool("generate-rpc-samples", opts.GenerateRpcSamples)
+	setBool("internal-builders", opts.InternalBuilders)
+	setString("quickstart-service-override", opts.QuickstartServiceOverride)
+	return options
+}
+
+func formatPackageOption(pkg *libconfig.RustCodecPackage) string {
+	var parts []string
+	if pkg.Package != "" {
+		parts = append(parts, "package="+pkg.Package)
+	}
+	for _, source := range pkg.Sources {
+		parts = append(parts, "source="+source)
+	}
+	for _, feature := range pkg.Features {
+		parts = append(parts, "feature="+feature)
+	}
+	if pkg.Ignore {
+		parts = append(parts, "ignore=true")
+	}
+	if pkg.ForceUsed {
+		parts = append(parts, "force-used=true")
+	}
+	for _, usedIf := range pkg.UsedIf {
+		parts = append(parts, "used-if="+usedIf)
+	}
+	return strings.Join(parts, ",")
+}
+
+// ValidateCodecOptions returns an error if the options cannot be used to
+// generate code, such as a package without a name or an unknown template
+// directory.
+func ValidateCodecOptions(opts *libconfig.RustCodec) error {
+	for _, pkg := range opts.Packages {
+		if pkg.Name == "" {
+			return fmt.Errorf("missing name for rust package %q", pkg.Package)
+		}
+		if !pkg.Ignore && pkg.Package == "" {
+			return fmt.Errorf("missing rust package name for package %s", pkg.Name)
+		}
+		for _, usedIf := range pkg.UsedIf {
+			if !slices.Contains(usedIfConditions, usedIf) {
+				return fmt.Errorf("unknown used-if condition %q for rust package %s, want one of %v", usedIf, pkg.Name, usedIfConditions)
+			}
+		}
+	}
+	if opts.TemplateOverride != "" {
+		if _, _, err := language.Templates(templates, opts.TemplateOverride, ""); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
+// usedIfConditions are the conditions recognized by the `used-if` field of a
+// package.
+var usedIfConditions = []string{"autopopulated", "lro", "services"}
+
 func splitOption(definition string) []string {
 	if definition == "" {
 		return []string{}
@@ -208,15 +348,9 @@ func splitOption(definition string) []string {
 	return strings.Split(definition, ",")
 }
 
-type packageOption struct {
-	pkg        *packagez
-	otherNames []string
-}
-
-func parsePackageOption(key, definition string) (*packageOption, error) {
-	var specificationPackages []string
-	pkg := &packagez{
-		name: strings.TrimPrefix(key, "package:"),
+func parsePackageOption(key, definition string) (*libconfig.RustCodecPackage, error) {
+	pkg := &libconfig.RustCodecPackage{
+		Name: strings.TrimPrefix(key, "package:"),
 	}
 	for _, element := range strings.Split(definition, ",") {
 		s := strings.SplitN(element, "=", 2)
@@ -225,33 +359,33 @@ func parsePackageOption(key, definition string) (*packageOption, error) {
 		}
 		switch s[0] {
 		case "package":
-			pkg.packageName = s[1]
+			pkg.Package = s[1]
 		case "source":
-			specificationPackages = append(specificationPackages, s[1])
+			pkg.Sources = append(pkg.Sources, s[1])
 		case "feature":
-			pkg.features = append(pkg.features, s[1])
+			pkg.Features = append(pkg.Features, s[1])
 		case "ignore":
 			value, err := strconv.ParseBool(s[1])
 			if err != nil {
 				return nil, fmt.Errorf("cannot convert `ignore` value %q (part of %q) to boolean: %w", definition, s[1], err)
 			}
-			pkg.ignore = value
+			pkg.Ignore = value
 		case "force-used":
 			value, err := strconv.ParseBool(s[1])
 			if err != nil {
 				return nil, fmt.Errorf("cannot convert `force-used` value %q (part of %q) to boolean: %w", definition, s[1], err)
 			}
-			pkg.used = value
+			pkg.ForceUsed = value
 		case "used-if":
-			pkg.usedIf = append(pkg.usedIf, s[1])
+			pkg.UsedIf = append(pkg.UsedIf, s[1])
 		default:
 			return nil, fmt.Errorf("unknown field %q in definition of rust package %q, got=%q", s[0], key, definition)
 		}
 	}
-	if !pkg.ignore && pkg.packageName == "" {
+	if !pkg.Ignore && pkg.Package == "" {
 		return nil, fmt.Errorf("missing rust package name for package %s, got=%s", key, definition)
 	}
-	return &packageOption{pkg: pkg, otherNames: specificationPackages}, nil
+	return pkg, nil
 }
 
 type codec struct {
@@ -301,6 +435,8 @@ type codec struct {
 	bytesUseUrlSafeAlphabet bool
 	// Overrides the template subdirectory.
 	templateOverride string
+	// A directory with templates that replace the embedded templates.
+	templateOverlay string
 	// If true, this includes gRPC-only methods, such as methods without HTTP
 	// annotations.
 	includeGrpcOnlyMethods bool
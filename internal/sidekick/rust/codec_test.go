@@ -375,6 +375,124 @@ func TestParseOptions(t *testing.T) {
 	}
 }
 
+func TestParseCodecOptions(t *testing.T) {
+	got, err := ParseCodecOptions(map[string]string{
+		"package-name-override":   "google-cloud-storage",
+		"name-overrides":          ".google.test.Service=Rename",
+		"release-level":           "stable",
+		"generate-setter-samples": "true",
+		"default-features":        "a,b",
+		"package:wkt":             "package=google-cloud-wkt,source=google.protobuf,source=google.type",
+		"package:gax":             "package=google-cloud-gax,used-if=services,feature=unstable",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &libconfig.RustCodec{
+		PackageNameOverride:   "google-cloud-storage",
+		NameOverrides:         map[string]string{".google.test.Service": "Rename"},
+		ReleaseLevel:          "stable",
+		GenerateSetterSamples: true,
+		DefaultFeatures:       []string{"a", "b"},
+		Packages: []*libconfig.RustCodecPackage{
+			{
+				Name:     "gax",
+				Package:  "google-cloud-gax",
+				Features: []string{"unstable"},
+				UsedIf:   []string{"services"},
+			},
+			{
+				Name:    "wkt",
+				Package: "google-cloud-wkt",
+				Sources: []string{"google.protobuf", "google.type"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormatCodecOptions(t *testing.T) {
+	opts := &libconfig.RustCodec{
+		PackageNameOverride:     "google-cloud-storage",
+		NameOverrides:           map[string]string{".google.test.Service": "Rename", ".google.test.Other": "Another"},
+		CopyrightYear:           "2025",
+		ReleaseLevel:            "stable",
+		DisabledClippyWarnings:  []string{"doc_lazy_continuation"},
+		GenerateSetterSamples:   true,
+		IncludeStreamingMethods: true,
+		Packages: []*libconfig.RustCodecPackage{
+			{
+				Name:      "gax",
+				Package:   "google-cloud-gax",
+				Features:  []string{"unstable"},
+				ForceUsed: true,
+				UsedIf:    []string{"services"},
+			},
+			{Name: "wkt", Ignore: true, Sources: []string{"google.protobuf"}},
+		},
+	}
+	got, err := ParseCodecOptions(FormatCodecOptions(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(opts, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if got := FormatCodecOptions(&libconfig.RustCodec{}); len(got) != 0 {
+		t.Errorf("FormatCodecOptions() = %v, want no options", got)
+	}
+}
+
+func TestValidateCodecOptions(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		opts    *libconfig.RustCodec
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			opts: &libconfig.RustCodec{
+				TemplateOverride: "templates/grpc-client",
+				Packages: []*libconfig.RustCodecPackage{
+					{Name: "gax", Package: "google-cloud-gax", UsedIf: []string{"services", "lro"}},
+					{Name: "wkt", Ignore: true},
+				},
+			},
+		},
+		{
+			name:    "package without name",
+			opts:    &libconfig.RustCodec{Packages: []*libconfig.RustCodecPackage{{Package: "google-cloud-gax"}}},
+			wantErr: true,
+		},
+		{
+			name:    "package without package",
+			opts:    &libconfig.RustCodec{Packages: []*libconfig.RustCodecPackage{{Name: "gax"}}},
+			wantErr: true,
+		},
+		{
+			name: "unknown used-if",
+			opts: &libconfig.RustCodec{Packages: []*libconfig.RustCodecPackage{
+				{Name: "gax", Package: "google-cloud-gax", UsedIf: []string{"always"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown template override",
+			opts:    &libconfig.RustCodec{TemplateOverride: "templates/missing"},
+			wantErr: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateCodecOptions(test.opts)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("ValidateCodecOptions() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
 func TestParseOptionsErrors(t *testing.T) {
 	for i, test := range []struct {
 		Options map[string]string
@@ -539,14 +657,22 @@ func TestGeneratedFiles(t *testing.T) {
 	c := codec{
 		templateOverride: "templates/mod",
 	}
-	files := c.generatedFiles(false)
+	generatedFiles := func(hasServices bool) []language.GeneratedFile {
+		t.Helper()
+		_, files, err := c.templates(c.templateRoot(hasServices))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return files
+	}
+	files := generatedFiles(false)
 	if len(files) == 0 {
 		t.Errorf("expected a non-empty list of template files from generatedFiles(true, false)")
 	}
 	// No crate for module-only files
 	unexpectedGeneratedFile(t, "Cargo.toml", files)
 
-	files = c.generatedFiles(true)
+	files = generatedFiles(true)
 	if len(files) == 0 {
 		t.Errorf("expected a non-empty list of template files from generatedFiles(true, true)")
 	}
@@ -554,7 +680,7 @@ func TestGeneratedFiles(t *testing.T) {
 	unexpectedGeneratedFile(t, "Cargo.toml", files)
 
 	c.templateOverride = ""
-	files = c.generatedFiles(false)
+	files = generatedFiles(false)
 	if len(files) == 0 {
 		t.Errorf("expected a non-empty list of template files from generatedFiles(false, false)")
 	}
@@ -563,7 +689,7 @@ func TestGeneratedFiles(t *testing.T) {
 	// Should not have a client if there are no services.
 	unexpectedGeneratedFile(t, "client.rs", files)
 
-	files = c.generatedFiles(true)
+	files = generatedFiles(true)
 	if len(files) == 0 {
 		t.Errorf("expected a non-empty list of template files from generatedFiles(false, false)")
 	}
@@ -572,6 +698,13 @@ func TestGeneratedFiles(t *testing.T) {
 	expectGeneratedFile(t, "client.rs", files)
 }
 
+func TestTemplates_UnknownOverride(t *testing.T) {
+	c := codec{templateOverride: "templates/missing"}
+	if _, _, err := c.templates(c.templateRoot(true)); err == nil {
+		t.Error("templates() error = nil, want error")
+	}
+}
+
 func expectGeneratedFile(t *testing.T, name string, files []language.GeneratedFile) {
 	t.Helper()
 	for _, g := range files {
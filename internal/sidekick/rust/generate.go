@@ -17,7 +17,6 @@ package rust
 import (
 	"context"
 	"embed"
-	"path/filepath"
 
 	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/language"
@@ -37,8 +36,10 @@ func Generate(ctx context.Context, model *api.API, outdir string, cfg *parser.Mo
 	if err != nil {
 		return err
 	}
-	provider := templatesProvider()
-	generatedFiles := c.generatedFiles(annotations.HasServices())
+	provider, generatedFiles, err := c.templates(c.templateRoot(annotations.HasServices()))
+	if err != nil {
+		return err
+	}
 	return language.GenerateFromModel(outdir, model, provider, generatedFiles)
 }
 
@@ -65,8 +66,10 @@ func GenerateStorage(ctx context.Context, outdir string, storageModel *api.API,
 			Control: controlModel,
 		},
 	}
-	provider := templatesProvider()
-	generatedFiles := language.WalkTemplatesDir(templates, "templates/storage")
+	provider, generatedFiles, err := storageCodec.templates("templates/storage")
+	if err != nil {
+		return err
+	}
 	return language.GenerateFromModel(outdir, model, provider, generatedFiles)
 }
 
@@ -130,8 +133,10 @@ func GenerateBigQueryBuilder(ctx context.Context, outdir string, model *api.API,
 		},
 	}
 
-	provider := templatesProvider()
-	generatedFiles := language.WalkTemplatesDir(templates, "templates/bigquery")
+	provider, generatedFiles, err := c.templates("templates/bigquery")
+	if err != nil {
+		return err
+	}
 	return language.GenerateFromModel(outdir, model, provider, generatedFiles)
 }
 
@@ -144,26 +149,27 @@ type bigQueryAnnotations struct {
 	QueryMetadataMsg    *api.Message
 }
 
-func templatesProvider() language.TemplateProvider {
-	return func(name string) (string, error) {
-		contents, err := templates.ReadFile(filepath.ToSlash(name))
-		if err != nil {
-			return "", err
-		}
-		return string(contents), nil
-	}
+// OverriddenTemplates returns the templates replaced by the overlay directory.
+func OverriddenTemplates(overlay string) ([]string, error) {
+	return language.OverriddenTemplates(templates, overlay)
 }
 
-func (c *codec) generatedFiles(hasServices bool) []language.GeneratedFile {
-	if c.templateOverride != "" {
-		return language.WalkTemplatesDir(templates, c.templateOverride)
-	}
-	var root string
+// templates returns the provider and the files to generate for the templates
+// in root, with the templates in the overlay directory replacing the embedded
+// ones.
+func (c *codec) templates(root string) (language.TemplateProvider, []language.GeneratedFile, error) {
+	return language.Templates(templates, root, c.templateOverlay)
+}
+
+// templateRoot returns the template directory used to generate a crate. The
+// template override, if any, replaces the default directory.
+func (c *codec) templateRoot(hasServices bool) string {
 	switch {
+	case c.templateOverride != "":
+		return c.templateOverride
 	case !hasServices:
-		root = "templates/nosvc"
+		return "templates/nosvc"
 	default:
-		root = "templates/crate"
+		return "templates/crate"
 	}
-	return language.WalkTemplatesDir(templates, root)
 }
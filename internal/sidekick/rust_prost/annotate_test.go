@@ -39,7 +39,10 @@ func TestModelAnnotations(t *testing.T) {
 	model := api.NewTestAPI(
 		[]*api.Message{}, []*api.Enum{},
 		[]*api.Service{{Name: "Workflows", Package: "google.cloud.workflows.v1"}})
-	codec := newCodec(cfg)
+	codec, err := newCodec(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if err := codec.annotateModel(model, cfg); err != nil {
 		t.Fatal(err)
 	}
@@ -76,7 +79,10 @@ func TestServiceAnnotations(t *testing.T) {
 				ID:      ".google.cloud.workflows.v1.Workflows",
 			},
 		})
-	codec := newCodec(cfg)
+	codec, err := newCodec(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if err := codec.annotateModel(model, cfg); err != nil {
 		t.Fatal(err)
 	}
@@ -118,7 +124,10 @@ func TestMethodAnnotations(t *testing.T) {
 				},
 			},
 		})
-	codec := newCodec(cfg)
+	codec, err := newCodec(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if err := codec.annotateModel(model, cfg); err != nil {
 		t.Fatal(err)
 	}
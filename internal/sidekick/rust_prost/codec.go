@@ -19,6 +19,7 @@ import (
 	"strings"
 	"time"
 
+	libconfig "github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/sidekick/parser"
 )
 
@@ -33,26 +34,60 @@ type codec struct {
 	RootName string
 }
 
-func newCodec(cfg *parser.ModelConfig) *codec {
+func newCodec(cfg *parser.ModelConfig) (*codec, error) {
 	year, _, _ := time.Now().Date()
 	result := &codec{
 		GenerationYear: fmt.Sprintf("%04d", year),
 		PackageName:    "",
 		RootName:       "googleapis",
 	}
-	for key, definition := range cfg.Codec {
+	opts, err := ParseCodecOptions(cfg.Codec)
+	if err != nil {
+		return nil, err
+	}
+	if opts.CopyrightYear != "" {
+		result.GenerationYear = opts.CopyrightYear
+	}
+	result.PackageName = opts.PackageNameOverride
+	if opts.PostProcessProtos != "" {
+		result.PostProcessProtos = strings.Split(opts.PostProcessProtos, "\n")
+	}
+	if opts.RootName != "" {
+		result.RootName = opts.RootName
+	}
+	return result, nil
+}
+
+// ParseCodecOptions converts the string map used by older configurations to
+// the typed options of the rust_prost codec.
+//
+// It returns an error for unknown keys.
+func ParseCodecOptions(options map[string]string) (*libconfig.RustProstCodec, error) {
+	opts := &libconfig.RustProstCodec{}
+	for key, definition := range options {
 		switch key {
 		case "copyright-year":
-			result.GenerationYear = definition
+			opts.CopyrightYear = definition
 		case "package-name-override":
-			result.PackageName = definition
+			opts.PackageNameOverride = definition
 		case "post-process-protos":
-			result.PostProcessProtos = strings.Split(definition, "\n")
+			opts.PostProcessProtos = definition
 		case "root-name":
-			result.RootName = definition
+			opts.RootName = definition
 		default:
-			// Ignore other options.
+			return nil, fmt.Errorf("unknown rust_prost codec option %q", key)
 		}
 	}
-	return result
+	return opts, nil
+}
+
+// ValidateCodecOptions returns an error if the options cannot be used to
+// generate code, such as an unknown source root.
+func ValidateCodecOptions(opts *libconfig.RustProstCodec) error {
+	switch opts.RootName {
+	case "", "conformance", "discovery", "googleapis", "protobuf-src", "showcase":
+		return nil
+	default:
+		return fmt.Errorf("unknown source root %q", opts.RootName)
+	}
 }
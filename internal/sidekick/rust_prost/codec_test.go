@@ -36,7 +36,10 @@ func TestParseOptions(t *testing.T) {
 			"root-name":             "test-root",
 		},
 	}
-	got := newCodec(cfg)
+	got, err := newCodec(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
 	want := &codec{
 		GenerationYear: "2038",
 		PackageName:    "google-cloud-bigtable",
@@ -46,3 +49,52 @@ func TestParseOptions(t *testing.T) {
 		t.Errorf("mismatch in codec (-want, +got)\n:%s", diff)
 	}
 }
+
+func TestNewCodec_UnknownOption(t *testing.T) {
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{"generate-setter-samples": "true"},
+	}
+	if got, err := newCodec(cfg); err == nil {
+		t.Errorf("newCodec() = %v, want an error", got)
+	}
+}
+
+func TestParseCodecOptions(t *testing.T) {
+	got, err := ParseCodecOptions(map[string]string{
+		"copyright-year":        "2038",
+		"package-name-override": "google-cloud-wkt",
+		"post-process-protos":   "line1\nline2",
+		"root-name":             "protobuf-src",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &libconfig.RustProstCodec{
+		CopyrightYear:       "2038",
+		PackageNameOverride: "google-cloud-wkt",
+		PostProcessProtos:   "line1\nline2",
+		RootName:            "protobuf-src",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateCodecOptions(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		opts    *libconfig.RustProstCodec
+		wantErr bool
+	}{
+		{name: "default root", opts: &libconfig.RustProstCodec{}},
+		{name: "known root", opts: &libconfig.RustProstCodec{RootName: "conformance"}},
+		{name: "unknown root", opts: &libconfig.RustProstCodec{RootName: "test-root"}, wantErr: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateCodecOptions(test.opts)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("ValidateCodecOptions() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -45,7 +45,10 @@ func Generate(ctx context.Context, model *api.API, outdir string, template strin
 		return fmt.Errorf("got an error trying to run `protoc --version`, the instructions on https://grpc.io/docs/protoc-installation/ may solve this problem: %w", err)
 	}
 
-	codec := newCodec(cfg)
+	codec, err := newCodec(cfg)
+	if err != nil {
+		return err
+	}
 	codec.annotateModel(model, cfg)
 	provider := templatesProvider()
 	generatedFiles := language.WalkTemplatesDir(templates, "templates/"+template)
@@ -34,12 +34,38 @@ type methodAnnotations struct {
 	Pagination     *paginationAnnotations
 	LRO            *lroAnnotations
 	ReturnType     string
+	// The parameters for the `x-goog-request-params` header.
+	RoutingParameters []*routingParameter
 }
 
 type paginationAnnotations struct {
 	ItemType string
 }
 
+// routingParameter describes a single key in the `x-goog-request-params`
+// header.
+//
+// Implicit parameters use the full value of a path variable, as described in
+// [AIP-4222](https://google.aip.dev/client-libraries/4222). Explicit
+// parameters come from the `google.api.routing` annotation, and use the first
+// variant that matches.
+type routingParameter struct {
+	Name string
+	// Implicit is true if the parameter comes from the path template.
+	Implicit bool
+	// For implicit parameters, the expression to access the field in the
+	// request.
+	Expression string
+	Variants   []*routingVariant
+}
+
+// routingVariant is a field, and the regular expression its value must match,
+// for an explicit routing parameter.
+type routingVariant struct {
+	Expression string
+	Pattern    string
+}
+
 type lroAnnotations struct {
 	ReturnType      string
 	MetadataType    string
@@ -76,6 +102,12 @@ func (ann *methodAnnotations) HasQueryParams() bool {
 	return len(ann.QueryParams) != 0
 }
 
+// HasRouting returns true if the method sends the `x-goog-request-params`
+// header.
+func (ann *methodAnnotations) HasRouting() bool {
+	return len(ann.RoutingParameters) != 0
+}
+
 // PlainRPC returns true if the method is not a pagination or LRO.
 func (ann *methodAnnotations) PlainRPC() bool {
 	return ann.LRO == nil && ann.Pagination == nil
@@ -113,6 +145,10 @@ func (c *codec) annotateMethod(method *api.Method, modelAnn *modelAnnotations) e
 	if err != nil {
 		return err
 	}
+	routingParameters, err := c.routingParameters(method)
+	if err != nil {
+		return err
+	}
 	var pagination *paginationAnnotations
 	if method.Pagination != nil && method.OutputType != nil && method.OutputType.Pagination != nil {
 		itemField := method.OutputType.Pagination.PageableItem
@@ -158,7 +194,7 @@ func (c *codec) annotateMethod(method *api.Method, modelAnn *modelAnnotations) e
 			ResponseIsEmpty: responseIsEmpty,
 		}
 	}
-	method.Codec = &methodAnnotations{
+	annotations := &methodAnnotations{
 		Name:           camelCase(method.Name),
 		DocLines:       docLines,
 		PathExpression: pathExpression(binding.PathTemplate),
@@ -171,13 +207,39 @@ func (c *codec) annotateMethod(method *api.Method, modelAnn *modelAnnotations) e
 		Pagination:     pagination,
 		LRO:            lro,
 		ReturnType:     returnType,
+
+		RoutingParameters: routingParameters,
 	}
+	method.Codec = annotations
 	if method.SampleInfo != nil {
 		c.annotateSampleInfo(method)
 	}
 	return nil
 }
 
+func (c *codec) routingParameters(method *api.Method) ([]*routingParameter, error) {
+	var params []*routingParameter
+	for _, p := range language.RoutingParameters(method) {
+		param := &routingParameter{Name: p.Name, Implicit: p.Implicit}
+		for _, v := range p.Variants {
+			variable, err := c.newPathVariable(method.InputType, api.NewPathVariable(v.FieldPath...), 0)
+			if err != nil {
+				return nil, err
+			}
+			if p.Implicit {
+				param.Expression = variable.Expression
+				continue
+			}
+			param.Variants = append(param.Variants, &routingVariant{
+				Expression: variable.Expression,
+				Pattern:    v.Pattern,
+			})
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
 func (a *methodAnnotations) Idempotent() bool {
 	return a.HTTPMethod == "GET" || a.HTTPMethod == "PUT"
 }
@@ -34,6 +34,8 @@ type serviceAnnotations struct {
 	Model            *modelAnnotations
 	DependsOn        map[string]*Dependency
 	IsGated          bool
+	// Server-side streaming methods, using the REST/JSON stream framing.
+	ServerStreamingMethods []*api.Method
 }
 
 // ServiceImports returns the list of dependencies for this service.
@@ -46,6 +48,15 @@ func (ann *serviceAnnotations) ServiceImports() []string {
 	return result
 }
 
+// HasRouting returns true if any method sends the `x-goog-request-params`
+// header.
+func (ann *serviceAnnotations) HasRouting() bool {
+	return slices.ContainsFunc(slices.Concat(ann.RestMethods, ann.ServerStreamingMethods), func(m *api.Method) bool {
+		codec, ok := m.Codec.(*methodAnnotations)
+		return ok && codec.HasRouting()
+	})
+}
+
 // SnippetImports returns the sorted list of dependencies for this service's
 // snippets.
 //
@@ -71,13 +82,21 @@ func (c *codec) annotateService(service *api.Service, model *modelAnnotations) e
 	if err != nil {
 		return err
 	}
-	var restMethods []*api.Method
+	// Client-side and bidirectional streaming methods need a gRPC transport.
+	// `GoogleCloudGax` only has a REST transport, so these methods are skipped
+	// and there is no option to enable them.
+	var restMethods, serverStreamingMethods []*api.Method
 	for _, method := range service.Methods {
-		if isGeneratedMethod(method) {
-			if err := c.annotateMethod(method, model); err != nil {
-				return err
-			}
+		switch {
+		case isGeneratedMethod(method):
 			restMethods = append(restMethods, method)
+		case isServerStreamingMethod(method):
+			serverStreamingMethods = append(serverStreamingMethods, method)
+		default:
+			continue
+		}
+		if err := c.annotateMethod(method, model); err != nil {
+			return err
 		}
 	}
 	var quickstartMethod *api.Method
@@ -97,6 +116,8 @@ func (c *codec) annotateService(service *api.Service, model *modelAnnotations) e
 		Model:            model,
 		DependsOn:        map[string]*Dependency{},
 		IsGated:          c.PerServiceTraits,
+
+		ServerStreamingMethods: serverStreamingMethods,
 	}
 
 	// Iterate through the list of all dependencies declared in librarian.yaml
@@ -121,7 +142,7 @@ func (c *codec) annotateService(service *api.Service, model *modelAnnotations) e
 	}
 	annotations.DependsOn[wktDep.Name] = wktDep
 
-	for _, method := range restMethods {
+	for _, method := range slices.Concat(restMethods, serverStreamingMethods) {
 		if method.InputType != nil {
 			if method.InputType.Package != c.Model.PackageName {
 				dep, err := c.addApiPackageDependency(method.InputType.Package)
@@ -197,7 +218,18 @@ func (c *codec) annotateService(service *api.Service, model *modelAnnotations) e
 	return c.addFeatureAnnotations(service)
 }
 
+// isGeneratedMethod returns true for unary methods with REST bindings.
 func isGeneratedMethod(method *api.Method) bool {
+	return hasBindings(method) && !method.ClientSideStreaming && !method.ServerSideStreaming
+}
+
+// isServerStreamingMethod returns true for server-side streaming methods with
+// REST bindings.
+func isServerStreamingMethod(method *api.Method) bool {
+	return hasBindings(method) && method.ServerSideStreaming && !method.ClientSideStreaming
+}
+
+func hasBindings(method *api.Method) bool {
 	return method.PathInfo != nil && len(method.PathInfo.Bindings) != 0
 }
 
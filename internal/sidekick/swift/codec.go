@@ -67,6 +67,9 @@ type codec struct {
 	// gapic-showcase, or a different root.
 	RootName string
 
+	// A directory with templates that replace the embedded templates.
+	TemplateOverlay string
+
 	// Modules have a different directory structure.
 	Module bool
 
@@ -145,24 +148,21 @@ func newCodec(model *api.API, cfg *parser.ModelConfig, swiftCfg *config.SwiftPac
 		result.PerServiceTraits = swiftCfg.PerServiceTraits
 		result.DefaultTraits = swiftCfg.DefaultTraits
 	}
-	for key, definition := range cfg.Codec {
-		switch key {
-		case "copyright-year":
-			result.GenerationYear = definition
-		case "package-name-override":
-			result.PackageName = definition
-		case "root-name":
-			result.RootName = definition
-		case "module":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `module` value %q to boolean: %w", definition, err)
-			}
-			result.Module = value
-		default:
-			// Ignore other options.
-		}
+	opts, err := ParseCodecOptions(cfg.Codec)
+	if err != nil {
+		return nil, err
+	}
+	if opts.CopyrightYear != "" {
+		result.GenerationYear = opts.CopyrightYear
 	}
+	if opts.PackageNameOverride != "" {
+		result.PackageName = opts.PackageNameOverride
+	}
+	if opts.RootName != "" {
+		result.RootName = opts.RootName
+	}
+	result.TemplateOverlay = opts.TemplateOverlay
+	result.Module = opts.Module
 	return result, nil
 }
 
@@ -197,3 +197,66 @@ func (c *codec) addDependency(dep *Dependency) (*Dependency, error) {
 	}
 	return dep, nil
 }
+
+// ParseCodecOptions converts the string map used by older configurations to
+// the typed options of the Swift codec.
+//
+// It returns an error for unknown keys and malformed values.
+func ParseCodecOptions(options map[string]string) (*config.SwiftCodec, error) {
+	opts := &config.SwiftCodec{}
+	for key, definition := range options {
+		switch key {
+		case "copyright-year":
+			opts.CopyrightYear = definition
+		case "version":
+			opts.Version = definition
+		case "package-name-override":
+			opts.PackageNameOverride = definition
+		case "root-name":
+			opts.RootName = definition
+		case "template-overlay":
+			opts.TemplateOverlay = definition
+		case "module":
+			value, err := strconv.ParseBool(definition)
+			if err != nil {
+				return nil, fmt.Errorf("cannot convert `module` value %q to boolean: %w", definition, err)
+			}
+			opts.Module = value
+		default:
+			return nil, fmt.Errorf("unknown Swift codec option %q", key)
+		}
+	}
+	return opts, nil
+}
+
+// FormatCodecOptions converts the typed options of the Swift codec to the
+// string map consumed by the codec. It is the inverse of
+// [ParseCodecOptions], and omits the options with zero values.
+func FormatCodecOptions(opts *config.SwiftCodec) map[string]string {
+	options := map[string]string{}
+	for key, value := range map[string]string{
+		"copyright-year":        opts.CopyrightYear,
+		"version":               opts.Version,
+		"package-name-override": opts.PackageNameOverride,
+		"root-name":             opts.RootName,
+		"template-overlay":      opts.TemplateOverlay,
+	} {
+		if value != "" {
+			options[key] = value
+		}
+	}
+	if opts.Module {
+		options["module"] = "true"
+	}
+	return options
+}
+
+// ValidateCodecOptions returns an error if the options cannot be used to
+// generate code, such as a template overlay with templates unknown to the
+// codec.
+func ValidateCodecOptions(opts *config.SwiftCodec) error {
+	if _, err := OverriddenTemplates(opts.TemplateOverlay); err != nil {
+		return err
+	}
+	return nil
+}
@@ -116,6 +116,79 @@ func TestNewCodec_WithSwiftCfg(t *testing.T) {
 	}
 }
 
+func TestNewCodec_UnknownOption(t *testing.T) {
+	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{})
+	cfg := &parser.ModelConfig{Codec: map[string]string{"root-nam": "googleapis"}}
+	if got, err := newCodec(model, cfg, nil, "."); err == nil {
+		t.Errorf("newCodec() = %v, want an error", got)
+	}
+}
+
+func TestParseCodecOptions(t *testing.T) {
+	got, err := ParseCodecOptions(map[string]string{
+		"copyright-year":        "2038",
+		"version":               "1.2.3",
+		"package-name-override": "GoogleCloudBigtable",
+		"root-name":             "test-root",
+		"template-overlay":      "overlays/swift",
+		"module":                "true",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &config.SwiftCodec{
+		CopyrightYear:       "2038",
+		Version:             "1.2.3",
+		PackageNameOverride: "GoogleCloudBigtable",
+		RootName:            "test-root",
+		TemplateOverlay:     "overlays/swift",
+		Module:              true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, mustParseCodecOptions(t, FormatCodecOptions(want))); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+	if got := FormatCodecOptions(&config.SwiftCodec{}); len(got) != 0 {
+		t.Errorf("FormatCodecOptions() = %v, want no options", got)
+	}
+}
+
+func TestParseCodecOptions_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		options map[string]string
+	}{
+		{name: "unknown key", options: map[string]string{"generate-setter-samples": "true"}},
+		{name: "invalid boolean", options: map[string]string{"module": "yes"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ParseCodecOptions(test.options); err == nil {
+				t.Errorf("ParseCodecOptions(%v) should fail", test.options)
+			}
+		})
+	}
+}
+
+func TestValidateCodecOptions(t *testing.T) {
+	if err := ValidateCodecOptions(&config.SwiftCodec{RootName: "googleapis"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateCodecOptions(&config.SwiftCodec{TemplateOverlay: "testdata/missing"}); err == nil {
+		t.Errorf("ValidateCodecOptions() should fail for a missing template overlay")
+	}
+}
+
+func mustParseCodecOptions(t *testing.T, options map[string]string) *config.SwiftCodec {
+	t.Helper()
+	opts, err := ParseCodecOptions(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return opts
+}
+
 // newTestCodec creates a simple codec for the tests.
 func newTestCodec(t *testing.T, model *api.API, options map[string]string) *codec {
 	t.Helper()
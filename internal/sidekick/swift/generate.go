@@ -40,12 +40,9 @@ func Generate(ctx context.Context, model *api.API, outdir string, cfg *parser.Mo
 	if err := codec.annotateModel(); err != nil {
 		return err
 	}
-	provider := func(name string) (string, error) {
-		contents, err := templates.ReadFile(name)
-		if err != nil {
-			return "", err
-		}
-		return string(contents), nil
+	provider, err := language.OverlayProvider(templates, codec.TemplateOverlay)
+	if err != nil {
+		return err
 	}
 	if err := codec.generateMessages(outdir, model, provider); err != nil {
 		return err
@@ -73,6 +70,11 @@ func Generate(ctx context.Context, model *api.API, outdir string, cfg *parser.Mo
 	return language.GenerateFromModel(outdir, model, provider, generatedFiles)
 }
 
+// OverriddenTemplates returns the templates replaced by the overlay directory.
+func OverriddenTemplates(overlay string) ([]string, error) {
+	return language.OverriddenTemplates(templates, overlay)
+}
+
 func (c *codec) swiftFilename(basename string) string {
 	name := basename + ".swift"
 	key := strings.ToLower(basename)
@@ -151,6 +153,7 @@ func (c *codec) generateStubs(outdir string, model *api.API, provider language.T
 			{suffix: "+Stub", template: "templates/common/stub.swift.mustache"},
 			{suffix: "+Logging", template: "templates/common/logging.swift.mustache"},
 			{suffix: "+Retry", template: "templates/common/retry.swift.mustache"},
+			{suffix: "+Fake", template: "templates/common/fake.swift.mustache"},
 		} {
 			generated := language.GeneratedFile{
 				TemplatePath: stub.template,
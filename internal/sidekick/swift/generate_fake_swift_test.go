@@ -0,0 +1,188 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swift
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+)
+
+func TestGenerateFake_Unary(t *testing.T) {
+	outDir := t.TempDir()
+	request := &api.Message{Name: "Request", ID: ".test.Request", Package: "test"}
+	response := &api.Message{Name: "Response", ID: ".test.Response", Package: "test"}
+	binding := func(verb string) *api.PathInfo {
+		return &api.PathInfo{
+			Bindings: []*api.PathBinding{{Verb: verb, PathTemplate: (&api.PathTemplate{}).WithLiteral("v1")}},
+		}
+	}
+	service := &api.Service{
+		Name:    "Service",
+		ID:      ".test.Service",
+		Package: "test",
+		Methods: []*api.Method{
+			{
+				Name:         "GetThing",
+				ID:           ".test.Service.GetThing",
+				InputTypeID:  request.ID,
+				InputType:    request,
+				OutputTypeID: response.ID,
+				OutputType:   response,
+				PathInfo:     binding("GET"),
+			},
+			{
+				Name:         "DeleteThing",
+				ID:           ".test.Service.DeleteThing",
+				InputTypeID:  request.ID,
+				InputType:    request,
+				OutputTypeID: response.ID,
+				OutputType:   response,
+				ReturnsEmpty: true,
+				PathInfo:     binding("DELETE"),
+			},
+		},
+	}
+	model := api.NewTestAPI([]*api.Message{request, response}, nil, []*api.Service{service})
+	model.PackageName = "test"
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"copyright-year": "2038",
+		},
+	}
+	if err := Generate(t.Context(), model, outDir, cfg, swiftConfig(t, nil)); err != nil {
+		t.Fatal(err)
+	}
+	fake := readGenerated(t, outDir, "Sources", "GoogleTest", "Service+Fake.swift")
+
+	got := extractBlock(t, fake, "  public class FakeService: ", "\n")
+	want := "  public class FakeService: Service {\n"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	got = extractBlock(t, fake, "      self.getThingRequests.append(request)", "\n    }")
+	want = `      self.getThingRequests.append(request)
+      if !self.getThingResults.isEmpty {
+        return try self.getThingResults.removeFirst().get()
+      }
+      guard let handler = self.getThingHandler else {
+        throw GoogleCloudGax.RequestError.unimplemented
+      }
+      return try await handler(request)
+    }`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, want := range []string{
+		"public var deleteThingResults: [Result<Void, Swift.Error>] = []",
+		"public var deleteThingHandler: ((Request) async throws -> Void)?",
+		"        try self.deleteThingResults.removeFirst().get()\n        return\n",
+		"      try await handler(request)\n",
+	} {
+		if !strings.Contains(fake, want) {
+			t.Errorf("missing %q in generated fake:\n%s", want, fake)
+		}
+	}
+}
+
+func TestGenerateFake_LRO(t *testing.T) {
+	outDir := t.TempDir()
+	operationType := &api.Message{Name: "Operation", Package: "google.longrunning", ID: ".google.longrunning.Operation"}
+	workflowType := &api.Message{Name: "Workflow", Package: "google.cloud.workflows.v1", ID: ".google.cloud.workflows.v1.Workflow"}
+	metadataType := &api.Message{Name: "OperationMetadata", Package: "google.cloud.workflows.v1", ID: ".google.cloud.workflows.v1.OperationMetadata"}
+	inputType := &api.Message{Name: "CreateWorkflowRequest", Package: "google.cloud.workflows.v1", ID: ".google.cloud.workflows.v1.CreateWorkflowRequest"}
+	workflows := &api.Service{
+		Name: "WorkflowsService",
+		Methods: []*api.Method{
+			{
+				Name:         "CreateWorkflow",
+				InputTypeID:  inputType.ID,
+				InputType:    inputType,
+				OutputTypeID: operationType.ID,
+				OutputType:   operationType,
+				PathInfo: &api.PathInfo{
+					Bindings: []*api.PathBinding{{
+						Verb:         "POST",
+						PathTemplate: (&api.PathTemplate{}).WithLiteral("v1").WithLiteral("workflows"),
+					}},
+				},
+				IsLRO: true,
+				OperationInfo: &api.OperationInfo{
+					ResponseTypeID: workflowType.ID,
+					MetadataTypeID: metadataType.ID,
+				},
+			},
+		},
+	}
+	model := api.NewTestAPI([]*api.Message{inputType, workflowType, metadataType, operationType}, nil, []*api.Service{workflows})
+	model.PackageName = "google.cloud.workflows.v1"
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"copyright-year": "2038",
+		},
+	}
+	swiftCfg := swiftConfig(t, []config.SwiftDependency{
+		{Name: "GoogleCloudGax", RequiredByServices: true},
+		{ApiPackage: "google.longrunning", Name: "GoogleCloudLongrunningV1"},
+		{ApiPackage: "google.rpc", Name: "GoogleRpc"},
+	})
+	if err := Generate(t.Context(), model, outDir, cfg, swiftCfg); err != nil {
+		t.Fatal(err)
+	}
+	fake := readGenerated(t, outDir, "Sources", "GoogleCloudWorkflowsV1", "WorkflowsService+Fake.swift")
+
+	got := extractBlock(t, fake, "      self.createWorkflowRequests.append(withPolling)", "\n    }")
+	want := `      self.createWorkflowRequests.append(withPolling)
+      guard !self.createWorkflowOutcomes.isEmpty else {
+        throw GoogleCloudGax.RequestError.unimplemented
+      }
+      let outcome = self.createWorkflowOutcomes.removeFirst()
+      let poll = { () async throws -> GoogleCloudGax._PollableOperationImpl<Workflow>.State in
+        .init(done: true, result: outcome)
+      }
+      return GoogleCloudGax._PollableOperationImpl(initialState: .init(done: true, result: outcome), poll: poll)
+    }`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	wantOutcomes := "public var createWorkflowOutcomes: [Result<Workflow, Swift.Error>] = []"
+	if !strings.Contains(fake, wantOutcomes) {
+		t.Errorf("missing %q in generated fake:\n%s", wantOutcomes, fake)
+	}
+}
+
+func TestGenerateFake_ServerStreaming(t *testing.T) {
+	outDir := generateStreaming(t)
+	fake := readGenerated(t, outDir, "Sources", "GoogleTest", "Service+Fake.swift")
+	got := extractBlock(t, fake, "        let responses = try self.listenResults", "\n      }\n")
+	want := `        let responses = try self.listenResults.removeFirst().get()
+        return AsyncThrowingStream { continuation in
+          for response in responses {
+            continuation.yield(response)
+          }
+          continuation.finish()
+        }
+      }
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
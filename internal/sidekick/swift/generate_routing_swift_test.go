@@ -0,0 +1,195 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swift
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+)
+
+func TestGenerateService_Routing(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		routing []*api.RoutingInfo
+		want    string
+	}{
+		{
+			name: "Implicit",
+			want: `      var routingParameters: [Swift.String] = []
+      if let value = request.name as Swift.String? {
+        routingParameters.append("name=\(routingValue("\(value)"))")
+      }
+      if !routingParameters.isEmpty {
+        req.setValue(routingParameters.joined(separator: "&"), forHTTPHeaderField: "x-goog-request-params")
+      }`,
+		},
+		{
+			name: "Explicit",
+			routing: []*api.RoutingInfo{
+				{
+					Name: "table_name",
+					Variants: []*api.RoutingInfoVariant{
+						{
+							FieldPath: []string{"name"},
+							Prefix:    api.RoutingPathSpec{Segments: []string{"projects", "*"}},
+							Matching:  api.RoutingPathSpec{Segments: []string{"instances", "*", "tables", "*"}},
+						},
+					},
+				},
+				{
+					Name: "app_profile_id",
+					Variants: []*api.RoutingInfoVariant{
+						{
+							FieldPath: []string{"profile", "id"},
+							Matching:  api.RoutingPathSpec{Segments: []string{"**"}},
+						},
+						{
+							FieldPath: []string{"name"},
+							Matching:  api.RoutingPathSpec{Segments: []string{"profiles", "*"}},
+							Suffix:    api.RoutingPathSpec{Segments: []string{"**"}},
+						},
+					},
+				},
+			},
+			want: `      var routingParameters: [Swift.String] = []
+      if let value = routingParameter([
+        (request.name as Swift.String?, #"^projects/[^/]+/(instances/[^/]+/tables/[^/]+)$"#),
+      ]) {
+        routingParameters.append("table_name=\(routingValue(value))")
+      }
+      if let value = routingParameter([
+        (request.profile.map({ $0.id }), #"^(.*)$"#),
+        (request.name as Swift.String?, #"^(profiles/[^/]+)/.*$"#),
+      ]) {
+        routingParameters.append("app_profile_id=\(routingValue(value))")
+      }
+      if !routingParameters.isEmpty {
+        req.setValue(routingParameters.joined(separator: "&"), forHTTPHeaderField: "x-goog-request-params")
+      }`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			outDir := t.TempDir()
+			model := routingTestModel(test.routing)
+			cfg := &parser.ModelConfig{
+				Codec: map[string]string{
+					"copyright-year": "2038",
+				},
+			}
+			if err := Generate(t.Context(), model, outDir, cfg, swiftConfig(t, []config.SwiftDependency{})); err != nil {
+				t.Fatal(err)
+			}
+
+			filename := filepath.Join(outDir, "Sources", "GoogleTest", "Service+Stub.swift")
+			content, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			contentStr := string(content)
+
+			got := extractBlock(t, contentStr, "      var routingParameters:", `forHTTPHeaderField: "x-goog-request-params")`+"\n      }")
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			for _, helper := range []string{"fileprivate func routingParameter(", "fileprivate func routingValue("} {
+				if !strings.Contains(contentStr, helper) {
+					t.Errorf("missing helper %q in generated stub", helper)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateService_RoutingDisabled(t *testing.T) {
+	outDir := t.TempDir()
+	// An empty routing annotation disables the implicit routing parameters.
+	model := routingTestModel([]*api.RoutingInfo{{}})
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"copyright-year": "2038",
+		},
+	}
+	if err := Generate(t.Context(), model, outDir, cfg, swiftConfig(t, []config.SwiftDependency{})); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join(outDir, "Sources", "GoogleTest", "Service+Stub.swift")
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, unexpected := range []string{"x-goog-request-params", "routingParameter", "routingValue"} {
+		if strings.Contains(string(content), unexpected) {
+			t.Errorf("unexpected %q in generated stub:\n%s", unexpected, content)
+		}
+	}
+}
+
+func routingTestModel(routing []*api.RoutingInfo) *api.API {
+	profile := &api.Message{
+		Name:    "Profile",
+		ID:      ".test.Profile",
+		Package: "test",
+		Fields: []*api.Field{
+			{Name: "id", JSONName: "id", ID: ".test.Profile.id", Typez: api.TypezString},
+		},
+	}
+	request := &api.Message{
+		Name:    "Request",
+		ID:      ".test.Request",
+		Package: "test",
+		Fields: []*api.Field{
+			{Name: "name", JSONName: "name", ID: ".test.Request.name", Typez: api.TypezString},
+			{Name: "profile", JSONName: "profile", ID: ".test.Request.profile", Typez: api.TypezMessage, TypezID: ".test.Profile", Optional: true},
+		},
+	}
+	response := &api.Message{
+		Name:    "Response",
+		ID:      ".test.Response",
+		Package: "test",
+	}
+	service := &api.Service{
+		Name:    "Service",
+		ID:      ".test.Service",
+		Package: "test",
+		Methods: []*api.Method{
+			{
+				Name:         "ReadRows",
+				ID:           ".test.Service.ReadRows",
+				InputTypeID:  ".test.Request",
+				InputType:    request,
+				OutputTypeID: ".test.Response",
+				OutputType:   response,
+				PathInfo: &api.PathInfo{
+					Bindings: []*api.PathBinding{{
+						Verb:         "GET",
+						PathTemplate: (&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("name"),
+					}},
+				},
+				Routing: routing,
+			},
+		},
+	}
+	model := api.NewTestAPI([]*api.Message{profile, request, response}, nil, []*api.Service{service})
+	model.PackageName = "test"
+	return model
+}
@@ -59,11 +59,13 @@ func TestGenerateService_Files(t *testing.T) {
 		"SecretManagerService+Stub.swift",
 		"SecretManagerService+Logging.swift",
 		"SecretManagerService+Retry.swift",
+		"SecretManagerService+Fake.swift",
 		"InstanceSettings.swift",
 		"instanceSettings+000.swift",
 		"instanceSettings+Stub.swift",
 		"instanceSettings+Logging.swift",
 		"instanceSettings+Retry.swift",
+		"instanceSettings+Fake.swift",
 	}
 	for _, expected := range wantFiles {
 		filename := filepath.Join(expectedDir, expected)
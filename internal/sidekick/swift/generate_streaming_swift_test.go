@@ -0,0 +1,165 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swift
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+)
+
+func TestGenerateService_ServerStreaming(t *testing.T) {
+	outDir := generateStreaming(t)
+	stub := readGenerated(t, outDir, "Sources", "GoogleTest", "Service+Stub.swift")
+	got := extractBlock(t, stub, "      // Over REST, the response body", "      }\n")
+	want := `      // Over REST, the response body is a JSON array with all the messages.
+      let (data, _) = try await self.inner.rpc(for: req).get()
+      let messages = try GoogleCloudWkt._ProtoJSONDecoder().decode(
+        [GoogleTest.Response].self, from: data)
+      return AsyncThrowingStream { continuation in
+        for message in messages {
+          continuation.yield(message)
+        }
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	service := readGenerated(t, outDir, "Sources", "GoogleTest", "Service.swift")
+	signature := "func listen(request: Request) async throws -> any AsyncSequence<GoogleTest.Response, Swift.Error>"
+	if !strings.Contains(service, signature) {
+		t.Errorf("missing %q in generated service:\n%s", signature, service)
+	}
+	// Streaming methods do not have snippets.
+	if _, err := os.Stat(filepath.Join(outDir, "Snippets", "Service_Listen.swift")); err == nil {
+		t.Errorf("unexpected snippet for server-side streaming method")
+	}
+}
+
+func TestGenerateService_StreamingSkipped(t *testing.T) {
+	outDir := generateStreaming(t)
+	for _, test := range []struct {
+		name   string
+		method string
+	}{
+		{name: "client-side streaming", method: "func upload("},
+		{name: "bidirectional streaming", method: "func chat("},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for _, file := range []string{"Service.swift", "Service+Stub.swift", "Service+Logging.swift", "Service+Retry.swift", "Service+Fake.swift"} {
+				content := readGenerated(t, outDir, "Sources", "GoogleTest", file)
+				if strings.Contains(content, test.method) {
+					t.Errorf("unexpected %q in %s", test.method, file)
+				}
+			}
+		})
+	}
+}
+
+func generateStreaming(t *testing.T) string {
+	t.Helper()
+	outDir := t.TempDir()
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"copyright-year": "2038",
+		},
+	}
+	if err := Generate(t.Context(), streamingTestModel(), outDir, cfg, swiftConfig(t, []config.SwiftDependency{})); err != nil {
+		t.Fatal(err)
+	}
+	return outDir
+}
+
+func readGenerated(t *testing.T, elem ...string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(elem...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func streamingTestModel() *api.API {
+	request := &api.Message{
+		Name:    "Request",
+		ID:      ".test.Request",
+		Package: "test",
+		Fields: []*api.Field{
+			{Name: "name", JSONName: "name", ID: ".test.Request.name", Typez: api.TypezString},
+		},
+	}
+	response := &api.Message{
+		Name:    "Response",
+		ID:      ".test.Response",
+		Package: "test",
+	}
+	service := &api.Service{
+		Name:        "Service",
+		ID:          ".test.Service",
+		Package:     "test",
+		DefaultHost: "test.googleapis.com",
+		Methods: []*api.Method{
+			{
+				Name:                "Listen",
+				ID:                  ".test.Service.Listen",
+				Documentation:       "Listens for changes.",
+				InputTypeID:         ".test.Request",
+				InputType:           request,
+				OutputTypeID:        ".test.Response",
+				OutputType:          response,
+				ServerSideStreaming: true,
+				PathInfo: &api.PathInfo{
+					Bindings: []*api.PathBinding{{
+						Verb:         "POST",
+						PathTemplate: (&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("name").WithVerb("listen"),
+					}},
+					BodyFieldPath: "*",
+				},
+			},
+			{
+				Name:                "Chat",
+				ID:                  ".test.Service.Chat",
+				Documentation:       "Chats in both directions.",
+				InputTypeID:         ".test.Request",
+				InputType:           request,
+				OutputTypeID:        ".test.Response",
+				OutputType:          response,
+				ClientSideStreaming: true,
+				ServerSideStreaming: true,
+				PathInfo:            &api.PathInfo{},
+			},
+			{
+				Name:                "Upload",
+				ID:                  ".test.Service.Upload",
+				Documentation:       "Uploads in one direction.",
+				InputTypeID:         ".test.Request",
+				InputType:           request,
+				OutputTypeID:        ".test.Response",
+				OutputType:          response,
+				ClientSideStreaming: true,
+				PathInfo:            &api.PathInfo{},
+			},
+		},
+	}
+	model := api.NewTestAPI([]*api.Message{request, response}, nil, []*api.Service{service})
+	model.PackageName = "test"
+	return model
+}
@@ -0,0 +1,636 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typescript
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/googleapis/librarian/internal/license"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/language"
+	"github.com/iancoleman/strcase"
+)
+
+// The version used for packages without a configured version.
+const defaultPackageVersion = "0.1.0"
+
+type modelAnnotations struct {
+	// The npm package name (e.g. google-cloud-secretmanager-v1).
+	PackageName string
+	// The version of the generated package.
+	PackageVersion string
+	CopyrightYear  string
+	BoilerPlate    []string
+	DocLines       []string
+	// DoNotPublish marks the package as `private` in package.json.
+	DoNotPublish bool
+	// The messages generated in the package, including nested messages and
+	// the messages from other packages used by this package.
+	Messages []*api.Message
+	// The enums generated in the package, including nested enums and the
+	// enums from other packages used by this package.
+	Enums []*api.Enum
+	// The services with at least one generated method.
+	Services []*api.Service
+	// The name of the first generated service, used in the README.md
+	// quickstart.
+	QuickstartService string
+}
+
+// HasServices returns true if the package contains any clients.
+func (m *modelAnnotations) HasServices() bool {
+	return len(m.Services) > 0
+}
+
+type serviceAnnotations struct {
+	// The service name using TypeScript naming conventions.
+	Name        string
+	DocLines    []string
+	DefaultHost string
+	// The methods that can be called using `fetch()`.
+	Methods []*api.Method
+}
+
+type messageAnnotations struct {
+	// The name of the TypeScript type, e.g. `Secret` or `GoogleRpc_Status`.
+	Name     string
+	DocLines []string
+	// The fields that are not part of a oneof.
+	Fields []*api.Field
+	// The oneofs, each oneof is a separate TypeScript type.
+	OneOfs []*api.OneOf
+}
+
+// HasOneOfs returns true if the message type is an intersection with the
+// types for its oneofs.
+func (m *messageAnnotations) HasOneOfs() bool {
+	return len(m.OneOfs) > 0
+}
+
+// OneOfTypes returns the intersection of the oneof types, e.g.
+// `Secret_ExpirationOneOf & Secret_RotationOneOf`.
+func (m *messageAnnotations) OneOfTypes() string {
+	var names []string
+	for _, o := range m.OneOfs {
+		names = append(names, o.Codec.(*oneOfAnnotations).Name)
+	}
+	return strings.Join(names, " & ")
+}
+
+type fieldAnnotations struct {
+	// The property name, quoted if needed.
+	Name     string
+	Type     string
+	DocLines []string
+}
+
+type oneOfAnnotations struct {
+	// The name of the TypeScript type, e.g. `Secret_ExpirationOneOf`.
+	Name     string
+	DocLines []string
+	// Each variant sets one of the fields in the oneof and none of the others,
+	// e.g. `{ expireTime?: string; ttl?: never }`.
+	Variants []string
+}
+
+type enumAnnotations struct {
+	// The name of the TypeScript type and constant, e.g. `Secret_State`.
+	Name     string
+	DocLines []string
+}
+
+type enumValueAnnotations struct {
+	Name     string
+	DocLines []string
+}
+
+type methodAnnotations struct {
+	// The method name using TypeScript naming conventions.
+	Name         string
+	DocLines     []string
+	RequestType  string
+	ResponseType string
+	// The HTTP verb, e.g. `GET`.
+	Verb string
+	// A TypeScript template literal for the request path, e.g.
+	// "`/v1/${runtime.pathParameter(request.name, 'name')}`".
+	Path string
+	// The properties of the query parameters object, e.g. `pageSize: request.pageSize`.
+	QueryParameters []string
+	// The expression for the request body, empty if the request has no body.
+	Body string
+	// The TypeScript expressions for each parameter in the
+	// `x-goog-request-params` header. Each expression has type
+	// `string | undefined`, undefined values are skipped.
+	RoutingParameters []string
+	// Pagination is set for methods that conform to AIP-4233.
+	Pagination *paginationAnnotations
+	// Operation is set for methods returning long-running operations, if the
+	// service can poll them.
+	Operation *operationAnnotations
+}
+
+// ReturnsEmpty returns true if the method resolves to `void`.
+func (m *methodAnnotations) ReturnsEmpty() bool {
+	return m.ResponseType == "void"
+}
+
+// HasQueryParameters returns true if the method has query parameters.
+func (m *methodAnnotations) HasQueryParameters() bool {
+	return len(m.QueryParameters) > 0
+}
+
+// HasRoutingParameters returns true if the method sends the
+// `x-goog-request-params` header.
+func (m *methodAnnotations) HasRoutingParameters() bool {
+	return len(m.RoutingParameters) > 0
+}
+
+// paginationAnnotations contains the information needed to generate the
+// pagination helpers of a method.
+type paginationAnnotations struct {
+	// The name of the helper returning the pages, e.g. `listSecretsPages`.
+	PagesName string
+	// The name of the helper returning the items, e.g. `listSecretsItems`.
+	ItemsName string
+	// The TypeScript type of the pageable items, e.g. `Secret` or
+	// `[string, Secret]` for map fields.
+	ItemType string
+	// The expression returning the items in a `page`, e.g.
+	// `page.secrets ?? []` or `Object.entries(page.items ?? {})`.
+	PageItems string
+	// The name of the page token property in the request.
+	PageToken string
+	// The name of the next page token property in the response.
+	NextPageToken string
+}
+
+// operationAnnotations contains the information needed to generate the helper
+// that waits for a long-running operation.
+type operationAnnotations struct {
+	// The name of the helper, e.g. `createSecretAndWait`.
+	WaitName string
+	// The TypeScript type of the operation result, `void` for
+	// `google.protobuf.Empty`.
+	ResultType string
+	// The name of the method used to poll the operation, e.g. `getOperation`.
+	GetOperation string
+}
+
+// ReturnsEmpty returns true if the operation result is `void`.
+func (o *operationAnnotations) ReturnsEmpty() bool {
+	return o.ResultType == "void"
+}
+
+type annotateModel struct {
+	// The API model we're annotating.
+	model *api.API
+	// The messages and enums from other packages referenced by this package,
+	// they are generated with a prefixed name.
+	externalMessages []*api.Message
+	externalEnums    []*api.Enum
+}
+
+func newAnnotateModel(model *api.API) *annotateModel {
+	return &annotateModel{model: model}
+}
+
+// annotateModel creates the structs used as input for the Mustache templates.
+func (annotate *annotateModel) annotateModel(options map[string]string) error {
+	year, _, _ := time.Now().Date()
+	var (
+		packageNameOverride string
+		copyrightYear       = fmt.Sprintf("%04d", year)
+		packageVersion      = defaultPackageVersion
+		doNotPublish        bool
+	)
+	for key, definition := range options {
+		switch key {
+		case "package-name-override":
+			packageNameOverride = definition
+		case "copyright-year":
+			copyrightYear = definition
+		case "version":
+			packageVersion = definition
+		case "not-for-publication":
+			value, err := strconv.ParseBool(definition)
+			if err != nil {
+				return fmt.Errorf("cannot convert `not-for-publication` value %q to boolean: %w", definition, err)
+			}
+			doNotPublish = value
+		default:
+			// Ignore other options, such as `template-overlay`.
+		}
+	}
+
+	model := annotate.model
+	var messages []*api.Message
+	var enums []*api.Enum
+	var walk func(m *api.Message)
+	walk = func(m *api.Message) {
+		if !m.IsMap && !m.ServicePlaceholder && wellKnownTypes[m.ID] == "" {
+			messages = append(messages, m)
+		}
+		enums = append(enums, m.Enums...)
+		for _, child := range m.Messages {
+			walk(child)
+		}
+	}
+	// Nested messages and enums are generated when walking their parent.
+	for _, e := range model.Enums {
+		if e.Parent == nil {
+			enums = append(enums, e)
+		}
+	}
+	for _, m := range model.Messages {
+		if m.Parent == nil {
+			walk(m)
+		}
+	}
+	for _, e := range enums {
+		annotate.annotateEnum(e)
+	}
+	for _, m := range messages {
+		annotate.annotateMessage(m)
+	}
+
+	var services []*api.Service
+	for _, s := range model.Services {
+		if annotate.annotateService(s) {
+			services = append(services, s)
+		}
+	}
+
+	// Annotating a message may reference more messages from other packages.
+	for i := 0; i < len(annotate.externalMessages); i++ {
+		annotate.annotateMessage(annotate.externalMessages[i])
+	}
+	// Some messages from other packages may be part of the model already.
+	external := slices.DeleteFunc(slices.Clone(annotate.externalMessages), func(m *api.Message) bool {
+		return slices.Contains(messages, m)
+	})
+	slices.SortFunc(external, func(a, b *api.Message) int {
+		return strings.Compare(a.Codec.(*messageAnnotations).Name, b.Codec.(*messageAnnotations).Name)
+	})
+	externalEnums := slices.DeleteFunc(slices.Clone(annotate.externalEnums), func(e *api.Enum) bool {
+		return slices.Contains(enums, e)
+	})
+	slices.SortFunc(externalEnums, func(a, b *api.Enum) int {
+		return strings.Compare(a.Codec.(*enumAnnotations).Name, b.Codec.(*enumAnnotations).Name)
+	})
+
+	var quickstart string
+	if len(services) > 0 {
+		quickstart = services[0].Codec.(*serviceAnnotations).Name
+	}
+	model.Codec = &modelAnnotations{
+		PackageName:    packageName(model, packageNameOverride),
+		PackageVersion: packageVersion,
+		CopyrightYear:  copyrightYear,
+		BoilerPlate: append(license.HeaderBulk(),
+			"",
+			" Code generated by sidekick. DO NOT EDIT."),
+		DocLines:          formatDocComments(model.Description, false),
+		DoNotPublish:      doNotPublish,
+		Messages:          append(messages, external...),
+		Enums:             append(enums, externalEnums...),
+		Services:          services,
+		QuickstartService: quickstart,
+	}
+	return nil
+}
+
+// annotateService annotates s and its methods. It returns false if the
+// service has no methods that can be called using `fetch()`.
+func (annotate *annotateModel) annotateService(s *api.Service) bool {
+	methods := language.FilterSlice(s.Methods, shouldGenerateMethod)
+	if len(methods) == 0 {
+		return false
+	}
+	getOperation := slices.IndexFunc(methods, func(m *api.Method) bool {
+		return m.Name == "GetOperation" && m.OutputTypeID == ".google.longrunning.Operation"
+	})
+	for _, m := range methods {
+		annotate.annotateMethod(m)
+		if getOperation != -1 && m.OperationInfo != nil {
+			m.Codec.(*methodAnnotations).Operation = annotate.operationAnnotation(m, methods[getOperation])
+		}
+	}
+	s.Codec = &serviceAnnotations{
+		Name:        localName(s.Name, nil),
+		DocLines:    formatDocComments(s.Documentation, s.Deprecated),
+		DefaultHost: s.DefaultHost,
+		Methods:     methods,
+	}
+	return true
+}
+
+func (annotate *annotateModel) annotateMessage(m *api.Message) {
+	if m.Codec != nil {
+		return
+	}
+	ann := &messageAnnotations{
+		Name:     annotate.messageName(m),
+		DocLines: formatDocComments(m.Documentation, m.Deprecated),
+	}
+	m.Codec = ann
+	for _, f := range m.Fields {
+		annotate.annotateField(f)
+		if !f.IsOneOf {
+			ann.Fields = append(ann.Fields, f)
+		}
+	}
+	for _, o := range m.OneOfs {
+		annotate.annotateOneOf(o, ann.Name)
+		ann.OneOfs = append(ann.OneOfs, o)
+	}
+}
+
+func (annotate *annotateModel) annotateField(f *api.Field) {
+	f.Codec = &fieldAnnotations{
+		Name:     propertyName(fieldName(f)),
+		Type:     annotate.fieldType(f),
+		DocLines: formatDocComments(f.Documentation, f.Deprecated),
+	}
+}
+
+// annotateOneOf annotates a oneof in the message named parent. The fields in
+// the oneof must be annotated first.
+func (annotate *annotateModel) annotateOneOf(o *api.OneOf, parent string) {
+	var variants []string
+	for _, selected := range o.Fields {
+		var properties []string
+		for _, f := range o.Fields {
+			codec := f.Codec.(*fieldAnnotations)
+			typ := "never"
+			if f == selected {
+				typ = codec.Type
+			}
+			properties = append(properties, fmt.Sprintf("%s?: %s", codec.Name, typ))
+		}
+		variants = append(variants, fmt.Sprintf("{ %s }", strings.Join(properties, "; ")))
+	}
+	o.Codec = &oneOfAnnotations{
+		// The suffix avoids conflicts with nested messages of the same name.
+		Name:     parent + "_" + strcase.ToCamel(o.Name) + "OneOf",
+		DocLines: formatDocComments(o.Documentation, false),
+		Variants: variants,
+	}
+}
+
+func (annotate *annotateModel) annotateEnum(e *api.Enum) {
+	if e.Codec != nil {
+		return
+	}
+	for _, v := range e.Values {
+		v.Codec = &enumValueAnnotations{
+			Name:     propertyName(v.Name),
+			DocLines: formatDocComments(v.Documentation, v.Deprecated),
+		}
+	}
+	e.Codec = &enumAnnotations{
+		Name:     annotate.enumName(e),
+		DocLines: formatDocComments(e.Documentation, e.Deprecated),
+	}
+}
+
+func (annotate *annotateModel) annotateMethod(m *api.Method) {
+	binding := m.PathInfo.Bindings[0]
+	var query []string
+	for _, f := range language.QueryParams(m, binding) {
+		name := fieldName(f)
+		query = append(query, fmt.Sprintf("%s: request%s", propertyName(name), propertyAccess(".", name)))
+	}
+	var body string
+	switch m.PathInfo.BodyFieldPath {
+	case "":
+	case "*":
+		body = "request"
+	default:
+		body = annotate.accessor(m.InputType, strings.Split(m.PathInfo.BodyFieldPath, "."))
+	}
+	responseType := "void"
+	if !m.ReturnsEmpty {
+		responseType = annotate.messageType(m.OutputType)
+	}
+	m.Codec = &methodAnnotations{
+		Name:              methodName(m),
+		DocLines:          formatDocComments(m.Documentation, m.Deprecated),
+		RequestType:       annotate.messageType(m.InputType),
+		ResponseType:      responseType,
+		Verb:              binding.Verb,
+		Path:              annotate.pathExpression(m.InputType, binding.PathTemplate),
+		QueryParameters:   query,
+		Body:              body,
+		RoutingParameters: annotate.routingParameters(m),
+		Pagination:        annotate.paginationAnnotation(m),
+	}
+}
+
+// pathExpression returns the TypeScript template literal for the path of a
+// request.
+func (annotate *annotateModel) pathExpression(request *api.Message, t *api.PathTemplate) string {
+	var builder strings.Builder
+	builder.WriteString("`")
+	for _, segment := range t.Segments {
+		builder.WriteString("/")
+		switch {
+		case segment.Literal != "":
+			builder.WriteString(segment.Literal)
+		case segment.Variable != nil:
+			fmt.Fprintf(&builder, "${runtime.pathParameter(%s, %s)}",
+				annotate.accessor(request, segment.Variable.FieldPath),
+				quote(strings.Join(segment.Variable.FieldPath, ".")))
+		}
+	}
+	if t.Verb != "" {
+		builder.WriteString(":")
+		builder.WriteString(t.Verb)
+	}
+	builder.WriteString("`")
+	return builder.String()
+}
+
+// routingParameters returns the TypeScript expressions used to build the
+// `x-goog-request-params` header of a method.
+func (annotate *annotateModel) routingParameters(m *api.Method) []string {
+	var params []string
+	for _, p := range language.RoutingParameters(m) {
+		if p.Implicit {
+			params = append(params, fmt.Sprintf("runtime.implicitRouting(%s, %s)",
+				quote(p.Name), annotate.accessor(m.InputType, p.Variants[0].FieldPath)))
+			continue
+		}
+		var variants []string
+		for _, v := range p.Variants {
+			variants = append(variants, fmt.Sprintf("[%s, new RegExp(%s)]",
+				annotate.accessor(m.InputType, v.FieldPath), quote(v.Pattern)))
+		}
+		params = append(params, fmt.Sprintf("runtime.explicitRouting(%s, [%s])",
+			quote(p.Name), strings.Join(variants, ", ")))
+	}
+	return params
+}
+
+// accessor returns the expression to access fieldPath in the request, e.g.
+// `request.appProfile?.id`.
+func (annotate *annotateModel) accessor(message *api.Message, fieldPath []string) string {
+	var builder strings.Builder
+	builder.WriteString("request")
+	sep := "."
+	for _, name := range fieldPath {
+		var field *api.Field
+		if message != nil {
+			if idx := slices.IndexFunc(message.Fields, func(f *api.Field) bool { return f.Name == name }); idx != -1 {
+				field = message.Fields[idx]
+			}
+		}
+		jsonName := name
+		if field != nil {
+			jsonName = fieldName(field)
+			message = field.MessageType
+		} else {
+			message = nil
+		}
+		builder.WriteString(propertyAccess(sep, jsonName))
+		sep = "?."
+	}
+	return builder.String()
+}
+
+// paginationAnnotation returns the annotations for the pagination helpers of
+// a method, or nil if the method is not paginated.
+func (annotate *annotateModel) paginationAnnotation(m *api.Method) *paginationAnnotations {
+	if m.Pagination == nil || m.OutputType == nil || m.OutputType.Pagination == nil {
+		return nil
+	}
+	info := m.OutputType.Pagination
+	item := info.PageableItem
+	itemName := fieldName(item)
+	var itemType, pageItems string
+	if item.Map {
+		entry := annotate.model.Message(item.TypezID)
+		if entry == nil || len(entry.Fields) != 2 {
+			return nil
+		}
+		itemType = fmt.Sprintf("[string, %s]", annotate.fieldType(entry.Fields[1]))
+		pageItems = fmt.Sprintf("Object.entries(page%s ?? {})", propertyAccess(".", itemName))
+	} else {
+		single := *item
+		single.Repeated = false
+		itemType = annotate.fieldType(&single)
+		pageItems = fmt.Sprintf("page%s ?? []", propertyAccess(".", itemName))
+	}
+	name := methodName(m)
+	return &paginationAnnotations{
+		PagesName:     name + "Pages",
+		ItemsName:     name + "Items",
+		ItemType:      itemType,
+		PageItems:     pageItems,
+		PageToken:     fieldName(m.Pagination),
+		NextPageToken: fieldName(info.NextPageToken),
+	}
+}
+
+// operationAnnotation returns the annotations for the helper waiting for the
+// long-running operation returned by m, polling with getOperation.
+func (annotate *annotateModel) operationAnnotation(m, getOperation *api.Method) *operationAnnotations {
+	resultType := "void"
+	if result := annotate.model.Message(m.OperationInfo.ResponseTypeID); result != nil && result.ID != ".google.protobuf.Empty" {
+		resultType = annotate.messageType(result)
+	}
+	return &operationAnnotations{
+		WaitName:     methodName(m) + "AndWait",
+		ResultType:   resultType,
+		GetOperation: methodName(getOperation),
+	}
+}
+
+// fieldType returns the TypeScript type of a field in the ProtoJSON
+// representation.
+func (annotate *annotateModel) fieldType(f *api.Field) string {
+	if f.Map {
+		entry := annotate.model.Message(f.TypezID)
+		if entry == nil || len(entry.Fields) != 2 {
+			return "Record<string, unknown>"
+		}
+		return fmt.Sprintf("Record<string, %s>", annotate.fieldType(entry.Fields[1]))
+	}
+	var typ string
+	switch f.Typez {
+	case api.TypezMessage:
+		typ = "unknown"
+		if m := annotate.model.Message(f.TypezID); m != nil {
+			typ = annotate.messageType(m)
+		}
+	case api.TypezEnum:
+		typ = "string"
+		if e := annotate.model.Enum(f.TypezID); e != nil {
+			typ = annotate.enumType(e)
+		}
+	default:
+		typ = scalarType(f.Typez)
+	}
+	if f.Repeated {
+		return typ + "[]"
+	}
+	return typ
+}
+
+// messageType returns the TypeScript type for a message, mapping the
+// well-known types to their ProtoJSON representation.
+func (annotate *annotateModel) messageType(m *api.Message) string {
+	if wkt, ok := wellKnownTypes[m.ID]; ok {
+		return wkt
+	}
+	return annotate.messageName(m)
+}
+
+// enumType returns the TypeScript type for an enum.
+func (annotate *annotateModel) enumType(e *api.Enum) string {
+	if wkt, ok := wellKnownEnums[e.ID]; ok {
+		return wkt
+	}
+	return annotate.enumName(e)
+}
+
+// messageName returns the name of the TypeScript type for a message. Messages
+// from other packages are added to the list of external messages.
+func (annotate *annotateModel) messageName(m *api.Message) string {
+	if m.Package == annotate.model.PackageName {
+		return localName(m.Name, m.Parent)
+	}
+	if !slices.Contains(annotate.externalMessages, m) {
+		annotate.externalMessages = append(annotate.externalMessages, m)
+	}
+	return externalName(m.Package, m.Name, m.Parent)
+}
+
+// enumName returns the name of the TypeScript type for an enum. Enums from
+// other packages are annotated and added to the list of external enums.
+func (annotate *annotateModel) enumName(e *api.Enum) string {
+	if e.Package == annotate.model.PackageName {
+		return localName(e.Name, e.Parent)
+	}
+	if !slices.Contains(annotate.externalEnums, e) {
+		annotate.externalEnums = append(annotate.externalEnums, e)
+		annotate.annotateEnum(e)
+	}
+	return externalName(e.Package, e.Name, e.Parent)
+}
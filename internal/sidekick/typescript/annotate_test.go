@@ -0,0 +1,529 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typescript
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func annotateTestModel(t *testing.T, model *api.API, options map[string]string) {
+	t.Helper()
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	api.UpdateMethodPagination(nil, model)
+	if err := newAnnotateModel(model).annotateModel(options); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func messageField(name, typezID string) *api.Field {
+	f := api.NewTestField(name).WithType(api.TypezMessage)
+	f.TypezID = typezID
+	return f
+}
+
+func enumField(name, typezID string) *api.Field {
+	f := api.NewTestField(name).WithType(api.TypezEnum)
+	f.TypezID = typezID
+	return f
+}
+
+func TestAnnotateModel_Options(t *testing.T) {
+	message := api.NewTestMessage("Secret")
+	model := api.NewTestAPI([]*api.Message{message}, []*api.Enum{}, []*api.Service{})
+	model.PackageName = "google.cloud.secretmanager.v1"
+	annotateTestModel(t, model, map[string]string{
+		"copyright-year":        "2025",
+		"version":               "1.2.3",
+		"package-name-override": "@example/secretmanager",
+		"not-for-publication":   "true",
+		"template-overlay":      "ignored",
+	})
+	got := model.Codec.(*modelAnnotations)
+	if got.CopyrightYear != "2025" {
+		t.Errorf("CopyrightYear = %q, want %q", got.CopyrightYear, "2025")
+	}
+	if got.PackageVersion != "1.2.3" {
+		t.Errorf("PackageVersion = %q, want %q", got.PackageVersion, "1.2.3")
+	}
+	if got.PackageName != "@example/secretmanager" {
+		t.Errorf("PackageName = %q, want %q", got.PackageName, "@example/secretmanager")
+	}
+	if !got.DoNotPublish {
+		t.Errorf("DoNotPublish = false, want true")
+	}
+}
+
+func TestAnnotateModel_Defaults(t *testing.T) {
+	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{})
+	model.PackageName = "google.cloud.secretmanager.v1"
+	annotateTestModel(t, model, nil)
+	got := model.Codec.(*modelAnnotations)
+	if got.PackageName != "google-cloud-secretmanager-v1" {
+		t.Errorf("PackageName = %q, want %q", got.PackageName, "google-cloud-secretmanager-v1")
+	}
+	if got.PackageVersion != defaultPackageVersion {
+		t.Errorf("PackageVersion = %q, want %q", got.PackageVersion, defaultPackageVersion)
+	}
+	if got.CopyrightYear == "" {
+		t.Errorf("expected a default CopyrightYear")
+	}
+	if got.HasServices() {
+		t.Errorf("HasServices() = true, want false")
+	}
+}
+
+func TestAnnotateModel_BadOption(t *testing.T) {
+	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{})
+	if err := newAnnotateModel(model).annotateModel(map[string]string{"not-for-publication": "maybe"}); err == nil {
+		t.Errorf("expected an error for an invalid boolean option")
+	}
+}
+
+func TestAnnotateMessage(t *testing.T) {
+	state := &api.Enum{
+		Name:    "State",
+		ID:      ".test.Secret.State",
+		Package: "test",
+		Values: []*api.EnumValue{
+			{Name: "STATE_UNSPECIFIED", Documentation: "Unspecified."},
+			{Name: "ENABLED"},
+		},
+	}
+	status := api.NewTestMessage("Status").WithPackage("google.rpc").WithFields(
+		api.NewTestField("code").WithType(api.TypezInt32),
+		messageField("details", ".google.protobuf.Any").WithRepeated(),
+	)
+	labels := &api.Message{
+		Name:    "LabelsEntry",
+		ID:      ".test.Secret.LabelsEntry",
+		Package: "test",
+		IsMap:   true,
+		Fields: []*api.Field{
+			api.NewTestField("key").WithType(api.TypezString),
+			api.NewTestField("value").WithType(api.TypezString),
+		},
+	}
+	replication := api.NewTestMessage("Replication").WithID(".test.Secret.Replication")
+	expireTime := messageField("expire_time", ".google.protobuf.Timestamp")
+	expireTime.IsOneOf = true
+	ttl := messageField("ttl", ".google.protobuf.Duration")
+	ttl.IsOneOf = true
+	secret := api.NewTestMessage("Secret").WithFields(
+		api.NewTestField("name").WithType(api.TypezString),
+		api.NewTestField("version_count").WithType(api.TypezInt64),
+		messageField("labels", ".test.Secret.LabelsEntry").WithMap(),
+		messageField("replication", ".test.Secret.Replication"),
+		enumField("state", ".test.Secret.State"),
+		messageField("error", ".google.rpc.Status"),
+		api.NewTestField("aliases").WithType(api.TypezString).WithRepeated(),
+		expireTime,
+		ttl,
+	)
+	secret.Documentation = "A secret."
+	secret.OneOfs = []*api.OneOf{{Name: "expiration", Fields: []*api.Field{expireTime, ttl}}}
+	model := api.NewTestAPI([]*api.Message{secret, labels, replication, status}, []*api.Enum{state}, []*api.Service{})
+	model.PackageName = "test"
+	annotateTestModel(t, model, nil)
+
+	got := secret.Codec.(*messageAnnotations)
+	if got.Name != "Secret" {
+		t.Errorf("Name = %q, want %q", got.Name, "Secret")
+	}
+	if diff := cmp.Diff([]string{"/**", " * A secret.", " */"}, got.DocLines); diff != "" {
+		t.Errorf("DocLines mismatch (-want, +got):\n%s", diff)
+	}
+	wantTypes := map[string]string{
+		"name":          "string",
+		"version_count": "string",
+		"labels":        "Record<string, string>",
+		"replication":   "Secret_Replication",
+		"state":         "Secret_State",
+		"error":         "GoogleRpc_Status",
+		"aliases":       "string[]",
+		"expire_time":   "string",
+		"ttl":           "string",
+	}
+	for _, f := range secret.Fields {
+		if got := f.Codec.(*fieldAnnotations).Type; got != wantTypes[f.Name] {
+			t.Errorf("type for %s = %q, want %q", f.Name, got, wantTypes[f.Name])
+		}
+	}
+	if len(got.Fields) != 7 {
+		t.Errorf("expected the oneof fields to be excluded, got=%v", got.Fields)
+	}
+	if got.OneOfTypes() != "Secret_ExpirationOneOf" {
+		t.Errorf("OneOfTypes() = %q, want %q", got.OneOfTypes(), "Secret_ExpirationOneOf")
+	}
+	wantVariants := []string{
+		"{ expireTime?: string; ttl?: never }",
+		"{ expireTime?: never; ttl?: string }",
+	}
+	if diff := cmp.Diff(wantVariants, secret.OneOfs[0].Codec.(*oneOfAnnotations).Variants); diff != "" {
+		t.Errorf("Variants mismatch (-want, +got):\n%s", diff)
+	}
+
+	codec := model.Codec.(*modelAnnotations)
+	var names []string
+	for _, m := range codec.Messages {
+		names = append(names, m.Codec.(*messageAnnotations).Name)
+	}
+	wantNames := []string{"Secret", "Secret_Replication", "GoogleRpc_Status"}
+	if diff := cmp.Diff(wantNames, names); diff != "" {
+		t.Errorf("messages mismatch (-want, +got):\n%s", diff)
+	}
+	if len(codec.Enums) != 1 || codec.Enums[0] != state {
+		t.Errorf("expected a single enum, got=%v", codec.Enums)
+	}
+	if got := state.Codec.(*enumAnnotations).Name; got != "Secret_State" {
+		t.Errorf("enum Name = %q, want %q", got, "Secret_State")
+	}
+	wantDocs := []string{"/**", " * Unspecified.", " */"}
+	if diff := cmp.Diff(wantDocs, state.Values[0].Codec.(*enumValueAnnotations).DocLines); diff != "" {
+		t.Errorf("enum value DocLines mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestAnnotateMethod(t *testing.T) {
+	secret := api.NewTestMessage("Secret").WithFields(api.NewTestField("name").WithType(api.TypezString))
+	empty := api.NewTestMessage("Empty").WithPackage("google.protobuf")
+	getRequest := api.NewTestMessage("GetSecretRequest").WithFields(
+		api.NewTestField("name").WithType(api.TypezString),
+		api.NewTestField("view").WithType(api.TypezString))
+	updateRequest := api.NewTestMessage("UpdateSecretRequest").WithFields(
+		messageField("secret", ".test.Secret"),
+		messageField("update_mask", ".google.protobuf.FieldMask"))
+	deleteRequest := api.NewTestMessage("DeleteSecretRequest").WithFields(
+		api.NewTestField("name").WithType(api.TypezString))
+
+	get := api.NewTestMethod("GetSecret").WithInput(getRequest).WithOutput(secret).WithVerb("GET").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("name"))
+	get.PathInfo.Bindings[0].QueryParameters = map[string]bool{"view": true}
+	get.Documentation = "Gets a secret."
+	update := api.NewTestMethod("UpdateSecret").WithInput(updateRequest).WithOutput(secret).WithVerb("PATCH").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("secret", "name").WithVerb("update"))
+	update.PathInfo.Bindings[0].QueryParameters = map[string]bool{"update_mask": true}
+	update.PathInfo.BodyFieldPath = "secret"
+	remove := api.NewTestMethod("DeleteSecret").WithInput(deleteRequest).WithOutput(empty).WithVerb("DELETE").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("name"))
+	remove.ReturnsEmpty = true
+	remove.PathInfo.BodyFieldPath = "*"
+	streaming := api.NewTestMethod("WatchSecrets").WithInput(getRequest).WithOutput(secret).WithVerb("GET").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1"))
+	streaming.ServerSideStreaming = true
+
+	service := api.NewTestService("SecretManagerService").WithMethods(get, update, remove, streaming)
+	service.DefaultHost = "secretmanager.googleapis.com"
+	model := api.NewTestAPI(
+		[]*api.Message{secret, getRequest, updateRequest, deleteRequest},
+		[]*api.Enum{},
+		[]*api.Service{service})
+	model.PackageName = "test"
+	annotateTestModel(t, model, nil)
+
+	for _, test := range []struct {
+		method *api.Method
+		want   *methodAnnotations
+	}{
+		{
+			method: get,
+			want: &methodAnnotations{
+				Name:              "getSecret",
+				DocLines:          []string{"/**", " * Gets a secret.", " */"},
+				RequestType:       "GetSecretRequest",
+				ResponseType:      "Secret",
+				Verb:              "GET",
+				Path:              "`/v1/${runtime.pathParameter(request.name, 'name')}`",
+				QueryParameters:   []string{"view: request.view"},
+				RoutingParameters: []string{"runtime.implicitRouting('name', request.name)"},
+			},
+		},
+		{
+			method: update,
+			want: &methodAnnotations{
+				Name:              "updateSecret",
+				RequestType:       "UpdateSecretRequest",
+				ResponseType:      "Secret",
+				Verb:              "PATCH",
+				Path:              "`/v1/${runtime.pathParameter(request.secret?.name, 'secret.name')}:update`",
+				QueryParameters:   []string{"updateMask: request.updateMask"},
+				Body:              "request.secret",
+				RoutingParameters: []string{"runtime.implicitRouting('secret.name', request.secret?.name)"},
+			},
+		},
+		{
+			method: remove,
+			want: &methodAnnotations{
+				Name:              "deleteSecret",
+				RequestType:       "DeleteSecretRequest",
+				ResponseType:      "void",
+				Verb:              "DELETE",
+				Path:              "`/v1/${runtime.pathParameter(request.name, 'name')}`",
+				Body:              "request",
+				RoutingParameters: []string{"runtime.implicitRouting('name', request.name)"},
+			},
+		},
+	} {
+		t.Run(test.method.Name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, test.method.Codec); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+	if streaming.Codec != nil {
+		t.Errorf("expected streaming method to be skipped, got=%v", streaming.Codec)
+	}
+	got := service.Codec.(*serviceAnnotations)
+	if got.Name != "SecretManagerService" || got.DefaultHost != "secretmanager.googleapis.com" {
+		t.Errorf("mismatched service annotations: %v", got)
+	}
+	var names []string
+	for _, m := range got.Methods {
+		names = append(names, m.Name)
+	}
+	if diff := cmp.Diff([]string{"GetSecret", "UpdateSecret", "DeleteSecret"}, names); diff != "" {
+		t.Errorf("methods mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestAnnotateService_NoMethods(t *testing.T) {
+	request := api.NewTestMessage("Request")
+	streaming := api.NewTestMethod("Watch").WithInput(request).WithOutput(request).WithVerb("GET").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1"))
+	streaming.ClientSideStreaming = true
+	service := api.NewTestService("Service").WithMethods(streaming)
+	model := api.NewTestAPI([]*api.Message{request}, []*api.Enum{}, []*api.Service{service})
+	annotateTestModel(t, model, nil)
+	if got := model.Codec.(*modelAnnotations).Services; len(got) != 0 {
+		t.Errorf("expected no services, got=%v", got)
+	}
+}
+
+func TestAnnotateMethod_Pagination(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		response []*api.Field
+		extra    []*api.Message
+		want     *paginationAnnotations
+	}{
+		{
+			name: "repeated",
+			response: []*api.Field{
+				messageField("secrets", ".test.Secret").WithRepeated(),
+				api.NewTestField("next_page_token").WithType(api.TypezString),
+			},
+			extra: []*api.Message{api.NewTestMessage("Secret")},
+			want: &paginationAnnotations{
+				PagesName:     "listSecretsPages",
+				ItemsName:     "listSecretsItems",
+				ItemType:      "Secret",
+				PageItems:     "page.secrets ?? []",
+				PageToken:     "pageToken",
+				NextPageToken: "nextPageToken",
+			},
+		},
+		{
+			name: "map",
+			response: []*api.Field{
+				messageField("secrets", ".test.ListSecretsResponse.SecretsEntry").WithMap(),
+				api.NewTestField("next_page_token").WithType(api.TypezString),
+			},
+			extra: []*api.Message{
+				{
+					Name:    "SecretsEntry",
+					ID:      ".test.ListSecretsResponse.SecretsEntry",
+					Package: "test",
+					IsMap:   true,
+					Fields: []*api.Field{
+						api.NewTestField("key").WithType(api.TypezString),
+						api.NewTestField("value").WithType(api.TypezInt64),
+					},
+				},
+			},
+			want: &paginationAnnotations{
+				PagesName:     "listSecretsPages",
+				ItemsName:     "listSecretsItems",
+				ItemType:      "[string, string]",
+				PageItems:     "Object.entries(page.secrets ?? {})",
+				PageToken:     "pageToken",
+				NextPageToken: "nextPageToken",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			request := api.NewTestMessage("ListSecretsRequest").WithFields(
+				api.NewTestField("page_size").WithType(api.TypezInt32),
+				api.NewTestField("page_token").WithType(api.TypezString))
+			response := api.NewTestMessage("ListSecretsResponse").WithFields(test.response...)
+			method := api.NewTestMethod("ListSecrets").WithInput(request).WithOutput(response).WithVerb("GET").
+				WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithLiteral("secrets"))
+			service := api.NewTestService("Service").WithMethods(method)
+			model := api.NewTestAPI(append([]*api.Message{request, response}, test.extra...), []*api.Enum{}, []*api.Service{service})
+			model.PackageName = "test"
+			annotateTestModel(t, model, nil)
+			if diff := cmp.Diff(test.want, method.Codec.(*methodAnnotations).Pagination); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAnnotateMethod_Routing(t *testing.T) {
+	profile := api.NewTestMessage("AppProfile").WithFields(
+		api.NewTestField("id").WithType(api.TypezString))
+	request := api.NewTestMessage("ReadRowsRequest").WithFields(
+		api.NewTestField("table_name").WithType(api.TypezString),
+		messageField("app_profile", ".test.AppProfile"))
+	response := api.NewTestMessage("ReadRowsResponse")
+	newMethod := func(name string) *api.Method {
+		return api.NewTestMethod(name).WithInput(request).WithOutput(response).WithVerb("POST").
+			WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("table_name"))
+	}
+	implicit := newMethod("Implicit")
+	explicit := newMethod("Explicit")
+	explicit.Routing = []*api.RoutingInfo{
+		{
+			Name: "table",
+			Variants: []*api.RoutingInfoVariant{
+				{
+					FieldPath: []string{"table_name"},
+					Prefix:    api.RoutingPathSpec{Segments: []string{"projects", "*"}},
+					Matching:  api.RoutingPathSpec{Segments: []string{"tables", "*"}},
+				},
+			},
+		},
+		{
+			Name: "app_profile_id",
+			Variants: []*api.RoutingInfoVariant{
+				{
+					FieldPath: []string{"app_profile", "id"},
+					Matching:  api.RoutingPathSpec{Segments: []string{"**"}},
+				},
+			},
+		},
+	}
+	disabled := newMethod("Disabled")
+	disabled.Routing = []*api.RoutingInfo{{}}
+	service := api.NewTestService("Service").WithMethods(implicit, explicit, disabled)
+	model := api.NewTestAPI([]*api.Message{profile, request, response}, []*api.Enum{}, []*api.Service{service})
+	model.PackageName = "test"
+	annotateTestModel(t, model, nil)
+
+	for _, test := range []struct {
+		method *api.Method
+		want   []string
+	}{
+		{
+			method: implicit,
+			want:   []string{"runtime.implicitRouting('table_name', request.tableName)"},
+		},
+		{
+			method: explicit,
+			want: []string{
+				"runtime.explicitRouting('table', [[request.tableName, new RegExp('^projects/[^/]+/(tables/[^/]+)$')]])",
+				"runtime.explicitRouting('app_profile_id', [[request.appProfile?.id, new RegExp('^(.*)$')]])",
+			},
+		},
+		{
+			method: disabled,
+		},
+	} {
+		t.Run(test.method.Name, func(t *testing.T) {
+			got := test.method.Codec.(*methodAnnotations).RoutingParameters
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAnnotateMethod_Operation(t *testing.T) {
+	operation := api.NewTestMessage("Operation").WithPackage("google.longrunning").WithFields(
+		api.NewTestField("name").WithType(api.TypezString),
+		api.NewTestField("done").WithType(api.TypezBool))
+	getOperationRequest := api.NewTestMessage("GetOperationRequest").WithPackage("google.longrunning").WithFields(
+		api.NewTestField("name").WithType(api.TypezString))
+	empty := api.NewTestMessage("Empty").WithPackage("google.protobuf")
+	secret := api.NewTestMessage("Secret")
+	request := api.NewTestMessage("CreateSecretRequest").WithFields(
+		api.NewTestField("parent").WithType(api.TypezString))
+	newMethod := func(name string) *api.Method {
+		return api.NewTestMethod(name).WithInput(request).WithOutput(operation).WithVerb("POST").
+			WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("parent"))
+	}
+	create := newMethod("CreateSecret")
+	create.OperationInfo = &api.OperationInfo{ResponseTypeID: ".test.Secret", MetadataTypeID: ".google.protobuf.Empty"}
+	purge := newMethod("PurgeSecrets")
+	purge.OperationInfo = &api.OperationInfo{ResponseTypeID: ".google.protobuf.Empty", MetadataTypeID: ".google.protobuf.Empty"}
+	getOperation := api.NewTestMethod("GetOperation").WithInput(getOperationRequest).WithOutput(operation).WithVerb("GET").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("name"))
+	service := api.NewTestService("Service").WithMethods(create, purge, getOperation)
+	model := api.NewTestAPI(
+		[]*api.Message{secret, request, operation, getOperationRequest, empty},
+		[]*api.Enum{},
+		[]*api.Service{service})
+	model.PackageName = "test"
+	annotateTestModel(t, model, nil)
+
+	if got := create.Codec.(*methodAnnotations).ResponseType; got != "GoogleLongrunning_Operation" {
+		t.Errorf("ResponseType = %q, want %q", got, "GoogleLongrunning_Operation")
+	}
+	for _, test := range []struct {
+		method *api.Method
+		want   *operationAnnotations
+	}{
+		{
+			method: create,
+			want: &operationAnnotations{
+				WaitName:     "createSecretAndWait",
+				ResultType:   "Secret",
+				GetOperation: "getOperation",
+			},
+		},
+		{
+			method: purge,
+			want: &operationAnnotations{
+				WaitName:     "purgeSecretsAndWait",
+				ResultType:   "void",
+				GetOperation: "getOperation",
+			},
+		},
+	} {
+		t.Run(test.method.Name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, test.method.Codec.(*methodAnnotations).Operation); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAnnotateMethod_OperationWithoutPolling(t *testing.T) {
+	operation := api.NewTestMessage("Operation").WithPackage("google.longrunning")
+	request := api.NewTestMessage("CreateSecretRequest")
+	create := api.NewTestMethod("CreateSecret").WithInput(request).WithOutput(operation).WithVerb("POST").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1"))
+	create.OperationInfo = &api.OperationInfo{ResponseTypeID: ".test.CreateSecretRequest"}
+	service := api.NewTestService("Service").WithMethods(create)
+	model := api.NewTestAPI([]*api.Message{request, operation}, []*api.Enum{}, []*api.Service{service})
+	model.PackageName = "test"
+	annotateTestModel(t, model, nil)
+	if got := create.Codec.(*methodAnnotations).Operation; got != nil {
+		t.Errorf("expected no operation helper without GetOperation, got=%v", got)
+	}
+}
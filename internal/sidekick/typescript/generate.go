@@ -0,0 +1,56 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typescript
+
+import (
+	"context"
+	"embed"
+	"path/filepath"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/language"
+)
+
+//go:embed all:templates
+var templates embed.FS
+
+// Generate generates TypeScript code from the model.
+func Generate(ctx context.Context, model *api.API, outdir string, codec map[string]string) error {
+	annotate := newAnnotateModel(model)
+	if err := annotate.annotateModel(codec); err != nil {
+		return err
+	}
+	provider, err := language.OverlayProvider(templates, codec["template-overlay"])
+	if err != nil {
+		return err
+	}
+	return language.GenerateFromModel(outdir, model, provider, generatedFiles())
+}
+
+// OverriddenTemplates returns the templates replaced by the overlay directory.
+func OverriddenTemplates(overlay string) ([]string, error) {
+	return language.OverriddenTemplates(templates, overlay)
+}
+
+func generatedFiles() []language.GeneratedFile {
+	files := language.WalkTemplatesDir(templates, "templates")
+	for i, f := range files {
+		// Remove the extension from "LICENSE.txt".
+		if filepath.Base(f.OutputPath) == "LICENSE.txt" {
+			files[i].OutputPath = filepath.Join(filepath.Dir(f.OutputPath), "LICENSE")
+		}
+	}
+	return files
+}
@@ -0,0 +1,264 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typescript
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+// update is used to refresh the golden files in testdata/ when template
+// changes are intentional.
+// Usage: go test ./internal/sidekick/typescript -v -update.
+var update = flag.Bool("update", false, "update golden files")
+
+func testModel(t *testing.T) *api.API {
+	t.Helper()
+	state := &api.Enum{
+		Name:    "State",
+		ID:      ".google.cloud.secretmanager.v1.Secret.State",
+		Package: "google.cloud.secretmanager.v1",
+		Values:  []*api.EnumValue{{Name: "STATE_UNSPECIFIED"}, {Name: "ENABLED"}},
+	}
+	expireTime := messageField("expire_time", ".google.protobuf.Timestamp")
+	expireTime.IsOneOf = true
+	ttl := messageField("ttl", ".google.protobuf.Duration")
+	ttl.IsOneOf = true
+	secret := api.NewTestMessage("Secret").WithPackage("google.cloud.secretmanager.v1").WithFields(
+		api.NewTestField("name").WithType(api.TypezString),
+		enumField("state", ".google.cloud.secretmanager.v1.Secret.State"),
+		expireTime,
+		ttl,
+	)
+	secret.Documentation = "A secret."
+	secret.OneOfs = []*api.OneOf{{Name: "expiration", Fields: []*api.Field{expireTime, ttl}}}
+	listRequest := api.NewTestMessage("ListSecretsRequest").WithPackage("google.cloud.secretmanager.v1").WithFields(
+		api.NewTestField("parent").WithType(api.TypezString),
+		api.NewTestField("page_size").WithType(api.TypezInt32),
+		api.NewTestField("page_token").WithType(api.TypezString))
+	listResponse := api.NewTestMessage("ListSecretsResponse").WithPackage("google.cloud.secretmanager.v1").WithFields(
+		messageField("secrets", ".google.cloud.secretmanager.v1.Secret").WithRepeated(),
+		api.NewTestField("next_page_token").WithType(api.TypezString))
+	list := api.NewTestMethod("ListSecrets").WithInput(listRequest).WithOutput(listResponse).WithVerb("GET").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("parent").WithLiteral("secrets"))
+	list.PathInfo.Bindings[0].QueryParameters = map[string]bool{"page_size": true, "page_token": true}
+	list.Documentation = "Lists [Secrets][google.cloud.secretmanager.v1.Secret]."
+	createRequest := api.NewTestMessage("CreateSecretRequest").WithPackage("google.cloud.secretmanager.v1").WithFields(
+		api.NewTestField("parent").WithType(api.TypezString),
+		messageField("secret", ".google.cloud.secretmanager.v1.Secret"))
+	operation := api.NewTestMessage("Operation").WithPackage("google.longrunning").WithFields(
+		api.NewTestField("name").WithType(api.TypezString),
+		api.NewTestField("done").WithType(api.TypezBool))
+	getOperationRequest := api.NewTestMessage("GetOperationRequest").WithPackage("google.longrunning").WithFields(
+		api.NewTestField("name").WithType(api.TypezString))
+	create := api.NewTestMethod("CreateSecret").WithInput(createRequest).WithOutput(operation).WithVerb("POST").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("parent").WithLiteral("secrets"))
+	create.PathInfo.BodyFieldPath = "secret"
+	create.OperationInfo = &api.OperationInfo{
+		ResponseTypeID: ".google.cloud.secretmanager.v1.Secret",
+		MetadataTypeID: ".google.protobuf.Empty",
+	}
+	create.Routing = []*api.RoutingInfo{
+		{
+			Name: "project",
+			Variants: []*api.RoutingInfoVariant{
+				{
+					FieldPath: []string{"parent"},
+					Matching:  api.RoutingPathSpec{Segments: []string{"projects", "*"}},
+				},
+			},
+		},
+	}
+	getOperation := api.NewTestMethod("GetOperation").WithInput(getOperationRequest).WithOutput(operation).WithVerb("GET").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("name"))
+	service := api.NewTestService("SecretManagerService").WithPackage("google.cloud.secretmanager.v1").
+		WithMethods(list, create, getOperation)
+	service.DefaultHost = "secretmanager.googleapis.com"
+	model := api.NewTestAPI(
+		[]*api.Message{secret, listRequest, listResponse, createRequest, operation, getOperationRequest},
+		[]*api.Enum{state},
+		[]*api.Service{service})
+	model.Title = "Secret Manager API"
+	model.Description = "Stores sensitive data."
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	api.UpdateMethodPagination(nil, model)
+	return model
+}
+
+func TestGenerate(t *testing.T) {
+	outDir := t.TempDir()
+	model := testModel(t)
+	if err := Generate(t.Context(), model, outDir, map[string]string{"copyright-year": "2026"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		filename string
+		want     []string
+	}{
+		{
+			filename: "package.json",
+			want:     []string{`"name": "google-cloud-secretmanager-v1"`, `"version": "0.1.0"`},
+		},
+		{
+			filename: "tsconfig.json",
+			want:     []string{`"strict": true`},
+		},
+		{
+			filename: "README.md",
+			want:     []string{"# Secret Manager API", "import { SecretManagerService } from 'google-cloud-secretmanager-v1';"},
+		},
+		{
+			filename: "LICENSE",
+			want:     []string{"Apache License"},
+		},
+		{
+			filename: "src/runtime.ts",
+			want:     []string{"// Copyright 2026 Google LLC", "export class Transport {"},
+		},
+		{
+			filename: "src/index.ts",
+			want: []string{
+				"// Copyright 2026 Google LLC",
+				"// Code generated by sidekick. DO NOT EDIT.",
+				"export class SecretManagerService {",
+				"async listSecrets(request: ListSecretsRequest, options?: runtime.CallOptions): Promise<ListSecretsResponse> {",
+				"path: `/v1/${runtime.pathParameter(request.parent, 'parent')}/secrets`,",
+				"pageSize: request.pageSize,",
+				"runtime.implicitRouting('parent', request.parent),",
+				"async *listSecretsItems(request: ListSecretsRequest, options?: runtime.CallOptions): AsyncGenerator<Secret> {",
+				"export type Secret = {",
+				"} & Secret_ExpirationOneOf;",
+				"  | { expireTime?: string; ttl?: never }",
+				"state?: Secret_State;",
+				"export const Secret_State = {",
+				"STATE_UNSPECIFIED: 'STATE_UNSPECIFIED',",
+				"export type Secret_State = (typeof Secret_State)[keyof typeof Secret_State];",
+				"export interface ListSecretsRequest {",
+			},
+		},
+	} {
+		t.Run(test.filename, func(t *testing.T) {
+			contents, err := os.ReadFile(filepath.Join(outDir, test.filename))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(string(contents), want) {
+					t.Errorf("expected %q in %s, got:\n%s", want, test.filename, contents)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_Golden(t *testing.T) {
+	outDir := t.TempDir()
+	if err := Generate(t.Context(), testModel(t), outDir, map[string]string{"copyright-year": "2026"}); err != nil {
+		t.Fatal(err)
+	}
+	// The golden files cover the oneof types, the long-running operation
+	// helpers, and the implicit and explicit routing headers.
+	for _, filename := range []string{"package.json", "README.md", "src/index.ts"} {
+		t.Run(filename, func(t *testing.T) {
+			got, err := os.ReadFile(filepath.Join(outDir, filename))
+			if err != nil {
+				t.Fatal(err)
+			}
+			goldenPath := filepath.Join("testdata", "golden", filename)
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(goldenPath), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(want), string(got)); diff != "" {
+				t.Errorf("mismatch in %s (-want +got):\n%s\n\nHint: run 'go test ./internal/sidekick/typescript -v -update' to update golden files.", filename, diff)
+			}
+		})
+	}
+}
+
+func TestGenerate_Compile(t *testing.T) {
+	if _, err := exec.LookPath("tsc"); err != nil {
+		t.Skip("skipping test because tsc is not installed")
+	}
+	outDir := t.TempDir()
+	if err := Generate(t.Context(), testModel(t), outDir, map[string]string{"copyright-year": "2026"}); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.CommandContext(t.Context(), "tsc", "--noEmit", "--project", outDir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("tsc --noEmit failed: %v\n%s", err, output)
+	}
+}
+
+func TestGenerate_TemplateOverlay(t *testing.T) {
+	overlay := t.TempDir()
+	if err := os.WriteFile(filepath.Join(overlay, "README.md.mustache"), []byte("# Custom {{Title}}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if err := Generate(t.Context(), testModel(t), outDir, map[string]string{"template-overlay": overlay}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(outDir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Custom Secret Manager API\n"; string(got) != want {
+		t.Errorf("README.md = %q, want %q", got, want)
+	}
+
+	names, err := OverriddenTemplates(overlay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != "templates/README.md.mustache" {
+		t.Errorf("OverriddenTemplates() = %v", names)
+	}
+}
+
+func TestGeneratedFiles(t *testing.T) {
+	var outputs []string
+	for _, f := range generatedFiles() {
+		outputs = append(outputs, filepath.ToSlash(f.OutputPath))
+	}
+	for _, want := range []string{"LICENSE", "README.md", "package.json", "tsconfig.json", "src/index.ts", "src/runtime.ts"} {
+		found := false
+		for _, got := range outputs {
+			if strings.TrimPrefix(got, "/") == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing %s in generated files %v", want, outputs)
+		}
+	}
+}
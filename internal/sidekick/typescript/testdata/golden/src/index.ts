@@ -0,0 +1,151 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by sidekick. DO NOT EDIT.
+
+/**
+ * The Google Cloud client library for the Secret Manager API.
+ *
+ * @packageDocumentation
+ */
+
+import * as runtime from './runtime.js';
+
+export { OperationError, ServiceError } from './runtime.js';
+export type { CallOptions, ClientOptions, PollingOptions } from './runtime.js';
+
+export class SecretManagerService {
+  readonly #transport: runtime.Transport;
+
+  /**
+   * Creates a new client. The client uses `https://secretmanager.googleapis.com`
+   * unless `options.endpoint` is set.
+   */
+  constructor(options: runtime.ClientOptions = {}) {
+    this.#transport = new runtime.Transport('secretmanager.googleapis.com', options);
+  }
+
+  /**
+   * Lists `Secrets`.
+   */
+  async listSecrets(request: ListSecretsRequest, options?: runtime.CallOptions): Promise<ListSecretsResponse> {
+    return this.#transport.call<ListSecretsResponse>({
+      method: 'GET',
+      path: `/v1/${runtime.pathParameter(request.parent, 'parent')}/secrets`,
+      query: {
+        pageSize: request.pageSize,
+        pageToken: request.pageToken,
+      },
+      routing: [
+        runtime.implicitRouting('parent', request.parent),
+      ],
+    }, options);
+  }
+
+  /**
+   * Returns the pages of results for `listSecrets()`, starting with the page
+   * requested by `request.pageToken`.
+   */
+  async *listSecretsPages(request: ListSecretsRequest, options?: runtime.CallOptions): AsyncGenerator<ListSecretsResponse> {
+    let pageToken = request.pageToken;
+    do {
+      const page = await this.listSecrets({ ...request, pageToken }, options);
+      yield page;
+      pageToken = page.nextPageToken;
+    } while (pageToken);
+  }
+
+  /**
+   * Returns the items in all the pages of results for `listSecrets()`.
+   */
+  async *listSecretsItems(request: ListSecretsRequest, options?: runtime.CallOptions): AsyncGenerator<Secret> {
+    for await (const page of this.listSecretsPages(request, options)) {
+      yield* page.secrets ?? [];
+    }
+  }
+
+  async createSecret(request: CreateSecretRequest, options?: runtime.CallOptions): Promise<GoogleLongrunning_Operation> {
+    return this.#transport.call<GoogleLongrunning_Operation>({
+      method: 'POST',
+      path: `/v1/${runtime.pathParameter(request.parent, 'parent')}/secrets`,
+      body: request.secret,
+      routing: [
+        runtime.explicitRouting('project', [[request.parent, new RegExp('^(projects/[^/]+)$')]]),
+      ],
+    }, options);
+  }
+
+  /**
+   * Calls `createSecret()` and waits until the long-running operation
+   * completes.
+   */
+  async createSecretAndWait(request: CreateSecretRequest, options?: runtime.PollingOptions): Promise<Secret> {
+    const operation = await this.createSecret(request, options);
+    return runtime.pollOperation<Secret>(operation, (name) => this.getOperation({ name }, options), options);
+  }
+
+  async getOperation(request: GoogleLongrunning_GetOperationRequest, options?: runtime.CallOptions): Promise<GoogleLongrunning_Operation> {
+    return this.#transport.call<GoogleLongrunning_Operation>({
+      method: 'GET',
+      path: `/v1/${runtime.pathParameter(request.name, 'name')}`,
+      routing: [
+        runtime.implicitRouting('name', request.name),
+      ],
+    }, options);
+  }
+}
+
+/**
+ * A secret.
+ */
+export type Secret = {
+  name?: string;
+  state?: Secret_State;
+} & Secret_ExpirationOneOf;
+
+export type Secret_ExpirationOneOf =
+  | { expireTime?: string; ttl?: never }
+  | { expireTime?: never; ttl?: string }
+  ;
+
+export interface ListSecretsRequest {
+  parent?: string;
+  pageSize?: number;
+  pageToken?: string;
+}
+
+export interface ListSecretsResponse {
+  secrets?: Secret[];
+  nextPageToken?: string;
+}
+
+export interface CreateSecretRequest {
+  parent?: string;
+  secret?: Secret;
+}
+
+export interface GoogleLongrunning_Operation {
+  name?: string;
+  done?: boolean;
+}
+
+export interface GoogleLongrunning_GetOperationRequest {
+  name?: string;
+}
+
+export const Secret_State = {
+  STATE_UNSPECIFIED: 'STATE_UNSPECIFIED',
+  ENABLED: 'ENABLED',
+} as const;
+export type Secret_State = (typeof Secret_State)[keyof typeof Secret_State];
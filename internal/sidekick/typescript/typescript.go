@@ -0,0 +1,239 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package typescript implements a sidekick codec for TypeScript.
+//
+// The generated packages contain REST clients based on `fetch()`, so they can
+// run in browsers, edge runtimes, and Node.js. The messages use the
+// [ProtoJSON] representation, and the packages have no runtime dependencies.
+//
+// Client-side, bidirectional, and server-side streaming methods are not
+// generated, as they are not supported by `fetch()`-based REST clients.
+//
+// [ProtoJSON]: https://protobuf.dev/programming-guides/json/
+package typescript
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/iancoleman/strcase"
+)
+
+// The suffix appended to names that conflict with TypeScript built-in types.
+const deconflictChar = "_"
+
+// reservedNames are the global TypeScript types used by the generated code,
+// and the types exported from the runtime module. Messages and enums with these
+// names are renamed to avoid shadowing them.
+var reservedNames = map[string]bool{
+	"Array":          true,
+	"Boolean":        true,
+	"CallOptions":    true,
+	"ClientOptions":  true,
+	"Date":           true,
+	"Error":          true,
+	"Function":       true,
+	"Map":            true,
+	"Number":         true,
+	"Object":         true,
+	"OperationError": true,
+	"Partial":        true,
+	"PollingOptions": true,
+	"Promise":        true,
+	"Record":         true,
+	"ServiceError":   true,
+	"Set":            true,
+	"String":         true,
+	"Symbol":         true,
+	"Uint8Array":     true,
+}
+
+// wellKnownTypes maps the Protobuf well-known types to their TypeScript type
+// in the ProtoJSON representation.
+var wellKnownTypes = map[string]string{
+	".google.protobuf.Any":         "{ '@type': string; [key: string]: unknown }",
+	".google.protobuf.BoolValue":   "boolean",
+	".google.protobuf.BytesValue":  "string",
+	".google.protobuf.DoubleValue": "number",
+	".google.protobuf.Duration":    "string",
+	".google.protobuf.Empty":       "Record<string, never>",
+	".google.protobuf.FieldMask":   "string",
+	".google.protobuf.FloatValue":  "number",
+	".google.protobuf.Int32Value":  "number",
+	".google.protobuf.Int64Value":  "string",
+	".google.protobuf.ListValue":   "unknown[]",
+	".google.protobuf.StringValue": "string",
+	".google.protobuf.Struct":      "Record<string, unknown>",
+	".google.protobuf.Timestamp":   "string",
+	".google.protobuf.UInt32Value": "number",
+	".google.protobuf.UInt64Value": "string",
+	".google.protobuf.Value":       "unknown",
+}
+
+// wellKnownEnums maps the Protobuf well-known enums to their TypeScript type.
+var wellKnownEnums = map[string]string{
+	".google.protobuf.NullValue": "null",
+}
+
+// scalarType returns the ProtoJSON type for a scalar field.
+//
+// Note that 64-bit integers are represented as strings in ProtoJSON, as they
+// may not fit in a JavaScript `number`.
+func scalarType(typez api.Typez) string {
+	switch typez {
+	case api.TypezBool:
+		return "boolean"
+	case api.TypezInt32, api.TypezUint32, api.TypezSint32, api.TypezFixed32, api.TypezSfixed32,
+		api.TypezFloat, api.TypezDouble:
+		return "number"
+	case api.TypezInt64, api.TypezUint64, api.TypezSint64, api.TypezFixed64, api.TypezSfixed64,
+		api.TypezString, api.TypezBytes:
+		return "string"
+	default:
+		return "unknown"
+	}
+}
+
+// localName returns the TypeScript name for a message or enum defined in the
+// package being generated. Nested types use the name of their parent as a
+// prefix, e.g. `Secret_Replication`.
+func localName(name string, parent *api.Message) string {
+	name = strcase.ToCamel(name)
+	if parent != nil {
+		return localName(parent.Name, parent.Parent) + "_" + name
+	}
+	if reservedNames[name] {
+		return name + deconflictChar
+	}
+	return name
+}
+
+// externalName returns the TypeScript name for a message or enum defined in
+// a different package. The name is prefixed with the package to avoid
+// conflicts, e.g. `GoogleRpc_Status`.
+func externalName(pkg, name string, parent *api.Message) string {
+	var prefix strings.Builder
+	for _, p := range strings.Split(pkg, ".") {
+		prefix.WriteString(strcase.ToCamel(p))
+	}
+	return prefix.String() + "_" + localName(name, parent)
+}
+
+// fieldName returns the name of a field in the ProtoJSON representation.
+func fieldName(f *api.Field) string {
+	if f.JSONName != "" {
+		return f.JSONName
+	}
+	return strcase.ToLowerCamel(f.Name)
+}
+
+// identifierRegex matches the names that can be used as TypeScript property
+// names without quotes.
+var identifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// propertyName returns the name of a property in a TypeScript type, quoting
+// the name if it is not a valid identifier, e.g. `'foo-bar'`.
+func propertyName(name string) string {
+	if identifierRegex.MatchString(name) {
+		return name
+	}
+	return quote(name)
+}
+
+// propertyAccess returns the expression to access a property, e.g. `.foo` or
+// `['foo-bar']`. The `sep` is used for identifiers, and should be `.` or `?.`.
+func propertyAccess(sep, name string) string {
+	if identifierRegex.MatchString(name) {
+		return sep + name
+	}
+	if sep == "?." {
+		return "?.[" + quote(name) + "]"
+	}
+	return "[" + quote(name) + "]"
+}
+
+// quote returns a single-quoted TypeScript string literal.
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
+// methodName returns the TypeScript name for a method.
+func methodName(m *api.Method) string {
+	return strcase.ToLowerCamel(m.Name)
+}
+
+// packageName returns the npm package name, e.g. `google-cloud-secretmanager-v1`
+// for `google.cloud.secretmanager.v1`.
+func packageName(model *api.API, override string) string {
+	if override != "" {
+		return override
+	}
+	return strings.ReplaceAll(model.PackageName, ".", "-")
+}
+
+// commentRefsRegex matches Google API documentation reference links, both
+// regular (`[Code][google.rpc.Code]`) and implicit (`[google.rpc.Code][]`).
+var commentRefsRegex = regexp.MustCompile(`\[([\w\d\._]+)\]\[([\d\w\._]*)\]`)
+
+// formatDocComments returns the lines of a JSDoc comment for documentation,
+// or nil if the documentation is empty and the element is not deprecated.
+func formatDocComments(documentation string, deprecated bool) []string {
+	lines := strings.Split(documentation, "\n")
+	for i, line := range lines {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		line = commentRefsRegex.ReplaceAllString(line, "`$1`")
+		// A `*/` sequence would terminate the comment.
+		lines[i] = strings.ReplaceAll(line, "*/", "*\\/")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	if deprecated {
+		if len(lines) != 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "@deprecated")
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	result := []string{"/**"}
+	for _, line := range lines {
+		if line == "" {
+			result = append(result, " *")
+			continue
+		}
+		result = append(result, " * "+line)
+	}
+	return append(result, " */")
+}
+
+// shouldGenerateMethod returns true if the method can be called using a
+// `fetch()`-based REST client.
+func shouldGenerateMethod(m *api.Method) bool {
+	if m.ClientSideStreaming || m.ServerSideStreaming {
+		return false
+	}
+	if m.PathInfo == nil || len(m.PathInfo.Bindings) == 0 {
+		return false
+	}
+	return m.PathInfo.Bindings[0].PathTemplate != nil
+}
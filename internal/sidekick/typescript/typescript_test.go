@@ -0,0 +1,203 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typescript
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func TestScalarType(t *testing.T) {
+	for _, test := range []struct {
+		typez api.Typez
+		want  string
+	}{
+		{api.TypezBool, "boolean"},
+		{api.TypezInt32, "number"},
+		{api.TypezUint32, "number"},
+		{api.TypezDouble, "number"},
+		{api.TypezInt64, "string"},
+		{api.TypezFixed64, "string"},
+		{api.TypezString, "string"},
+		{api.TypezBytes, "string"},
+		{api.TypezMessage, "unknown"},
+	} {
+		t.Run(test.typez.String(), func(t *testing.T) {
+			if got := scalarType(test.typez); got != test.want {
+				t.Errorf("scalarType(%s) = %q, want %q", test.typez, got, test.want)
+			}
+		})
+	}
+}
+
+func TestLocalName(t *testing.T) {
+	parent := &api.Message{Name: "Secret"}
+	for _, test := range []struct {
+		name   string
+		parent *api.Message
+		want   string
+	}{
+		{"Secret", nil, "Secret"},
+		{"replication", parent, "Secret_Replication"},
+		{"Promise", nil, "Promise_"},
+		{"ServiceError", nil, "ServiceError_"},
+		{"Promise", parent, "Secret_Promise"},
+	} {
+		t.Run(test.want, func(t *testing.T) {
+			if got := localName(test.name, test.parent); got != test.want {
+				t.Errorf("localName(%q) = %q, want %q", test.name, got, test.want)
+			}
+		})
+	}
+}
+
+func TestExternalName(t *testing.T) {
+	got := externalName("google.rpc", "Status", nil)
+	if want := "GoogleRpc_Status"; got != want {
+		t.Errorf("externalName() = %q, want %q", got, want)
+	}
+	got = externalName("google.iam.v1", "Binding", &api.Message{Name: "Policy"})
+	if want := "GoogleIamV1_Policy_Binding"; got != want {
+		t.Errorf("externalName() = %q, want %q", got, want)
+	}
+}
+
+func TestFieldName(t *testing.T) {
+	for _, test := range []struct {
+		field *api.Field
+		want  string
+	}{
+		{&api.Field{Name: "secret_id", JSONName: "secretId"}, "secretId"},
+		{&api.Field{Name: "secret_id"}, "secretId"},
+	} {
+		if got := fieldName(test.field); got != test.want {
+			t.Errorf("fieldName(%q) = %q, want %q", test.field.Name, got, test.want)
+		}
+	}
+}
+
+func TestPropertyName(t *testing.T) {
+	for _, test := range []struct {
+		name, want, access, optional string
+	}{
+		{"secretId", "secretId", ".secretId", "?.secretId"},
+		{"$ref", "$ref", ".$ref", "?.$ref"},
+		{"foo-bar", "'foo-bar'", "['foo-bar']", "?.['foo-bar']"},
+		{"@type", "'@type'", "['@type']", "?.['@type']"},
+		{"it's", `'it\'s'`, `['it\'s']`, `?.['it\'s']`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := propertyName(test.name); got != test.want {
+				t.Errorf("propertyName() = %q, want %q", got, test.want)
+			}
+			if got := propertyAccess(".", test.name); got != test.access {
+				t.Errorf("propertyAccess(.) = %q, want %q", got, test.access)
+			}
+			if got := propertyAccess("?.", test.name); got != test.optional {
+				t.Errorf("propertyAccess(?.) = %q, want %q", got, test.optional)
+			}
+		})
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	model := &api.API{PackageName: "google.cloud.secretmanager.v1"}
+	if got, want := packageName(model, ""), "google-cloud-secretmanager-v1"; got != want {
+		t.Errorf("packageName() = %q, want %q", got, want)
+	}
+	if got, want := packageName(model, "@example/secrets"), "@example/secrets"; got != want {
+		t.Errorf("packageName() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatDocComments(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		documentation string
+		deprecated    bool
+		want          []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:          "references",
+			documentation: "\nReturns a [Secret][google.cloud.secretmanager.v1.Secret].\n\nSee [google.rpc.Code][].\n",
+			want: []string{
+				"/**",
+				" * Returns a `Secret`.",
+				" *",
+				" * See `google.rpc.Code`.",
+				" */",
+			},
+		},
+		{
+			name:          "comment terminator",
+			documentation: "Matches `*/` paths.",
+			want:          []string{"/**", " * Matches `*\\/` paths.", " */"},
+		},
+		{
+			name:       "deprecated without documentation",
+			deprecated: true,
+			want:       []string{"/**", " * @deprecated", " */"},
+		},
+		{
+			name:          "deprecated",
+			documentation: "Gets a secret.",
+			deprecated:    true,
+			want:          []string{"/**", " * Gets a secret.", " *", " * @deprecated", " */"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := formatDocComments(test.documentation, test.deprecated)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestShouldGenerateMethod(t *testing.T) {
+	newMethod := func() *api.Method {
+		return api.NewTestMethod("GetSecret").WithVerb("GET").
+			WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1"))
+	}
+	streaming := newMethod()
+	streaming.ServerSideStreaming = true
+	clientStreaming := newMethod()
+	clientStreaming.ClientSideStreaming = true
+	noPath := api.NewTestMethod("NoPath")
+	noBindings := newMethod()
+	noBindings.PathInfo.Bindings = nil
+	for _, test := range []struct {
+		name   string
+		method *api.Method
+		want   bool
+	}{
+		{"unary", newMethod(), true},
+		{"server streaming", streaming, false},
+		{"client streaming", clientStreaming, false},
+		{"no path template", noPath, false},
+		{"no bindings", noBindings, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := shouldGenerateMethod(test.method); got != test.want {
+				t.Errorf("shouldGenerateMethod() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -59,6 +59,20 @@ func writeMetadata(path string, metadata map[string]any) error {
 	return nil
 }
 
+// Write encodes metadata as JSON and writes it to the given path, using the
+// same format as [ReformatAll]. The metadata must encode as a JSON object.
+func Write(path string, metadata any) error {
+	content, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("error encoding snippet metadata file %s: %w", path, err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(content, &m); err != nil {
+		return fmt.Errorf("error encoding snippet metadata file %s: %w", path, err)
+	}
+	return writeMetadata(path, m)
+}
+
 // updateLibraryVersion updates the client library version for a single file.
 func updateLibraryVersion(path, version string) error {
 	metadata, err := readMetadata(path)
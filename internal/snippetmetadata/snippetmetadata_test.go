@@ -172,6 +172,40 @@ func TestUpdateAllLibraryVersions_Error(t *testing.T) {
 	}
 }
 
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snippet_metadata.json")
+	metadata := struct {
+		String string         `json:"string"`
+		Obj    map[string]int `json:"obj"`
+		Array  []int          `json:"array"`
+	}{
+		String: "value",
+		Obj:    map[string]int{"y": 2, "x": 1},
+		Array:  []int{1, 2, 3},
+	}
+	if err := Write(path, metadata); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("testdata/formatted.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWrite_Error(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snippet_metadata.json")
+	if err := Write(path, []string{"not", "an", "object"}); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
 func TestReformat(t *testing.T) {
 	path := copyInputFileToTemp(t, "testdata/unformatted.json")
 	if err := reformat(path); err != nil {
@@ -0,0 +1,261 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package toolenv provides the isolated tool environment of a workspace,
+// into which librarian install installs the tools configured in
+// librarian.yaml.
+//
+// Each workspace and set of tools has its own environment directory, so that
+// workspaces that need different versions of the same tool can coexist on
+// one machine. The environment contains a virtualenv for pip tools, a
+// private pnpm store, a GOBIN directory for go tools, a cargo install root
+// for cargo tools and a lib directory for Maven tools.
+package toolenv
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/cache"
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+// EnvLibrarianToolEnv is the environment variable used to set the tool
+// environment directory, overriding the per-workspace environments.
+const EnvLibrarianToolEnv = "LIBRARIAN_TOOLENV"
+
+const (
+	// envsDir is the directory, under the librarian bin directory, that
+	// contains the environments.
+	envsDir = "envs"
+	// hashLen is the number of hex digits of the hashes used to name the
+	// environment directories.
+	hashLen = 16
+)
+
+// Env is the tool environment of a workspace and set of tools.
+type Env struct {
+	// Dir is the root directory of the environment.
+	Dir string
+
+	// workspaceDir is the directory that contains the environments of the
+	// workspace, one per set of tools. It is empty if the environment is
+	// not located by workspace.
+	workspaceDir string
+}
+
+// New returns the tool environment of the workspace rooted at workspace,
+// for the given tools. The environment is located under the librarian bin
+// directory, in a directory named after the hash of the absolute path of the
+// workspace, and a subdirectory named after the hash of the tools. It is not
+// created until tools are installed into it.
+//
+// If $LIBRARIAN_TOOLENV is set, it is the environment of every workspace
+// and set of tools instead, such as in a container image that installs the
+// tools once and generates libraries in workspaces mounted later.
+func New(workspace string, tools *config.Tools) (*Env, error) {
+	if dir := os.Getenv(EnvLibrarianToolEnv); dir != "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		return &Env{Dir: abs}, nil
+	}
+	abs, err := filepath.Abs(workspace)
+	if err != nil {
+		return nil, err
+	}
+	binDir, err := cache.BinDirectory()
+	if err != nil {
+		return nil, err
+	}
+	binDir, err = filepath.Abs(binDir)
+	if err != nil {
+		return nil, err
+	}
+	if tools == nil {
+		tools = &config.Tools{}
+	}
+	toolsYAML, err := yaml.Marshal(tools)
+	if err != nil {
+		return nil, fmt.Errorf("hashing tools: %w", err)
+	}
+	workspaceDir := filepath.Join(binDir, envsDir, hash([]byte(abs)))
+	return &Env{Dir: filepath.Join(workspaceDir, hash(toolsYAML)), workspaceDir: workspaceDir}, nil
+}
+
+// GoBin returns the directory into which go tools are installed, used as
+// GOBIN.
+func (e *Env) GoBin() string {
+	return filepath.Join(e.Dir, "go", "bin")
+}
+
+// CargoRoot returns the directory used as the root of cargo install. Cargo
+// tools are installed into its bin subdirectory.
+func (e *Env) CargoRoot() string {
+	return filepath.Join(e.Dir, "cargo")
+}
+
+// Venv returns the directory of the virtualenv into which pip tools are
+// installed.
+func (e *Env) Venv() string {
+	return filepath.Join(e.Dir, "venv")
+}
+
+// PNPMHome returns the directory used as PNPM_HOME. It contains the global
+// packages, binaries and content-addressable store of pnpm.
+func (e *Env) PNPMHome() string {
+	return filepath.Join(e.Dir, "pnpm")
+}
+
+// MavenBin returns the directory of the wrapper scripts of Maven tools.
+func (e *Env) MavenBin() string {
+	return filepath.Join(e.Dir, "maven", "bin")
+}
+
+// MavenLib returns the directory of the artifacts, such as .jar or .exe
+// files, of Maven tools.
+func (e *Env) MavenLib() string {
+	return filepath.Join(e.Dir, "maven", "lib")
+}
+
+// BinDirs returns the directories that contain the executables of the
+// environment, in the order in which they are searched.
+func (e *Env) BinDirs() []string {
+	return []string{
+		e.GoBin(),
+		filepath.Join(e.CargoRoot(), "bin"),
+		filepath.Join(e.Venv(), "bin"),
+		filepath.Join(e.PNPMHome(), "bin"),
+		e.MavenBin(),
+	}
+}
+
+// Environ returns the environment variables that make tools and package
+// managers use the environment: PATH lists the bin directories, VIRTUAL_ENV
+// activates the virtualenv if it exists, and the [Env.PNPMEnviron]
+// variables direct global pnpm installs and the pnpm store to the
+// environment if its pnpm home exists. PATH is meant to be prepended to the
+// PATH of the calling process.
+func (e *Env) Environ() map[string]string {
+	environ := map[string]string{
+		"PATH": strings.Join(e.BinDirs(), string(filepath.ListSeparator)),
+	}
+	if isDir(e.Venv()) {
+		environ["VIRTUAL_ENV"] = e.Venv()
+	}
+	if isDir(e.PNPMHome()) {
+		maps.Copy(environ, e.PNPMEnviron())
+	}
+	return environ
+}
+
+// PNPMEnviron returns the PNPM_* environment variables that direct global
+// pnpm installs and the pnpm store to the environment, whether or not its
+// pnpm home exists yet.
+func (e *Env) PNPMEnviron() map[string]string {
+	return map[string]string{
+		"PNPM_HOME":                  e.PNPMHome(),
+		"PNPM_CONFIG_GLOBAL_BIN_DIR": filepath.Join(e.PNPMHome(), "bin"),
+		"PNPM_CONFIG_GLOBAL_DIR":     filepath.Join(e.PNPMHome(), "global"),
+		"PNPM_CONFIG_STORE_DIR":      filepath.Join(e.PNPMHome(), "store"),
+	}
+}
+
+// Executables returns the absolute path of each executable in the bin
+// directories of the environment, keyed by name. When more than one bin
+// directory has an executable with the same name, the first one in
+// [Env.BinDirs] is returned. Missing bin directories are skipped.
+func (e *Env) Executables() (map[string]string, error) {
+	executables := map[string]string{}
+	for _, dir := range e.BinDirs() {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if _, ok := executables[entry.Name()]; ok {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() || info.Mode().Perm()&0111 == 0 {
+				continue
+			}
+			executables[entry.Name()] = path
+		}
+	}
+	return executables, nil
+}
+
+// Context returns a copy of ctx in which the commands run by the command
+// package resolve executables from the environment, and run with
+// [Env.Environ] added to their environment.
+func (e *Env) Context(ctx context.Context) (context.Context, error) {
+	executables, err := e.Executables()
+	if err != nil {
+		return nil, fmt.Errorf("listing executables in %s: %w", e.Dir, err)
+	}
+	return command.WithTools(ctx, executables, e.Environ()), nil
+}
+
+// Prune removes the other environments of the workspace of e, which were
+// created for earlier sets of tools, and returns their directories. It does
+// nothing if e is not located by workspace, such as when it is set by
+// $LIBRARIAN_TOOLENV.
+func (e *Env) Prune() ([]string, error) {
+	if e.workspaceDir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(e.workspaceDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var pruned []string
+	for _, entry := range entries {
+		dir := filepath.Join(e.workspaceDir, entry.Name())
+		if !entry.IsDir() || dir == e.Dir {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, dir)
+	}
+	return pruned, nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func hash(data []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:hashLen]
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolenv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/cache"
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	binDir := t.TempDir()
+	t.Setenv(cache.EnvLibrarianBin, binDir)
+	tools := &config.Tools{Go: []*config.GoTool{{Name: "golang.org/x/tools/cmd/goimports", Version: "v0.44.0"}}}
+	otherTools := &config.Tools{Go: []*config.GoTool{{Name: "golang.org/x/tools/cmd/goimports", Version: "v0.45.0"}}}
+
+	env, err := New("/workspace/a", tools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(env.Dir, filepath.Join(binDir, envsDir)) {
+		t.Errorf("env.Dir = %q, want it under %q", env.Dir, filepath.Join(binDir, envsDir))
+	}
+	same, err := New("/workspace/a", tools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if same.Dir != env.Dir {
+		t.Errorf("New() is not deterministic: got %q and %q", env.Dir, same.Dir)
+	}
+	otherWorkspace, err := New("/workspace/b", tools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otherWorkspace.Dir == env.Dir {
+		t.Errorf("workspaces share environment %q", env.Dir)
+	}
+	if filepath.Base(otherWorkspace.Dir) != filepath.Base(env.Dir) {
+		t.Errorf("same tools in different workspaces: got %q and %q, want the same tool-set hash", env.Dir, otherWorkspace.Dir)
+	}
+	otherToolSet, err := New("/workspace/a", otherTools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otherToolSet.Dir == env.Dir {
+		t.Errorf("tool sets share environment %q", env.Dir)
+	}
+	if filepath.Dir(otherToolSet.Dir) != filepath.Dir(env.Dir) {
+		t.Errorf("different tools in the same workspace: got %q and %q, want the same workspace directory", env.Dir, otherToolSet.Dir)
+	}
+}
+
+func TestExecutables(t *testing.T) {
+	env := &Env{Dir: t.TempDir()}
+	for _, file := range []struct {
+		dir  string
+		name string
+		mode os.FileMode
+	}{
+		{env.GoBin(), "goimports", 0755},
+		{env.GoBin(), "shared", 0755},
+		{env.MavenBin(), "shared", 0755},
+		{env.MavenBin(), "google-java-format", 0755},
+		{filepath.Join(env.Venv(), "bin"), "activate", 0644},
+	} {
+		if err := os.MkdirAll(file.dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(file.dir, file.name), nil, file.mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := env.Executables()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"goimports":          filepath.Join(env.GoBin(), "goimports"),
+		"shared":             filepath.Join(env.GoBin(), "shared"),
+		"google-java-format": filepath.Join(env.MavenBin(), "google-java-format"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEnviron(t *testing.T) {
+	env := &Env{Dir: "/env"}
+	got := env.Environ()
+	want := map[string]string{
+		"PATH": "/env/go/bin:/env/cargo/bin:/env/venv/bin:/env/pnpm/bin:/env/maven/bin",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEnviron_Installed(t *testing.T) {
+	env := &Env{Dir: t.TempDir()}
+	for _, dir := range []string{env.Venv(), env.PNPMHome()} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := env.Environ()
+	want := map[string]string{
+		"PATH":                       strings.Join(env.BinDirs(), string(filepath.ListSeparator)),
+		"VIRTUAL_ENV":                env.Venv(),
+		"PNPM_HOME":                  env.PNPMHome(),
+		"PNPM_CONFIG_GLOBAL_BIN_DIR": filepath.Join(env.PNPMHome(), "bin"),
+		"PNPM_CONFIG_GLOBAL_DIR":     filepath.Join(env.PNPMHome(), "global"),
+		"PNPM_CONFIG_STORE_DIR":      filepath.Join(env.PNPMHome(), "store"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNew_ToolEnvOverride(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(EnvLibrarianToolEnv, dir)
+	for _, workspace := range []string{"/workspace/a", "/workspace/b"} {
+		env, err := New(workspace, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if env.Dir != dir {
+			t.Errorf("New(%q).Dir = %q, want %q", workspace, env.Dir, dir)
+		}
+		pruned, err := env.Prune()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pruned != nil {
+			t.Errorf("Prune() = %v, want nil", pruned)
+		}
+	}
+}
+
+func TestPrune(t *testing.T) {
+	t.Setenv(cache.EnvLibrarianBin, t.TempDir())
+	oldTools := &config.Tools{Go: []*config.GoTool{{Name: "golang.org/x/tools/cmd/goimports", Version: "v0.44.0"}}}
+	tools := &config.Tools{Go: []*config.GoTool{{Name: "golang.org/x/tools/cmd/goimports", Version: "v0.45.0"}}}
+	old, err := New("/workspace/a", oldTools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherWorkspace, err := New("/workspace/b", oldTools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := New("/workspace/a", tools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []*Env{old, otherWorkspace, env} {
+		if err := os.MkdirAll(e.GoBin(), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pruned, err := env.Prune()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{old.Dir}, pruned); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	for _, test := range []struct {
+		dir  string
+		want bool
+	}{
+		{old.Dir, false},
+		{otherWorkspace.Dir, true},
+		{env.Dir, true},
+	} {
+		if got := isDir(test.dir); got != test.want {
+			t.Errorf("%s exists = %t, want %t", test.dir, got, test.want)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	env := &Env{Dir: t.TempDir()}
+	for _, dir := range []string{env.GoBin(), env.Venv()} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	script := "#!/bin/sh\necho \"isolated $VIRTUAL_ENV\"\n"
+	if err := os.WriteFile(filepath.Join(env.GoBin(), "librarian-test-tool"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := env.Context(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := command.Output(ctx, "librarian-test-tool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("isolated "+env.Venv()+"\n", got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
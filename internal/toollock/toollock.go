@@ -0,0 +1,279 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package toollock reads and writes the lockfile of the tools installed by
+// librarian install, which records the resolved version and the SHA256
+// checksum of the package downloaded for each tool.
+package toollock
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+// File is the name of the lockfile, written next to librarian.yaml.
+const File = "librarian-lock.yaml"
+
+// The kinds of tools, matching the keys of the tools section of
+// librarian.yaml.
+const (
+	KindCargo = "cargo"
+	KindGo    = "go"
+	KindMaven = "maven"
+	KindPip   = "pip"
+	KindPNPM  = "pnpm"
+)
+
+// ErrChecksumMismatch indicates that a downloaded package does not match the
+// checksum in librarian.yaml or in the lockfile.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// Lock is the content of the lockfile.
+type Lock struct {
+	// Tools lists the installed tools, sorted by kind and name.
+	Tools []*Tool `yaml:"tools,omitempty"`
+}
+
+// Tool is a tool recorded in the lockfile.
+type Tool struct {
+	// Kind is how the tool is installed, such as "go" or "pip".
+	Kind string `yaml:"kind"`
+
+	// Name is the name of the tool in librarian.yaml.
+	Name string `yaml:"name"`
+
+	// Version is the resolved version of the tool.
+	Version string `yaml:"version,omitempty"`
+
+	// Checksum is the SHA256 checksum of the package downloaded for the
+	// tool. It is empty for tools built from a local path, and for tools
+	// whose checksums are recorded per file in Files.
+	Checksum string `yaml:"checksum,omitempty"`
+
+	// Files maps the name of each file downloaded for the tool to its SHA256
+	// checksum. It is used for pip tools, which are often published as one
+	// wheel per platform, so that each platform verifies its own wheel.
+	Files map[string]string `yaml:"files,omitempty"`
+}
+
+// String returns the kind and name of the tool, for use in messages.
+func (t *Tool) String() string {
+	return fmt.Sprintf("%s tool %s", t.Kind, t.Name)
+}
+
+// Read reads the lockfile at path. It returns an empty lock if the file does
+// not exist.
+func Read(path string) (*Lock, error) {
+	lock, err := yaml.Read[Lock](path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Lock{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return lock, nil
+}
+
+// Write writes the lock to path, sorting the tools by kind and name.
+func Write(path string, lock *Lock) error {
+	slices.SortFunc(lock.Tools, func(a, b *Tool) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name))
+	})
+	return yaml.Write(path, lock)
+}
+
+// Find returns the locked tool with the given kind and name, or nil if it is
+// not locked.
+func (l *Lock) Find(kind, name string) *Tool {
+	return find(l.Tools, kind, name)
+}
+
+// Record records the resolved version and checksum of a tool, replacing the
+// previous entry for the tool.
+func (l *Lock) Record(kind, name, version, checksum string) {
+	if t := l.Find(kind, name); t != nil {
+		t.Version = version
+		t.Checksum = checksum
+		t.Files = nil
+		return
+	}
+	l.Tools = append(l.Tools, &Tool{Kind: kind, Name: name, Version: version, Checksum: checksum})
+}
+
+// Verify computes the SHA256 checksum of the package file at path, which was
+// downloaded for a tool, and records it with the resolved version. The
+// checksum must match want, the checksum in librarian.yaml, if it is set, and
+// the checksum in the lockfile if the tool is locked at the same version.
+func (l *Lock) Verify(kind, name, version, want, path string) error {
+	got, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if want != "" && got != want {
+		return fmt.Errorf("%w for %s tool %s@%s: librarian.yaml has %s, downloaded %s", ErrChecksumMismatch, kind, name, version, want, got)
+	}
+	if t := l.Find(kind, name); t != nil && t.Version == version && t.Checksum != "" && got != t.Checksum {
+		return fmt.Errorf("%w for %s tool %s@%s: %s has %s, downloaded %s", ErrChecksumMismatch, kind, name, version, File, t.Checksum, got)
+	}
+	l.Record(kind, name, version, got)
+	return nil
+}
+
+// VerifyFile is like Verify, but records the checksum of the file at path by
+// its file name, for tools whose downloaded file depends on the platform. The
+// checksum must match want, if it is set, and the checksum in the lockfile for
+// the same file if the tool is locked at the same version.
+func (l *Lock) VerifyFile(kind, name, version, want, path string) error {
+	got, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if want != "" && got != want {
+		return fmt.Errorf("%w for %s tool %s@%s: librarian.yaml has %s, downloaded %s", ErrChecksumMismatch, kind, name, version, want, got)
+	}
+	file := filepath.Base(path)
+	t := l.Find(kind, name)
+	if t != nil && t.Version == version && t.Files[file] != "" && got != t.Files[file] {
+		return fmt.Errorf("%w for %s tool %s@%s: %s has %s for %s, downloaded %s", ErrChecksumMismatch, kind, name, version, File, t.Files[file], file, got)
+	}
+	if t == nil {
+		t = &Tool{Kind: kind, Name: name}
+		l.Tools = append(l.Tools, t)
+	}
+	if t.Version != version || t.Files == nil {
+		t.Files = map[string]string{}
+	}
+	t.Version = version
+	t.Checksum = ""
+	t.Files[file] = got
+	return nil
+}
+
+// hasChecksum reports whether checksum is the checksum of the tool or of one
+// of its files.
+func (t *Tool) hasChecksum(checksum string) bool {
+	return checksum == t.Checksum || slices.Contains(slices.Collect(maps.Values(t.Files)), checksum)
+}
+
+// Wanted returns the tools configured in librarian.yaml, with the versions
+// and checksums they are pinned to.
+func Wanted(tools *config.Tools) []*Tool {
+	if tools == nil {
+		return nil
+	}
+	var wanted []*Tool
+	for _, t := range tools.Cargo {
+		wanted = append(wanted, &Tool{Kind: KindCargo, Name: t.Name, Version: t.Version, Checksum: t.Checksum})
+	}
+	for _, t := range tools.Go {
+		wanted = append(wanted, &Tool{Kind: KindGo, Name: t.Name, Version: t.Version, Checksum: t.Checksum})
+	}
+	for _, t := range tools.Maven {
+		checksum := t.Checksum
+		if t.LocalPath != "" {
+			checksum = ""
+		}
+		wanted = append(wanted, &Tool{Kind: KindMaven, Name: t.Name, Version: t.Version, Checksum: checksum})
+	}
+	for _, t := range tools.Pip {
+		checksum := t.Checksum
+		if t.LocalPath != "" {
+			checksum = ""
+		}
+		wanted = append(wanted, &Tool{Kind: KindPip, Name: t.Name, Version: t.Version, Checksum: checksum})
+	}
+	for _, t := range tools.PNPM {
+		wanted = append(wanted, &Tool{Kind: KindPNPM, Name: t.Name, Version: t.Version, Checksum: t.Checksum})
+	}
+	return wanted
+}
+
+// Check reports the drift between the tools wanted by librarian.yaml, the
+// lock and the installed tools. A wanted tool without a version, or with
+// version "latest", matches any locked version, and an installed tool without
+// a version matches any locked version. It returns one message per problem.
+func Check(wanted []*Tool, lock *Lock, installed []*Tool) []string {
+	var problems []string
+	for _, w := range wanted {
+		locked := lock.Find(w.Kind, w.Name)
+		switch {
+		case locked == nil:
+			problems = append(problems, fmt.Sprintf("%s is in librarian.yaml but not in %s", w, File))
+		case w.Version != "" && w.Version != "latest" && w.Version != locked.Version:
+			problems = append(problems, fmt.Sprintf("%s is version %s in librarian.yaml but %s in %s", w, w.Version, locked.Version, File))
+		case w.Checksum != "" && len(locked.Files) > 0 && !locked.hasChecksum(w.Checksum):
+			problems = append(problems, fmt.Sprintf("%s has checksum %s in librarian.yaml but no such file in %s", w, w.Checksum, File))
+		case w.Checksum != "" && !locked.hasChecksum(w.Checksum):
+			problems = append(problems, fmt.Sprintf("%s has checksum %s in librarian.yaml but %s in %s", w, w.Checksum, locked.Checksum, File))
+		}
+	}
+	for _, locked := range lock.Tools {
+		if find(wanted, locked.Kind, locked.Name) == nil {
+			problems = append(problems, fmt.Sprintf("%s is in %s but not in librarian.yaml", locked, File))
+		}
+		got := find(installed, locked.Kind, locked.Name)
+		switch {
+		case got == nil:
+			problems = append(problems, fmt.Sprintf("%s is in %s but not installed", locked, File))
+		case got.Version != "" && got.Version != locked.Version:
+			problems = append(problems, fmt.Sprintf("%s is version %s in %s but %s is installed", locked, locked.Version, File, got.Version))
+		}
+	}
+	return problems
+}
+
+// find returns the tool with the given kind and name, or nil if there is
+// none.
+func find(tools []*Tool, kind, name string) *Tool {
+	for _, t := range tools {
+		if t.Kind == kind && normalize(kind, t.Name) == normalize(kind, name) {
+			return t
+		}
+	}
+	return nil
+}
+
+// normalize returns the canonical form of a tool name. Pip package names are
+// case insensitive and treat "-", "_" and "." as equivalent.
+func normalize(kind, name string) string {
+	if kind != KindPip {
+		return name
+	}
+	return strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(name))
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
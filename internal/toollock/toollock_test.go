@@ -0,0 +1,354 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toollock
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+)
+
+func TestReadWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	lock, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&Lock{}, lock); diff != "" {
+		t.Errorf("missing lockfile mismatch (-want +got):\n%s", diff)
+	}
+
+	lock.Record(KindPip, "nox", "2025.11.12", "abc")
+	lock.Record(KindGo, "golang.org/x/tools/cmd/goimports", "v0.44.0", "def")
+	lock.Record(KindCargo, "taplo-cli", "0.10.0", "")
+	if err := Write(path, lock); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Lock{Tools: []*Tool{
+		{Kind: KindCargo, Name: "taplo-cli", Version: "0.10.0"},
+		{Kind: KindGo, Name: "golang.org/x/tools/cmd/goimports", Version: "v0.44.0", Checksum: "def"},
+		{Kind: KindPip, Name: "nox", Version: "2025.11.12", Checksum: "abc"},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRead_Error(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	if err := os.WriteFile(path, []byte("tools: ["), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(path); err == nil {
+		t.Error("Read() error = nil, want error")
+	}
+}
+
+func TestFind(t *testing.T) {
+	lock := &Lock{Tools: []*Tool{
+		{Kind: KindPip, Name: "PyYAML", Version: "6.0.2"},
+		{Kind: KindGo, Name: "Tool", Version: "v1.0.0"},
+	}}
+	for _, test := range []struct {
+		kind, name string
+		want       *Tool
+	}{
+		{KindPip, "pyyaml", lock.Tools[0]},
+		{KindPip, "py_yaml", nil},
+		{KindGo, "Tool", lock.Tools[1]},
+		{KindGo, "tool", nil},
+		{KindCargo, "Tool", nil},
+	} {
+		t.Run(test.kind+"/"+test.name, func(t *testing.T) {
+			if got := lock.Find(test.kind, test.name); got != test.want {
+				t.Errorf("Find() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tool.tar.gz")
+	if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256Hex("content")
+	other := sha256Hex("other")
+	for _, test := range []struct {
+		name    string
+		lock    *Lock
+		want    string
+		wantErr error
+	}{
+		{
+			name: "new tool",
+			lock: &Lock{},
+		},
+		{
+			name: "matches librarian.yaml",
+			lock: &Lock{},
+			want: sum,
+		},
+		{
+			name: "matches lockfile",
+			lock: &Lock{Tools: []*Tool{{Kind: KindPip, Name: "tool", Version: "1.0.0", Checksum: sum}}},
+		},
+		{
+			name: "different version in lockfile",
+			lock: &Lock{Tools: []*Tool{{Kind: KindPip, Name: "tool", Version: "0.9.0", Checksum: other}}},
+		},
+		{
+			name: "locked without checksum",
+			lock: &Lock{Tools: []*Tool{{Kind: KindPip, Name: "tool", Version: "1.0.0"}}},
+		},
+		{
+			name:    "mismatch with librarian.yaml",
+			lock:    &Lock{},
+			want:    other,
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name:    "mismatch with lockfile",
+			lock:    &Lock{Tools: []*Tool{{Kind: KindPip, Name: "tool", Version: "1.0.0", Checksum: other}}},
+			wantErr: ErrChecksumMismatch,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.lock.Verify(KindPip, "tool", "1.0.0", test.want, path)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, test.wantErr)
+			}
+			if test.wantErr != nil {
+				return
+			}
+			want := []*Tool{{Kind: KindPip, Name: "tool", Version: "1.0.0", Checksum: sum}}
+			if diff := cmp.Diff(want, test.lock.Tools); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVerifyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tool-1.0.0-cp312-linux.whl")
+	if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256Hex("content")
+	other := sha256Hex("other")
+	for _, test := range []struct {
+		name      string
+		lock      *Lock
+		want      string
+		wantFiles map[string]string
+		wantErr   error
+	}{
+		{
+			name:      "new tool",
+			lock:      &Lock{},
+			wantFiles: map[string]string{"tool-1.0.0-cp312-linux.whl": sum},
+		},
+		{
+			name:      "matches lockfile",
+			lock:      &Lock{Tools: []*Tool{{Kind: KindPip, Name: "tool", Version: "1.0.0", Files: map[string]string{"tool-1.0.0-cp312-linux.whl": sum}}}},
+			wantFiles: map[string]string{"tool-1.0.0-cp312-linux.whl": sum},
+		},
+		{
+			name: "other platform in lockfile",
+			lock: &Lock{Tools: []*Tool{{Kind: KindPip, Name: "tool", Version: "1.0.0", Files: map[string]string{"tool-1.0.0-cp312-macos.whl": other}}}},
+			wantFiles: map[string]string{
+				"tool-1.0.0-cp312-linux.whl": sum,
+				"tool-1.0.0-cp312-macos.whl": other,
+			},
+		},
+		{
+			name:      "different version in lockfile",
+			lock:      &Lock{Tools: []*Tool{{Kind: KindPip, Name: "tool", Version: "0.9.0", Files: map[string]string{"tool-0.9.0-cp312-macos.whl": other}}}},
+			wantFiles: map[string]string{"tool-1.0.0-cp312-linux.whl": sum},
+		},
+		{
+			name:    "mismatch with librarian.yaml",
+			lock:    &Lock{},
+			want:    other,
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name:    "mismatch with lockfile",
+			lock:    &Lock{Tools: []*Tool{{Kind: KindPip, Name: "tool", Version: "1.0.0", Files: map[string]string{"tool-1.0.0-cp312-linux.whl": other}}}},
+			wantErr: ErrChecksumMismatch,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.lock.VerifyFile(KindPip, "tool", "1.0.0", test.want, path)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("VerifyFile() error = %v, want %v", err, test.wantErr)
+			}
+			if test.wantErr != nil {
+				return
+			}
+			want := []*Tool{{Kind: KindPip, Name: "tool", Version: "1.0.0", Files: test.wantFiles}}
+			if diff := cmp.Diff(want, test.lock.Tools); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWanted(t *testing.T) {
+	tools := &config.Tools{
+		Cargo: []*config.CargoTool{{Name: "taplo-cli", Version: "0.10.0", Checksum: "a"}},
+		Go:    []*config.GoTool{{Name: "golang.org/x/tools/cmd/goimports", Version: "v0.44.0"}},
+		Maven: []*config.MavenTool{
+			{Name: "google-java-format", Version: "1.25.2", Checksum: "b"},
+			{Name: "local", LocalPath: "local", Checksum: "ignored"},
+		},
+		Pip: []*config.PipTool{
+			{Name: "nox", Version: "2025.11.12", Checksum: "c"},
+			{Name: "synthtool", LocalPath: "synthtool", Checksum: "ignored"},
+		},
+		PNPM: []*config.PNPMTool{{Name: "gapic-tools", Version: "1.0.6", Checksum: "d"}},
+	}
+	want := []*Tool{
+		{Kind: KindCargo, Name: "taplo-cli", Version: "0.10.0", Checksum: "a"},
+		{Kind: KindGo, Name: "golang.org/x/tools/cmd/goimports", Version: "v0.44.0"},
+		{Kind: KindMaven, Name: "google-java-format", Version: "1.25.2", Checksum: "b"},
+		{Kind: KindMaven, Name: "local"},
+		{Kind: KindPip, Name: "nox", Version: "2025.11.12", Checksum: "c"},
+		{Kind: KindPip, Name: "synthtool"},
+		{Kind: KindPNPM, Name: "gapic-tools", Version: "1.0.6", Checksum: "d"},
+	}
+	if diff := cmp.Diff(want, Wanted(tools)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if got := Wanted(nil); got != nil {
+		t.Errorf("Wanted(nil) = %v, want nil", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	lock := &Lock{Tools: []*Tool{
+		{Kind: KindGo, Name: "goimports", Version: "v0.44.0", Checksum: "a"},
+		{Kind: KindPip, Name: "PyYAML", Version: "6.0.2", Checksum: "b"},
+	}}
+	for _, test := range []struct {
+		name      string
+		wanted    []*Tool
+		installed []*Tool
+		want      []string
+	}{
+		{
+			name: "no drift",
+			wanted: []*Tool{
+				{Kind: KindGo, Name: "goimports", Version: "v0.44.0", Checksum: "a"},
+				{Kind: KindPip, Name: "pyyaml", Version: "6.0.2"},
+			},
+			installed: []*Tool{
+				{Kind: KindGo, Name: "goimports", Version: "v0.44.0"},
+				{Kind: KindPip, Name: "pyyaml", Version: "6.0.2"},
+			},
+		},
+		{
+			name: "latest and unknown versions",
+			wanted: []*Tool{
+				{Kind: KindGo, Name: "goimports", Version: "latest"},
+				{Kind: KindPip, Name: "PyYAML"},
+			},
+			installed: []*Tool{
+				{Kind: KindGo, Name: "goimports"},
+				{Kind: KindPip, Name: "PyYAML", Version: "6.0.2"},
+			},
+		},
+		{
+			name: "drift",
+			wanted: []*Tool{
+				{Kind: KindGo, Name: "goimports", Version: "v0.45.0"},
+				{Kind: KindPip, Name: "PyYAML", Version: "6.0.2", Checksum: "c"},
+				{Kind: KindCargo, Name: "taplo-cli", Version: "0.10.0"},
+			},
+			installed: []*Tool{
+				{Kind: KindGo, Name: "goimports", Version: "v0.43.0"},
+			},
+			want: []string{
+				"go tool goimports is version v0.45.0 in librarian.yaml but v0.44.0 in librarian-lock.yaml",
+				"pip tool PyYAML has checksum c in librarian.yaml but b in librarian-lock.yaml",
+				"cargo tool taplo-cli is in librarian.yaml but not in librarian-lock.yaml",
+				"go tool goimports is version v0.44.0 in librarian-lock.yaml but v0.43.0 is installed",
+				"pip tool PyYAML is in librarian-lock.yaml but not installed",
+			},
+		},
+		{
+			name: "removed from librarian.yaml",
+			installed: []*Tool{
+				{Kind: KindGo, Name: "goimports", Version: "v0.44.0"},
+				{Kind: KindPip, Name: "PyYAML", Version: "6.0.2"},
+			},
+			want: []string{
+				"go tool goimports is in librarian-lock.yaml but not in librarian.yaml",
+				"pip tool PyYAML is in librarian-lock.yaml but not in librarian.yaml",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := Check(test.wanted, lock, test.installed)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheck_Files(t *testing.T) {
+	lock := &Lock{Tools: []*Tool{
+		{Kind: KindPip, Name: "ruff", Version: "0.14.14", Files: map[string]string{"ruff-0.14.14-linux.whl": "a", "ruff-0.14.14-macos.whl": "b"}},
+	}}
+	installed := []*Tool{{Kind: KindPip, Name: "ruff", Version: "0.14.14"}}
+	for _, test := range []struct {
+		name     string
+		checksum string
+		want     []string
+	}{
+		{
+			name:     "matches a file",
+			checksum: "b",
+		},
+		{
+			name:     "matches no file",
+			checksum: "c",
+			want:     []string{"pip tool ruff has checksum c in librarian.yaml but no such file in librarian-lock.yaml"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			wanted := []*Tool{{Kind: KindPip, Name: "ruff", Version: "0.14.14", Checksum: test.checksum}}
+			got := Check(wanted, lock, installed)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func sha256Hex(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+}